@@ -11,6 +11,7 @@ import (
 
 	"github.com/gnolang/gno/contribs/gnodev/pkg/packages"
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/mattn/go-isatty"
 )
@@ -59,6 +60,7 @@ It enables features such as interactive mode, unsafe API access for testing, and
 The log format is set to console for easier readability, and the web interface is accessible locally, making it ideal for iterative development and testing.
 
 By default, the current directory and the "example" folder from "gnoroot" will be used as the root resolver.
+The dependencies vendored by "gno mod vendor" in the current module take precedence over them.
 `,
 			NoParentFlags: true,
 		},
@@ -111,6 +113,11 @@ func execLocalApp(cfg *LocalAppConfig, args []string, cio commands.IO) error {
 		baseResolvers = append(baseResolvers, packages.NewRootResolver(exampleRoot))
 	}
 
+	// Prefer the vendored dependencies of the current module, if any
+	if vendorDir := gnomod.FindVendorDir(dir); vendorDir != "" {
+		baseResolvers = append([]packages.Resolver{packages.NewRootResolver(vendorDir)}, baseResolvers...)
+	}
+
 	// Check if current directory is a valid gno package
 	path := guessPath(&cfg.AppConfig, dir)
 	resolver := packages.NewLocalResolver(path, dir)
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	githubOAuthURL = "https://github.com/login/oauth/access_token"
+	githubAPIURL   = "https://api.github.com"
+
+	defaultGitHubMinAccountAge = time.Hour * 24 * 30
+	defaultGitHubCooldown      = time.Hour * 24
+)
+
+var (
+	errGitHubAccountTooRecent = errors.New("github account is too recent")
+	errGitHubCooldown         = errors.New("github account already used recently")
+)
+
+// githubUser is the GitHub identity of a faucet user
+type githubUser struct {
+	ID        int64     `json:"id"`
+	Login     string    `json:"login"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// githubAuth verifies the GitHub identity of faucet users, using OAuth.
+// Users authorize the OAuth app, and send the resulting code
+// with their faucet request
+type githubAuth struct {
+	clientID      string
+	clientSecret  string
+	minAccountAge time.Duration // minimum age of the GitHub account
+	cooldown      time.Duration // minimum time between two requests of the same account
+
+	oauthURL string
+	apiURL   string
+	client   *http.Client
+
+	store *throttleStore
+}
+
+// newGitHubAuth creates a new GitHub OAuth verifier
+func newGitHubAuth(
+	store *throttleStore,
+	clientID,
+	clientSecret string,
+	minAccountAge,
+	cooldown time.Duration,
+) *githubAuth {
+	return &githubAuth{
+		clientID:      clientID,
+		clientSecret:  clientSecret,
+		minAccountAge: minAccountAge,
+		cooldown:      cooldown,
+		oauthURL:      githubOAuthURL,
+		apiURL:        githubAPIURL,
+		client: &http.Client{
+			Timeout: time.Second * 10,
+		},
+		store: store,
+	}
+}
+
+// verify fetches the GitHub user from the OAuth code, checks its account age,
+// and returns the reservation registering the request
+func (g *githubAuth) verify(code string) (*githubUser, reservation, error) {
+	token, err := g.exchangeCode(code)
+	if err != nil {
+		return nil, reservation{}, err
+	}
+
+	user, err := g.fetchUser(token)
+	if err != nil {
+		return nil, reservation{}, err
+	}
+
+	if g.store.now().Sub(user.CreatedAt) < g.minAccountAge {
+		return nil, reservation{}, errGitHubAccountTooRecent
+	}
+
+	res := reservation{
+		key:      githubPrefix + strconv.FormatInt(user.ID, 10),
+		duration: g.cooldown,
+		err:      fmt.Errorf("unable to verify github user, %w", errGitHubCooldown),
+	}
+
+	if g.store.reserved(res.key) {
+		return nil, reservation{}, errGitHubCooldown
+	}
+
+	return user, res, nil
+}
+
+// exchangeCode exchanges the OAuth code for an access token
+func (g *githubAuth) exchangeCode(code string) (string, error) {
+	form := url.Values{}
+	form.Set("client_id", g.clientID)
+	form.Set("client_secret", g.clientSecret)
+	form.Set("code", code)
+
+	req, err := http.NewRequest(http.MethodPost, g.oauthURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("unable to create request, %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to execute request, %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code, %d", resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode response, %w", err)
+	}
+
+	if body.Error != "" {
+		return "", fmt.Errorf("unable to exchange code, %s: %s", body.Error, body.ErrorDescription)
+	}
+
+	return body.AccessToken, nil
+}
+
+// fetchUser fetches the GitHub user associated with the access token
+func (g *githubAuth) fetchUser(token string) (*githubUser, error) {
+	req, err := http.NewRequest(http.MethodGet, g.apiURL+"/user", nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request, %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute request, %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code, %d", resp.StatusCode)
+	}
+
+	var user githubUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("failed to decode response, %w", err)
+	}
+
+	return &user, nil
+}
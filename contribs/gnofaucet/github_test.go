@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestGitHubServer creates a mock GitHub server,
+// which resolves OAuth codes to the given users
+func newTestGitHubServer(t *testing.T, users map[string]githubUser) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		response := map[string]string{}
+
+		if _, ok := users[r.Form.Get("code")]; ok && r.Form.Get("client_secret") == "secret" {
+			response["access_token"] = r.Form.Get("code")
+		} else {
+			response["error"] = "bad_verification_code"
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	})
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("Authorization")[len("Bearer "):]
+
+		user, ok := users[token]
+		if !ok {
+			http.Error(w, "bad credentials", http.StatusUnauthorized)
+
+			return
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(user))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func newTestGitHubAuth(t *testing.T, store *throttleStore, users map[string]githubUser) *githubAuth {
+	t.Helper()
+
+	srv := newTestGitHubServer(t, users)
+
+	auth := newGitHubAuth(store, "client", "secret", time.Hour*24, time.Hour)
+	auth.oauthURL = srv.URL + "/login/oauth/access_token"
+	auth.apiURL = srv.URL
+	auth.client = srv.Client()
+
+	return auth
+}
+
+func TestGitHubAuth_Verify(t *testing.T) {
+	t.Parallel()
+
+	store, now := newTestStore(t)
+
+	auth := newTestGitHubAuth(t, store, map[string]githubUser{
+		"old": {ID: 1, Login: "old", CreatedAt: now.Add(-time.Hour * 48)},
+		"new": {ID: 2, Login: "new", CreatedAt: now.Add(-time.Hour)},
+	})
+
+	user, res, err := auth.verify("old")
+	require.NoError(t, err)
+	assert.Equal(t, "old", user.Login)
+
+	// The account is only in cooldown once reserved
+	_, _, err = auth.verify("old")
+	require.NoError(t, err)
+
+	require.NoError(t, store.reserve(res))
+	assert.ErrorIs(t, store.reserve(res), errGitHubCooldown)
+
+	_, _, err = auth.verify("old")
+	assert.ErrorIs(t, err, errGitHubCooldown)
+
+	// The account is too recent
+	_, _, err = auth.verify("new")
+	assert.ErrorIs(t, err, errGitHubAccountTooRecent)
+
+	// The code is invalid
+	_, _, err = auth.verify("unknown")
+	assert.ErrorContains(t, err, "bad_verification_code")
+
+	// The cooldown is over
+	*now = now.Add(time.Hour * 2)
+
+	_, _, err = auth.verify("old")
+	assert.NoError(t, err)
+}
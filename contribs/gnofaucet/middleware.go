@@ -2,16 +2,25 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net"
 	"net/http"
 	"net/netip"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/gnolang/faucet"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 )
 
+var errAddressCooldown = errors.New("address already received funds recently")
+
 // getIPMiddleware returns the IP verification middleware, using the given subnet throttler
 func getIPMiddleware(behindProxy bool, st *ipThrottler) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -88,24 +97,13 @@ func getCaptchaMiddleware(secret string) func(next http.Handler) http.Handler {
 					Captcha string `json:"captcha"`
 				}
 
-				body, err := io.ReadAll(r.Body)
+				body, err := readBody(r)
 				if err != nil {
-					http.Error(w, "unable to read request body", http.StatusInternalServerError)
-
-					return
-				}
-
-				// Close the original body
-				if err := r.Body.Close(); err != nil {
-					http.Error(w, "unable to close request body", http.StatusInternalServerError)
+					http.Error(w, err.Error(), http.StatusInternalServerError)
 
 					return
 				}
 
-				// Create a new ReadCloser from the read bytes
-				// so that future middleware will be able to read
-				r.Body = io.NopCloser(bytes.NewReader(body))
-
 				// Decode the original request
 				if err := json.NewDecoder(bytes.NewBuffer(body)).Decode(&request); err != nil {
 					http.Error(w, "invalid captcha request", http.StatusBadRequest)
@@ -127,6 +125,341 @@ func getCaptchaMiddleware(secret string) func(next http.Handler) http.Handler {
 	}
 }
 
+// faucetRequest is the part of a faucet request
+// used by the abuse protection middlewares
+type faucetRequest struct {
+	To           string `json:"to"`
+	GitHubCode   string `json:"github_code"`
+	PoWTimestamp int64  `json:"pow_timestamp"`
+	PoWNonce     string `json:"pow_nonce"`
+}
+
+// readBody reads the request body, and restores it
+// so that future middleware will be able to read it
+func readBody(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.New("unable to read request body")
+	}
+
+	// Close the original body
+	if err := r.Body.Close(); err != nil {
+		return nil, errors.New("unable to close request body")
+	}
+
+	// Create a new ReadCloser from the read bytes
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// isBatchRequest checks if the request body is a batch request
+func isBatchRequest(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+// parseFaucetRequests parses the single or batch faucet request
+func parseFaucetRequests(body []byte) ([]faucetRequest, error) {
+	if isBatchRequest(body) {
+		var requests []faucetRequest
+		if err := json.Unmarshal(body, &requests); err != nil {
+			return nil, err
+		}
+
+		return requests, nil
+	}
+
+	var request faucetRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, err
+	}
+
+	return []faucetRequest{request}, nil
+}
+
+// parseSingleFaucetRequest parses the faucet request,
+// making sure it is not a batch request
+func parseSingleFaucetRequest(r *http.Request) (faucetRequest, error) {
+	body, err := readBody(r)
+	if err != nil {
+		return faucetRequest{}, err
+	}
+
+	if isBatchRequest(body) {
+		return faucetRequest{}, errors.New("batch requests are not supported")
+	}
+
+	var request faucetRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return faucetRequest{}, errors.New("invalid faucet request")
+	}
+
+	return request, nil
+}
+
+// reservationsKey is the request context key of the reservations
+// to make once the request passed all the checks
+type reservationsKey struct{}
+
+// withReservation adds the reservation to the ones
+// to make once the request passed all the checks
+func withReservation(r *http.Request, res reservation) *http.Request {
+	reservations, _ := r.Context().Value(reservationsKey{}).([]reservation)
+	reservations = append(slices.Clip(reservations), res)
+
+	return r.WithContext(context.WithValue(r.Context(), reservationsKey{}, reservations))
+}
+
+// getReserveMiddleware returns the middleware making the reservations of the request
+// (proofs of work, GitHub users and recipient addresses, if the address cooldown is enabled),
+// once it passed all the other middlewares. The reservations of the failed transfers are released
+func getReserveMiddleware(addressCooldown time.Duration, store *throttleStore) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				reservations, _ := r.Context().Value(reservationsKey{}).([]reservation)
+
+				// Check if there is anything to reserve
+				if addressCooldown == 0 && len(reservations) == 0 {
+					// Continue with serving the faucet request
+					next.ServeHTTP(w, r)
+
+					return
+				}
+
+				body, err := readBody(r)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+
+					return
+				}
+
+				requests, err := parseFaucetRequests(body)
+				if err != nil {
+					http.Error(w, "invalid faucet request", http.StatusBadRequest)
+
+					return
+				}
+
+				// Gather the recipient addresses. Invalid addresses
+				// are left for the faucet to reject
+				addresses := make([]string, len(requests))
+
+				if addressCooldown != 0 {
+					for i, request := range requests {
+						address, err := crypto.AddressFromBech32(strings.TrimSpace(request.To))
+						if err != nil {
+							continue
+						}
+
+						addresses[i] = addressPrefix + address.String()
+						reservations = append(reservations, reservation{
+							key:      addresses[i],
+							duration: addressCooldown,
+							err:      fmt.Errorf("unable to verify address request, %w", errAddressCooldown),
+						})
+					}
+				}
+
+				// Make sure none of the reservations is already taken
+				if err := store.reserve(reservations...); err != nil {
+					http.Error(w, err.Error(), http.StatusUnauthorized)
+
+					return
+				}
+
+				// Serve the faucet request, keeping the response
+				// until the failed transfers are known
+				bw := newBufferedResponseWriter()
+				next.ServeHTTP(bw, r)
+
+				failed := failedTransfers(bw.status, bw.body.Bytes(), len(requests))
+
+				var released []string
+
+				for i, address := range addresses {
+					if failed[i] && address != "" {
+						released = append(released, address)
+					}
+				}
+
+				// The other reservations are only made for single requests
+				if !slices.Contains(failed, false) {
+					for _, res := range reservations {
+						if !strings.HasPrefix(res.key, addressPrefix) {
+							released = append(released, res.key)
+						}
+					}
+				}
+
+				store.release(released...)
+				bw.flush(w)
+			},
+		)
+	}
+}
+
+// failedTransfers returns which transfers of the faucet requests failed, from the faucet response.
+// All the transfers are considered failed if the response can't be decoded
+func failedTransfers(status int, body []byte, count int) []bool {
+	failed := make([]bool, count)
+
+	var responses []faucet.Response
+
+	err := json.Unmarshal(body, &responses)
+	if !isBatchRequest(body) {
+		// Single responses are written for batches of a single request
+		var response faucet.Response
+
+		err = json.Unmarshal(body, &response)
+		responses = []faucet.Response{response}
+	}
+
+	if status != http.StatusOK || err != nil || len(responses) != count {
+		for i := range failed {
+			failed[i] = true
+		}
+
+		return failed
+	}
+
+	for i, response := range responses {
+		failed[i] = response.Error != ""
+	}
+
+	return failed
+}
+
+// bufferedResponseWriter buffers a response,
+// so it can be inspected before being written
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+// newBufferedResponseWriter creates a new buffered response writer
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponseWriter) WriteHeader(status int) {
+	b.status = status
+}
+
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+// flush writes the buffered response
+func (b *bufferedResponseWriter) flush(w http.ResponseWriter) {
+	maps.Copy(w.Header(), b.header)
+	w.WriteHeader(b.status)
+
+	//nolint:errcheck // the client is gone if the write fails
+	w.Write(b.body.Bytes())
+}
+
+// getPoWMiddleware returns the proof of work middleware, if any
+func getPoWMiddleware(verifier *powVerifier) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				// Check if the proof of work is enabled
+				if verifier == nil {
+					// Continue with serving the faucet request
+					next.ServeHTTP(w, r)
+
+					return
+				}
+
+				request, err := parseSingleFaucetRequest(r)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+
+					return
+				}
+
+				// Verify the proof of work
+				res, err := verifier.verify(
+					strings.TrimSpace(request.To),
+					request.PoWTimestamp,
+					request.PoWNonce,
+				)
+				if err != nil {
+					http.Error(
+						w,
+						fmt.Sprintf("invalid proof of work, %s", err.Error()),
+						http.StatusUnauthorized,
+					)
+
+					return
+				}
+
+				// Continue with serving the faucet request,
+				// which marks the proof as used
+				next.ServeHTTP(w, withReservation(r, res))
+			},
+		)
+	}
+}
+
+// getGitHubMiddleware returns the GitHub OAuth middleware, if any
+func getGitHubMiddleware(auth *githubAuth) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				// Check if the GitHub authentication is enabled
+				if auth == nil {
+					// Continue with serving the faucet request
+					next.ServeHTTP(w, r)
+
+					return
+				}
+
+				request, err := parseSingleFaucetRequest(r)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+
+					return
+				}
+
+				code := strings.TrimSpace(request.GitHubCode)
+				if code == "" {
+					http.Error(w, "missing github code", http.StatusUnauthorized)
+
+					return
+				}
+
+				// Verify the GitHub user
+				_, res, err := auth.verify(code)
+				if err != nil {
+					http.Error(
+						w,
+						fmt.Sprintf("unable to verify github user, %s", err.Error()),
+						http.StatusUnauthorized,
+					)
+
+					return
+				}
+
+				// Continue with serving the faucet request,
+				// which starts the cooldown of the user
+				next.ServeHTTP(w, withReservation(r, res))
+			},
+		)
+	}
+}
+
 // checkRecaptcha checks the captcha challenge
 func checkRecaptcha(secret, response string) error {
 	// Create an HTTP client with a timeout
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gnolang/faucet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveMiddleware executes the request body against the middleware,
+// and returns the response status code.
+// The final handler makes sure the body is still readable, and responds
+// like the faucet, failing the transfers to the given recipients
+func serveMiddleware(t *testing.T, middleware func(http.Handler) http.Handler, body string, failed ...string) int {
+	t.Helper()
+
+	final := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		read, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, body, string(read))
+
+		requests, err := parseFaucetRequests(read)
+		require.NoError(t, err)
+
+		responses := make([]faucet.Response, 0, len(requests))
+		for _, request := range requests {
+			response := faucet.Response{Result: "successfully executed faucet transfer"}
+			if slices.Contains(failed, request.To) {
+				response = faucet.Response{Result: "unable to handle faucet request", Error: "transfer failed"}
+			}
+
+			responses = append(responses, response)
+		}
+
+		if len(responses) == 1 {
+			require.NoError(t, json.NewEncoder(w).Encode(responses[0]))
+
+			return
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(responses))
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	middleware(final).ServeHTTP(rec, req)
+
+	return rec.Code
+}
+
+// chainMiddlewares chains the middlewares, the first one being the outermost like in the faucet
+func chainMiddlewares(middlewares ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		for _, middleware := range slices.Backward(middlewares) {
+			next = middleware(next)
+		}
+
+		return next
+	}
+}
+
+func TestReserveMiddleware(t *testing.T) {
+	t.Parallel()
+
+	const (
+		addr1 = "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5"
+		addr2 = "g1us8428u2a5satrlxzagqqa5m6vmuze025anjlj"
+		addr3 = "g1u7y667z64x2h7vc6fmpcprgey4ck233jaww9zq"
+	)
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		store, _ := newTestStore(t)
+		middleware := getReserveMiddleware(0, store)
+
+		body := fmt.Sprintf(`{"to":%q}`, addr1)
+
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, body))
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, body))
+	})
+
+	t.Run("single requests", func(t *testing.T) {
+		t.Parallel()
+
+		store, now := newTestStore(t)
+		middleware := getReserveMiddleware(time.Hour, store)
+
+		body := fmt.Sprintf(`{"to":%q}`, addr1)
+
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, body))
+		assert.Equal(t, http.StatusUnauthorized, serveMiddleware(t, middleware, body))
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, fmt.Sprintf(`{"to":%q}`, addr2)))
+
+		*now = now.Add(time.Hour * 2)
+
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, body))
+	})
+
+	t.Run("batch requests", func(t *testing.T) {
+		t.Parallel()
+
+		store, _ := newTestStore(t)
+		middleware := getReserveMiddleware(time.Hour, store)
+
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, fmt.Sprintf(`[{"to":%q}]`, addr1)))
+
+		// A batch containing a recent address is rejected as a whole
+		assert.Equal(
+			t,
+			http.StatusUnauthorized,
+			serveMiddleware(t, middleware, fmt.Sprintf(`[{"to":%q},{"to":%q}]`, addr2, addr1)),
+		)
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, fmt.Sprintf(`{"to":%q}`, addr2)))
+
+		// Duplicate addresses within a batch are rejected
+		assert.Equal(
+			t,
+			http.StatusUnauthorized,
+			serveMiddleware(t, middleware, fmt.Sprintf(`[{"to":%q},{"to":%q}]`, addr3, addr3)),
+		)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		t.Parallel()
+
+		store, _ := newTestStore(t)
+		middleware := getReserveMiddleware(time.Hour, store)
+
+		assert.Equal(t, http.StatusBadRequest, serveMiddleware(t, middleware, "{"))
+	})
+
+	t.Run("failed transfers", func(t *testing.T) {
+		t.Parallel()
+
+		store, _ := newTestStore(t)
+		middleware := getReserveMiddleware(time.Hour, store)
+
+		// The address of a failed transfer is released
+		body := fmt.Sprintf(`{"to":%q}`, addr1)
+
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, body, addr1))
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, body))
+		assert.Equal(t, http.StatusUnauthorized, serveMiddleware(t, middleware, body))
+
+		// Only the failed transfers of a batch are released
+		batch := fmt.Sprintf(`[{"to":%q},{"to":%q}]`, addr2, addr3)
+
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, batch, addr3))
+		assert.Equal(t, http.StatusUnauthorized, serveMiddleware(t, middleware, fmt.Sprintf(`{"to":%q}`, addr2)))
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, fmt.Sprintf(`{"to":%q}`, addr3)))
+	})
+}
+
+func TestPoWMiddleware(t *testing.T) {
+	t.Parallel()
+
+	const (
+		to         = "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5"
+		difficulty = 8
+	)
+
+	newBody := func(t *testing.T, timestamp int64) string {
+		t.Helper()
+
+		nonce := solvePoW(t, to, timestamp, difficulty)
+
+		return fmt.Sprintf(`{"to":%q,"pow_timestamp":%d,"pow_nonce":%q}`, to, timestamp, nonce)
+	}
+
+	t.Run("valid and reused", func(t *testing.T) {
+		t.Parallel()
+
+		store, now := newTestStore(t)
+		middleware := chainMiddlewares(
+			getPoWMiddleware(newPoWVerifier(store, difficulty, time.Minute)),
+			getReserveMiddleware(0, store),
+		)
+
+		body := newBody(t, now.Unix())
+
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, body))
+		assert.Equal(t, http.StatusUnauthorized, serveMiddleware(t, middleware, body))
+		assert.Equal(t, http.StatusUnauthorized, serveMiddleware(t, middleware, fmt.Sprintf(`{"to":%q}`, to)))
+		assert.Equal(t, http.StatusBadRequest, serveMiddleware(t, middleware, "["+body+"]"))
+
+		// Disabled
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, getPoWMiddleware(nil), body))
+	})
+
+	t.Run("rejected by a later check", func(t *testing.T) {
+		t.Parallel()
+
+		store, now := newTestStore(t)
+		verifier := newPoWVerifier(store, difficulty, time.Minute)
+
+		// The recipient address already received funds
+		require.NoError(t, store.reserve(reservation{key: addressPrefix + to, duration: time.Hour}))
+
+		body := newBody(t, now.Unix())
+
+		assert.Equal(
+			t,
+			http.StatusUnauthorized,
+			serveMiddleware(t, chainMiddlewares(getPoWMiddleware(verifier), getReserveMiddleware(time.Hour, store)), body),
+		)
+
+		// The proof of work was not used
+		assert.Equal(
+			t,
+			http.StatusOK,
+			serveMiddleware(t, chainMiddlewares(getPoWMiddleware(verifier), getReserveMiddleware(0, store)), body),
+		)
+	})
+
+	t.Run("failed transfer", func(t *testing.T) {
+		t.Parallel()
+
+		store, now := newTestStore(t)
+		middleware := chainMiddlewares(
+			getPoWMiddleware(newPoWVerifier(store, difficulty, time.Minute)),
+			getReserveMiddleware(time.Hour, store),
+		)
+
+		body := newBody(t, now.Unix())
+
+		// The proof of work and the address are released
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, body, to))
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, body))
+		assert.Equal(t, http.StatusUnauthorized, serveMiddleware(t, middleware, body))
+	})
+}
+
+func TestGitHubMiddleware(t *testing.T) {
+	t.Parallel()
+
+	const (
+		addr1 = "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5"
+		addr2 = "g1us8428u2a5satrlxzagqqa5m6vmuze025anjlj"
+	)
+
+	newMiddleware := func(t *testing.T, addressCooldown time.Duration) func(http.Handler) http.Handler {
+		t.Helper()
+
+		store, now := newTestStore(t)
+
+		auth := newTestGitHubAuth(t, store, map[string]githubUser{
+			"code":  {ID: 1, Login: "user", CreatedAt: now.Add(-time.Hour * 48)},
+			"other": {ID: 2, Login: "other", CreatedAt: now.Add(-time.Hour * 48)},
+		})
+
+		return chainMiddlewares(getGitHubMiddleware(auth), getReserveMiddleware(addressCooldown, store))
+	}
+
+	t.Run("valid and cooldown", func(t *testing.T) {
+		t.Parallel()
+
+		middleware := newMiddleware(t, 0)
+
+		body := fmt.Sprintf(`{"to":%q,"github_code":"code"}`, addr1)
+
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, body))
+		assert.Equal(t, http.StatusUnauthorized, serveMiddleware(t, middleware, body))
+		assert.Equal(t, http.StatusUnauthorized, serveMiddleware(t, middleware, fmt.Sprintf(`{"to":%q}`, addr1)))
+
+		// Disabled
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, getGitHubMiddleware(nil), body))
+	})
+
+	t.Run("rejected by a later check", func(t *testing.T) {
+		t.Parallel()
+
+		middleware := newMiddleware(t, time.Hour)
+
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, fmt.Sprintf(`{"to":%q,"github_code":"code"}`, addr1)))
+
+		// The recipient address already received funds,
+		// so the cooldown of the other user doesn't start
+		assert.Equal(t, http.StatusUnauthorized, serveMiddleware(t, middleware, fmt.Sprintf(`{"to":%q,"github_code":"other"}`, addr1)))
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, fmt.Sprintf(`{"to":%q,"github_code":"other"}`, addr2)))
+	})
+
+	t.Run("failed transfer", func(t *testing.T) {
+		t.Parallel()
+
+		middleware := newMiddleware(t, time.Hour)
+
+		body := fmt.Sprintf(`{"to":%q,"github_code":"code"}`, addr1)
+
+		// The cooldowns of the user and the address don't start
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, body, addr1))
+		assert.Equal(t, http.StatusOK, serveMiddleware(t, middleware, body))
+		assert.Equal(t, http.StatusUnauthorized, serveMiddleware(t, middleware, body))
+	})
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"time"
+)
+
+const defaultPoWWindow = time.Minute * 5
+
+var (
+	errPoWExpired      = errors.New("proof of work timestamp is outside the accepted window")
+	errPoWInsufficient = errors.New("proof of work does not meet the difficulty")
+	errPoWReused       = errors.New("proof of work was already used")
+)
+
+// powVerifier verifies hashcash-style proofs of work, which are bound to
+// the recipient address and a recent timestamp.
+//
+// A proof is valid if sha256("<to>:<timestamp>:<nonce>") starts with
+// at least difficulty zero bits. Each proof can only be used once
+type powVerifier struct {
+	difficulty uint          // required number of leading zero bits
+	window     time.Duration // accepted timestamp drift
+
+	store *throttleStore
+}
+
+// newPoWVerifier creates a new proof of work verifier
+func newPoWVerifier(store *throttleStore, difficulty uint, window time.Duration) *powVerifier {
+	return &powVerifier{
+		difficulty: difficulty,
+		window:     window,
+		store:      store,
+	}
+}
+
+// powHash computes the proof of work hash
+func powHash(to string, timestamp int64, nonce string) []byte {
+	hash := sha256.Sum256([]byte(to + ":" + strconv.FormatInt(timestamp, 10) + ":" + nonce))
+
+	return hash[:]
+}
+
+// leadingZeroBits returns the number of leading zero bits of the hash
+func leadingZeroBits(hash []byte) uint {
+	var count uint
+
+	for _, b := range hash {
+		if b != 0 {
+			return count + uint(bits.LeadingZeros8(b))
+		}
+
+		count += 8
+	}
+
+	return count
+}
+
+// verify verifies the proof of work for the recipient address,
+// and returns the reservation marking it as used
+func (p *powVerifier) verify(to string, timestamp int64, nonce string) (reservation, error) {
+	// Make sure the proof is recent
+	drift := p.store.now().Sub(time.Unix(timestamp, 0))
+	if drift > p.window || drift < -p.window {
+		return reservation{}, errPoWExpired
+	}
+
+	hash := powHash(to, timestamp, nonce)
+	if leadingZeroBits(hash) < p.difficulty {
+		return reservation{}, errPoWInsufficient
+	}
+
+	// Proofs are kept until they can't be valid anymore
+	res := reservation{
+		key:      powPrefix + hex.EncodeToString(hash),
+		duration: 2 * p.window,
+		err:      fmt.Errorf("invalid proof of work, %w", errPoWReused),
+	}
+
+	if p.store.reserved(res.key) {
+		return reservation{}, errPoWReused
+	}
+
+	return res, nil
+}
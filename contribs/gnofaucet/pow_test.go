@@ -0,0 +1,94 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// solvePoW finds a nonce satisfying the given difficulty
+func solvePoW(t *testing.T, to string, timestamp int64, difficulty uint) string {
+	t.Helper()
+
+	for i := 0; ; i++ {
+		nonce := strconv.Itoa(i)
+
+		if leadingZeroBits(powHash(to, timestamp, nonce)) >= difficulty {
+			return nonce
+		}
+	}
+}
+
+func TestLeadingZeroBits(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, uint(0), leadingZeroBits([]byte{0xff}))
+	assert.Equal(t, uint(3), leadingZeroBits([]byte{0x10, 0x00}))
+	assert.Equal(t, uint(12), leadingZeroBits([]byte{0x00, 0x08}))
+	assert.Equal(t, uint(16), leadingZeroBits([]byte{0x00, 0x00}))
+}
+
+func TestPoWVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	const (
+		to         = "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5"
+		difficulty = 8
+	)
+
+	store, now := newTestStore(t)
+	verifier := newPoWVerifier(store, difficulty, time.Minute)
+
+	timestamp := now.Unix()
+	nonce := solvePoW(t, to, timestamp, difficulty)
+
+	t.Run("insufficient work", func(t *testing.T) {
+		// Find a nonce that doesn't satisfy the difficulty
+		for i := 0; ; i++ {
+			invalid := strconv.Itoa(i)
+
+			if leadingZeroBits(powHash(to, timestamp, invalid)) < difficulty {
+				_, err := verifier.verify(to, timestamp, invalid)
+				assert.ErrorIs(t, err, errPoWInsufficient)
+
+				return
+			}
+		}
+	})
+
+	t.Run("bound to the address", func(t *testing.T) {
+		other := "g1us8428u2a5satrlxzagqqa5m6vmuze025anjlj"
+
+		if leadingZeroBits(powHash(other, timestamp, nonce)) >= difficulty {
+			t.Skip("nonce also valid for the other address")
+		}
+
+		_, err := verifier.verify(other, timestamp, nonce)
+		assert.ErrorIs(t, err, errPoWInsufficient)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		old := now.Add(-time.Minute * 2).Unix()
+
+		_, err := verifier.verify(to, old, solvePoW(t, to, old, difficulty))
+		assert.ErrorIs(t, err, errPoWExpired)
+	})
+
+	t.Run("valid and reused", func(t *testing.T) {
+		res, err := verifier.verify(to, timestamp, nonce)
+		require.NoError(t, err)
+
+		// The proof is only used once reserved
+		_, err = verifier.verify(to, timestamp, nonce)
+		require.NoError(t, err)
+
+		require.NoError(t, store.reserve(res))
+		assert.ErrorIs(t, store.reserve(res), errPoWReused)
+
+		_, err = verifier.verify(to, timestamp, nonce)
+		assert.ErrorIs(t, err, errPoWReused)
+	})
+}
@@ -14,6 +14,9 @@ import (
 	"github.com/gnolang/faucet/estimate/static"
 	"github.com/gnolang/gno/gno.land/pkg/log"
 	"github.com/gnolang/gno/tm2/pkg/commands"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/goleveldb"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"go.uber.org/zap/zapcore"
@@ -59,6 +62,17 @@ type serveCfg struct {
 
 	captchaSecret string
 	isBehindProxy bool
+
+	dbDir           string
+	addressCooldown time.Duration
+
+	githubClientID      string
+	githubClientSecret  string
+	githubMinAccountAge time.Duration
+	githubCooldown      time.Duration
+
+	powDifficulty uint
+	powWindow     time.Duration
 }
 
 func newServeCmd() *commands.Command {
@@ -133,6 +147,62 @@ func (c *serveCfg) RegisterFlags(fs *flag.FlagSet) {
 		false,
 		"use X-Forwarded-For IP for throttling",
 	)
+
+	fs.StringVar(
+		&c.dbDir,
+		"db-dir",
+		"",
+		"the directory of the throttle state database (if empty, the state is kept in memory)",
+	)
+
+	fs.DurationVar(
+		&c.addressCooldown,
+		"address-cooldown",
+		0,
+		"the minimum time between two requests for the same address (if 0, address cooldowns are disabled)",
+	)
+
+	fs.StringVar(
+		&c.githubClientID,
+		"github-client-id",
+		"",
+		"GitHub OAuth app client ID (if empty, GitHub authentication is disabled)",
+	)
+
+	fs.StringVar(
+		&c.githubClientSecret,
+		"github-client-secret",
+		"",
+		"GitHub OAuth app client secret",
+	)
+
+	fs.DurationVar(
+		&c.githubMinAccountAge,
+		"github-min-account-age",
+		defaultGitHubMinAccountAge,
+		"the minimum age of GitHub accounts",
+	)
+
+	fs.DurationVar(
+		&c.githubCooldown,
+		"github-cooldown",
+		defaultGitHubCooldown,
+		"the minimum time between two requests for the same GitHub account",
+	)
+
+	fs.UintVar(
+		&c.powDifficulty,
+		"pow-difficulty",
+		0,
+		"the number of leading zero bits required in proofs of work (if 0, proof of work is disabled)",
+	)
+
+	fs.DurationVar(
+		&c.powWindow,
+		"pow-window",
+		defaultPoWWindow,
+		"the maximum drift of proof of work timestamps",
+	)
 }
 
 // generateFaucetConfig generates the Faucet configuration
@@ -187,14 +257,47 @@ func execServe(ctx context.Context, cfg *serveCfg, io commands.IO) error {
 		),
 	)
 
+	// Open the throttle state database
+	db, err := openThrottleDB(cfg.dbDir)
+	if err != nil {
+		return fmt.Errorf("unable to open throttle database, %w", err)
+	}
+	defer db.Close()
+
+	store := newThrottleStore(db)
+
 	// Start throttled faucet.
-	st := newIPThrottler(defaultRateLimitInterval, defaultCleanTimeout)
+	st := newIPThrottler(store, defaultRateLimitInterval, defaultCleanTimeout)
 	st.start(ctx)
 
-	// Prepare the middlewares
+	var (
+		pow    *powVerifier
+		github *githubAuth
+	)
+
+	if cfg.powDifficulty > 0 {
+		pow = newPoWVerifier(store, cfg.powDifficulty, cfg.powWindow)
+	}
+
+	if cfg.githubClientID != "" {
+		github = newGitHubAuth(
+			store,
+			cfg.githubClientID,
+			cfg.githubClientSecret,
+			cfg.githubMinAccountAge,
+			cfg.githubCooldown,
+		)
+	}
+
+	// Prepare the middlewares.
+	// The reservations are made last, once every check passed, so that
+	// unverified requests can't lock out addresses, proofs of work or GitHub users
 	middlewares := []faucet.Middleware{
 		getIPMiddleware(cfg.isBehindProxy, st),
+		getPoWMiddleware(pow),
 		getCaptchaMiddleware(cfg.captchaSecret),
+		getGitHubMiddleware(github),
+		getReserveMiddleware(cfg.addressCooldown, store),
 	}
 
 	// Create a new faucet with
@@ -212,3 +315,13 @@ func execServe(ctx context.Context, cfg *serveCfg, io commands.IO) error {
 
 	return f.Serve(ctx)
 }
+
+// openThrottleDB opens the throttle state database,
+// which is kept in memory if no directory is given
+func openThrottleDB(dir string) (dbm.DB, error) {
+	if dir == "" {
+		return memdb.NewMemDB(), nil
+	}
+
+	return goleveldb.NewGoLevelDB("faucet", dir)
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+// Store key prefixes
+const (
+	ipPrefix      = "ip/"      // request rate of IPs
+	addressPrefix = "address/" // cooldown of recipient addresses
+	githubPrefix  = "github/"  // cooldown of GitHub users
+	powPrefix     = "pow/"     // used proof-of-work stamps
+)
+
+var errAlreadyReserved = errors.New("already reserved")
+
+// throttleEntry is the throttling state of a single key
+type throttleEntry struct {
+	Tokens  float64   `json:"tokens,omitempty"` // available requests (rate limited keys)
+	Updated time.Time `json:"updated"`          // last update of the entry
+	Expires time.Time `json:"expires"`          // time at which the entry can be dropped
+}
+
+// throttleStore keeps the throttling state of the faucet in a database,
+// so it is not lost on restarts
+type throttleStore struct {
+	db  dbm.DB
+	now func() time.Time
+
+	sync.Mutex
+}
+
+// newThrottleStore creates a new throttle store, using the given database
+func newThrottleStore(db dbm.DB) *throttleStore {
+	return &throttleStore{
+		db:  db,
+		now: time.Now,
+	}
+}
+
+// start starts the store cleanup service
+func (s *throttleStore) start(ctx context.Context, cleanupInterval time.Duration) {
+	go s.runCleanup(ctx, cleanupInterval)
+}
+
+// runCleanup runs the main store cleanup loop
+func (s *throttleStore) runCleanup(ctx context.Context, cleanupInterval time.Duration) {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.cleanup()
+		}
+	}
+}
+
+// cleanup removes the expired entries from the store
+func (s *throttleStore) cleanup() {
+	s.Lock()
+	defer s.Unlock()
+
+	now := s.now()
+
+	// Collect the expired keys first, as the db
+	// can't be written to while iterating
+	var expired [][]byte
+
+	it := s.db.Iterator(nil, nil)
+	for ; it.Valid(); it.Next() {
+		var entry throttleEntry
+		if err := json.Unmarshal(it.Value(), &entry); err != nil || now.After(entry.Expires) {
+			expired = append(expired, append([]byte(nil), it.Key()...))
+		}
+	}
+	it.Close()
+
+	for _, key := range expired {
+		s.db.Delete(key)
+	}
+}
+
+// get fetches the entry of the given key, if any (NOT thread safe)
+func (s *throttleStore) get(key string) (throttleEntry, bool) {
+	var entry throttleEntry
+
+	value := s.db.Get([]byte(key))
+	if value == nil {
+		return entry, false
+	}
+
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return entry, false
+	}
+
+	return entry, true
+}
+
+// set saves the entry of the given key (NOT thread safe)
+func (s *throttleStore) set(key string, entry throttleEntry) {
+	//nolint:errcheck // throttleEntry is always serializable
+	value, _ := json.Marshal(entry)
+
+	s.db.SetSync([]byte(key), value)
+}
+
+// allow takes a request from the token bucket of the key,
+// which holds up to burst requests and is refilled every interval.
+// The bucket is reset once the key is idle for ttl.
+// It returns false if no request is available
+func (s *throttleStore) allow(key string, interval time.Duration, burst int, ttl time.Duration) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	now := s.now()
+
+	entry, ok := s.get(key)
+	if !ok || now.After(entry.Expires) {
+		entry = throttleEntry{
+			Tokens:  float64(burst),
+			Updated: now,
+		}
+	}
+
+	// Refill the bucket
+	elapsed := now.Sub(entry.Updated)
+	entry.Tokens = min(float64(burst), entry.Tokens+float64(elapsed)/float64(interval))
+	entry.Updated = now
+
+	if entry.Tokens < 1 {
+		return false
+	}
+
+	entry.Tokens--
+	entry.Expires = now.Add(ttl)
+
+	s.set(key, entry)
+
+	return true
+}
+
+// reservation reserves a key for a duration,
+// once a request passed all the checks
+type reservation struct {
+	key      string
+	duration time.Duration
+	err      error // error reported if the key is already reserved (errAlreadyReserved by default)
+}
+
+// reserved returns true if the key is currently reserved
+func (s *throttleStore) reserved(key string) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	entry, ok := s.get(key)
+
+	return ok && s.now().Before(entry.Expires)
+}
+
+// reserve makes all the reservations.
+// It returns the error of the first reservation whose key is already reserved,
+// or given more than once, and reserves nothing in that case
+func (s *throttleStore) reserve(reservations ...reservation) error {
+	s.Lock()
+	defer s.Unlock()
+
+	now := s.now()
+	seen := make(map[string]struct{}, len(reservations))
+
+	for _, res := range reservations {
+		if _, ok := seen[res.key]; ok {
+			return cmp.Or(res.err, errAlreadyReserved)
+		}
+
+		seen[res.key] = struct{}{}
+
+		if entry, ok := s.get(res.key); ok && now.Before(entry.Expires) {
+			return cmp.Or(res.err, errAlreadyReserved)
+		}
+	}
+
+	for _, res := range reservations {
+		s.set(res.key, throttleEntry{
+			Updated: now,
+			Expires: now.Add(res.duration),
+		})
+	}
+
+	return nil
+}
+
+// release drops the reservations of the keys
+func (s *throttleStore) release(keys ...string) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, key := range keys {
+		s.db.DeleteSync([]byte(key))
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/db/goleveldb"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore creates a new in-memory throttle store,
+// with a controllable clock
+func newTestStore(t *testing.T) (*throttleStore, *time.Time) {
+	t.Helper()
+
+	now := time.Unix(1_700_000_000, 0)
+
+	store := newThrottleStore(memdb.NewMemDB())
+	store.now = func() time.Time {
+		return now
+	}
+
+	return store, &now
+}
+
+func TestThrottleStore_Allow(t *testing.T) {
+	t.Parallel()
+
+	store, now := newTestStore(t)
+
+	// Use up the burst
+	for i := 0; i < 3; i++ {
+		assert.True(t, store.allow("key", time.Second, 3, time.Minute))
+	}
+
+	assert.False(t, store.allow("key", time.Second, 3, time.Minute))
+
+	// Other keys are not affected
+	assert.True(t, store.allow("other", time.Second, 3, time.Minute))
+
+	// Wait for a single request to be refilled
+	*now = now.Add(time.Second)
+
+	assert.True(t, store.allow("key", time.Second, 3, time.Minute))
+	assert.False(t, store.allow("key", time.Second, 3, time.Minute))
+}
+
+func TestThrottleStore_Reserve(t *testing.T) {
+	t.Parallel()
+
+	store, now := newTestStore(t)
+
+	errA, errB := errors.New("a"), errors.New("b")
+	a := reservation{key: "a", duration: time.Minute, err: errA}
+	b := reservation{key: "b", duration: time.Minute, err: errB}
+	c := reservation{key: "c", duration: time.Hour}
+	d := reservation{key: "d", duration: time.Minute, err: errors.New("d")}
+
+	require.NoError(t, store.reserve(a, b))
+	assert.True(t, store.reserved("a"))
+
+	// Nothing is reserved if any key is already reserved
+	assert.ErrorIs(t, store.reserve(c, b), errB)
+	assert.False(t, store.reserved("c"))
+	assert.NoError(t, store.reserve(c))
+	assert.ErrorIs(t, store.reserve(c), errAlreadyReserved)
+
+	// Keys can't be reserved twice at once
+	assert.Error(t, store.reserve(d, d))
+
+	// Reservations expire
+	*now = now.Add(time.Minute + time.Second)
+
+	assert.NoError(t, store.reserve(a, b))
+	assert.True(t, store.reserved("c"))
+
+	// Released keys can be reserved again
+	store.release("a", "c")
+
+	assert.False(t, store.reserved("a"))
+	assert.NoError(t, store.reserve(a, c))
+}
+
+func TestThrottleStore_Cleanup(t *testing.T) {
+	t.Parallel()
+
+	store, now := newTestStore(t)
+
+	require.NoError(t, store.reserve(reservation{key: "short", duration: time.Minute}))
+	require.NoError(t, store.reserve(reservation{key: "long", duration: time.Hour}))
+
+	*now = now.Add(time.Minute * 2)
+	store.cleanup()
+
+	_, ok := store.get("short")
+	assert.False(t, ok)
+
+	_, ok = store.get("long")
+	assert.True(t, ok)
+}
+
+func TestThrottleStore_Persistence(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	db, err := goleveldb.NewGoLevelDB("faucet", dir)
+	require.NoError(t, err)
+
+	res := reservation{key: addressPrefix + "addr", duration: time.Hour, err: errAddressCooldown}
+
+	require.NoError(t, newThrottleStore(db).reserve(res))
+	db.Close()
+
+	// Reopen the database, and make sure the state is kept
+	db, err = goleveldb.NewGoLevelDB("faucet", dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	assert.ErrorIs(t, newThrottleStore(db).reserve(res), errAddressCooldown)
+}
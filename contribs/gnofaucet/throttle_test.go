@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,7 +22,7 @@ func TestIPThrottler_RegisterNewRequest(t *testing.T) {
 		require.NoError(t, err)
 
 		// Create the IP throttler
-		th := newIPThrottler(defaultRateLimitInterval, defaultCleanTimeout)
+		th := newIPThrottler(newThrottleStore(memdb.NewMemDB()), defaultRateLimitInterval, defaultCleanTimeout)
 
 		// Register < max requests
 		for i := uint64(0); i < maxRequestsPerMinute; i++ {
@@ -36,7 +37,7 @@ func TestIPThrottler_RegisterNewRequest(t *testing.T) {
 		require.NoError(t, err)
 
 		// Create the IP throttler
-		th := newIPThrottler(defaultRateLimitInterval, defaultCleanTimeout)
+		th := newIPThrottler(newThrottleStore(memdb.NewMemDB()), defaultRateLimitInterval, defaultCleanTimeout)
 
 		// Register max requests
 		for i := uint64(0); i < maxRequestsPerMinute; i++ {
@@ -62,7 +63,7 @@ func TestIPThrottler_RequestsThrottled(t *testing.T) {
 	require.NoError(t, err)
 
 	// Create the IP throttler
-	th := newIPThrottler(defaultRateLimitInterval, cleanupInterval)
+	th := newIPThrottler(newThrottleStore(memdb.NewMemDB()), defaultRateLimitInterval, cleanupInterval)
 
 	ctx, cancelFn := context.WithCancel(context.Background())
 	defer cancelFn()
@@ -0,0 +1,150 @@
+// Package checks contains the gnohealth node health checks,
+// and the serve mode exposing their results as Prometheus metrics
+package checks
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	rpcClient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+const (
+	defaultRemoteAddress = "http://127.0.0.1:26657"
+	defaultWebSocket     = false
+	defaultCheckTimeout  = time.Minute
+	defaultVerbose       = false
+)
+
+// Result is the outcome of a single health check run
+type Result struct {
+	Err     error    // nil if the check is healthy
+	Metrics []Metric // measurements gathered by the check
+}
+
+// checker is a single node health check
+type checker interface {
+	commands.Config
+
+	// name returns the name of the check
+	name() string
+
+	// check runs the check against the node
+	check(ctx context.Context, client rpcClient.Client) Result
+}
+
+// rpcCfg is the node RPC configuration, shared by all checks
+type rpcCfg struct {
+	remoteAddress string
+	webSocket     bool
+}
+
+// RegisterFlags registers command-line flags for the node RPC connection
+func (c *rpcCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.remoteAddress,
+		"remote",
+		defaultRemoteAddress,
+		"the remote address of the node to connect to via RPC",
+	)
+
+	fs.BoolVar(
+		&c.webSocket,
+		"ws",
+		defaultWebSocket,
+		"flag indicating whether to use the WebSocket protocol for RPC",
+	)
+}
+
+// newClient creates the node RPC client
+func (c *rpcCfg) newClient() (*rpcClient.RPCClient, error) {
+	if c.webSocket {
+		client, err := rpcClient.NewWSClient(c.remoteAddress)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create WS client: %w", err)
+		}
+
+		return client, nil
+	}
+
+	client, err := rpcClient.NewHTTPClient(c.remoteAddress)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create HTTP client: %w", err)
+	}
+
+	return client, nil
+}
+
+// checkCmdCfg is the configuration of a single check subcommand
+type checkCmdCfg struct {
+	rpcCfg
+
+	checker checker
+	timeout time.Duration
+	verbose bool
+}
+
+// RegisterFlags registers command-line flags for the check subcommand
+func (c *checkCmdCfg) RegisterFlags(fs *flag.FlagSet) {
+	c.rpcCfg.RegisterFlags(fs)
+	c.checker.RegisterFlags(fs)
+
+	fs.DurationVar(
+		&c.timeout,
+		"timeout",
+		defaultCheckTimeout,
+		"maximum duration of the check",
+	)
+
+	fs.BoolVar(
+		&c.verbose,
+		"verbose",
+		defaultVerbose,
+		"flag indicating whether to print the check metrics",
+	)
+}
+
+// newCheckCmd creates a subcommand running a single check
+func newCheckCmd(io commands.IO, meta commands.Metadata, checker checker) *commands.Command {
+	cfg := &checkCmdCfg{
+		checker: checker,
+	}
+
+	return commands.NewCommand(
+		meta,
+		cfg,
+		func(ctx context.Context, _ []string) error {
+			return execCheck(ctx, cfg, io)
+		},
+	)
+}
+
+func execCheck(ctx context.Context, cfg *checkCmdCfg, io commands.IO) error {
+	client, err := cfg.newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.timeout)
+	defer cancel()
+
+	res := cfg.checker.check(ctx, client)
+
+	if cfg.verbose {
+		for _, metric := range res.Metrics {
+			io.Println(metric.sample())
+		}
+	}
+
+	if res.Err != nil {
+		return fmt.Errorf("%s: %w: KO", cfg.checker.name(), res.Err)
+	}
+
+	io.Printf("%s: OK\n", cfg.checker.name())
+
+	return nil
+}
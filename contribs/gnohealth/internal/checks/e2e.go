@@ -0,0 +1,234 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoclient"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	rpcClient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+const (
+	defaultE2EChainID    = "dev"
+	defaultE2EPkgPath    = "gno.land/r/gnoland/monit"
+	defaultE2EFunc       = "Incr"
+	defaultE2EGasFee     = "10000000ugnot"
+	defaultE2EGasWanted  = 10_000_000
+	defaultE2EMaxLatency = 30 * time.Second
+)
+
+var errMissingMnemonic = errors.New("missing mnemonic")
+
+// e2eCfg checks that transactions are included in blocks,
+// by signing a MsgCall to a test realm and waiting for its inclusion
+type e2eCfg struct {
+	mnemonic   string
+	chainID    string
+	pkgPath    string
+	funcName   string
+	args       commands.StringArr
+	gasFee     string
+	gasWanted  int64
+	maxLatency time.Duration
+
+	mux     sync.Mutex
+	client  rpcClient.Client
+	builder *gnoclient.TxBuilder
+}
+
+// NewE2ECmd creates the gnohealth e2e subcommand
+func NewE2ECmd(io commands.IO) *commands.Command {
+	return newCheckCmd(
+		io,
+		commands.Metadata{
+			Name:       "e2e",
+			ShortUsage: "e2e [flags]",
+			ShortHelp:  "check if transactions are included in blocks",
+			LongHelp:   "This command signs a MsgCall to a test realm, broadcasts it and waits for its inclusion in a block.",
+		},
+		&e2eCfg{},
+	)
+}
+
+// RegisterFlags registers command-line flags for the e2e check
+func (c *e2eCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.mnemonic,
+		"mnemonic",
+		"",
+		"the mnemonic of the (funded) account signing the test transaction",
+	)
+
+	fs.StringVar(
+		&c.chainID,
+		"chain-id",
+		defaultE2EChainID,
+		"the chain ID of the remote node",
+	)
+
+	fs.StringVar(
+		&c.pkgPath,
+		"pkgpath",
+		defaultE2EPkgPath,
+		"the package path of the test realm",
+	)
+
+	fs.StringVar(
+		&c.funcName,
+		"func",
+		defaultE2EFunc,
+		"the test realm function to call",
+	)
+
+	fs.Var(
+		&c.args,
+		"args",
+		"the arguments of the test realm function (can be repeated)",
+	)
+
+	fs.StringVar(
+		&c.gasFee,
+		"gas-fee",
+		defaultE2EGasFee,
+		"the gas fee of the test transaction",
+	)
+
+	fs.Int64Var(
+		&c.gasWanted,
+		"gas-wanted",
+		defaultE2EGasWanted,
+		"the gas wanted of the test transaction",
+	)
+
+	fs.DurationVar(
+		&c.maxLatency,
+		"max-latency",
+		defaultE2EMaxLatency,
+		"maximum time for the test transaction to be included in a block",
+	)
+}
+
+func (c *e2eCfg) name() string {
+	return "e2e"
+}
+
+func (c *e2eCfg) check(ctx context.Context, client rpcClient.Client) Result {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	builder, caller, err := c.txBuilder(client)
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	msg := vm.MsgCall{
+		Caller:  caller,
+		PkgPath: c.pkgPath,
+		Func:    c.funcName,
+		Args:    c.args,
+	}
+
+	type broadcastResult struct {
+		res *gnoclient.BroadcastResult
+		err error
+	}
+
+	var (
+		start  = time.Now()
+		doneCh = make(chan broadcastResult, 1)
+	)
+
+	// Broadcast in the background, so the check
+	// can give up once the context is done
+	go func() {
+		res, err := builder.Broadcast(
+			gnoclient.BaseTxCfg{
+				GasFee:    c.gasFee,
+				GasWanted: c.gasWanted,
+				Memo:      "gnohealth",
+			},
+			msg,
+		)
+
+		doneCh <- broadcastResult{res: res, err: err}
+	}()
+
+	var done broadcastResult
+
+	select {
+	case <-ctx.Done():
+		// The transaction may still be included,
+		// so the sequence can't be trusted anymore
+		c.builder = nil
+
+		return Result{Err: fmt.Errorf("transaction not included: %w", ctx.Err())}
+	case done = <-doneCh:
+	}
+
+	latency := time.Since(start)
+
+	if done.err != nil {
+		return Result{Err: fmt.Errorf("unable to broadcast transaction: %w", done.err)}
+	}
+
+	res := Result{
+		Metrics: []Metric{
+			{
+				Name:  "e2e_latency_seconds",
+				Help:  "Time for the test transaction to be included in a block.",
+				Value: latency.Seconds(),
+			},
+			{
+				Name:  "e2e_height",
+				Help:  "Height of the block including the test transaction.",
+				Value: float64(done.res.Commit.Height),
+			},
+		},
+	}
+
+	if latency > c.maxLatency {
+		res.Err = fmt.Errorf("transaction included after %s (max %s)", latency, c.maxLatency)
+	}
+
+	return res
+}
+
+// txBuilder returns the transaction builder of the test account,
+// along with its address. The builder is kept between runs,
+// so the account sequence is tracked locally
+func (c *e2eCfg) txBuilder(client rpcClient.Client) (*gnoclient.TxBuilder, crypto.Address, error) {
+	if c.mnemonic == "" {
+		return nil, crypto.Address{}, errMissingMnemonic
+	}
+
+	signer, err := gnoclient.SignerFromBip39(c.mnemonic, c.chainID, "", 0, 0)
+	if err != nil {
+		return nil, crypto.Address{}, fmt.Errorf("unable to create signer: %w", err)
+	}
+
+	info, err := signer.Info()
+	if err != nil {
+		return nil, crypto.Address{}, fmt.Errorf("unable to fetch signer info: %w", err)
+	}
+
+	if c.builder == nil || c.client != client {
+		c.builder, err = (&gnoclient.Client{
+			Signer:    signer,
+			RPCClient: client,
+		}).NewTxBuilder()
+		if err != nil {
+			return nil, crypto.Address{}, fmt.Errorf("unable to create transaction builder: %w", err)
+		}
+
+		c.client = client
+	}
+
+	return c.builder, info.GetAddress(), nil
+}
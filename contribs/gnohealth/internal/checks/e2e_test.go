@@ -0,0 +1,218 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const testMnemonic = "index brass unknown lecture autumn provide royal shrimp elegant wink now zebra discover swarm act ill you bullet entire outdoor tilt usage gap multiply"
+
+// newE2ETestCfg returns an e2e check signing with the test mnemonic
+func newE2ETestCfg() *e2eCfg {
+	return &e2eCfg{
+		mnemonic:   testMnemonic,
+		chainID:    defaultE2EChainID,
+		pkgPath:    defaultE2EPkgPath,
+		funcName:   defaultE2EFunc,
+		gasFee:     defaultE2EGasFee,
+		gasWanted:  defaultE2EGasWanted,
+		maxLatency: defaultE2EMaxLatency,
+	}
+}
+
+// queryAccount returns an account query response for a new account
+func queryAccount(t *testing.T) mockABCIQuery {
+	t.Helper()
+
+	data, err := amino.MarshalJSON(struct{ BaseAccount std.BaseAccount }{})
+	require.NoError(t, err)
+
+	return func(_ string, _ []byte) (*ctypes.ResultABCIQuery, error) {
+		return &ctypes.ResultABCIQuery{
+			Response: abci.ResponseQuery{
+				ResponseBase: abci.ResponseBase{Data: data},
+			},
+		}, nil
+	}
+}
+
+// broadcastTx returns a broadcast mock including the transaction at the given height
+func broadcastTx(height int64) mockBroadcastTx {
+	return func(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+		return &ctypes.ResultBroadcastTxCommit{
+			Hash:   tx.Hash(),
+			Height: height,
+		}, nil
+	}
+}
+
+func TestE2E_Check(t *testing.T) {
+	t.Parallel()
+
+	t.Run("included", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockClient{
+			abciQuery:   queryAccount(t),
+			broadcastTx: broadcastTx(42),
+		}
+
+		res := newE2ETestCfg().check(context.Background(), client)
+		require.NoError(t, res.Err)
+
+		require.Len(t, res.Metrics, 2)
+		assert.Equal(t, "e2e_height", res.Metrics[1].Name)
+		assert.Equal(t, float64(42), res.Metrics[1].Value)
+	})
+
+	testTable := []struct {
+		name      string
+		mnemonic  string
+		query     func(t *testing.T) mockABCIQuery
+		broadcast mockBroadcastTx
+		err       string
+	}{
+		{
+			name:     "missing mnemonic",
+			mnemonic: "",
+			err:      errMissingMnemonic.Error(),
+		},
+		{
+			name:     "invalid mnemonic",
+			mnemonic: "invalid",
+			err:      "unable to create signer",
+		},
+		{
+			name:     "account query error",
+			mnemonic: testMnemonic,
+			query: func(*testing.T) mockABCIQuery {
+				return func(_ string, _ []byte) (*ctypes.ResultABCIQuery, error) {
+					return nil, errors.New("unreachable")
+				}
+			},
+			err: "unreachable",
+		},
+		{
+			name:     "unknown account",
+			mnemonic: testMnemonic,
+			query: func(*testing.T) mockABCIQuery {
+				return func(_ string, _ []byte) (*ctypes.ResultABCIQuery, error) {
+					return &ctypes.ResultABCIQuery{}, nil
+				}
+			},
+			err: "unknown address",
+		},
+		{
+			name:     "broadcast error",
+			mnemonic: testMnemonic,
+			query:    queryAccount,
+			broadcast: func(_ types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+				return nil, errors.New("connection reset")
+			},
+			err: "connection reset",
+		},
+		{
+			name:     "check error",
+			mnemonic: testMnemonic,
+			query:    queryAccount,
+			broadcast: func(_ types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+				return &ctypes.ResultBroadcastTxCommit{
+					CheckTx: abci.ResponseCheckTx{
+						ResponseBase: abci.ResponseBase{Error: std.InsufficientFundsError{}},
+					},
+				}, nil
+			},
+			err: "insufficient funds",
+		},
+		{
+			name:     "deliver error",
+			mnemonic: testMnemonic,
+			query:    queryAccount,
+			broadcast: func(_ types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+				return &ctypes.ResultBroadcastTxCommit{
+					DeliverTx: abci.ResponseDeliverTx{
+						ResponseBase: abci.ResponseBase{Error: std.InternalError{}},
+					},
+				}, nil
+			},
+			err: "internal error",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &mockClient{broadcastTx: testCase.broadcast}
+			if testCase.query != nil {
+				client.abciQuery = testCase.query(t)
+			}
+
+			cfg := newE2ETestCfg()
+			cfg.mnemonic = testCase.mnemonic
+
+			res := cfg.check(context.Background(), client)
+			assert.ErrorContains(t, res.Err, testCase.err)
+			assert.Empty(t, res.Metrics)
+		})
+	}
+
+	t.Run("latency above threshold", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockClient{
+			abciQuery: queryAccount(t),
+			broadcastTx: func(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+				time.Sleep(10 * time.Millisecond)
+
+				return broadcastTx(1)(tx)
+			},
+		}
+
+		cfg := newE2ETestCfg()
+		cfg.maxLatency = time.Millisecond
+
+		res := cfg.check(context.Background(), client)
+		assert.ErrorContains(t, res.Err, "transaction included after")
+		assert.Len(t, res.Metrics, 2)
+	})
+
+	t.Run("not included", func(t *testing.T) {
+		t.Parallel()
+
+		release := make(chan struct{})
+		defer close(release)
+
+		client := &mockClient{
+			abciQuery: queryAccount(t),
+			broadcastTx: func(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+				<-release
+
+				return broadcastTx(1)(tx)
+			},
+		}
+
+		cfg := newE2ETestCfg()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		res := cfg.check(ctx, client)
+		assert.ErrorIs(t, res.Err, context.DeadlineExceeded)
+		assert.ErrorContains(t, res.Err, "transaction not included")
+
+		// The sequence can't be trusted anymore, so the builder is dropped
+		assert.Nil(t, cfg.builder)
+	})
+}
@@ -0,0 +1,95 @@
+package checks
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	rpcClient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+const defaultMaxBlockAge = 30 * time.Second
+
+// livenessCfg checks that the node is producing (or receiving) new blocks
+type livenessCfg struct {
+	maxBlockAge time.Duration
+
+	now func() time.Time
+}
+
+// NewLivenessCmd creates the gnohealth liveness subcommand
+func NewLivenessCmd(io commands.IO) *commands.Command {
+	return newCheckCmd(
+		io,
+		commands.Metadata{
+			Name:       "liveness",
+			ShortUsage: "liveness [flags]",
+			ShortHelp:  "check if blocks are being produced",
+			LongHelp:   "This command checks if the node is synced and if its latest block is recent enough.",
+		},
+		newLivenessCfg(),
+	)
+}
+
+func newLivenessCfg() *livenessCfg {
+	return &livenessCfg{
+		now: time.Now,
+	}
+}
+
+// RegisterFlags registers command-line flags for the liveness check
+func (c *livenessCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.DurationVar(
+		&c.maxBlockAge,
+		"max-block-age",
+		defaultMaxBlockAge,
+		"maximum allowable time difference between the current time and the latest block time",
+	)
+}
+
+func (c *livenessCfg) name() string {
+	return "liveness"
+}
+
+func (c *livenessCfg) check(_ context.Context, client rpcClient.Client) Result {
+	status, err := client.Status()
+	if err != nil {
+		return Result{Err: fmt.Errorf("unable to fetch status: %w", err)}
+	}
+
+	var (
+		info = status.SyncInfo
+		age  = c.now().Sub(info.LatestBlockTime)
+	)
+
+	res := Result{
+		Metrics: []Metric{
+			{
+				Name:  "latest_block_height",
+				Help:  "Height of the latest block of the node.",
+				Value: float64(info.LatestBlockHeight),
+			},
+			{
+				Name:  "latest_block_age_seconds",
+				Help:  "Time elapsed since the latest block of the node.",
+				Value: age.Seconds(),
+			},
+			{
+				Name:  "catching_up",
+				Help:  "Whether the node is catching up with the chain.",
+				Value: boolValue(info.CatchingUp),
+			},
+		},
+	}
+
+	switch {
+	case info.CatchingUp:
+		res.Err = fmt.Errorf("node is catching up (height %d)", info.LatestBlockHeight)
+	case age > c.maxBlockAge:
+		res.Err = fmt.Errorf("block %d is %s old (max %s)", info.LatestBlockHeight, age, c.maxBlockAge)
+	}
+
+	return res
+}
@@ -0,0 +1,75 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+)
+
+func TestLiveness_Check(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+
+	testTable := []struct {
+		name       string
+		blockAge   time.Duration
+		catchingUp bool
+		healthy    bool
+	}{
+		{"recent block", time.Second, false, true},
+		{"at threshold", 30 * time.Second, false, true},
+		{"above threshold", 31 * time.Second, false, false},
+		{"catching up", time.Second, true, false},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newLivenessCfg()
+			cfg.maxBlockAge = 30 * time.Second
+			cfg.now = func() time.Time { return now }
+
+			client := &mockClient{
+				status: func() (*ctypes.ResultStatus, error) {
+					return &ctypes.ResultStatus{
+						SyncInfo: ctypes.SyncInfo{
+							LatestBlockHeight: 10,
+							LatestBlockTime:   now.Add(-testCase.blockAge),
+							CatchingUp:        testCase.catchingUp,
+						},
+					}, nil
+				},
+			}
+
+			res := cfg.check(context.Background(), client)
+			assert.Equal(t, testCase.healthy, res.Err == nil, res.Err)
+
+			require.Len(t, res.Metrics, 3)
+			assert.Equal(t, float64(10), res.Metrics[0].Value)
+			assert.Equal(t, testCase.blockAge.Seconds(), res.Metrics[1].Value)
+			assert.Equal(t, boolValue(testCase.catchingUp), res.Metrics[2].Value)
+		})
+	}
+
+	t.Run("status error", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockClient{
+			status: func() (*ctypes.ResultStatus, error) {
+				return nil, errors.New("unreachable")
+			},
+		}
+
+		res := newLivenessCfg().check(context.Background(), client)
+		assert.ErrorContains(t, res.Err, "unreachable")
+		assert.Empty(t, res.Metrics)
+	})
+}
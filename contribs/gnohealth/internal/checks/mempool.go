@@ -0,0 +1,187 @@
+package checks
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sync"
+	"time"
+
+	rpcClient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+const (
+	defaultMaxMempoolSize = 1000
+	defaultMaxMempoolAge  = 5 * time.Minute
+	defaultMempoolObserve = 10 * time.Second
+
+	// mempoolSampleInterval is the interval between mempool samples
+	mempoolSampleInterval = time.Second
+
+	// mempoolSampleLimit is the number of (oldest) mempool
+	// transactions tracked on each sample, which is the RPC maximum
+	mempoolSampleLimit = 100
+)
+
+// mempoolCfg checks the size of the mempool, and the age
+// of its transactions. As the node doesn't expose transaction ages,
+// they are measured from when they were first seen by the check
+type mempoolCfg struct {
+	maxSize int
+	maxAge  time.Duration
+	observe time.Duration
+
+	now func() time.Time
+
+	mux       sync.Mutex
+	firstSeen map[string]time.Time // transaction hash -> first sample time
+}
+
+// NewMempoolCmd creates the gnohealth mempool subcommand
+func NewMempoolCmd(io commands.IO) *commands.Command {
+	return newCheckCmd(
+		io,
+		commands.Metadata{
+			Name:       "mempool",
+			ShortUsage: "mempool [flags]",
+			ShortHelp:  "check the mempool size and transaction age",
+			LongHelp:   "This command checks the number of pending transactions, and samples the mempool to detect transactions stuck for too long.",
+		},
+		newMempoolCfg(),
+	)
+}
+
+func newMempoolCfg() *mempoolCfg {
+	return &mempoolCfg{
+		now:       time.Now,
+		firstSeen: make(map[string]time.Time),
+	}
+}
+
+// RegisterFlags registers command-line flags for the mempool check
+func (c *mempoolCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.IntVar(
+		&c.maxSize,
+		"max-mempool-size",
+		defaultMaxMempoolSize,
+		"maximum number of pending transactions",
+	)
+
+	fs.DurationVar(
+		&c.maxAge,
+		"max-mempool-age",
+		defaultMaxMempoolAge,
+		"maximum time a transaction can stay in the mempool",
+	)
+
+	fs.DurationVar(
+		&c.observe,
+		"mempool-observe",
+		defaultMempoolObserve,
+		"duration for which the mempool is sampled to measure transaction ages",
+	)
+}
+
+func (c *mempoolCfg) name() string {
+	return "mempool"
+}
+
+func (c *mempoolCfg) check(ctx context.Context, client rpcClient.Client) Result {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	var (
+		start = c.now()
+
+		size     int
+		bytes    int64
+		oldestAt time.Time
+		err      error
+	)
+
+	for {
+		if size, bytes, oldestAt, err = c.sample(client); err != nil {
+			return Result{Err: err}
+		}
+
+		if c.now().Sub(start) >= c.observe {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return Result{Err: ctx.Err()}
+		case <-time.After(mempoolSampleInterval):
+		}
+	}
+
+	var age time.Duration
+	if !oldestAt.IsZero() {
+		age = c.now().Sub(oldestAt)
+	}
+
+	res := Result{
+		Metrics: []Metric{
+			{
+				Name:  "mempool_size",
+				Help:  "Number of transactions in the mempool.",
+				Value: float64(size),
+			},
+			{
+				Name:  "mempool_bytes",
+				Help:  "Total size of the transactions in the mempool, in bytes.",
+				Value: float64(bytes),
+			},
+			{
+				Name:  "mempool_oldest_tx_age_seconds",
+				Help:  "Time elapsed since the oldest mempool transaction was first seen.",
+				Value: age.Seconds(),
+			},
+		},
+	}
+
+	switch {
+	case size > c.maxSize:
+		res.Err = fmt.Errorf("mempool holds %d transactions (max %d)", size, c.maxSize)
+	case age > c.maxAge:
+		res.Err = fmt.Errorf("a transaction is pending for %s (max %s)", age, c.maxAge)
+	}
+
+	return res
+}
+
+// sample fetches the oldest mempool transactions, updates their first seen time,
+// and returns the mempool size and the first seen time of the oldest transaction
+func (c *mempoolCfg) sample(client rpcClient.Client) (int, int64, time.Time, error) {
+	res, err := client.UnconfirmedTxs(mempoolSampleLimit)
+	if err != nil {
+		return 0, 0, time.Time{}, fmt.Errorf("unable to fetch unconfirmed transactions: %w", err)
+	}
+
+	var (
+		now      = c.now()
+		oldestAt time.Time
+		present  = make(map[string]time.Time, len(res.Txs))
+	)
+
+	for _, tx := range res.Txs {
+		hash := string(tx.Hash())
+
+		seen, ok := c.firstSeen[hash]
+		if !ok {
+			seen = now
+		}
+
+		present[hash] = seen
+
+		if oldestAt.IsZero() || seen.Before(oldestAt) {
+			oldestAt = seen
+		}
+	}
+
+	// Forget the transactions that left the mempool
+	c.firstSeen = present
+
+	return res.Total, res.TotalBytes, oldestAt, nil
+}
@@ -0,0 +1,144 @@
+package checks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+// newMempoolTestCfg returns a mempool check sampling the mempool once,
+// with a clock that only moves when advanced
+func newMempoolTestCfg(maxSize int, maxAge time.Duration) (*mempoolCfg, func(time.Duration)) {
+	now := time.Unix(1_700_000_000, 0)
+
+	cfg := newMempoolCfg()
+	cfg.maxSize = maxSize
+	cfg.maxAge = maxAge
+	cfg.now = func() time.Time { return now }
+
+	return cfg, func(d time.Duration) { now = now.Add(d) }
+}
+
+// newMempoolClient returns a client whose mempool holds the given transactions
+func newMempoolClient(txs *[]types.Tx) *mockClient {
+	return &mockClient{
+		unconfirmedTxs: func(limit int) (*ctypes.ResultUnconfirmedTxs, error) {
+			var bytes int64
+			for _, tx := range *txs {
+				bytes += int64(len(tx))
+			}
+
+			return &ctypes.ResultUnconfirmedTxs{
+				Count:      len(*txs),
+				Total:      len(*txs),
+				TotalBytes: bytes,
+				Txs:        *txs,
+			}, nil
+		},
+	}
+}
+
+func TestMempool_Size(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name    string
+		size    int
+		healthy bool
+	}{
+		{"empty", 0, true},
+		{"at threshold", 2, true},
+		{"above threshold", 3, false},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			txs := make([]types.Tx, 0, testCase.size)
+			for i := 0; i < testCase.size; i++ {
+				txs = append(txs, types.Tx{byte(i)})
+			}
+
+			cfg, _ := newMempoolTestCfg(2, time.Minute)
+
+			res := cfg.check(context.Background(), newMempoolClient(&txs))
+
+			if testCase.healthy {
+				assert.NoError(t, res.Err)
+			} else {
+				assert.ErrorContains(t, res.Err, "mempool holds 3 transactions (max 2)")
+			}
+
+			require.Len(t, res.Metrics, 3)
+			assert.Equal(t, "mempool_size", res.Metrics[0].Name)
+			assert.Equal(t, float64(testCase.size), res.Metrics[0].Value)
+			assert.Equal(t, "mempool_bytes", res.Metrics[1].Name)
+			assert.Equal(t, float64(testCase.size), res.Metrics[1].Value)
+		})
+	}
+}
+
+func TestMempool_Age(t *testing.T) {
+	t.Parallel()
+
+	var (
+		stuck = types.Tx("stuck")
+		fresh = types.Tx("fresh")
+		txs   = []types.Tx{stuck}
+
+		client       = newMempoolClient(&txs)
+		cfg, advance = newMempoolTestCfg(10, time.Minute)
+	)
+
+	ageOf := func(res Result) float64 {
+		t.Helper()
+
+		require.Len(t, res.Metrics, 3)
+		assert.Equal(t, "mempool_oldest_tx_age_seconds", res.Metrics[2].Name)
+
+		return res.Metrics[2].Value
+	}
+
+	// The transaction is first seen
+	res := cfg.check(context.Background(), client)
+	require.NoError(t, res.Err)
+	assert.Equal(t, float64(0), ageOf(res))
+
+	// The transaction is still pending, within the max age
+	advance(time.Minute)
+	txs = append(txs, fresh)
+
+	res = cfg.check(context.Background(), client)
+	require.NoError(t, res.Err)
+	assert.Equal(t, float64(60), ageOf(res))
+
+	// The transaction is stuck for too long
+	advance(time.Second)
+
+	res = cfg.check(context.Background(), client)
+	assert.ErrorContains(t, res.Err, "a transaction is pending for 1m1s (max 1m0s)")
+	assert.Equal(t, float64(61), ageOf(res))
+
+	// Once the stuck transaction is included,
+	// the age is the one of the remaining transaction
+	txs = []types.Tx{fresh}
+
+	res = cfg.check(context.Background(), client)
+	require.NoError(t, res.Err)
+	assert.Equal(t, float64(1), ageOf(res))
+
+	// A transaction leaving and coming back is seen anew
+	advance(time.Minute)
+	txs = []types.Tx{stuck, fresh}
+
+	res = cfg.check(context.Background(), client)
+	assert.ErrorContains(t, res.Err, "a transaction is pending for 1m1s (max 1m0s)")
+	assert.Equal(t, float64(61), ageOf(res))
+}
@@ -0,0 +1,108 @@
+package checks
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// metricPrefix is the prefix of all gnohealth metrics
+const metricPrefix = "gnohealth_"
+
+// Metric is a single gauge measurement
+type Metric struct {
+	Name   string            // metric name, without the gnohealth_ prefix
+	Help   string            // metric description
+	Labels map[string]string // optional metric labels
+	Value  float64
+}
+
+// sample formats the metric as a Prometheus text exposition sample
+func (m Metric) sample() string {
+	var sb strings.Builder
+
+	sb.WriteString(metricPrefix + m.Name)
+
+	if len(m.Labels) > 0 {
+		keys := make([]string, 0, len(m.Labels))
+		for key := range m.Labels {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		sb.WriteByte('{')
+
+		for i, key := range keys {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+
+			fmt.Fprintf(&sb, "%s=\"%s\"", key, escapeLabelValue(m.Labels[key]))
+		}
+
+		sb.WriteByte('}')
+	}
+
+	sb.WriteByte(' ')
+	sb.WriteString(strconv.FormatFloat(m.Value, 'g', -1, 64))
+
+	return sb.String()
+}
+
+// labelValueEscaper escapes label values, as defined
+// by the Prometheus text exposition format
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
+
+// writeMetrics writes the metrics in the Prometheus text exposition format.
+// Samples of the same metric are grouped under a single HELP and TYPE header
+func writeMetrics(w io.Writer, metrics []Metric) error {
+	var (
+		names  []string
+		groups = make(map[string][]Metric)
+	)
+
+	for _, metric := range metrics {
+		if _, ok := groups[metric.Name]; !ok {
+			names = append(names, metric.Name)
+		}
+
+		groups[metric.Name] = append(groups[metric.Name], metric)
+	}
+
+	for _, name := range names {
+		group := groups[name]
+
+		if _, err := fmt.Fprintf(
+			w,
+			"# HELP %s%s %s\n# TYPE %s%s gauge\n",
+			metricPrefix, name, group[0].Help,
+			metricPrefix, name,
+		); err != nil {
+			return err
+		}
+
+		for _, metric := range group {
+			if _, err := fmt.Fprintln(w, metric.sample()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// boolValue converts the boolean to a metric value
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
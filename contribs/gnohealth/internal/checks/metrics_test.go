@@ -0,0 +1,87 @@
+package checks
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetric_Sample(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name     string
+		metric   Metric
+		expected string
+	}{
+		{
+			"no labels",
+			Metric{Name: "mempool_size", Value: 42},
+			"gnohealth_mempool_size 42",
+		},
+		{
+			"float value",
+			Metric{Name: "validator_participation_ratio", Value: 0.75},
+			"gnohealth_validator_participation_ratio 0.75",
+		},
+		{
+			"sorted labels",
+			Metric{Name: "check_up", Labels: map[string]string{"z": "1", "a": "2"}, Value: 1},
+			`gnohealth_check_up{a="2",z="1"} 1`,
+		},
+		{
+			"escaped label value",
+			Metric{Name: "peers", Labels: map[string]string{"v": "a\\b\"c\nd"}, Value: 0},
+			`gnohealth_peers{v="a\\b\"c\nd"} 0`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expected, testCase.metric.sample())
+		})
+	}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := []Metric{
+		{
+			Name:   "peers",
+			Help:   "Number of peers connected to the node.",
+			Labels: map[string]string{"direction": "inbound"},
+			Value:  2,
+		},
+		{
+			Name:  "mempool_size",
+			Help:  "Number of transactions in the mempool.",
+			Value: 0,
+		},
+		{
+			Name:   "peers",
+			Help:   "Number of peers connected to the node.",
+			Labels: map[string]string{"direction": "outbound"},
+			Value:  3,
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeMetrics(&buf, metrics))
+
+	// Samples of the same metric are grouped, in order of first appearance
+	expected := `# HELP gnohealth_peers Number of peers connected to the node.
+# TYPE gnohealth_peers gauge
+gnohealth_peers{direction="inbound"} 2
+gnohealth_peers{direction="outbound"} 3
+# HELP gnohealth_mempool_size Number of transactions in the mempool.
+# TYPE gnohealth_mempool_size gauge
+gnohealth_mempool_size 0
+`
+
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,107 @@
+package checks
+
+import (
+	"context"
+	"flag"
+
+	rpcClient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+// RPC client mock
+type (
+	mockStatus         func() (*ctypes.ResultStatus, error)
+	mockNetInfo        func() (*ctypes.ResultNetInfo, error)
+	mockCommit         func(height *int64) (*ctypes.ResultCommit, error)
+	mockValidators     func(height *int64) (*ctypes.ResultValidators, error)
+	mockUnconfirmedTxs func(limit int) (*ctypes.ResultUnconfirmedTxs, error)
+	mockABCIQuery      func(path string, data []byte) (*ctypes.ResultABCIQuery, error)
+	mockBroadcastTx    func(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
+)
+
+// mockClient is an RPC client mock, only implementing
+// the methods used by the checks
+type mockClient struct {
+	rpcClient.Client
+
+	status         mockStatus
+	netInfo        mockNetInfo
+	commit         mockCommit
+	validators     mockValidators
+	unconfirmedTxs mockUnconfirmedTxs
+	abciQuery      mockABCIQuery
+	broadcastTx    mockBroadcastTx
+}
+
+func (m *mockClient) Status() (*ctypes.ResultStatus, error) {
+	if m.status != nil {
+		return m.status()
+	}
+
+	return &ctypes.ResultStatus{}, nil
+}
+
+func (m *mockClient) NetInfo() (*ctypes.ResultNetInfo, error) {
+	if m.netInfo != nil {
+		return m.netInfo()
+	}
+
+	return &ctypes.ResultNetInfo{}, nil
+}
+
+func (m *mockClient) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	if m.commit != nil {
+		return m.commit(height)
+	}
+
+	return &ctypes.ResultCommit{}, nil
+}
+
+func (m *mockClient) Validators(height *int64) (*ctypes.ResultValidators, error) {
+	if m.validators != nil {
+		return m.validators(height)
+	}
+
+	return &ctypes.ResultValidators{}, nil
+}
+
+func (m *mockClient) UnconfirmedTxs(limit int) (*ctypes.ResultUnconfirmedTxs, error) {
+	if m.unconfirmedTxs != nil {
+		return m.unconfirmedTxs(limit)
+	}
+
+	return &ctypes.ResultUnconfirmedTxs{}, nil
+}
+
+func (m *mockClient) ABCIQuery(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+	if m.abciQuery != nil {
+		return m.abciQuery(path, data)
+	}
+
+	return &ctypes.ResultABCIQuery{}, nil
+}
+
+func (m *mockClient) BroadcastTxCommit(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	if m.broadcastTx != nil {
+		return m.broadcastTx(tx)
+	}
+
+	return &ctypes.ResultBroadcastTxCommit{}, nil
+}
+
+// mockChecker is a check returning a fixed result
+type mockChecker struct {
+	checkName string
+	res       Result
+}
+
+func (m *mockChecker) RegisterFlags(_ *flag.FlagSet) {}
+
+func (m *mockChecker) name() string {
+	return m.checkName
+}
+
+func (m *mockChecker) check(_ context.Context, _ rpcClient.Client) Result {
+	return m.res
+}
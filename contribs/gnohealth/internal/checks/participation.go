@@ -0,0 +1,175 @@
+package checks
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+
+	rpcClient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+const (
+	defaultParticipationBlocks = 10
+	defaultMinParticipation    = 0.67
+)
+
+// participationCfg checks the share of voting power
+// signing the commits of the latest blocks
+type participationCfg struct {
+	blocks           int64
+	minParticipation float64
+}
+
+// NewParticipationCmd creates the gnohealth participation subcommand
+func NewParticipationCmd(io commands.IO) *commands.Command {
+	return newCheckCmd(
+		io,
+		commands.Metadata{
+			Name:       "participation",
+			ShortUsage: "participation [flags]",
+			ShortHelp:  "check the validator participation",
+			LongHelp:   "This command checks the share of voting power that signed the commits of the latest blocks, and reports the blocks missed by each validator.",
+		},
+		&participationCfg{},
+	)
+}
+
+// RegisterFlags registers command-line flags for the participation check
+func (c *participationCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.Int64Var(
+		&c.blocks,
+		"blocks",
+		defaultParticipationBlocks,
+		"number of latest blocks to check",
+	)
+
+	fs.Float64Var(
+		&c.minParticipation,
+		"min-participation",
+		defaultMinParticipation,
+		"minimum share of voting power (0-1) that must sign each commit",
+	)
+}
+
+func (c *participationCfg) name() string {
+	return "participation"
+}
+
+func (c *participationCfg) check(ctx context.Context, client rpcClient.Client) Result {
+	if c.blocks < 1 {
+		return Result{Err: fmt.Errorf("invalid number of blocks %d", c.blocks)}
+	}
+
+	status, err := client.Status()
+	if err != nil {
+		return Result{Err: fmt.Errorf("unable to fetch status: %w", err)}
+	}
+
+	var (
+		latest = status.SyncInfo.LatestBlockHeight
+		from   = max(1, latest-c.blocks+1)
+
+		minRatio     = 1.0
+		minHeight    int64
+		missed       = make(map[string]int) // validator address -> missed blocks
+		latestSetLen int
+	)
+
+	for height := from; height <= latest; height++ {
+		if err := ctx.Err(); err != nil {
+			return Result{Err: err}
+		}
+
+		commit, err := client.Commit(&height)
+		if err != nil {
+			return Result{Err: fmt.Errorf("unable to fetch commit %d: %w", height, err)}
+		}
+
+		validators, err := client.Validators(&height)
+		if err != nil {
+			return Result{Err: fmt.Errorf("unable to fetch validators %d: %w", height, err)}
+		}
+
+		// Gather the validators that signed the commit
+		signed := make(map[string]bool)
+
+		for _, sig := range commit.Commit.Precommits {
+			if sig == nil || !sig.BlockID.Equals(commit.Commit.BlockID) {
+				continue
+			}
+
+			signed[sig.ValidatorAddress.String()] = true
+		}
+
+		var totalPower, signedPower int64
+
+		for _, validator := range validators.Validators {
+			address := validator.Address.String()
+			totalPower += validator.VotingPower
+
+			if signed[address] {
+				signedPower += validator.VotingPower
+				missed[address] += 0 // make sure the validator is reported
+
+				continue
+			}
+
+			missed[address]++
+		}
+
+		latestSetLen = len(validators.Validators)
+
+		if totalPower == 0 {
+			continue
+		}
+
+		if ratio := float64(signedPower) / float64(totalPower); ratio < minRatio {
+			minRatio = ratio
+			minHeight = height
+		}
+	}
+
+	res := Result{
+		Metrics: []Metric{
+			{
+				Name:  "validator_participation_ratio",
+				Help:  "Lowest share of voting power signing the commits of the checked blocks.",
+				Value: minRatio,
+			},
+			{
+				Name:  "validator_set_size",
+				Help:  "Number of validators in the latest validator set.",
+				Value: float64(latestSetLen),
+			},
+		},
+	}
+
+	addresses := make([]string, 0, len(missed))
+	for address := range missed {
+		addresses = append(addresses, address)
+	}
+
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		res.Metrics = append(res.Metrics, Metric{
+			Name:   "validator_missed_blocks",
+			Help:   "Number of checked blocks whose commit was not signed by the validator.",
+			Labels: map[string]string{"validator": address},
+			Value:  float64(missed[address]),
+		})
+	}
+
+	if minRatio < c.minParticipation {
+		res.Err = fmt.Errorf(
+			"block %d was signed by %.2f%% of the voting power (min %.2f%%)",
+			minHeight,
+			minRatio*100,
+			c.minParticipation*100,
+		)
+	}
+
+	return res
+}
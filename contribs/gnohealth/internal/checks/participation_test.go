@@ -0,0 +1,165 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+// newParticipationClient mocks a chain of 3 blocks with 4 validators of
+// equal power, where the commit of the last block is only signed by the
+// first 2 validators (50%), and the commit of the others by all of them
+func newParticipationClient(t *testing.T) (*mockClient, []crypto.Address) {
+	t.Helper()
+
+	addresses := make([]crypto.Address, 4)
+	for i := range addresses {
+		addresses[i] = crypto.AddressFromPreimage([]byte{byte(i)})
+	}
+
+	blockID := types.BlockID{Hash: []byte("block")}
+
+	return &mockClient{
+		status: func() (*ctypes.ResultStatus, error) {
+			return &ctypes.ResultStatus{
+				SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 3},
+			}, nil
+		},
+		commit: func(height *int64) (*ctypes.ResultCommit, error) {
+			signers := addresses
+			if *height == 3 {
+				signers = addresses[:2]
+			}
+
+			precommits := make([]*types.CommitSig, 0, len(addresses))
+			for _, address := range signers {
+				precommits = append(precommits, &types.CommitSig{
+					BlockID:          blockID,
+					ValidatorAddress: address,
+				})
+			}
+
+			// nil precommits and precommits for another block don't count
+			precommits = append(
+				precommits,
+				nil,
+				&types.CommitSig{ValidatorAddress: addresses[3]},
+			)
+
+			return &ctypes.ResultCommit{
+				SignedHeader: types.SignedHeader{
+					Commit: &types.Commit{BlockID: blockID, Precommits: precommits},
+				},
+			}, nil
+		},
+		validators: func(height *int64) (*ctypes.ResultValidators, error) {
+			validators := make([]*types.Validator, 0, len(addresses))
+			for _, address := range addresses {
+				validators = append(validators, &types.Validator{Address: address, VotingPower: 10})
+			}
+
+			return &ctypes.ResultValidators{BlockHeight: *height, Validators: validators}, nil
+		},
+	}, addresses
+}
+
+func TestParticipation_Threshold(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name             string
+		blocks           int64
+		minParticipation float64
+		healthy          bool
+		ratio            float64
+	}{
+		{"below threshold", 3, 0.67, false, 0.5},
+		{"at threshold", 3, 0.5, true, 0.5},
+		{"only the latest block", 1, 0.51, false, 0.5},
+		{"more blocks than the chain", 10, 0.4, true, 0.5},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, addresses := newParticipationClient(t)
+			cfg := &participationCfg{
+				blocks:           testCase.blocks,
+				minParticipation: testCase.minParticipation,
+			}
+
+			res := cfg.check(context.Background(), client)
+
+			if testCase.healthy {
+				assert.NoError(t, res.Err)
+			} else {
+				require.Error(t, res.Err)
+				assert.Contains(t, res.Err.Error(), "block 3 was signed by 50.00% of the voting power")
+			}
+
+			require.Len(t, res.Metrics, 2+len(addresses))
+			assert.Equal(t, "validator_participation_ratio", res.Metrics[0].Name)
+			assert.Equal(t, testCase.ratio, res.Metrics[0].Value)
+			assert.Equal(t, "validator_set_size", res.Metrics[1].Name)
+			assert.Equal(t, float64(len(addresses)), res.Metrics[1].Value)
+		})
+	}
+}
+
+func TestParticipation_MissedBlocks(t *testing.T) {
+	t.Parallel()
+
+	client, addresses := newParticipationClient(t)
+	cfg := &participationCfg{blocks: 3, minParticipation: 0}
+
+	res := cfg.check(context.Background(), client)
+	require.NoError(t, res.Err)
+
+	missed := make(map[string]float64)
+	for _, metric := range res.Metrics[2:] {
+		assert.Equal(t, "validator_missed_blocks", metric.Name)
+		missed[metric.Labels["validator"]] = metric.Value
+	}
+
+	assert.Equal(t, map[string]float64{
+		addresses[0].String(): 0,
+		addresses[1].String(): 0,
+		addresses[2].String(): 1,
+		addresses[3].String(): 1,
+	}, missed)
+}
+
+func TestParticipation_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid number of blocks", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &participationCfg{blocks: 0}
+
+		res := cfg.check(context.Background(), &mockClient{})
+		assert.ErrorContains(t, res.Err, "invalid number of blocks 0")
+	})
+
+	t.Run("commit error", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newParticipationClient(t)
+		client.commit = func(_ *int64) (*ctypes.ResultCommit, error) {
+			return nil, errors.New("unavailable")
+		}
+
+		cfg := &participationCfg{blocks: 3}
+
+		res := cfg.check(context.Background(), client)
+		assert.ErrorContains(t, res.Err, "unable to fetch commit 1: unavailable")
+	})
+}
@@ -0,0 +1,85 @@
+package checks
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	rpcClient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+const defaultMinPeers = 1
+
+// peersCfg checks that the node is connected to enough peers
+type peersCfg struct {
+	minPeers int
+}
+
+// NewPeersCmd creates the gnohealth peers subcommand
+func NewPeersCmd(io commands.IO) *commands.Command {
+	return newCheckCmd(
+		io,
+		commands.Metadata{
+			Name:       "peers",
+			ShortUsage: "peers [flags]",
+			ShortHelp:  "check the node peer count",
+			LongHelp:   "This command checks if the node is connected to enough peers.",
+		},
+		&peersCfg{},
+	)
+}
+
+// RegisterFlags registers command-line flags for the peers check
+func (c *peersCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.IntVar(
+		&c.minPeers,
+		"min-peers",
+		defaultMinPeers,
+		"minimum number of connected peers",
+	)
+}
+
+func (c *peersCfg) name() string {
+	return "peers"
+}
+
+func (c *peersCfg) check(_ context.Context, client rpcClient.Client) Result {
+	info, err := client.NetInfo()
+	if err != nil {
+		return Result{Err: fmt.Errorf("unable to fetch net info: %w", err)}
+	}
+
+	var inbound, outbound int
+
+	for _, peer := range info.Peers {
+		if peer.IsOutbound {
+			outbound++
+		} else {
+			inbound++
+		}
+	}
+
+	res := Result{
+		Metrics: []Metric{
+			{
+				Name:   "peers",
+				Help:   "Number of peers connected to the node.",
+				Labels: map[string]string{"direction": "inbound"},
+				Value:  float64(inbound),
+			},
+			{
+				Name:   "peers",
+				Help:   "Number of peers connected to the node.",
+				Labels: map[string]string{"direction": "outbound"},
+				Value:  float64(outbound),
+			},
+		},
+	}
+
+	if info.NPeers < c.minPeers {
+		res.Err = fmt.Errorf("node has %d peers (min %d)", info.NPeers, c.minPeers)
+	}
+
+	return res
+}
@@ -0,0 +1,60 @@
+package checks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+)
+
+func TestPeers_Threshold(t *testing.T) {
+	t.Parallel()
+
+	client := &mockClient{
+		netInfo: func() (*ctypes.ResultNetInfo, error) {
+			return &ctypes.ResultNetInfo{
+				NPeers: 3,
+				Peers: []ctypes.Peer{
+					{IsOutbound: true},
+					{IsOutbound: false},
+					{IsOutbound: true},
+				},
+			}, nil
+		},
+	}
+
+	testTable := []struct {
+		name     string
+		minPeers int
+		healthy  bool
+	}{
+		{"below threshold", 2, true},
+		{"at threshold", 3, true},
+		{"above threshold", 4, false},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &peersCfg{minPeers: testCase.minPeers}
+
+			res := cfg.check(context.Background(), client)
+
+			if testCase.healthy {
+				assert.NoError(t, res.Err)
+			} else {
+				assert.ErrorContains(t, res.Err, "node has 3 peers (min 4)")
+			}
+
+			require.Len(t, res.Metrics, 2)
+			assert.Equal(t, map[string]string{"direction": "inbound"}, res.Metrics[0].Labels)
+			assert.Equal(t, float64(1), res.Metrics[0].Value)
+			assert.Equal(t, map[string]string{"direction": "outbound"}, res.Metrics[1].Labels)
+			assert.Equal(t, float64(2), res.Metrics[1].Value)
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	rpcClient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+const (
+	defaultListenAddress = "127.0.0.1:9095"
+	defaultServeInterval = 30 * time.Second
+)
+
+// serveCfg runs all the checks periodically,
+// and exposes their results over HTTP
+type serveCfg struct {
+	rpcCfg
+
+	listenAddress string
+	interval      time.Duration
+	timeout       time.Duration
+
+	e2e      *e2eCfg
+	checkers []checker
+}
+
+// NewServeCmd creates the gnohealth serve subcommand
+func NewServeCmd(io commands.IO) *commands.Command {
+	e2e := &e2eCfg{}
+
+	cfg := &serveCfg{
+		e2e: e2e,
+		checkers: []checker{
+			newLivenessCfg(),
+			&participationCfg{},
+			newMempoolCfg(),
+			&peersCfg{},
+			e2e,
+		},
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "serve",
+			ShortUsage: "serve [flags]",
+			ShortHelp:  "serve the check results as Prometheus metrics",
+			LongHelp: `This command periodically runs all the checks, and serves their results:
+- /metrics exposes the check results and measurements as Prometheus metrics
+- /ready responds with 200 if all checks are healthy, and 503 otherwise
+
+The e2e check only runs if a mnemonic is given.`,
+		},
+		cfg,
+		func(ctx context.Context, _ []string) error {
+			return execServe(ctx, cfg, io)
+		},
+	)
+}
+
+// RegisterFlags registers command-line flags for the serve command
+func (c *serveCfg) RegisterFlags(fs *flag.FlagSet) {
+	c.rpcCfg.RegisterFlags(fs)
+
+	for _, checker := range c.checkers {
+		checker.RegisterFlags(fs)
+	}
+
+	fs.StringVar(
+		&c.listenAddress,
+		"listen-address",
+		defaultListenAddress,
+		"the HTTP server listen address",
+	)
+
+	fs.DurationVar(
+		&c.interval,
+		"interval",
+		defaultServeInterval,
+		"interval between consecutive check runs",
+	)
+
+	fs.DurationVar(
+		&c.timeout,
+		"timeout",
+		defaultCheckTimeout,
+		"maximum duration of a single check",
+	)
+}
+
+// checkRun is the latest run of a check
+type checkRun struct {
+	Result
+
+	start    time.Time
+	duration time.Duration
+}
+
+// checkRunner periodically runs the checks, and keeps their latest results
+type checkRunner struct {
+	checkers []checker
+	client   rpcClient.Client
+	timeout  time.Duration
+
+	mux  sync.RWMutex
+	runs map[string]checkRun // check name -> latest run
+}
+
+// run runs all the checks concurrently, and saves their results
+func (r *checkRunner) run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, c := range r.checkers {
+		wg.Add(1)
+
+		go func(c checker) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+			defer cancel()
+
+			start := time.Now()
+			res := c.check(checkCtx, r.client)
+
+			r.mux.Lock()
+			defer r.mux.Unlock()
+
+			r.runs[c.name()] = checkRun{
+				Result:   res,
+				start:    start,
+				duration: time.Since(start),
+			}
+		}(c)
+	}
+
+	wg.Wait()
+}
+
+// serveMetrics serves the latest check results as Prometheus metrics
+func (r *checkRunner) serveMetrics(w http.ResponseWriter, _ *http.Request) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	var checkMetrics, metrics []Metric
+
+	for _, c := range r.checkers {
+		run, ok := r.runs[c.name()]
+		if !ok {
+			continue
+		}
+
+		labels := map[string]string{"check": c.name()}
+
+		checkMetrics = append(
+			checkMetrics,
+			Metric{
+				Name:   "check_up",
+				Help:   "Whether the latest run of the check was healthy.",
+				Labels: labels,
+				Value:  boolValue(run.Err == nil),
+			},
+			Metric{
+				Name:   "check_duration_seconds",
+				Help:   "Duration of the latest run of the check.",
+				Labels: labels,
+				Value:  run.duration.Seconds(),
+			},
+			Metric{
+				Name:   "check_last_run_timestamp_seconds",
+				Help:   "UNIX time of the latest run of the check.",
+				Labels: labels,
+				Value:  float64(run.start.Unix()),
+			},
+		)
+
+		metrics = append(metrics, run.Metrics...)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	//nolint:errcheck // the client may have gone away
+	writeMetrics(w, append(checkMetrics, metrics...))
+}
+
+// serveReady responds with 200 if all the checks are healthy, and 503 otherwise
+func (r *checkRunner) serveReady(w http.ResponseWriter, _ *http.Request) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	var (
+		status = http.StatusOK
+		body   string
+	)
+
+	for _, c := range r.checkers {
+		run, ok := r.runs[c.name()]
+
+		switch {
+		case !ok:
+			status = http.StatusServiceUnavailable
+			body += fmt.Sprintf("%s: not run yet\n", c.name())
+		case run.Err != nil:
+			status = http.StatusServiceUnavailable
+			body += fmt.Sprintf("%s: %s: KO\n", c.name(), run.Err)
+		default:
+			body += fmt.Sprintf("%s: OK\n", c.name())
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+
+	//nolint:errcheck // the client may have gone away
+	w.Write([]byte(body))
+}
+
+func execServe(ctx context.Context, cfg *serveCfg, io commands.IO) error {
+	client, err := cfg.newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// The e2e check needs a funded account
+	checkers := make([]checker, 0, len(cfg.checkers))
+
+	for _, c := range cfg.checkers {
+		if c == checker(cfg.e2e) && cfg.e2e.mnemonic == "" {
+			continue
+		}
+
+		checkers = append(checkers, c)
+	}
+
+	runner := &checkRunner{
+		checkers: checkers,
+		client:   client,
+		timeout:  cfg.timeout,
+		runs:     make(map[string]checkRun),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", runner.serveMetrics)
+	mux.HandleFunc("/ready", runner.serveReady)
+
+	srv := &http.Server{
+		Addr:              cfg.listenAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	srvErr := make(chan error, 1)
+
+	go func() {
+		defer cancel()
+
+		srvErr <- srv.ListenAndServe()
+	}()
+
+	io.Printf("serving health checks on %s\n", cfg.listenAddress)
+
+	ticker := time.NewTicker(cfg.interval)
+	defer ticker.Stop()
+
+	for {
+		runner.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer shutdownCancel()
+
+			if err := srv.Shutdown(shutdownCtx); err != nil {
+				return fmt.Errorf("unable to shut down server: %w", err)
+			}
+
+			if err := <-srvErr; !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("unable to serve: %w", err)
+			}
+
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
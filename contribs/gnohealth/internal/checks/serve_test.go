@@ -0,0 +1,108 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRunner(checkers ...checker) *checkRunner {
+	return &checkRunner{
+		checkers: checkers,
+		client:   &mockClient{},
+		timeout:  time.Second,
+		runs:     make(map[string]checkRun),
+	}
+}
+
+func TestCheckRunner_ServeReady(t *testing.T) {
+	t.Parallel()
+
+	var (
+		healthy = &mockChecker{checkName: "healthy"}
+		failing = &mockChecker{checkName: "failing", res: Result{Err: errors.New("node is down")}}
+	)
+
+	serve := func(runner *checkRunner) *httptest.ResponseRecorder {
+		t.Helper()
+
+		rec := httptest.NewRecorder()
+		runner.serveReady(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+		return rec
+	}
+
+	t.Run("not run yet", func(t *testing.T) {
+		t.Parallel()
+
+		rec := serve(newTestRunner(healthy))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "healthy: not run yet\n", rec.Body.String())
+	})
+
+	t.Run("all healthy", func(t *testing.T) {
+		t.Parallel()
+
+		runner := newTestRunner(healthy, &mockChecker{checkName: "other"})
+		runner.run(context.Background())
+
+		rec := serve(runner)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "healthy: OK\nother: OK\n", rec.Body.String())
+	})
+
+	t.Run("one failing", func(t *testing.T) {
+		t.Parallel()
+
+		runner := newTestRunner(healthy, failing)
+		runner.run(context.Background())
+
+		rec := serve(runner)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "healthy: OK\nfailing: node is down: KO\n", rec.Body.String())
+	})
+}
+
+func TestCheckRunner_ServeMetrics(t *testing.T) {
+	t.Parallel()
+
+	runner := newTestRunner(
+		&mockChecker{
+			checkName: "mempool",
+			res: Result{
+				Metrics: []Metric{{Name: "mempool_size", Help: "Number of transactions in the mempool.", Value: 3}},
+			},
+		},
+		&mockChecker{checkName: "peers", res: Result{Err: errors.New("no peers")}},
+	)
+	runner.run(context.Background())
+
+	// A check added after the run has no results yet
+	runner.checkers = append(runner.checkers, &mockChecker{checkName: "never"})
+
+	rec := httptest.NewRecorder()
+	runner.serveMetrics(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := rec.Body.String()
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, body, "# TYPE gnohealth_check_up gauge\n")
+	assert.Contains(t, body, "gnohealth_check_up{check=\"mempool\"} 1\n")
+	assert.Contains(t, body, "gnohealth_check_up{check=\"peers\"} 0\n")
+	assert.Contains(t, body, "gnohealth_check_duration_seconds{check=\"mempool\"}")
+	assert.Contains(t, body, "gnohealth_check_last_run_timestamp_seconds{check=\"peers\"}")
+	assert.Contains(t, body, "# HELP gnohealth_mempool_size Number of transactions in the mempool.\n")
+	assert.Contains(t, body, "gnohealth_mempool_size 3\n")
+
+	// Checks which haven't run yet are not reported
+	assert.NotContains(t, body, "never")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 
+	"github.com/gnolang/gno/contribs/gnohealth/internal/checks"
 	"github.com/gnolang/gno/contribs/gnohealth/internal/timestamp"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 )
@@ -21,6 +22,12 @@ func main() {
 	io := commands.NewDefaultIO()
 	cmd.AddSubCommands(
 		timestamp.NewTimestampCmd(io),
+		checks.NewLivenessCmd(io),
+		checks.NewParticipationCmd(io),
+		checks.NewMempoolCmd(io),
+		checks.NewPeersCmd(io),
+		checks.NewE2ECmd(io),
+		checks.NewServeCmd(io),
 	)
 
 	cmd.Execute(context.Background(), os.Args[1:])
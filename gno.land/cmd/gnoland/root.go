@@ -27,6 +27,7 @@ func newRootCmd(io commands.IO) *commands.Command {
 		newStartCmd(io),
 		newSecretsCmd(io),
 		newConfigCmd(io),
+		newSignerCmd(io),
 	)
 
 	return cmd
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/bft/privval"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	errMissingServerAddr = errors.New("missing node server address")
+	errMissingChainID    = errors.New("missing chain ID")
+	errInvalidServerAddr = errors.New("invalid node server address, expected tcp:// or unix://")
+)
+
+const (
+	defaultSignerTimeout       = 3 * time.Second
+	defaultSignerRetryInterval = time.Second
+)
+
+type signerCfg struct {
+	dataDir               string
+	home                  string
+	keyName               string
+	insecurePasswordStdin bool
+
+	serverAddr    string
+	chainID       string
+	timeout       time.Duration
+	retryInterval time.Duration
+
+	logLevel  string
+	logFormat string
+}
+
+// newSignerCmd creates the remote signer command
+func newSignerCmd(io commands.IO) *commands.Command {
+	cfg := &signerCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "signer",
+			ShortUsage: "signer [flags]",
+			ShortHelp:  "runs a remote validator signer",
+			LongHelp: "Runs a remote signer serving a validator key to a node configured with a priv_validator_laddr. " +
+				"The key is loaded either from the validator secrets in the data directory, or from an encrypted keybase " +
+				"when a key name is given. The signer dials the node, reconnects whenever the connection drops, " +
+				"persists the last signed height/round/step to prevent double signing, " +
+				"and refuses to sign for any chain other than the configured one",
+		},
+		cfg,
+		func(ctx context.Context, _ []string) error {
+			return execSigner(ctx, cfg, io)
+		},
+	)
+}
+
+func (c *signerCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.dataDir,
+		"data-dir",
+		constructSecretsPath(defaultNodeDir),
+		"the secrets directory containing the validator key and last sign state",
+	)
+
+	fs.StringVar(
+		&c.home,
+		"home",
+		"",
+		"the keybase home directory, used when a key name is provided",
+	)
+
+	fs.StringVar(
+		&c.keyName,
+		"key",
+		"",
+		"the name or address of the keybase key to serve, instead of the data directory validator key",
+	)
+
+	fs.BoolVar(
+		&c.insecurePasswordStdin,
+		"insecure-password-stdin",
+		false,
+		"read the keybase password from stdin, without a prompt",
+	)
+
+	fs.StringVar(
+		&c.serverAddr,
+		"server-addr",
+		"",
+		"the node's priv_validator_laddr to dial (tcp:// or unix://)",
+	)
+
+	fs.StringVar(
+		&c.chainID,
+		"chainid",
+		"",
+		"the ID of the chain the signer is allowed to sign for",
+	)
+
+	fs.DurationVar(
+		&c.timeout,
+		"timeout",
+		defaultSignerTimeout,
+		"the read / write timeout for the node connection",
+	)
+
+	fs.DurationVar(
+		&c.retryInterval,
+		"retry-interval",
+		defaultSignerRetryInterval,
+		"the wait interval between node dial attempts",
+	)
+
+	fs.StringVar(
+		&c.logLevel,
+		"log-level",
+		zapcore.InfoLevel.String(),
+		"log level for the signer",
+	)
+
+	fs.StringVar(
+		&c.logFormat,
+		"log-format",
+		log.ConsoleFormat.String(),
+		"log format for the signer",
+	)
+}
+
+func execSigner(ctx context.Context, c *signerCfg, io commands.IO) error {
+	// Make sure the signer configuration is valid
+	if c.serverAddr == "" {
+		return errMissingServerAddr
+	}
+
+	if c.chainID == "" {
+		return errMissingChainID
+	}
+
+	if c.dataDir == "" || !isValidDirectory(c.dataDir) {
+		return errInvalidDataDir
+	}
+
+	// Initialize the logger
+	zapLogger, err := initializeLogger(io.Out(), c.logLevel, c.logFormat)
+	if err != nil {
+		return fmt.Errorf("unable to initialize zap logger, %w", err)
+	}
+
+	defer func() {
+		// Sync the logger before exiting
+		_ = zapLogger.Sync()
+	}()
+
+	logger := log.ZapLoggerToSlog(zapLogger).With("module", "signer")
+
+	// Load the validator
+	pv, err := loadSignerPrivValidator(c, io)
+	if err != nil {
+		return fmt.Errorf("unable to load validator key, %w", err)
+	}
+
+	// Set up the wait context
+	signerCtx, cancelFn := signal.NotifyContext(
+		ctx,
+		os.Interrupt,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT,
+	)
+	defer cancelFn()
+
+	// Create the node dialer
+	dialer, err := newSignerDialer(c, pv)
+	if err != nil {
+		return err
+	}
+
+	endpoint := privval.NewSignerDialerEndpoint(
+		logger,
+		retryDialer(signerCtx, dialer, c.retryInterval, logger),
+		privval.SignerDialerEndpointTimeoutReadWrite(c.timeout),
+		privval.SignerDialerEndpointConnRetries(1), // retries are handled by the dialer
+	)
+
+	server := privval.NewSignerServer(endpoint, c.chainID, pv)
+	if err := server.Start(); err != nil {
+		return fmt.Errorf("unable to start the signer, %w", err)
+	}
+
+	logger.Info(
+		"Remote signer started",
+		"address", pv.GetAddress().String(),
+		"server", c.serverAddr,
+		"chain_id", c.chainID,
+	)
+
+	// Wait for the exit signal
+	<-signerCtx.Done()
+
+	if !server.IsRunning() {
+		return nil
+	}
+
+	// Gracefully stop the signer
+	if err := server.Stop(); err != nil {
+		return fmt.Errorf("unable to gracefully stop the signer, %w", err)
+	}
+
+	return nil
+}
+
+// loadSignerPrivValidator loads the validator served by the signer,
+// either from the keybase (if a key name is set), or from the data directory
+func loadSignerPrivValidator(c *signerCfg, io commands.IO) (*privval.FilePV, error) {
+	stateFile := filepath.Join(c.dataDir, defaultValidatorStateName)
+
+	if c.keyName == "" {
+		keyFile := filepath.Join(c.dataDir, defaultValidatorKeyName)
+
+		key, err := readSecretData[privval.FilePVKey](keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read validator key, %w", err)
+		}
+
+		if err := validateValidatorKey(key); err != nil {
+			return nil, err
+		}
+
+		return privval.NewFilePVWithKey(key.PrivKey, stateFile)
+	}
+
+	kb, err := keys.NewKeyBaseFromDir(c.home)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open keybase, %w", err)
+	}
+
+	pass, err := io.GetPassword("Enter password to decrypt the validator key:", c.insecurePasswordStdin)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get decryption key, %w", err)
+	}
+
+	privKey, err := kb.ExportPrivKey(c.keyName, pass)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt key %q, %w", c.keyName, err)
+	}
+
+	return privval.NewFilePVWithKey(privKey, stateFile)
+}
+
+// newSignerDialer creates the node dialer for the given server address.
+// TCP connections are authenticated and encrypted using the validator key,
+// if it is an ed25519 key, or an ephemeral connection key otherwise
+func newSignerDialer(c *signerCfg, pv *privval.FilePV) (privval.SocketDialer, error) {
+	protocol, address := osm.ProtocolAndAddress(c.serverAddr)
+
+	switch protocol {
+	case "tcp":
+		connKey, ok := pv.Key.PrivKey.(ed25519.PrivKeyEd25519)
+		if !ok {
+			connKey = ed25519.GenPrivKey()
+		}
+
+		return privval.DialTCPFn(address, c.timeout, connKey), nil
+	case "unix":
+		return privval.DialUnixFn(address), nil
+	default:
+		return nil, errInvalidServerAddr
+	}
+}
+
+// retryDialer wraps the given dialer so that it keeps redialing the node
+// at the given interval, until a connection is established or the context is done
+func retryDialer(
+	ctx context.Context,
+	dialer privval.SocketDialer,
+	interval time.Duration,
+	logger *slog.Logger,
+) privval.SocketDialer {
+	return func() (net.Conn, error) {
+		for {
+			conn, err := dialer()
+			if err == nil {
+				logger.Info("Connected to node")
+
+				return conn, nil
+			}
+
+			logger.Warn("Unable to dial node", "err", err, "retry_in", interval)
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+	}
+}
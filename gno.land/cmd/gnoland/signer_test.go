@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/privval"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name        string
+		args        []string
+		expectedErr error
+	}{
+		{
+			"missing server address",
+			[]string{"--chainid", "dev"},
+			errMissingServerAddr,
+		},
+		{
+			"missing chain ID",
+			[]string{"--server-addr", "tcp://127.0.0.1:26659"},
+			errMissingChainID,
+		},
+		{
+			"invalid data directory",
+			[]string{"--server-addr", "tcp://127.0.0.1:26659", "--chainid", "dev", "--data-dir", ""},
+			errInvalidDataDir,
+		},
+		{
+			"invalid server address",
+			[]string{"--server-addr", "udp://127.0.0.1:26659", "--chainid", "dev"},
+			errInvalidServerAddr,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Initialize the secrets, if needed
+			dataDir := t.TempDir()
+			cmd := newRootCmd(commands.NewTestIO())
+			require.NoError(t, cmd.ParseAndRun(
+				context.Background(),
+				[]string{"secrets", "init", "--data-dir", dataDir},
+			))
+
+			cmd = newRootCmd(commands.NewTestIO())
+			args := append([]string{"signer", "--data-dir", dataDir}, testCase.args...)
+			assert.ErrorIs(t, cmd.ParseAndRun(context.Background(), args), testCase.expectedErr)
+		})
+	}
+}
+
+func TestSigner_SignOverTCP(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dataDir = t.TempDir()
+		chainID = "signer-test"
+	)
+
+	// Initialize the validator secrets
+	cmd := newRootCmd(commands.NewTestIO())
+	require.NoError(t, cmd.ParseAndRun(
+		context.Background(),
+		[]string{"secrets", "init", "--data-dir", dataDir},
+	))
+
+	key, err := readSecretData[privval.FilePVKey](filepath.Join(dataDir, defaultValidatorKeyName))
+	require.NoError(t, err)
+
+	// Set up the node listener
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	listener := privval.NewSignerListenerEndpoint(
+		log.NewNoopLogger(),
+		privval.NewTCPListener(ln, ed25519.GenPrivKey()),
+	)
+
+	client, err := privval.NewSignerClient(listener)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = listener.Stop()
+	})
+
+	// Start the signer
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+
+	mockOut := new(bytes.Buffer)
+	io := commands.NewTestIO()
+	io.SetOut(commands.WriteNopCloser(mockOut))
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- newRootCmd(io).ParseAndRun(ctx, []string{
+			"signer",
+			"--data-dir", dataDir,
+			"--server-addr", "tcp://" + ln.Addr().String(),
+			"--chainid", chainID,
+			"--retry-interval", "10ms",
+		})
+	}()
+
+	require.NoError(t, client.WaitForConnection(5*time.Second))
+
+	// Make sure the served key is the validator key
+	assert.Equal(t, key.PubKey, client.GetPubKey())
+
+	// Sign a vote for the configured chain
+	vote := &types.Vote{
+		Type:             types.PrecommitType,
+		Height:           10,
+		Timestamp:        time.Now(),
+		ValidatorAddress: key.Address,
+	}
+	require.NoError(t, client.SignVote(chainID, vote))
+	assert.True(t, key.PubKey.VerifyBytes(vote.SignBytes(chainID), vote.Signature))
+
+	// Make sure the signer refuses to sign for other chains
+	otherVote := &types.Vote{
+		Type:             types.PrecommitType,
+		Height:           11,
+		Timestamp:        time.Now(),
+		ValidatorAddress: key.Address,
+	}
+	assert.ErrorContains(t, client.SignVote("other-chain", otherVote), privval.ErrUnknownChainID.Error())
+
+	// Make sure the last sign state was persisted
+	state, err := readSecretData[privval.FilePVLastSignState](filepath.Join(dataDir, defaultValidatorStateName))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), state.Height)
+
+	// Stop the signer
+	cancelFn()
+
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("signer did not stop")
+	}
+}
@@ -29,6 +29,7 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/telemetry"
+	_ "github.com/gnolang/gno/tm2/pkg/telemetry/traces/otlp"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
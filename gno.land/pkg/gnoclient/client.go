@@ -1,6 +1,8 @@
 package gnoclient
 
 import (
+	"context"
+
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 )
 
@@ -25,3 +27,41 @@ func (c *Client) validateRPCClient() error {
 	}
 	return nil
 }
+
+// contextualRPCClient is an RPC client whose requests carry a context
+type contextualRPCClient interface {
+	Context() context.Context
+	WithContext(ctx context.Context) *rpcclient.RPCClient
+}
+
+// WithContext returns a shallow copy of the client, whose transaction
+// broadcasts are traced as children of the span in ctx.
+// Over HTTP, the trace context is propagated to the node.
+// It has no effect if the RPC client doesn't support contexts
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := *c
+
+	if client, ok := c.RPCClient.(contextualRPCClient); ok {
+		c2.RPCClient = client.WithContext(ctx)
+	}
+
+	return &c2
+}
+
+// context returns the parent context of the client requests
+func (c *Client) context() context.Context {
+	if client, ok := c.RPCClient.(contextualRPCClient); ok {
+		return client.Context()
+	}
+
+	return context.Background()
+}
+
+// rpcClientWithContext returns the RPC client, carrying ctx if supported
+func (c *Client) rpcClientWithContext(ctx context.Context) rpcclient.Client {
+	if client, ok := c.RPCClient.(contextualRPCClient); ok {
+		return client.WithContext(ctx)
+	}
+
+	return c.RPCClient
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/traces"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var (
@@ -17,6 +19,9 @@ var (
 	ErrInvalidGasFee    = errors.New("invalid gas fee")
 	ErrMissingSigner    = errors.New("missing Signer")
 	ErrMissingRPCClient = errors.New("missing RPCClient")
+	ErrNoMsgs           = errors.New("no msgs")
+
+	ErrInvalidBroadcastMode = errors.New("invalid broadcast mode")
 )
 
 const simulatePath = ".app/simulate"
@@ -230,6 +235,40 @@ func NewAddPackageTx(cfg BaseTxCfg, msgs ...vm.MsgAddPackage) (*std.Tx, error) {
 	}, nil
 }
 
+// NewTx makes an unsigned transaction from one or more msgs,
+// which can be of different types (for example vm.MsgCall, vm.MsgRun and bank.MsgSend).
+func NewTx(cfg BaseTxCfg, msgs ...std.Msg) (*std.Tx, error) {
+	// Validate base transaction config
+	if err := cfg.validateBaseTxConfig(); err != nil {
+		return nil, err
+	}
+
+	if len(msgs) == 0 {
+		return nil, ErrNoMsgs
+	}
+
+	for _, msg := range msgs {
+		// Validate msg fields
+		if err := msg.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Parse gas fee
+	gasFeeCoins, err := std.ParseCoin(cfg.GasFee)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pack transaction
+	return &std.Tx{
+		Msgs:       msgs,
+		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
+		Signatures: nil,
+		Memo:       cfg.Memo,
+	}, nil
+}
+
 // signAndBroadcastTxCommit signs a transaction and broadcasts it, returning the result
 func (c *Client) signAndBroadcastTxCommit(tx std.Tx, accountNumber, sequenceNumber uint64) (*ctypes.ResultBroadcastTxCommit, error) {
 	signedTx, err := c.SignTx(tx, accountNumber, sequenceNumber)
@@ -273,7 +312,7 @@ func (c *Client) SignTx(tx std.Tx, accountNumber, sequenceNumber uint64) (*std.T
 
 // BroadcastTxCommit marshals and broadcasts the signed transaction, returning the result.
 // If the result has a delivery error, then return a wrapped error.
-func (c *Client) BroadcastTxCommit(signedTx *std.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+func (c *Client) BroadcastTxCommit(signedTx *std.Tx) (_ *ctypes.ResultBroadcastTxCommit, err error) {
 	if err := c.validateRPCClient(); err != nil {
 		return nil, err
 	}
@@ -282,11 +321,21 @@ func (c *Client) BroadcastTxCommit(signedTx *std.Tx) (*ctypes.ResultBroadcastTxC
 		return nil, errors.Wrap(err, "marshaling tx binary bytes")
 	}
 
-	bres, err := c.RPCClient.BroadcastTxCommit(bz)
+	ctx, span := traces.StartSpan(c.context(), "gnoclient.BroadcastTxCommit")
+	defer func() {
+		traces.EndSpan(span, err)
+	}()
+
+	bres, err := c.rpcClientWithContext(ctx).BroadcastTxCommit(bz)
 	if err != nil {
 		return nil, errors.Wrap(err, "broadcasting bytes")
 	}
 
+	span.SetAttributes(
+		attribute.String("tx.hash", fmt.Sprintf("%X", bres.Hash)),
+		attribute.Int64("tx.height", bres.Height),
+	)
+
 	if bres.CheckTx.IsErr() {
 		return bres, errors.Wrapf(bres.CheckTx.Error, "check transaction failed: log:%s", bres.CheckTx.Log)
 	}
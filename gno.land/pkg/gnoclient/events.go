@@ -0,0 +1,287 @@
+package gnoclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gnostd "github.com/gnolang/gno/gnovm/stdlibs/std"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+var ErrInvalidBlockRange = errors.New("invalid block range provided")
+
+// defaultEventsPollInterval is the default interval at which
+// StreamEvents checks for new blocks
+const defaultEventsPollInterval = time.Second
+
+// Event is a GnoVM event, emitted by a realm with std.Emit,
+// along with the position of the transaction which emitted it
+type Event struct {
+	gnostd.GnoEvent
+
+	Height  int64  // Height of the block including the transaction
+	TxIndex int    // Index of the transaction in the block, -1 if unknown
+	TxHash  []byte // Hash of the transaction, if known
+	Index   int    // Index of the event in the transaction
+}
+
+// Attr returns the value of the event attribute with the given key
+func (e Event) Attr(key string) (string, bool) {
+	for _, attr := range e.Attributes {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// EventFilter selects events. Empty fields match any event
+type EventFilter struct {
+	PkgPath string            // Path of the realm emitting the event
+	Type    string            // Type of the event
+	Attrs   map[string]string // Attribute values, by key
+}
+
+// Match returns true if the event matches the filter
+func (f EventFilter) Match(e gnostd.GnoEvent) bool {
+	if f.PkgPath != "" && f.PkgPath != e.PkgPath {
+		return false
+	}
+
+	if f.Type != "" && f.Type != e.Type {
+		return false
+	}
+
+	for key, value := range f.Attrs {
+		found := false
+		for _, attr := range e.Attributes {
+			if attr.Key == key && attr.Value == value {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchFilters returns true if the event matches any of the filters,
+// or if there are no filters
+func matchFilters(e gnostd.GnoEvent, filters []EventFilter) bool {
+	if len(filters) == 0 {
+		return true
+	}
+
+	for _, f := range filters {
+		if f.Match(e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deliverTxEvents decodes the GnoVM events of a transaction.
+// Failed transactions are ignored, as their state changes were reverted
+func deliverTxEvents(res abci.ResponseDeliverTx) []gnostd.GnoEvent {
+	if res.IsErr() {
+		return nil
+	}
+
+	var events []gnostd.GnoEvent
+	for _, ev := range res.Events {
+		var gnoEv gnostd.GnoEvent
+
+		switch e := ev.(type) {
+		case gnostd.GnoEvent:
+			gnoEv = e
+		case *gnostd.GnoEvent:
+			gnoEv = *e
+		default:
+			// Not a GnoVM event
+			continue
+		}
+
+		events = append(events, gnoEv)
+	}
+
+	return events
+}
+
+// TxEvents decodes the GnoVM events emitted by a committed transaction,
+// which match any of the filters (all events if there are no filters)
+func TxEvents(res *ctypes.ResultBroadcastTxCommit, filters ...EventFilter) []Event {
+	var events []Event
+	for i, ev := range deliverTxEvents(res.DeliverTx) {
+		if !matchFilters(ev, filters) {
+			continue
+		}
+
+		events = append(events, Event{
+			GnoEvent: ev,
+			Height:   res.Height,
+			TxIndex:  -1,
+			TxHash:   res.Hash,
+			Index:    i,
+		})
+	}
+
+	return events
+}
+
+// BlockEvents decodes the GnoVM events emitted by the transactions of a block,
+// which match any of the filters (all events if there are no filters).
+// The transaction hashes are not set, as they are not part of the block results
+func BlockEvents(res *ctypes.ResultBlockResults, filters ...EventFilter) []Event {
+	if res.Results == nil {
+		return nil
+	}
+
+	var events []Event
+	for txIndex, deliverTx := range res.Results.DeliverTxs {
+		for i, ev := range deliverTxEvents(deliverTx) {
+			if !matchFilters(ev, filters) {
+				continue
+			}
+
+			events = append(events, Event{
+				GnoEvent: ev,
+				Height:   res.Height,
+				TxIndex:  txIndex,
+				Index:    i,
+			})
+		}
+	}
+
+	return events
+}
+
+// blockEvents fetches the events of the block at height matching the filters,
+// along with the hashes of the transactions which emitted them
+func (c *Client) blockEvents(height int64, filters []EventFilter) ([]Event, error) {
+	results, err := c.BlockResult(height)
+	if err != nil {
+		return nil, err
+	}
+
+	events := BlockEvents(results, filters...)
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	block, err := c.Block(height)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := block.Block.Txs
+	for i := range events {
+		if txIndex := events[i].TxIndex; txIndex < len(txs) {
+			events[i].TxHash = txs[txIndex].Hash()
+		}
+	}
+
+	return events, nil
+}
+
+// Events fetches the GnoVM events emitted in the blocks from the given height
+// to the given height (inclusive), which match any of the filters
+// (all events if there are no filters)
+func (c *Client) Events(from, to int64, filters ...EventFilter) ([]Event, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, err
+	}
+
+	if from <= 0 || to < from {
+		return nil, ErrInvalidBlockRange
+	}
+
+	var events []Event
+	for height := from; height <= to; height++ {
+		blockEvents, err := c.blockEvents(height, filters)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch events of block %d: %w", height, err)
+		}
+
+		events = append(events, blockEvents...)
+	}
+
+	return events, nil
+}
+
+// StreamEventsCfg contains configuration options for streaming events
+type StreamEventsCfg struct {
+	FromHeight   int64         // Height of the first block, defaults to the block after the latest one
+	PollInterval time.Duration // Interval at which new blocks are checked, defaults to 1s
+	Filters      []EventFilter // Events match any filter, all events match if empty
+}
+
+// StreamEvents follows the chain, calling fn with the GnoVM events of every new
+// block matching the filters, in order. It returns when the context is done,
+// or when fn returns an error (which is returned)
+func (c *Client) StreamEvents(ctx context.Context, cfg StreamEventsCfg, fn func(Event) error) error {
+	if err := c.validateRPCClient(); err != nil {
+		return err
+	}
+
+	if cfg.FromHeight < 0 {
+		return ErrInvalidBlockHeight
+	}
+
+	interval := cfg.PollInterval
+	if interval <= 0 {
+		interval = defaultEventsPollInterval
+	}
+
+	next := cfg.FromHeight
+	if next == 0 {
+		latest, err := c.LatestBlockHeight()
+		if err != nil {
+			return err
+		}
+
+		next = latest + 1
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		latest, err := c.LatestBlockHeight()
+		if err != nil {
+			return err
+		}
+
+		for ; next <= latest; next++ {
+			events, err := c.blockEvents(next, cfg.Filters)
+			if err != nil {
+				return fmt.Errorf("unable to fetch events of block %d: %w", next, err)
+			}
+
+			for _, ev := range events {
+				if err := fn(ev); err != nil {
+					return err
+				}
+			}
+
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package gnoclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gnostd "github.com/gnolang/gno/gnovm/stdlibs/std"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+func newTestEvent(pkgPath, typ string, attrs ...string) gnostd.GnoEvent {
+	ev := gnostd.GnoEvent{
+		Type:    typ,
+		PkgPath: pkgPath,
+		Func:    "Func",
+	}
+
+	for i := 0; i+1 < len(attrs); i += 2 {
+		ev.Attributes = append(ev.Attributes, gnostd.GnoEventAttribute{Key: attrs[i], Value: attrs[i+1]})
+	}
+
+	return ev
+}
+
+// eventsChain is a mock chain, whose blocks contain a single transaction
+// emitting the given events
+type eventsChain struct {
+	mu     sync.Mutex
+	blocks [][]abci.Event
+}
+
+func (c *eventsChain) addBlock(events ...abci.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.blocks = append(c.blocks, events)
+}
+
+func (c *eventsChain) client() *Client {
+	return &Client{
+		RPCClient: &mockRPCClient{
+			status: func() (*ctypes.ResultStatus, error) {
+				c.mu.Lock()
+				defer c.mu.Unlock()
+
+				return &ctypes.ResultStatus{
+					SyncInfo: ctypes.SyncInfo{LatestBlockHeight: int64(len(c.blocks))},
+				}, nil
+			},
+			blockResults: func(height *int64) (*ctypes.ResultBlockResults, error) {
+				c.mu.Lock()
+				defer c.mu.Unlock()
+
+				return &ctypes.ResultBlockResults{
+					Height: *height,
+					Results: &state.ABCIResponses{
+						DeliverTxs: []abci.ResponseDeliverTx{
+							{ResponseBase: abci.ResponseBase{Events: c.blocks[*height-1]}},
+						},
+					},
+				}, nil
+			},
+			block: func(height *int64) (*ctypes.ResultBlock, error) {
+				return &ctypes.ResultBlock{
+					Block: &types.Block{
+						Data: types.Data{Txs: types.Txs{types.Tx("tx")}},
+					},
+				}, nil
+			},
+		},
+	}
+}
+
+func TestEventFilter_Match(t *testing.T) {
+	t.Parallel()
+
+	ev := newTestEvent("gno.land/r/demo/foo", "Transfer", "from", "a", "to", "b")
+
+	testTable := []struct {
+		name   string
+		filter EventFilter
+		match  bool
+	}{
+		{"empty filter", EventFilter{}, true},
+		{"pkgpath", EventFilter{PkgPath: "gno.land/r/demo/foo"}, true},
+		{"other pkgpath", EventFilter{PkgPath: "gno.land/r/demo/bar"}, false},
+		{"type", EventFilter{Type: "Transfer"}, true},
+		{"other type", EventFilter{Type: "Mint"}, false},
+		{"attrs", EventFilter{Attrs: map[string]string{"from": "a", "to": "b"}}, true},
+		{"other attr value", EventFilter{Attrs: map[string]string{"from": "b"}}, false},
+		{"missing attr", EventFilter{Attrs: map[string]string{"amount": "1"}}, false},
+		{"all fields", EventFilter{PkgPath: "gno.land/r/demo/foo", Type: "Transfer", Attrs: map[string]string{"to": "b"}}, true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.match, testCase.filter.Match(ev))
+		})
+	}
+}
+
+func TestTxEvents(t *testing.T) {
+	t.Parallel()
+
+	res := &ctypes.ResultBroadcastTxCommit{
+		DeliverTx: abci.ResponseDeliverTx{
+			ResponseBase: abci.ResponseBase{
+				Events: []abci.Event{
+					newTestEvent("gno.land/r/demo/foo", "Mint", "amount", "1"),
+					abci.EventString("not a gno event"),
+					newTestEvent("gno.land/r/demo/foo", "Transfer", "to", "b"),
+				},
+			},
+		},
+		Hash:   []byte("hash"),
+		Height: 10,
+	}
+
+	events := TxEvents(res)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "Mint", events[0].Type)
+	assert.Equal(t, int64(10), events[0].Height)
+	assert.Equal(t, -1, events[0].TxIndex)
+	assert.Equal(t, []byte("hash"), events[0].TxHash)
+	assert.Equal(t, 0, events[0].Index)
+
+	amount, ok := events[0].Attr("amount")
+	assert.True(t, ok)
+	assert.Equal(t, "1", amount)
+
+	_, ok = events[0].Attr("to")
+	assert.False(t, ok)
+
+	events = TxEvents(res, EventFilter{Type: "Transfer"})
+	require.Len(t, events, 1)
+	assert.Equal(t, "Transfer", events[0].Type)
+	assert.Equal(t, 1, events[0].Index)
+
+	// Events of failed transactions are ignored
+	res.DeliverTx.Error = abci.StringError("failed")
+	assert.Empty(t, TxEvents(res))
+}
+
+func TestClient_Events(t *testing.T) {
+	t.Parallel()
+
+	chain := &eventsChain{}
+	chain.addBlock(newTestEvent("gno.land/r/demo/foo", "Mint"))
+	chain.addBlock()
+	chain.addBlock(
+		newTestEvent("gno.land/r/demo/bar", "Mint"),
+		newTestEvent("gno.land/r/demo/foo", "Transfer"),
+	)
+
+	client := chain.client()
+
+	events, err := client.Events(1, 3)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, int64(1), events[0].Height)
+	assert.Equal(t, int64(3), events[2].Height)
+	assert.Equal(t, 0, events[2].TxIndex)
+	assert.Equal(t, 1, events[2].Index)
+	assert.Equal(t, types.Tx("tx").Hash(), events[2].TxHash)
+
+	events, err = client.Events(1, 3, EventFilter{PkgPath: "gno.land/r/demo/foo"})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "Mint", events[0].Type)
+	assert.Equal(t, "Transfer", events[1].Type)
+
+	_, err = client.Events(3, 1)
+	assert.ErrorIs(t, err, ErrInvalidBlockRange)
+
+	_, err = client.Events(0, 1)
+	assert.ErrorIs(t, err, ErrInvalidBlockRange)
+
+	_, err = (&Client{}).Events(1, 1)
+	assert.ErrorIs(t, err, ErrMissingRPCClient)
+}
+
+func TestClient_StreamEvents(t *testing.T) {
+	t.Parallel()
+
+	chain := &eventsChain{}
+	chain.addBlock(newTestEvent("gno.land/r/demo/foo", "Old"))
+
+	client := chain.client()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	errDone := errors.New("done")
+
+	var (
+		received []string
+		heights  []int64
+	)
+
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- client.StreamEvents(ctx, StreamEventsCfg{
+			PollInterval: 10 * time.Millisecond,
+			Filters:      []EventFilter{{Type: "New"}, {Type: "Last"}},
+		}, func(ev Event) error {
+			received = append(received, ev.Type)
+			heights = append(heights, ev.Height)
+
+			if ev.Type == "Last" {
+				return errDone
+			}
+
+			return nil
+		})
+	}()
+
+	// Wait for the stream to start after the existing block
+	time.Sleep(50 * time.Millisecond)
+
+	chain.addBlock(newTestEvent("gno.land/r/demo/foo", "New"))
+	chain.addBlock(newTestEvent("gno.land/r/demo/foo", "Ignored"))
+	chain.addBlock(newTestEvent("gno.land/r/demo/foo", "Last"))
+
+	select {
+	case err := <-streamErr:
+		require.ErrorIs(t, err, errDone)
+	case <-ctx.Done():
+		t.Fatal("stream timed out")
+	}
+
+	assert.Equal(t, []string{"New", "Last"}, received)
+	assert.Equal(t, []int64{2, 4}, heights)
+
+	t.Run("context canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := client.StreamEvents(ctx, StreamEventsCfg{FromHeight: 1}, func(Event) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -2,6 +2,7 @@ package gnoclient_test
 
 import (
 	"github.com/gnolang/gno/gno.land/pkg/gnoclient"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 )
@@ -54,3 +55,34 @@ func Example_readOnly() {
 	}
 	_ = client
 }
+
+// Example_txBuilder demonstrates how to broadcast several transactions in a row,
+// without tracking the account sequence.
+func Example_txBuilder() {
+	mnemo := "index brass unknown lecture autumn provide royal shrimp elegant wink now zebra discover swarm act ill you bullet entire outdoor tilt usage gap multiply"
+	signer, _ := gnoclient.SignerFromBip39(mnemo, "dev", "", 0, 0)
+
+	remote := "127.0.0.1:26657"
+	rpcClient, _ := rpcclient.NewHTTPClient(remote)
+
+	client := gnoclient.Client{
+		Signer:    signer,
+		RPCClient: rpcClient,
+	}
+
+	builder, _ := client.NewTxBuilder(gnoclient.WithBroadcastMode(gnoclient.BroadcastSync))
+
+	info, _ := signer.Info()
+	cfg := gnoclient.BaseTxCfg{
+		GasFee:    "1000000ugnot",
+		GasWanted: 2000000,
+	}
+
+	for range 100 {
+		_, _ = builder.Broadcast(cfg, vm.MsgCall{
+			Caller:  info.GetAddress(),
+			PkgPath: "gno.land/r/demo/counter",
+			Func:    "Increment",
+		})
+	}
+}
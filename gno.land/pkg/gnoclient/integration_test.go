@@ -1,8 +1,10 @@
 package gnoclient
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
 
@@ -680,6 +682,178 @@ func Hello(str string) string {
 	assert.Contains(t, string(query.Response.Data), "gno.mod")
 }
 
+func TestTxBuilder_Integration(t *testing.T) {
+	// Setup packages
+	rootdir := gnoenv.RootDir()
+	config := integration.TestingMinimalNodeConfig(gnoenv.RootDir())
+	meta := loadpkgs(t, rootdir, "gno.land/r/demo/deep/very/deep")
+	state := config.Genesis.AppState.(gnoland.GnoGenesisState)
+	state.Txs = append(state.Txs, meta...)
+	config.Genesis.AppState = state
+
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	// Init Signer & RPCClient
+	signer := newInMemorySigner(t, "tendermint_test")
+	rpcClient, err := rpcclient.NewHTTPClient(remoteAddr)
+	require.NoError(t, err)
+
+	// Setup Client
+	client := Client{
+		Signer:    signer,
+		RPCClient: rpcClient,
+	}
+
+	caller, err := client.Signer.Info()
+	require.NoError(t, err)
+
+	toAddress, _ := crypto.AddressFromBech32("g14a0y9a64dugh3l7hneshdxr4w0rfkkww9ls35p")
+
+	// Make Tx config
+	baseCfg := BaseTxCfg{
+		GasFee:    ugnot.ValueString(2100000),
+		GasWanted: 21000000,
+	}
+
+	// Mix a MsgCall and a MsgSend in the same tx
+	msgs := []std.Msg{
+		vm.MsgCall{
+			Caller:  caller.GetAddress(),
+			PkgPath: "gno.land/r/demo/deep/very/deep",
+			Func:    "Render",
+			Args:    []string{""},
+		},
+		bank.MsgSend{
+			FromAddress: caller.GetAddress(),
+			ToAddress:   toAddress,
+			Amount:      std.NewCoins(std.NewCoin(ugnot.Denom, 1)),
+		},
+	}
+
+	commitBuilder, err := client.NewTxBuilder()
+	require.NoError(t, err)
+
+	res, err := commitBuilder.Broadcast(baseCfg, msgs...)
+	require.NoError(t, err)
+	assert.Equal(t, "(\"it works!\" string)\n\n", string(res.Commit.DeliverTx.Data))
+
+	builder, err := client.NewTxBuilder(WithBroadcastMode(BroadcastSync))
+	require.NoError(t, err)
+
+	// Broadcast several txs without waiting for them to be committed
+	for i := range 3 {
+		syncRes, err := builder.Broadcast(baseCfg, msgs...)
+		require.NoError(t, err)
+		assert.Equal(t, res.Sequence+uint64(i+1), syncRes.Sequence)
+	}
+
+	// All the txs are eventually committed
+	seq, synced := builder.Sequence()
+	assert.True(t, synced)
+
+	require.Eventually(t, func() bool {
+		account, _, err := client.QueryAccount(caller.GetAddress())
+		require.NoError(t, err)
+
+		return account.Sequence == seq
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func TestEvents_Integration(t *testing.T) {
+	config := integration.TestingMinimalNodeConfig(gnoenv.RootDir())
+
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	// Init Signer & RPCClient
+	signer := newInMemorySigner(t, "tendermint_test")
+	rpcClient, err := rpcclient.NewHTTPClient(remoteAddr)
+	require.NoError(t, err)
+
+	// Setup Client
+	client := Client{
+		Signer:    signer,
+		RPCClient: rpcClient,
+	}
+
+	caller, err := client.Signer.Info()
+	require.NoError(t, err)
+
+	// Make Tx config
+	baseCfg := BaseTxCfg{
+		GasFee:    ugnot.ValueString(2100000),
+		GasWanted: 21000000,
+	}
+
+	fileBody := `package main
+import "std"
+func main() {
+	std.Emit("Greeted", "name", "gno")
+	std.Emit("Counted", "count", "1")
+}`
+
+	msg := vm.MsgRun{
+		Caller: caller.GetAddress(),
+		Package: &gnovm.MemPackage{
+			Name: "main",
+			Files: []*gnovm.MemFile{
+				{
+					Name: "main.gno",
+					Body: fileBody,
+				},
+			},
+		},
+	}
+
+	res, err := client.Run(baseCfg, msg)
+	require.NoError(t, err)
+
+	// Events of the committed tx
+	events := TxEvents(res)
+	require.Len(t, events, 2)
+	assert.Equal(t, "Greeted", events[0].Type)
+	assert.Equal(t, res.Height, events[0].Height)
+
+	name, ok := events[0].Attr("name")
+	assert.True(t, ok)
+	assert.Equal(t, "gno", name)
+
+	// Events of the block
+	events, err = client.Events(1, res.Height, EventFilter{Type: "Counted"})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, res.Height, events[0].Height)
+	assert.Equal(t, []byte(res.Hash), events[0].TxHash)
+	assert.Equal(t, 1, events[0].Index)
+
+	count, ok := events[0].Attr("count")
+	assert.True(t, ok)
+	assert.Equal(t, "1", count)
+
+	// Stream the events of the tx
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var streamed []Event
+	err = client.StreamEvents(ctx, StreamEventsCfg{
+		FromHeight:   res.Height,
+		PollInterval: 100 * time.Millisecond,
+		Filters:      []EventFilter{{PkgPath: events[0].PkgPath}},
+	}, func(ev Event) error {
+		streamed = append(streamed, ev)
+		if len(streamed) == 2 {
+			cancel()
+		}
+
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Len(t, streamed, 2)
+	assert.Equal(t, "Greeted", streamed[0].Type)
+	assert.Equal(t, "Counted", streamed[1].Type)
+}
+
 // todo add more integration tests:
 // MsgCall with Send field populated (single/multiple)
 // MsgRun with Send field populated (single/multiple)
@@ -0,0 +1,125 @@
+package gnoclient
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+var ErrInvalidCallResult = errors.New("invalid call result")
+
+// CallResult is a single value returned by a realm function,
+// as printed by the VM in MsgCall results and qeval responses: (<value> <type>)
+type CallResult struct {
+	Value string // Printed value, quoted for strings
+	Type  string // Gno type of the value
+}
+
+// ParseCallResults parses the values returned by a single realm function call,
+// either from the DeliverTx data of a transaction with a single MsgCall,
+// or from a qeval response.
+func ParseCallResults(data string) ([]CallResult, error) {
+	data = strings.TrimRight(data, "\n")
+	if data == "" {
+		return nil, nil
+	}
+
+	lines := strings.Split(data, "\n")
+	results := make([]CallResult, 0, len(lines))
+
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "(") || !strings.HasSuffix(line, ")") {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCallResult, line)
+		}
+
+		line = line[1 : len(line)-1]
+
+		// The type doesn't contain spaces for the supported values,
+		// while quoted strings can
+		sep := strings.LastIndex(line, " ")
+		if sep == -1 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCallResult, line)
+		}
+
+		results = append(results, CallResult{
+			Value: line[:sep],
+			Type:  line[sep+1:],
+		})
+	}
+
+	return results, nil
+}
+
+// CallResultType is a Go type a CallResult can be decoded into
+type CallResultType interface {
+	bool | string |
+		int | int8 | int16 | int32 | int64 |
+		uint | uint8 | uint16 | uint32 | uint64 |
+		float32 | float64
+}
+
+// DecodeCallResult decodes the value of the result into T
+func DecodeCallResult[T CallResultType](r CallResult) (T, error) {
+	var (
+		out T
+		err error
+	)
+
+	switch v := any(&out).(type) {
+	case *bool:
+		*v, err = strconv.ParseBool(r.Value)
+	case *string:
+		// Empty strings are printed without quotes
+		if r.Value != "" {
+			*v, err = strconv.Unquote(r.Value)
+		}
+	case *int:
+		*v, err = strconv.Atoi(r.Value)
+	case *int8:
+		var i int64
+		i, err = strconv.ParseInt(r.Value, 10, 8)
+		*v = int8(i)
+	case *int16:
+		var i int64
+		i, err = strconv.ParseInt(r.Value, 10, 16)
+		*v = int16(i)
+	case *int32:
+		var i int64
+		i, err = strconv.ParseInt(r.Value, 10, 32)
+		*v = int32(i)
+	case *int64:
+		*v, err = strconv.ParseInt(r.Value, 10, 64)
+	case *uint:
+		var u uint64
+		u, err = strconv.ParseUint(r.Value, 10, 0)
+		*v = uint(u)
+	case *uint8:
+		var u uint64
+		u, err = strconv.ParseUint(r.Value, 10, 8)
+		*v = uint8(u)
+	case *uint16:
+		var u uint64
+		u, err = strconv.ParseUint(r.Value, 10, 16)
+		*v = uint16(u)
+	case *uint32:
+		var u uint64
+		u, err = strconv.ParseUint(r.Value, 10, 32)
+		*v = uint32(u)
+	case *uint64:
+		*v, err = strconv.ParseUint(r.Value, 10, 64)
+	case *float32:
+		var f float64
+		f, err = strconv.ParseFloat(r.Value, 32)
+		*v = float32(f)
+	case *float64:
+		*v, err = strconv.ParseFloat(r.Value, 64)
+	}
+
+	if err != nil {
+		return out, fmt.Errorf("%w: unable to decode %s %s: %w", ErrInvalidCallResult, r.Type, r.Value, err)
+	}
+
+	return out, nil
+}
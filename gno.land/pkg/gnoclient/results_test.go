@@ -0,0 +1,74 @@
+package gnoclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCallResults(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid results", func(t *testing.T) {
+		t.Parallel()
+
+		data := "(\"hello world\" string)\n( string)\n(-42 int)\n(true bool)\n(1.5 float64)\n(\"g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5\" std.Address)\n\n"
+
+		results, err := ParseCallResults(data)
+		require.NoError(t, err)
+		require.Len(t, results, 6)
+
+		s, err := DecodeCallResult[string](results[0])
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", s)
+
+		s, err = DecodeCallResult[string](results[1])
+		require.NoError(t, err)
+		assert.Equal(t, "", s)
+
+		i, err := DecodeCallResult[int](results[2])
+		require.NoError(t, err)
+		assert.Equal(t, -42, i)
+
+		b, err := DecodeCallResult[bool](results[3])
+		require.NoError(t, err)
+		assert.True(t, b)
+
+		f, err := DecodeCallResult[float64](results[4])
+		require.NoError(t, err)
+		assert.Equal(t, 1.5, f)
+
+		addr, err := DecodeCallResult[string](results[5])
+		require.NoError(t, err)
+		assert.Equal(t, "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5", addr)
+		assert.Equal(t, "std.Address", results[5].Type)
+	})
+
+	t.Run("no results", func(t *testing.T) {
+		t.Parallel()
+
+		results, err := ParseCallResults("\n\n")
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("invalid results", func(t *testing.T) {
+		t.Parallel()
+
+		for _, data := range []string{"hello", "(hello)", "(42 int"} {
+			_, err := ParseCallResults(data)
+			assert.ErrorIs(t, err, ErrInvalidCallResult, data)
+		}
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := DecodeCallResult[int8](CallResult{Value: "300", Type: "int8"})
+		assert.ErrorIs(t, err, ErrInvalidCallResult)
+
+		_, err = DecodeCallResult[uint](CallResult{Value: "-1", Type: "uint"})
+		assert.ErrorIs(t, err, ErrInvalidCallResult)
+	})
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/hd"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -138,11 +140,18 @@ var _ Signer = (*SignerFromKeybase)(nil)
 //
 // Warning: Using keys.NewKeyBaseFromDir to get a keypair from local storage is recommended where possible, as it is more secure.
 func SignerFromBip39(mnemonic string, chainID string, passphrase string, account uint32, index uint32) (Signer, error) {
+	return SignerFromBip39WithAlgo(mnemonic, chainID, passphrase, keys.Secp256k1, account, index)
+}
+
+// SignerFromBip39WithAlgo is like SignerFromBip39, but derives a key of the given signing algo
+// (secp256k1, ed25519 or secp256r1).
+func SignerFromBip39WithAlgo(mnemonic string, chainID string, passphrase string, algo keys.SigningAlgo, account uint32, index uint32) (Signer, error) {
 	kb := keys.NewInMemory()
 	name := "default"
 	password := "" // Password isn't needed for in-memory storage
 
-	_, err := kb.CreateAccount(name, mnemonic, passphrase, password, account, index)
+	params := hd.NewFundraiserParams(account, crypto.CoinType, index)
+	_, err := kb.CreateAccountWithAlgo(name, mnemonic, passphrase, password, algo, *params)
 	if err != nil {
 		return nil, err
 	}
@@ -156,3 +165,26 @@ func SignerFromBip39(mnemonic string, chainID string, passphrase string, account
 
 	return &signer, nil
 }
+
+// SignerFromPrivKey creates a signer from an in-memory keybase with a single default account, holding the given private key.
+// Any supported key type can be used, such as a secp256r1 key generated for a passkey.
+//
+// Warning: Using keys.NewKeyBaseFromDir to get a keypair from local storage is recommended where possible, as it is more secure.
+func SignerFromPrivKey(priv crypto.PrivKey, chainID string) (Signer, error) {
+	kb := keys.NewInMemory()
+	name := "default"
+	password := "" // Password isn't needed for in-memory storage
+
+	if err := kb.ImportPrivKey(name, priv, password); err != nil {
+		return nil, err
+	}
+
+	signer := SignerFromKeybase{
+		Keybase:  kb,
+		Account:  name,
+		Password: password,
+		ChainID:  chainID,
+	}
+
+	return &signer, nil
+}
@@ -1 +1,86 @@
 package gnoclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256r1"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const testMnemonic = "index brass unknown lecture autumn provide royal shrimp elegant wink now zebra discover swarm act ill you bullet entire outdoor tilt usage gap multiply"
+
+// signTestTx signs a tx from the signer address,
+// and verifies the resulting signature
+func signTestTx(t *testing.T, signer Signer) {
+	t.Helper()
+
+	require.NoError(t, signer.Validate())
+
+	info, err := signer.Info()
+	require.NoError(t, err)
+
+	tx := std.Tx{
+		Msgs: []std.Msg{vm.MsgCall{Caller: info.GetAddress(), PkgPath: "gno.land/r/demo/deep/very/deep", Func: "Render"}},
+		Fee:  std.NewFee(1, std.NewCoin("ugnot", 1)),
+	}
+
+	signed, err := signer.Sign(SignCfg{UnsignedTX: tx})
+	require.NoError(t, err)
+	require.Len(t, signed.Signatures, 1)
+
+	signBytes, err := signed.GetSignBytes("dev", 0, 0)
+	require.NoError(t, err)
+
+	sig := signed.Signatures[0]
+	assert.Equal(t, info.GetAddress(), sig.PubKey.Address())
+	assert.True(t, sig.PubKey.VerifyBytes(signBytes, sig.Signature))
+}
+
+func TestSignerFromBip39WithAlgo(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		algo   keys.SigningAlgo
+		pubKey crypto.PubKey
+	}{
+		{keys.Ed25519, ed25519.PubKeyEd25519{}},
+		{keys.Secp256r1, secp256r1.PubKeySecp256r1{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.algo), func(t *testing.T) {
+			t.Parallel()
+
+			signer, err := SignerFromBip39WithAlgo(testMnemonic, "dev", "", tc.algo, 0, 0)
+			require.NoError(t, err)
+
+			info, err := signer.Info()
+			require.NoError(t, err)
+			assert.IsType(t, tc.pubKey, info.GetPubKey())
+
+			signTestTx(t, signer)
+		})
+	}
+}
+
+func TestSignerFromPrivKey(t *testing.T) {
+	t.Parallel()
+
+	priv := secp256r1.GenPrivKey()
+
+	signer, err := SignerFromPrivKey(priv, "dev")
+	require.NoError(t, err)
+
+	info, err := signer.Info()
+	require.NoError(t, err)
+	assert.Equal(t, priv.PubKey().Address(), info.GetAddress())
+
+	signTestTx(t, signer)
+}
@@ -0,0 +1,308 @@
+package gnoclient
+
+import (
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/traces"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// defaultMaxRetries is the default number of times a transaction
+// is re-signed and re-broadcast after a sequence mismatch
+const defaultMaxRetries = 3
+
+// BroadcastMode defines how a TxBuilder broadcasts transactions
+type BroadcastMode int
+
+const (
+	BroadcastCommit BroadcastMode = iota // Wait for the transaction to be included in a block
+	BroadcastSync                        // Wait for the transaction to pass CheckTx
+	BroadcastAsync                       // Return right after the transaction is sent
+)
+
+// TxBuilder signs and broadcasts transactions for the client Signer.
+// The account number is fetched from the chain on first use,
+// and the sequence is then tracked locally, so several transactions
+// can be broadcast in the same block.
+//
+// When a transaction is rejected because of a sequence mismatch,
+// the TxBuilder retries with the sequence expected by the chain.
+// A TxBuilder is safe for concurrent use.
+type TxBuilder struct {
+	client     *Client
+	mode       BroadcastMode
+	maxRetries int
+
+	mu            sync.Mutex
+	synced        bool
+	accountNumber uint64
+	sequence      uint64
+}
+
+// TxBuilderOption is a TxBuilder configuration option
+type TxBuilderOption func(*TxBuilder)
+
+// WithBroadcastMode sets the broadcast mode of the TxBuilder (BroadcastCommit by default)
+func WithBroadcastMode(mode BroadcastMode) TxBuilderOption {
+	return func(b *TxBuilder) {
+		b.mode = mode
+	}
+}
+
+// WithMaxRetries sets how many times a transaction is retried after a sequence mismatch
+func WithMaxRetries(retries int) TxBuilderOption {
+	return func(b *TxBuilder) {
+		b.maxRetries = retries
+	}
+}
+
+// BroadcastResult is the result of a transaction broadcast by a TxBuilder
+type BroadcastResult struct {
+	Hash          []byte // Transaction hash
+	AccountNumber uint64 // Account number used to sign the transaction
+	Sequence      uint64 // Sequence number used to sign the transaction
+
+	Commit    *ctypes.ResultBroadcastTxCommit // Set in BroadcastCommit mode
+	Broadcast *ctypes.ResultBroadcastTx       // Set in BroadcastSync and BroadcastAsync modes
+}
+
+// NewTxBuilder creates a TxBuilder for the client Signer
+func (c *Client) NewTxBuilder(opts ...TxBuilderOption) (*TxBuilder, error) {
+	// Validate required client fields.
+	if err := c.validateSigner(); err != nil {
+		return nil, err
+	}
+	if err := c.validateRPCClient(); err != nil {
+		return nil, err
+	}
+
+	b := &TxBuilder{
+		client:     c,
+		mode:       BroadcastCommit,
+		maxRetries: defaultMaxRetries,
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	switch b.mode {
+	case BroadcastCommit, BroadcastSync, BroadcastAsync:
+	default:
+		return nil, ErrInvalidBroadcastMode
+	}
+
+	return b, nil
+}
+
+// Sequence returns the next sequence number the TxBuilder will sign with,
+// and whether it is synced with the chain
+func (b *TxBuilder) Sequence() (uint64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.sequence, b.synced
+}
+
+// Resync fetches the account number and sequence from the chain.
+// It should be called after transactions sent in BroadcastAsync mode fail.
+func (b *TxBuilder) Resync() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.sync()
+}
+
+// Broadcast makes a transaction from the given msgs, which can be of any type
+// (for example vm.MsgCall, vm.MsgRun and bank.MsgSend), signs it and broadcasts it.
+// The AccountNumber and SequenceNumber of the config are ignored.
+func (b *TxBuilder) Broadcast(cfg BaseTxCfg, msgs ...std.Msg) (*BroadcastResult, error) {
+	tx, err := NewTx(cfg, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		if !b.synced {
+			if err := b.sync(); err != nil {
+				return nil, err
+			}
+		}
+
+		signedTx, err := b.client.Signer.Sign(SignCfg{
+			UnsignedTX:     *tx,
+			SequenceNumber: b.sequence,
+			AccountNumber:  b.accountNumber,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "sign")
+		}
+
+		res, err := b.broadcast(signedTx)
+		if err != nil {
+			// The transaction may or may not have been received:
+			// if it was, the next broadcast fails with a sequence mismatch
+			// and is retried with the expected sequence
+			return nil, err
+		}
+
+		if checkErr, checkLog := res.checkTx(); checkErr != nil {
+			if isSequenceMismatch(checkErr) && attempt < b.maxRetries {
+				if err := b.reconcile(checkLog); err != nil {
+					return nil, err
+				}
+
+				continue
+			}
+
+			return res, errors.Wrapf(checkErr, "check transaction failed: log:%s", checkLog)
+		}
+
+		// The sequence is incremented once the transaction passes CheckTx,
+		// even if its messages fail
+		b.sequence++
+
+		if res.Commit != nil && res.Commit.DeliverTx.IsErr() {
+			return res, errors.Wrapf(res.Commit.DeliverTx.Error, "deliver transaction failed: log:%s", res.Commit.DeliverTx.Log)
+		}
+
+		return res, nil
+	}
+}
+
+// sync fetches the account number and sequence of the signer from the chain
+func (b *TxBuilder) sync() error {
+	caller, err := b.client.Signer.Info()
+	if err != nil {
+		return err
+	}
+
+	account, _, err := b.client.QueryAccount(caller.GetAddress())
+	if err != nil {
+		return errors.Wrap(err, "query account")
+	}
+
+	b.accountNumber = account.AccountNumber
+	b.sequence = account.Sequence
+	b.synced = true
+
+	return nil
+}
+
+// reconcile updates the sequence after a sequence mismatch.
+// The sequence expected by CheckTx accounts for the transactions
+// pending in the mempool, unlike the committed one, so it is used if
+// the chain reports it. Otherwise, the account is fetched from the chain,
+// but the sequence is never moved back, as the transactions of the TxBuilder
+// may not be committed yet
+func (b *TxBuilder) reconcile(checkLog string) error {
+	if sequence, ok := expectedSequence(checkLog); ok {
+		b.sequence = sequence
+
+		return nil
+	}
+
+	local := b.sequence
+	if err := b.sync(); err != nil {
+		return err
+	}
+
+	b.sequence = max(b.sequence, local)
+
+	return nil
+}
+
+// broadcast broadcasts the signed transaction using the TxBuilder mode
+func (b *TxBuilder) broadcast(signedTx *std.Tx) (_ *BroadcastResult, err error) {
+	bz, err := amino.Marshal(signedTx)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling tx binary bytes")
+	}
+
+	res := &BroadcastResult{
+		AccountNumber: b.accountNumber,
+		Sequence:      b.sequence,
+	}
+
+	ctx, span := traces.StartSpan(
+		b.client.context(),
+		"gnoclient.Broadcast",
+		attribute.Int64("tx.sequence", int64(b.sequence)),
+	)
+	defer func() {
+		traces.EndSpan(span, err)
+	}()
+
+	rpcClient := b.client.rpcClientWithContext(ctx)
+
+	switch b.mode {
+	case BroadcastCommit:
+		bres, err := rpcClient.BroadcastTxCommit(bz)
+		if err != nil {
+			return nil, errors.Wrap(err, "broadcasting bytes")
+		}
+
+		res.Hash, res.Commit = bres.Hash, bres
+	case BroadcastSync, BroadcastAsync:
+		broadcastFn := rpcClient.BroadcastTxSync
+		if b.mode == BroadcastAsync {
+			broadcastFn = rpcClient.BroadcastTxAsync
+		}
+
+		bres, err := broadcastFn(bz)
+		if err != nil {
+			return nil, errors.Wrap(err, "broadcasting bytes")
+		}
+
+		res.Hash, res.Broadcast = bres.Hash, bres
+	}
+
+	return res, nil
+}
+
+// checkTx returns the CheckTx error and log of the broadcast, if any
+func (r *BroadcastResult) checkTx() (abci.Error, string) {
+	if r.Commit != nil {
+		return r.Commit.CheckTx.Error, r.Commit.CheckTx.Log
+	}
+
+	return r.Broadcast.Error, r.Broadcast.Log
+}
+
+// isSequenceMismatch returns true if the CheckTx error is caused
+// by an invalid signature, which is the case when the sequence is wrong
+func isSequenceMismatch(err abci.Error) bool {
+	_, ok := err.(std.UnauthorizedError)
+
+	return ok
+}
+
+// expectedSequenceRe matches the sequence reported by the chain
+// when the signature verification fails
+var expectedSequenceRe = regexp.MustCompile(`expected sequence (\d+)`)
+
+// expectedSequence returns the sequence expected by the chain,
+// if the CheckTx log reports it
+func expectedSequence(checkLog string) (uint64, bool) {
+	match := expectedSequenceRe.FindStringSubmatch(checkLog)
+	if match == nil {
+		return 0, false
+	}
+
+	sequence, err := strconv.ParseUint(match[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return sequence, true
+}
@@ -0,0 +1,378 @@
+package gnoclient
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// mockChain is a minimal chain keeping track of a single account,
+// which only accepts transactions signed with the current sequence
+type mockChain struct {
+	mu sync.Mutex
+
+	chainID       string
+	accountNumber uint64
+	sequence      uint64 // sequence expected by CheckTx
+	pending       uint64 // transactions in the mempool, not yet committed
+	omitSequence  bool   // do not report the expected sequence in the CheckTx log
+	queries       int
+	txs           []std.Tx
+}
+
+func (c *mockChain) queryAccount(t *testing.T, addr crypto.Address) *ctypes.ResultABCIQuery {
+	t.Helper()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.queries++
+
+	account := struct{ BaseAccount std.BaseAccount }{
+		BaseAccount: std.BaseAccount{
+			Address:       addr,
+			AccountNumber: c.accountNumber,
+			Sequence:      c.sequence - c.pending,
+		},
+	}
+
+	data, err := amino.MarshalJSON(account)
+	require.NoError(t, err)
+
+	return &ctypes.ResultABCIQuery{
+		Response: abci.ResponseQuery{
+			ResponseBase: abci.ResponseBase{Data: data},
+		},
+	}
+}
+
+// checkTx verifies the tx signature against the current sequence,
+// and increments it on success
+func (c *mockChain) checkTx(t *testing.T, bz types.Tx) (abci.Error, string) {
+	t.Helper()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var tx std.Tx
+	require.NoError(t, amino.Unmarshal(bz, &tx))
+
+	signBytes, err := tx.GetSignBytes(c.chainID, c.accountNumber, c.sequence)
+	require.NoError(t, err)
+
+	sig := tx.Signatures[0]
+	if !sig.PubKey.VerifyBytes(signBytes, sig.Signature) {
+		if c.omitSequence {
+			return std.UnauthorizedError{}, "signature verification failed"
+		}
+
+		return std.UnauthorizedError{}, fmt.Sprintf("signature verification failed (expected sequence %d)", c.sequence)
+	}
+
+	c.sequence++
+	c.txs = append(c.txs, tx)
+
+	return nil, ""
+}
+
+func newTxBuilderTestClient(t *testing.T, chain *mockChain) *Client {
+	t.Helper()
+
+	signer := newInMemorySignerForChain(t, chain.chainID)
+
+	return &Client{
+		Signer: signer,
+		RPCClient: &mockRPCClient{
+			abciQuery: func(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+				info, err := signer.Info()
+				require.NoError(t, err)
+
+				return chain.queryAccount(t, info.GetAddress()), nil
+			},
+			broadcastTxSync: func(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+				checkErr, checkLog := chain.checkTx(t, tx)
+
+				return &ctypes.ResultBroadcastTx{
+					Error: checkErr,
+					Log:   checkLog,
+					Hash:  tx.Hash(),
+				}, nil
+			},
+			broadcastTxAsync: func(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+				chain.checkTx(t, tx)
+
+				return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+			},
+			broadcastTxCommit: func(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+				checkErr, checkLog := chain.checkTx(t, tx)
+
+				return &ctypes.ResultBroadcastTxCommit{
+					CheckTx: abci.ResponseCheckTx{
+						ResponseBase: abci.ResponseBase{Error: checkErr, Log: checkLog},
+					},
+					Hash: tx.Hash(),
+				}, nil
+			},
+		},
+	}
+}
+
+func newInMemorySignerForChain(t *testing.T, chainID string) Signer {
+	t.Helper()
+
+	mnemonic := "index brass unknown lecture autumn provide royal shrimp elegant wink now zebra discover swarm act ill you bullet entire outdoor tilt usage gap multiply"
+
+	signer, err := SignerFromBip39(mnemonic, chainID, "", 0, 0)
+	require.NoError(t, err)
+
+	return signer
+}
+
+func txBuilderTestMsgs(t *testing.T, client *Client) []std.Msg {
+	t.Helper()
+
+	info, err := client.Signer.Info()
+	require.NoError(t, err)
+
+	return []std.Msg{
+		vm.MsgCall{
+			Caller:  info.GetAddress(),
+			PkgPath: "gno.land/r/demo/deep/very/deep",
+			Func:    "Render",
+		},
+		bank.MsgSend{
+			FromAddress: info.GetAddress(),
+			ToAddress:   info.GetAddress(),
+			Amount:      std.NewCoins(std.NewCoin("ugnot", 1)),
+		},
+	}
+}
+
+func TestTxBuilder_Broadcast(t *testing.T) {
+	t.Parallel()
+
+	cfg := BaseTxCfg{
+		GasWanted: 100000,
+		GasFee:    testGasFee,
+	}
+
+	modes := map[string]BroadcastMode{
+		"commit": BroadcastCommit,
+		"sync":   BroadcastSync,
+		"async":  BroadcastAsync,
+	}
+
+	for name, mode := range modes {
+		t.Run("sequence tracking, "+name, func(t *testing.T) {
+			t.Parallel()
+
+			chain := &mockChain{chainID: "dev", accountNumber: 10, sequence: 5}
+			client := newTxBuilderTestClient(t, chain)
+
+			builder, err := client.NewTxBuilder(WithBroadcastMode(mode))
+			require.NoError(t, err)
+
+			msgs := txBuilderTestMsgs(t, client)
+			for i := range 3 {
+				res, err := builder.Broadcast(cfg, msgs...)
+				require.NoError(t, err)
+
+				assert.Equal(t, uint64(10), res.AccountNumber)
+				assert.Equal(t, uint64(5+i), res.Sequence)
+				assert.NotEmpty(t, res.Hash)
+			}
+
+			// The account is only fetched once
+			assert.Equal(t, 1, chain.queries)
+			assert.Equal(t, uint64(8), chain.sequence)
+
+			require.Len(t, chain.txs, 3)
+			assert.Len(t, chain.txs[0].Msgs, 2)
+
+			seq, synced := builder.Sequence()
+			assert.Equal(t, uint64(8), seq)
+			assert.True(t, synced)
+		})
+	}
+
+	t.Run("sequence mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockChain{chainID: "dev", sequence: 1}
+		client := newTxBuilderTestClient(t, chain)
+
+		builder, err := client.NewTxBuilder(WithBroadcastMode(BroadcastSync))
+		require.NoError(t, err)
+
+		msgs := txBuilderTestMsgs(t, client)
+
+		_, err = builder.Broadcast(cfg, msgs...)
+		require.NoError(t, err)
+
+		// Another client uses the account
+		chain.sequence += 2
+
+		res, err := builder.Broadcast(cfg, msgs...)
+		require.NoError(t, err)
+
+		// The expected sequence is taken from the CheckTx error
+		assert.Equal(t, uint64(4), res.Sequence)
+		assert.Equal(t, 1, chain.queries)
+	})
+
+	t.Run("sequence mismatch, pending transactions", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockChain{chainID: "dev", sequence: 5}
+		client := newTxBuilderTestClient(t, chain)
+
+		builder, err := client.NewTxBuilder(WithBroadcastMode(BroadcastSync))
+		require.NoError(t, err)
+
+		msgs := txBuilderTestMsgs(t, client)
+
+		// The transactions stay in the mempool
+		for range 2 {
+			_, err = builder.Broadcast(cfg, msgs...)
+			require.NoError(t, err)
+		}
+		chain.pending = 2
+
+		// Another client uses the account, and its transaction is pending too
+		chain.sequence++
+		chain.pending++
+
+		res, err := builder.Broadcast(cfg, msgs...)
+		require.NoError(t, err)
+
+		// The committed sequence (5) is behind the one expected by CheckTx
+		assert.Equal(t, uint64(8), res.Sequence)
+
+		seq, _ := builder.Sequence()
+		assert.Equal(t, uint64(9), seq)
+	})
+
+	t.Run("sequence mismatch, sequence not reported", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockChain{chainID: "dev", sequence: 5, omitSequence: true}
+		client := newTxBuilderTestClient(t, chain)
+
+		builder, err := client.NewTxBuilder(WithBroadcastMode(BroadcastSync), WithMaxRetries(1))
+		require.NoError(t, err)
+
+		msgs := txBuilderTestMsgs(t, client)
+
+		for range 2 {
+			_, err = builder.Broadcast(cfg, msgs...)
+			require.NoError(t, err)
+		}
+		chain.pending = 2
+
+		// The transaction of another client is committed
+		chain.sequence++
+		chain.pending = 0
+
+		res, err := builder.Broadcast(cfg, msgs...)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(8), res.Sequence)
+		assert.Equal(t, 2, chain.queries)
+
+		// The transaction of another client is pending: the account is
+		// re-fetched, but the sequence is not moved back to the committed one
+		chain.sequence++
+		chain.pending = 2
+
+		_, err = builder.Broadcast(cfg, msgs...)
+		require.Error(t, err)
+
+		seq, _ := builder.Sequence()
+		assert.Equal(t, uint64(9), seq)
+		assert.Equal(t, 3, chain.queries)
+	})
+
+	t.Run("max retries", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockChain{chainID: "dev"}
+		client := newTxBuilderTestClient(t, chain)
+
+		// The signer uses another chain ID, so the signature never matches
+		client.Signer = newInMemorySignerForChain(t, "other")
+
+		builder, err := client.NewTxBuilder(WithBroadcastMode(BroadcastSync), WithMaxRetries(2))
+		require.NoError(t, err)
+
+		_, err = builder.Broadcast(cfg, txBuilderTestMsgs(t, client)...)
+		require.Error(t, err)
+
+		assert.ErrorAs(t, err, &std.UnauthorizedError{})
+		assert.Equal(t, 1, chain.queries)
+		assert.Equal(t, uint64(0), chain.sequence)
+	})
+
+	t.Run("deliver error", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockChain{chainID: "dev"}
+		client := newTxBuilderTestClient(t, chain)
+
+		rpcClient := client.RPCClient.(*mockRPCClient)
+		rpcClient.broadcastTxCommit = func(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+			checkErr, _ := chain.checkTx(t, tx)
+
+			return &ctypes.ResultBroadcastTxCommit{
+				CheckTx: abci.ResponseCheckTx{
+					ResponseBase: abci.ResponseBase{Error: checkErr},
+				},
+				DeliverTx: abci.ResponseDeliverTx{
+					ResponseBase: abci.ResponseBase{Error: std.InternalError{}},
+				},
+			}, nil
+		}
+
+		builder, err := client.NewTxBuilder()
+		require.NoError(t, err)
+
+		_, err = builder.Broadcast(cfg, txBuilderTestMsgs(t, client)...)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &std.InternalError{})
+
+		// The sequence was used by the failed transaction
+		seq, _ := builder.Sequence()
+		assert.Equal(t, uint64(1), seq)
+	})
+}
+
+func TestTxBuilder_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := (&Client{RPCClient: &mockRPCClient{}}).NewTxBuilder()
+	assert.ErrorIs(t, err, ErrMissingSigner)
+
+	_, err = (&Client{Signer: &mockSigner{}}).NewTxBuilder()
+	assert.ErrorIs(t, err, ErrMissingRPCClient)
+
+	client := &Client{Signer: &mockSigner{}, RPCClient: &mockRPCClient{}}
+
+	_, err = client.NewTxBuilder(WithBroadcastMode(BroadcastMode(42)))
+	assert.ErrorIs(t, err, ErrInvalidBroadcastMode)
+
+	_, err = NewTx(BaseTxCfg{GasWanted: 1, GasFee: testGasFee})
+	assert.ErrorIs(t, err, ErrNoMsgs)
+
+	_, err = NewTx(BaseTxCfg{GasFee: testGasFee}, bank.MsgSend{})
+	assert.ErrorIs(t, err, ErrInvalidGasWanted)
+}
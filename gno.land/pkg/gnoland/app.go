@@ -20,7 +20,9 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/log"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/authz"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/distribution"
 	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
@@ -41,6 +43,7 @@ type AppOptions struct {
 	SkipGenesisVerification bool               // default to verify genesis transactions
 	InitChainerConfig                          // options related to InitChainer
 	MinGasPrices            string             // optional
+	ViolationHandler        ViolationHandler   // optional Go-side hook for validator violations
 }
 
 // TestAppOptions provides a "ready" default [AppOptions] for use with
@@ -101,17 +104,20 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	acck := auth.NewAccountKeeper(mainKey, prmk.ForModule(auth.ModuleName), ProtoGnoAccount)
 	bankk := bank.NewBankKeeper(acck, prmk.ForModule(bank.ModuleName))
 	gpk := auth.NewGasPriceKeeper(mainKey)
-	vmk := vm.NewVMKeeper(baseKey, mainKey, acck, bankk, prmk)
+	distk := distribution.NewDistributionKeeper(mainKey, bankk, prmk.ForModule(distribution.ModuleName))
+	authzk := authz.NewAuthzKeeper(mainKey)
+	vmk := vm.NewVMKeeper(baseKey, mainKey, acck, bankk, prmk, distk)
 	vmk.Output = cfg.VMOutput
 
 	prmk.Register(auth.ModuleName, acck)
 	prmk.Register(bank.ModuleName, bankk)
+	prmk.Register(distribution.ModuleName, distk)
 	prmk.Register(vm.ModuleName, vmk)
 
 	// Set InitChainer
 	icc := cfg.InitChainerConfig
 	icc.baseApp = baseApp
-	icc.acck, icc.bankk, icc.vmk, icc.prmk, icc.gpk = acck, bankk, vmk, prmk, gpk
+	icc.acck, icc.bankk, icc.vmk, icc.prmk, icc.gpk, icc.distk = acck, bankk, vmk, prmk, gpk, distk
 	baseApp.SetInitChainer(icc.InitChainer)
 
 	// Set AnteHandler
@@ -155,12 +161,23 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 		validatorEventFilter, // filter fn that keeps the collector valid
 	)
 
+	// Set BeginBlocker
+	baseApp.SetBeginBlocker(
+		BeginBlocker(
+			c,
+			vmk,
+			cfg.ViolationHandler,
+			baseApp,
+		),
+	)
+
 	// Set EndBlocker
 	baseApp.SetEndBlocker(
 		EndBlocker(
 			c,
 			acck,
 			gpk,
+			distk,
 			vmk,
 			baseApp,
 		),
@@ -168,7 +185,9 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 
 	// Set a handler Route.
 	baseApp.Router().AddRoute("auth", auth.NewHandler(acck))
+	baseApp.Router().AddRoute("authz", authz.NewHandler(authzk, baseApp.Router()))
 	baseApp.Router().AddRoute("bank", bank.NewHandler(bankk))
+	baseApp.Router().AddRoute("distribution", distribution.NewHandler(distk))
 	baseApp.Router().AddRoute("params", params.NewHandler(prmk))
 	baseApp.Router().AddRoute("vm", vm.NewHandler(vmk))
 
@@ -271,6 +290,7 @@ type InitChainerConfig struct {
 	bankk   bank.BankKeeperI
 	prmk    params.ParamsKeeperI
 	gpk     auth.GasPriceKeeperI
+	distk   distribution.DistributionKeeperI
 }
 
 // InitChainer is the function that can be used as a [sdk.InitChainer].
@@ -354,6 +374,11 @@ func (cfg InitChainerConfig) loadAppState(ctx sdk.Context, appState any) ([]abci
 	}
 
 	cfg.vmk.InitGenesis(ctx, state.VM)
+	distGen := distribution.DefaultGenesisState()
+	if state.Distribution != nil {
+		distGen = *state.Distribution
+	}
+	cfg.distk.InitGenesis(ctx, distGen)
 
 	params := cfg.acck.GetParams(ctx)
 	ctx = ctx.WithValue(auth.AuthParamsContextKey{}, params)
@@ -416,12 +441,14 @@ type endBlockerApp interface {
 }
 
 // EndBlocker defines the logic executed after every block.
-// Currently, it parses events that happened during execution to calculate
+// Currently, it updates the gas price, distributes the collected fees,
+// and parses events that happened during execution to calculate
 // validator set changes
 func EndBlocker(
 	collector *collector[validatorUpdate],
 	acck auth.AccountKeeperI,
 	gpk auth.GasPriceKeeperI,
+	distk distribution.DistributionKeeperI,
 	vmk vm.VMKeeperI,
 	app endBlockerApp,
 ) func(
@@ -437,6 +464,9 @@ func EndBlocker(
 		if acck != nil && gpk != nil {
 			auth.EndBlocker(ctx, gpk)
 		}
+		if distk != nil {
+			distribution.EndBlocker(ctx, distk)
+		}
 		// Check if there was a valset change
 		if len(collector.getEvents()) == 0 {
 			// No valset updates
@@ -21,6 +21,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/distribution"
 	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/sdk/testutils"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -126,6 +127,46 @@ func TestNewAppWithOptions(t *testing.T) {
 	}
 }
 
+// Tests that a genesis without a distribution section gets the default params.
+func TestNewAppWithOptions_DefaultDistribution(t *testing.T) {
+	t.Parallel()
+
+	app, err := NewAppWithOptions(TestAppOptions(memdb.NewMemDB()))
+	require.NoError(t, err)
+	bapp := app.(*sdk.BaseApp)
+
+	appState := DefaultGenState()
+	appState.Distribution = nil
+
+	resp := bapp.InitChain(abci.RequestInitChain{
+		Time:    time.Now(),
+		ChainID: "dev",
+		ConsensusParams: &abci.ConsensusParams{
+			Block: defaultBlockParams(),
+		},
+		Validators: []abci.ValidatorUpdate{},
+		AppState:   appState,
+	})
+	require.True(t, resp.IsOK(), "InitChain response: %v", resp)
+	bapp.Commit()
+
+	dp := distribution.DefaultParams()
+	tcs := []struct {
+		path     string
+		expected int64
+	}{
+		{"params/distribution:p:proposer_share", dp.ProposerShare},
+		{"params/distribution:p:signers_share", dp.SignersShare},
+		{"params/distribution:p:authors_share", dp.AuthorsShare},
+	}
+
+	for _, tc := range tcs {
+		qres := bapp.Query(abci.RequestQuery{Path: tc.path})
+		require.True(t, qres.IsOK())
+		assert.Equal(t, fmt.Sprintf(`"%d"`, tc.expected), string(qres.Data), tc.path)
+	}
+}
+
 func TestNewAppWithOptions_ErrNoDB(t *testing.T) {
 	t.Parallel()
 
@@ -223,6 +264,7 @@ func testInitChainerLoadStdlib(t *testing.T, cached bool) { //nolint:thelper
 		bankk:           &mockBankKeeper{},
 		prmk:            &mockParamsKeeper{},
 		gpk:             &mockGasPriceKeeper{},
+		distk:           &mockDistributionKeeper{},
 		CacheStdlibLoad: cached,
 	}
 
@@ -505,7 +547,7 @@ func TestEndBlocker(t *testing.T) {
 		c := newCollector[validatorUpdate](&mockEventSwitch{}, noFilter)
 
 		// Create the EndBlocker
-		eb := EndBlocker(c, nil, nil, nil, &mockEndBlockerApp{})
+		eb := EndBlocker(c, nil, nil, nil, nil, &mockEndBlockerApp{})
 
 		// Run the EndBlocker
 		res := eb(sdk.Context{}, abci.RequestEndBlock{})
@@ -514,6 +556,37 @@ func TestEndBlocker(t *testing.T) {
 		assert.Equal(t, abci.ResponseEndBlock{}, res)
 	})
 
+	t.Run("fees distributed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			distributed bool
+
+			noFilter = func(_ events.Event) []validatorUpdate {
+				return []validatorUpdate{}
+			}
+
+			mockDistKeeper = &mockDistributionKeeper{
+				distributeFeesFn: func(_ sdk.Context) {
+					distributed = true
+				},
+			}
+		)
+
+		// Create the collector
+		c := newCollector[validatorUpdate](&mockEventSwitch{}, noFilter)
+
+		// Create the EndBlocker
+		eb := EndBlocker(c, nil, nil, mockDistKeeper, nil, &mockEndBlockerApp{})
+
+		// Run the EndBlocker
+		res := eb(sdk.Context{}, abci.RequestEndBlock{})
+
+		// Verify the fees were distributed
+		assert.True(t, distributed)
+		assert.Equal(t, abci.ResponseEndBlock{}, res)
+	})
+
 	t.Run("invalid VM call", func(t *testing.T) {
 		t.Parallel()
 
@@ -545,7 +618,7 @@ func TestEndBlocker(t *testing.T) {
 		mockEventSwitch.FireEvent(gnostdlibs.GnoEvent{})
 
 		// Create the EndBlocker
-		eb := EndBlocker(c, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
+		eb := EndBlocker(c, nil, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
 
 		// Run the EndBlocker
 		res := eb(sdk.Context{}, abci.RequestEndBlock{})
@@ -588,7 +661,7 @@ func TestEndBlocker(t *testing.T) {
 		mockEventSwitch.FireEvent(gnostdlibs.GnoEvent{})
 
 		// Create the EndBlocker
-		eb := EndBlocker(c, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
+		eb := EndBlocker(c, nil, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
 
 		// Run the EndBlocker
 		res := eb(sdk.Context{}, abci.RequestEndBlock{})
@@ -656,7 +729,7 @@ func TestEndBlocker(t *testing.T) {
 		mockEventSwitch.FireEvent(txEvent)
 
 		// Create the EndBlocker
-		eb := EndBlocker(c, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
+		eb := EndBlocker(c, nil, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
 
 		// Run the EndBlocker
 		res := eb(sdk.Context{}, abci.RequestEndBlock{})
@@ -836,14 +909,16 @@ func newGasPriceTestApp(t *testing.T) abci.Application {
 	acck := auth.NewAccountKeeper(mainKey, prmk.ForModule(auth.ModuleName), ProtoGnoAccount)
 	gpk := auth.NewGasPriceKeeper(mainKey)
 	bankk := bank.NewBankKeeper(acck, prmk.ForModule(bank.ModuleName))
-	vmk := vm.NewVMKeeper(baseKey, mainKey, acck, bankk, prmk)
+	distk := distribution.NewDistributionKeeper(mainKey, bankk, prmk.ForModule(distribution.ModuleName))
+	vmk := vm.NewVMKeeper(baseKey, mainKey, acck, bankk, prmk, distk)
 	prmk.Register(auth.ModuleName, acck)
 	prmk.Register(bank.ModuleName, bankk)
+	prmk.Register(distribution.ModuleName, distk)
 	prmk.Register(vm.ModuleName, vmk)
 	// Set InitChainer
 	icc := cfg.InitChainerConfig
 	icc.baseApp = baseApp
-	icc.acck, icc.bankk, icc.vmk, icc.gpk = acck, bankk, vmk, gpk
+	icc.acck, icc.bankk, icc.vmk, icc.gpk, icc.distk = acck, bankk, vmk, gpk, distk
 	baseApp.SetInitChainer(icc.InitChainer)
 
 	// Set AnteHandler
@@ -890,6 +965,7 @@ func newGasPriceTestApp(t *testing.T) abci.Application {
 			acck,
 			gpk,
 			nil,
+			nil,
 			baseApp,
 		),
 	)
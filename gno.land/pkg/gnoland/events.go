@@ -39,6 +39,12 @@ func (c *collector[T]) updateWith(event events.Event) {
 	}
 }
 
+// add adds the given events to the collector,
+// bypassing the filter
+func (c *collector[T]) add(events ...T) {
+	c.events = append(c.events, events...)
+}
+
 // getEvents returns the filtered events,
 // and resets the collector store
 func (c *collector[T]) getEvents() []T {
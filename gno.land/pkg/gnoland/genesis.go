@@ -14,6 +14,7 @@ import (
 	osm "github.com/gnolang/gno/tm2/pkg/os"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/distribution"
 	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/pelletier/go-toml"
@@ -236,12 +237,15 @@ func DefaultGenState() GnoGenesisState {
 	}
 	authGen.Params.InitialGasPrice = gp
 
+	distGen := distribution.DefaultGenesisState()
 	gs := GnoGenesisState{
 		Balances: []Balance{},
 		Txs:      []TxWithMetadata{},
 		Auth:     authGen,
 		Bank:     bank.DefaultGenesisState(),
 		VM:       vmm.DefaultGenesisState(),
+
+		Distribution: &distGen,
 	}
 	return gs
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/distribution"
 
 	"github.com/gnolang/gno/tm2/pkg/service"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -191,6 +192,44 @@ func (m *mockParamsKeeper) SetStruct(ctx sdk.Context, key string, strct any)
 func (m *mockParamsKeeper) GetAny(ctx sdk.Context, key string) any        { return nil }
 func (m *mockParamsKeeper) SetAny(ctx sdk.Context, key string, value any) {}
 
+type mockDistributionKeeper struct {
+	distributeFeesFn func(ctx sdk.Context)
+}
+
+func (m *mockDistributionKeeper) AddAuthorGas(ctx sdk.Context, author crypto.Address, gas int64) {}
+
+func (m *mockDistributionKeeper) DistributeFees(ctx sdk.Context) {
+	if m.distributeFeesFn != nil {
+		m.distributeFeesFn(ctx)
+	}
+}
+
+func (m *mockDistributionKeeper) GetRewards(ctx sdk.Context, addr crypto.Address) std.Coins {
+	return nil
+}
+func (m *mockDistributionKeeper) WithdrawRewards(ctx sdk.Context, addr crypto.Address) (std.Coins, error) {
+	return nil, nil
+}
+func (m *mockDistributionKeeper) GetCommunityPool(ctx sdk.Context) std.Coins                  { return nil }
+func (m *mockDistributionKeeper) InitGenesis(ctx sdk.Context, data distribution.GenesisState) {}
+func (m *mockDistributionKeeper) GetParams(ctx sdk.Context) distribution.Params {
+	return distribution.Params{}
+}
+
+func (m *mockDistributionKeeper) WithdrawCommunityPool(ctx sdk.Context, recipient crypto.Address, amt std.Coins) error {
+	return nil
+}
+
+func (m *mockDistributionKeeper) GetRewardAddress(ctx sdk.Context, val crypto.Address) (crypto.Address, bool) {
+	return crypto.Address{}, false
+}
+
+func (m *mockDistributionKeeper) SetRewardAddress(ctx sdk.Context, val, addr crypto.Address) {}
+
+func (m *mockDistributionKeeper) GetValidatorRewards(ctx sdk.Context, val crypto.Address) std.Coins {
+	return nil
+}
+
 type mockGasPriceKeeper struct{}
 
 func (m *mockGasPriceKeeper) LastGasPrice(ctx sdk.Context) std.GasPrice    { return std.GasPrice{} }
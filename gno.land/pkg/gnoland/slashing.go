@@ -0,0 +1,137 @@
+package gnoland
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+const (
+	valViolationFn = "ReportViolation"
+
+	// maxViolationGas is the gas limit for reporting a single violation to the VM
+	maxViolationGas = 10_000_000
+
+	duplicateVoteViolation = "duplicate_vote"
+	unknownViolation       = "unknown"
+)
+
+// valChainCaller is the origin caller used for reporting violations to the
+// valset realm. It is derived from the realm path, so no private key exists for it.
+// NOTE: keep in sync with examples/gno.land/r/sys/validators/v2/slashing.gno
+var valChainCaller = gno.DerivePkgAddr(valRealm)
+
+// ViolationHandler is a Go-side hook called in BeginBlock for every
+// consensus violation reported to the app, after the valset realm was notified
+type ViolationHandler func(ctx sdk.Context, violation abci.Violation)
+
+// beginBlockerApp is the app abstraction required by any BeginBlocker
+type beginBlockerApp interface {
+	// Logger returns the logger reference
+	Logger() *slog.Logger
+}
+
+// BeginBlocker defines the logic executed before every block.
+// Currently, it reports consensus violations (ie. double signing) committed by validators
+// to the valset realm, which applies the configured penalty, and then calls the optional handler.
+// Resulting valset changes are picked up by the EndBlocker, through the collector
+func BeginBlocker(
+	collector *collector[validatorUpdate],
+	vmk vm.VMKeeperI,
+	handler ViolationHandler,
+	app beginBlockerApp,
+) func(
+	ctx sdk.Context,
+	req abci.RequestBeginBlock,
+) abci.ResponseBeginBlock {
+	return func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+		for _, violation := range req.Violations {
+			for _, val := range violation.Validators {
+				if err := reportViolation(ctx, vmk, val.Address, violation); err != nil {
+					app.Logger().Error(
+						"unable to report validator violation",
+						"address", val.Address.String(),
+						"height", violation.Height,
+						"err", err,
+					)
+
+					continue
+				}
+
+				// Notify the EndBlocker that the valset might have changed
+				collector.add(validatorUpdate{})
+			}
+
+			if handler != nil {
+				handler(ctx, violation)
+			}
+		}
+
+		return abci.ResponseBeginBlock{}
+	}
+}
+
+// reportViolation reports the violation of the given validator to the valset realm.
+// Any changes are committed only if the VM call succeeds. A panicking call
+// (ie. out of gas) is returned as an error, so it can't halt the chain
+func reportViolation(
+	ctx sdk.Context,
+	vmk vm.VMKeeperI,
+	address crypto.Address,
+	violation abci.Violation,
+) (err error) {
+	// cache-wrap the store, so a failing call doesn't leave partial state behind.
+	// The gno transaction store is built on top of the cache, with a capped gas meter
+	msCache := ctx.MultiStore().MultiCacheWrap()
+
+	callCtx := ctx.
+		WithMultiStore(msCache).
+		WithGasMeter(store.NewGasMeter(maxViolationGas))
+	callCtx = vmk.MakeGnoTransactionStore(callCtx)
+
+	defer func() {
+		if r := recover(); r != nil {
+			// the cache is dropped, as it is never written
+			err = fmt.Errorf("panic while calling %s.%s: %v", valRealm, valViolationFn, r)
+		}
+	}()
+
+	msg := vm.NewMsgCall(
+		valChainCaller,
+		nil,
+		valRealm,
+		valViolationFn,
+		[]string{
+			address.String(),
+			strconv.FormatInt(violation.Height, 10),
+			violationKind(violation.Evidence),
+		},
+	)
+
+	if _, err := vmk.Call(callCtx, msg); err != nil {
+		return fmt.Errorf("unable to call %s.%s, %w", valRealm, valViolationFn, err)
+	}
+
+	vmk.CommitGnoTransactionStore(callCtx)
+	msCache.MultiWrite()
+
+	return nil
+}
+
+// violationKind returns the violation kind reported to the realm, for the given evidence
+func violationKind(evidence abci.Evidence) string {
+	switch evidence.(type) {
+	case *bft.DuplicateVoteEvidence:
+		return duplicateVoteViolation
+	default:
+		return unknownViolation
+	}
+}
@@ -0,0 +1,236 @@
+package gnoland
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeginBlocker(t *testing.T) {
+	t.Parallel()
+
+	noFilter := func(_ events.Event) []validatorUpdate {
+		return nil
+	}
+
+	generateViolation := func(count int) abci.Violation {
+		vals := make([]abci.Validator, 0, count)
+
+		for range count {
+			pubKey := ed25519.GenPrivKey().PubKey()
+
+			vals = append(vals, abci.Validator{
+				Address: pubKey.Address(),
+				PubKey:  pubKey,
+				Power:   10,
+			})
+		}
+
+		return abci.Violation{
+			Evidence:   &bft.DuplicateVoteEvidence{},
+			Validators: vals,
+			Height:     10,
+		}
+	}
+
+	t.Run("no violations", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			vmCalled bool
+
+			mockVMKeeper = &mockVMKeeper{
+				callFn: func(_ sdk.Context, _ vm.MsgCall) (string, error) {
+					vmCalled = true
+
+					return "", nil
+				},
+			}
+		)
+
+		// Create the collector
+		c := newCollector[validatorUpdate](&mockEventSwitch{}, noFilter)
+
+		// Create the BeginBlocker
+		bb := BeginBlocker(c, mockVMKeeper, nil, &mockEndBlockerApp{})
+
+		// Run the BeginBlocker
+		res := bb(setupTestEnv().ctx, abci.RequestBeginBlock{})
+
+		assert.Equal(t, abci.ResponseBeginBlock{}, res)
+		assert.False(t, vmCalled)
+		assert.Empty(t, c.getEvents())
+	})
+
+	t.Run("violations reported", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			violation = generateViolation(2)
+
+			calls    []vm.MsgCall
+			reported []abci.Violation
+
+			mockVMKeeper = &mockVMKeeper{
+				callFn: func(_ sdk.Context, msg vm.MsgCall) (string, error) {
+					calls = append(calls, msg)
+
+					return "", nil
+				},
+			}
+
+			handler = func(_ sdk.Context, v abci.Violation) {
+				reported = append(reported, v)
+			}
+		)
+
+		// Create the collector
+		c := newCollector[validatorUpdate](&mockEventSwitch{}, noFilter)
+
+		// Create the BeginBlocker
+		bb := BeginBlocker(c, mockVMKeeper, handler, &mockEndBlockerApp{})
+
+		// Run the BeginBlocker
+		bb(setupTestEnv().ctx, abci.RequestBeginBlock{
+			Violations: []abci.Violation{violation},
+		})
+
+		// Make sure the realm was notified of every offender
+		require.Len(t, calls, len(violation.Validators))
+
+		for i, call := range calls {
+			assert.Equal(t, valChainCaller, call.Caller)
+			assert.Equal(t, valRealm, call.PkgPath)
+			assert.Equal(t, valViolationFn, call.Func)
+			assert.Equal(
+				t,
+				[]string{violation.Validators[i].Address.String(), "10", duplicateVoteViolation},
+				call.Args,
+			)
+		}
+
+		// Make sure the handler was called, and the EndBlocker notified
+		assert.Equal(t, []abci.Violation{violation}, reported)
+		assert.Len(t, c.getEvents(), len(violation.Validators))
+	})
+
+	t.Run("failed VM call", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			committed bool
+
+			mockVMKeeper = &mockVMKeeper{
+				callFn: func(_ sdk.Context, _ vm.MsgCall) (string, error) {
+					return "", errors.New("random call error")
+				},
+				commitGnoTransactionStoreFn: func(_ sdk.Context) {
+					committed = true
+				},
+			}
+		)
+
+		// Create the collector
+		c := newCollector[validatorUpdate](&mockEventSwitch{}, noFilter)
+
+		// Create the BeginBlocker
+		bb := BeginBlocker(c, mockVMKeeper, nil, &mockEndBlockerApp{})
+
+		// Run the BeginBlocker
+		bb(setupTestEnv().ctx, abci.RequestBeginBlock{
+			Violations: []abci.Violation{generateViolation(1)},
+		})
+
+		// Make sure nothing was committed, and the EndBlocker isn't notified
+		assert.False(t, committed)
+		assert.Empty(t, c.getEvents())
+	})
+
+	t.Run("panicking VM call", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			calls     int
+			committed bool
+
+			mockVMKeeper = &mockVMKeeper{
+				callFn: func(ctx sdk.Context, _ vm.MsgCall) (string, error) {
+					calls++
+
+					ctx.GasMeter().ConsumeGas(maxViolationGas+1, "report")
+
+					return "", nil
+				},
+				commitGnoTransactionStoreFn: func(_ sdk.Context) {
+					committed = true
+				},
+			}
+		)
+
+		// Create the collector
+		c := newCollector[validatorUpdate](&mockEventSwitch{}, noFilter)
+
+		// Create the BeginBlocker
+		bb := BeginBlocker(c, mockVMKeeper, nil, &mockEndBlockerApp{})
+
+		// Run the BeginBlocker, which must not panic
+		require.NotPanics(t, func() {
+			bb(setupTestEnv().ctx, abci.RequestBeginBlock{
+				Violations: []abci.Violation{generateViolation(2)},
+			})
+		})
+
+		// Make sure every offender was reported, but nothing was committed
+		assert.Equal(t, 2, calls)
+		assert.False(t, committed)
+		assert.Empty(t, c.getEvents())
+	})
+
+	t.Run("isolated call context", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			env = setupTestEnv()
+
+			storeCtx sdk.Context
+
+			mockVMKeeper = &mockVMKeeper{
+				makeGnoTransactionStoreFn: func(ctx sdk.Context) sdk.Context {
+					storeCtx = ctx
+
+					return ctx
+				},
+			}
+		)
+
+		// Create the collector
+		c := newCollector[validatorUpdate](&mockEventSwitch{}, noFilter)
+
+		// Create the BeginBlocker
+		bb := BeginBlocker(c, mockVMKeeper, nil, &mockEndBlockerApp{})
+
+		// Run the BeginBlocker
+		bb(env.ctx, abci.RequestBeginBlock{
+			Violations: []abci.Violation{generateViolation(1)},
+		})
+
+		// Make sure the gno store is built on the cache, with the capped gas meter
+		assert.NotEqual(t, env.ctx.MultiStore(), storeCtx.MultiStore())
+		assert.Equal(t, int64(maxViolationGas), storeCtx.GasMeter().Limit())
+	})
+}
+
+func TestViolationKind(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, duplicateVoteViolation, violationKind(&bft.DuplicateVoteEvidence{}))
+	assert.Equal(t, unknownViolation, violationKind(nil))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/distribution"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -108,6 +109,10 @@ type GnoGenesisState struct {
 	Auth     auth.GenesisState `json:"auth"`
 	Bank     bank.GenesisState `json:"bank"`
 	VM       vm.GenesisState   `json:"vm"`
+
+	// Distribution is optional, genesis files without it
+	// get the default distribution params.
+	Distribution *distribution.GenesisState `json:"distribution,omitempty"`
 }
 
 type TxWithMetadata struct {
@@ -18,6 +18,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/distribution"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/stretchr/testify/require"
 )
@@ -210,6 +211,7 @@ func GenerateTestingGenesisState(creator crypto.PrivKey, pkgs ...gnovm.MemPackag
 	}
 
 	gnoland.SignGenesisTxs(txs, creator, "tendermint_test")
+	distGen := distribution.DefaultGenesisState()
 	return gnoland.GnoGenesisState{
 		Txs: txs,
 		Balances: []gnoland.Balance{{
@@ -219,5 +221,7 @@ func GenerateTestingGenesisState(creator crypto.PrivKey, pkgs ...gnovm.MemPackag
 		Auth: auth.DefaultGenesisState(),
 		Bank: bank.DefaultGenesisState(),
 		VM:   vmm.DefaultGenesisState(),
+
+		Distribution: &distGen,
 	}
 }
@@ -220,6 +220,7 @@ func SetupGnolandTestscript(t *testing.T, p *testscript.Params) error {
 		"gnokey":      gnokeyCmd(nodesManager),
 		"adduser":     adduserCmd(nodesManager),
 		"adduserfrom": adduserfromCmd(nodesManager),
+		"addkey":      addkeyCmd(),
 		"patchpkg":    patchpkgCmd(),
 		"loadpkg":     loadpkgCmd(gnoRootDir),
 		"scanf":       loadpkgCmd(gnoRootDir),
@@ -498,6 +499,26 @@ func adduserfromCmd(nodesManager *NodesManager) func(ts *testscript.TestScript,
 	}
 }
 
+func addkeyCmd() func(ts *testscript.TestScript, neg bool, args []string) {
+	return func(ts *testscript.TestScript, neg bool, args []string) {
+		gnoHomeDir := ts.Getenv("GNOHOME")
+
+		if len(args) == 0 {
+			ts.Fatalf("new key name required")
+		}
+
+		kb, err := keys.NewKeyBaseFromDir(gnoHomeDir)
+		if err != nil {
+			ts.Fatalf("unable to get keybase")
+		}
+
+		// The account is not added to the genesis
+		if _, err := createAccount(ts, kb, args[0]); err != nil {
+			ts.Fatalf("error creating key %s: %s", args[0], err)
+		}
+	}
+}
+
 func patchpkgCmd() func(ts *testscript.TestScript, neg bool, args []string) {
 	return func(ts *testscript.TestScript, neg bool, args []string) {
 		args, err := unquote(args)
@@ -113,6 +113,10 @@ func execMakeAddPkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
 	if err != nil {
 		panic(err)
 	}
+	fee, err := cfg.RootCfg.NewFee(gaswanted, gasfee)
+	if err != nil {
+		panic(err)
+	}
 	// construct msg & tx and marshal.
 	msg := vm.MsgAddPackage{
 		Creator: creator,
@@ -121,7 +125,7 @@ func execMakeAddPkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
 	}
 	tx := std.Tx{
 		Msgs:       []std.Msg{msg},
-		Fee:        std.NewFee(gaswanted, gasfee),
+		Fee:        fee,
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
 	}
@@ -99,8 +99,10 @@ func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
 	if err != nil {
 		return err
 	}
-	caller := info.GetAddress()
-	// info.GetPubKey()
+	caller, err := cfg.RootCfg.MsgSigner(info.GetAddress())
+	if err != nil {
+		return err
+	}
 
 	// Parse send amount.
 	send, err := std.ParseCoins(cfg.Send)
@@ -114,6 +116,10 @@ func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
 	if err != nil {
 		return errors.Wrap(err, "parsing gas fee coin")
 	}
+	fee, err := cfg.RootCfg.NewFee(gaswanted, gasfee)
+	if err != nil {
+		return errors.Wrap(err, "parsing fee granter")
+	}
 
 	// construct msg & tx and marshal.
 	msg := vm.MsgCall{
@@ -124,8 +130,8 @@ func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
 		Args:    cfg.Args,
 	}
 	tx := std.Tx{
-		Msgs:       []std.Msg{msg},
-		Fee:        std.NewFee(gaswanted, gasfee),
+		Msgs:       cfg.RootCfg.WrapMsgs(info.GetAddress(), msg),
+		Fee:        fee,
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
 	}
@@ -10,9 +10,11 @@ import (
 type MakeTxCfg struct {
 	RootCfg *client.BaseCfg
 
-	GasWanted int64
-	GasFee    string
-	Memo      string
+	GasWanted  int64
+	GasFee     string
+	FeeGranter string
+	ExecAs     string
+	Memo       string
 
 	Broadcast bool
 	ChainID   string
@@ -35,6 +37,11 @@ func NewMakeTxCmd(rootCfg *client.BaseCfg, io commands.IO) *commands.Command {
 
 	cmd.AddSubCommands(
 		client.NewMakeSendCmd(cfg, io),
+		client.NewMakeWithdrawCmd(cfg, io),
+		client.NewMakeGrantFeeCmd(cfg, io),
+		client.NewMakeRevokeFeeCmd(cfg, io),
+		client.NewMakeGrantCmd(cfg, io),
+		client.NewMakeRevokeCmd(cfg, io),
 
 		// custom commands
 		NewMakeAddPkgCmd(cfg, io),
@@ -60,6 +67,20 @@ func (c *MakeTxCfg) RegisterFlags(fs *flag.FlagSet) {
 		"gas payment fee",
 	)
 
+	fs.StringVar(
+		&c.FeeGranter,
+		"fee-granter",
+		"",
+		"address of the account paying the gas fee, using its fee allowance to the signer",
+	)
+
+	fs.StringVar(
+		&c.ExecAs,
+		"exec-as",
+		"",
+		"address of the account the msg is executed on behalf of, using its authz grant to the signer",
+	)
+
 	fs.StringVar(
 		&c.Memo,
 		"memo",
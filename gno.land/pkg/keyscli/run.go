@@ -73,6 +73,10 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 	if err != nil {
 		return errors.Wrap(err, "parsing gas fee coin")
 	}
+	fee, err := cfg.RootCfg.NewFee(gaswanted, gasfee)
+	if err != nil {
+		return errors.Wrap(err, "parsing fee granter")
+	}
 
 	memPkg := &gnovm.MemPackage{}
 	if sourcePath == "-" { // stdin
@@ -122,7 +126,7 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 	}
 	tx := std.Tx{
 		Msgs:       []std.Msg{msg},
-		Fee:        std.NewFee(gaswanted, gasfee),
+		Fee:        fee,
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
 	}
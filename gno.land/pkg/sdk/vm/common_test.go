@@ -52,7 +52,7 @@ func _setupTestEnv(cacheStdlibs bool) testEnv {
 	prmk := pm.NewParamsKeeper(iavlCapKey)
 	acck := authm.NewAccountKeeper(iavlCapKey, prmk.ForModule(authm.ModuleName), std.ProtoBaseAccount)
 	bankk := bankm.NewBankKeeper(acck, prmk.ForModule(bankm.ModuleName))
-	vmk := NewVMKeeper(baseCapKey, iavlCapKey, acck, bankk, prmk)
+	vmk := NewVMKeeper(baseCapKey, iavlCapKey, acck, bankk, prmk, nil)
 
 	prmk.Register(authm.ModuleName, acck)
 	prmk.Register(bankm.ModuleName, bankk)
@@ -74,6 +74,7 @@ const (
 	QueryEval   = "qeval"
 	QueryFile   = "qfile"
 	QueryDoc    = "qdoc"
+	QueryABI    = "qabi"
 )
 
 func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) abci.ResponseQuery {
@@ -93,6 +94,8 @@ func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) abci.ResponseQ
 		res = vh.queryFile(ctx, req)
 	case QueryDoc:
 		res = vh.queryDoc(ctx, req)
+	case QueryABI:
+		res = vh.queryABI(ctx, req)
 	default:
 		return sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest(fmt.Sprintf(
@@ -199,6 +202,18 @@ func (vh vmHandler) queryDoc(ctx sdk.Context, req abci.RequestQuery) (res abci.R
 	return
 }
 
+// queryABI returns the JSON of the ABI for a given pkgpath, suitable for generating clients
+func (vh vmHandler) queryABI(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgPath := string(req.Data)
+	abi, err := vh.vm.QueryABI(ctx, pkgPath)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(err)
+		return
+	}
+	res.Data = []byte(abi.JSON())
+	return
+}
+
 // ----------------------------------------
 // misc
 
@@ -443,3 +443,101 @@ func Hello(msg string) (res string) { res = prefix+" "+msg; return }
 		})
 	}
 }
+
+func TestVmHandlerQuery_ABI(t *testing.T) {
+	pkgPath := "gno.land/r/hello"
+	greeting := &doc.ABIType{Kind: doc.ABIKindRef, Ref: pkgPath + ".Greeting"}
+
+	expected := &doc.ABI{
+		Version: doc.ABIVersion,
+		PkgPath: pkgPath,
+		Name:    "hello",
+		Funcs: []*doc.ABIFunc{
+			{
+				Name:    "Hello",
+				Params:  []*doc.ABIField{{Name: "msg", Type: &doc.ABIType{Kind: doc.ABIKindString}}},
+				Results: []*doc.ABIField{{Name: "res", Type: greeting}},
+			},
+		},
+		Types: []*doc.ABITypeDecl{
+			{
+				Name:     "Greeting",
+				Ref:      pkgPath + ".Greeting",
+				Exported: true,
+				Type: &doc.ABIType{Kind: doc.ABIKindStruct, Fields: []*doc.ABIField{
+					{Name: "Msg", Type: &doc.ABIType{Kind: doc.ABIKindString}},
+					{Name: "From", Type: &doc.ABIType{Kind: doc.ABIKindRef, Ref: "std.Address"}},
+				}},
+			},
+		},
+		Events: []*doc.ABIEventDecl{
+			{Type: "Greeted", Attrs: []string{"msg"}, Funcs: []string{"Hello"}},
+		},
+	}
+
+	tt := []struct {
+		input              []byte
+		expectedResult     string
+		expectedErrorMatch string
+	}{
+		// valid queries
+		{input: []byte(`gno.land/r/hello`), expectedResult: expected.JSON()},
+		{input: []byte(`gno.land/r/doesnotexist`), expectedErrorMatch: `invalid package path`},
+	}
+
+	for _, tc := range tt {
+		name := string(tc.input)
+		t.Run(name, func(t *testing.T) {
+			env := setupTestEnv()
+			ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+			vmHandler := env.vmh
+
+			// Give "addr1" some gnots.
+			addr := crypto.AddressFromPreimage([]byte("addr1"))
+			acc := env.acck.NewAccountWithAddress(ctx, addr)
+			env.acck.SetAccount(ctx, acc)
+			env.bankk.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+			assert.True(t, env.bankk.GetCoins(ctx, addr).IsEqual(std.MustParseCoins("10000000ugnot")))
+
+			// Create test package.
+			files := []*gnovm.MemFile{
+				{Name: "hello.gno", Body: `
+package hello
+
+import "std"
+
+type Greeting struct {
+	Msg  string
+	From std.Address
+	sent bool
+}
+
+func Hello(msg string) (res Greeting) {
+	std.Emit("Greeted", "msg", msg)
+	return Greeting{Msg: msg, From: std.PreviousRealm().Address()}
+}
+`},
+			}
+			msg1 := NewMsgAddPackage(addr, pkgPath, files)
+			err := env.vmk.AddPackage(ctx, msg1)
+			assert.NoError(t, err)
+
+			req := abci.RequestQuery{
+				Path: "vm/qabi",
+				Data: tc.input,
+			}
+
+			res := vmHandler.Query(env.ctx, req)
+			if tc.expectedErrorMatch == "" {
+				assert.True(t, res.IsOK(), "should not have error")
+				if tc.expectedResult != "" {
+					assert.Equal(t, tc.expectedResult, string(res.Data))
+				}
+			} else {
+				assert.False(t, res.IsOK(), "should have an error")
+				errmsg := res.Error.Error()
+				assert.Regexp(t, tc.expectedErrorMatch, errmsg)
+			}
+		})
+	}
+}
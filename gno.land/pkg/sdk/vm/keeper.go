@@ -30,6 +30,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/store/types"
 	"github.com/gnolang/gno/tm2/pkg/telemetry"
 	"github.com/gnolang/gno/tm2/pkg/telemetry/metrics"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/traces"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 )
@@ -66,6 +67,7 @@ type VMKeeper struct {
 	acck    AccountKeeperI
 	bank    BankKeeperI
 	prmk    ParamsKeeperI
+	distk   DistributionKeeperI // optional, credits realm authors for the gas their code consumes
 
 	// cached, the DeliverTx persistent state.
 	gnoStore gno.Store
@@ -74,12 +76,14 @@ type VMKeeper struct {
 // NewVMKeeper returns a new VMKeeper.
 // NOTE: prmk must be the root ParamsKeeper such that
 // ExecContext.Params may set any module's parameter.
+// distk may be nil, in which case realm authors are not rewarded.
 func NewVMKeeper(
 	baseKey store.StoreKey,
 	iavlKey store.StoreKey,
 	acck AccountKeeperI,
 	bank BankKeeperI,
 	prmk ParamsKeeperI,
+	distk DistributionKeeperI,
 ) *VMKeeper {
 	vmk := &VMKeeper{
 		baseKey: baseKey,
@@ -87,6 +91,7 @@ func NewVMKeeper(
 		acck:    acck,
 		bank:    bank,
 		prmk:    prmk,
+		distk:   distk,
 	}
 
 	return vmk
@@ -205,6 +210,27 @@ func loadStdlibPackage(pkgPath, stdlibDir string, store gno.Store) {
 	m.RunMemPackage(memPkg, true)
 }
 
+// pkgCreatorKey returns the store key of the creator of the given package
+func pkgCreatorKey(pkgPath string) []byte {
+	return []byte("pkgcreator:" + pkgPath)
+}
+
+// setPkgCreator records the creator (author) of the given package
+func (vm *VMKeeper) setPkgCreator(ctx sdk.Context, pkgPath string, creator crypto.Address) {
+	ctx.Store(vm.iavlKey).Set(pkgCreatorKey(pkgPath), creator.Bytes())
+}
+
+// getPkgCreator returns the creator (author) of the given package.
+// Packages without a recorded creator, such as the standard libraries,
+// return the zero address
+func (vm *VMKeeper) getPkgCreator(ctx sdk.Context, pkgPath string) crypto.Address {
+	bz := ctx.Store(vm.iavlKey).Get(pkgCreatorKey(pkgPath))
+	if bz == nil {
+		return crypto.Address{}
+	}
+	return crypto.AddressFromBytes(bz)
+}
+
 type gnoStoreContextKeyType struct{}
 
 var gnoStoreContextKey gnoStoreContextKeyType
@@ -323,6 +349,15 @@ func (vm *VMKeeper) checkNamespacePermission(ctx sdk.Context, creator crypto.Add
 
 // AddPackage adds a package with given fileset.
 func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
+	_, span := traces.StartSpan(
+		ctx.Context(),
+		"vm.AddPackage",
+		attribute.String("vm.pkgpath", msg.Package.Path),
+	)
+	defer func() {
+		traces.EndSpan(span, err)
+	}()
+
 	creator := msg.Creator
 	pkgPath := msg.Package.Path
 	memPkg := msg.Package
@@ -399,6 +434,8 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 	defer doRecover(m2, &err)
 	m2.RunMemPackage(memPkg, true)
 
+	vm.setPkgCreator(ctx, pkgPath, creator)
+
 	// Log the telemetry
 	logTelemetry(
 		m2.GasMeter.GasConsumed(),
@@ -414,6 +451,16 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 
 // Call calls a public Gno function (for delivertx).
 func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
+	_, span := traces.StartSpan(
+		ctx.Context(),
+		"vm.Call",
+		attribute.String("vm.pkgpath", msg.PkgPath),
+		attribute.String("vm.func", msg.Func),
+	)
+	defer func() {
+		traces.EndSpan(span, err)
+	}()
+
 	pkgPath := msg.PkgPath // to import
 	fnc := msg.Func
 	gnostore := vm.getGnoTransactionStore(ctx)
@@ -488,6 +535,7 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 	defer m.Release()
 	m.SetActivePackage(mpv)
 	defer doRecover(m, &err)
+	gasStart := m.GasMeter.GasConsumed()
 	rtvs := m.Eval(xn)
 	for i, rtv := range rtvs {
 		res = res + rtv.String()
@@ -496,6 +544,13 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 		}
 	}
 
+	// Credit the realm author for the gas consumed by the call.
+	// NOTE: the gas of the imported packages is not split among
+	// their own authors, it all goes to the author of pkgPath.
+	if vm.distk != nil {
+		vm.distk.AddAuthorGas(ctx, vm.getPkgCreator(ctx, pkgPath), m.GasMeter.GasConsumed()-gasStart)
+	}
+
 	// Log the telemetry
 	logTelemetry(
 		m.GasMeter.GasConsumed(),
@@ -560,6 +615,11 @@ func doRecoverInternal(m *gno.Machine, e *error, r any, repanicOutOfGas bool) {
 
 // Run executes arbitrary Gno code in the context of the caller's realm.
 func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
+	_, span := traces.StartSpan(ctx.Context(), "vm.Run")
+	defer func() {
+		traces.EndSpan(span, err)
+	}()
+
 	caller := msg.Caller
 	pkgAddr := caller
 	gnostore := vm.getGnoTransactionStore(ctx)
@@ -841,6 +901,24 @@ func (vm *VMKeeper) QueryDoc(ctx sdk.Context, pkgPath string) (*doc.JSONDocument
 	return d.WriteJSONDocumentation()
 }
 
+// QueryABI returns the ABI of the package: its exported functions,
+// the types they use and the events it emits.
+func (vm *VMKeeper) QueryABI(ctx sdk.Context, pkgPath string) (*doc.ABI, error) {
+	store := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
+
+	memPkg := store.GetMemPackage(pkgPath)
+	if memPkg == nil {
+		err := ErrInvalidPkgPath(fmt.Sprintf(
+			"package not found: %s", pkgPath))
+		return nil, err
+	}
+	d, err := doc.NewDocumentableFromMemPkg(memPkg, false, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return d.WriteABI()
+}
+
 // logTelemetry logs the VM processing telemetry
 func logTelemetry(
 	gasUsed int64,
@@ -17,6 +17,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
 	"github.com/gnolang/gno/tm2/pkg/store/types"
@@ -150,6 +151,61 @@ func Echo(msg string) string {
 	// t.Log("result:", res)
 }
 
+type mockDistributionKeeper struct {
+	gas map[crypto.Address]int64
+}
+
+func (m *mockDistributionKeeper) AddAuthorGas(_ sdk.Context, author crypto.Address, gas int64) {
+	m.gas[author] += gas
+}
+
+// Calling a realm credits its author with the consumed gas.
+func TestVMKeeperAuthorGas(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	distk := &mockDistributionKeeper{gas: map[crypto.Address]int64{}}
+	env.vmk.distk = distk
+
+	// Give "author" and "caller" some gnots.
+	author := crypto.AddressFromPreimage([]byte("author"))
+	caller := crypto.AddressFromPreimage([]byte("caller"))
+	for _, addr := range []crypto.Address{author, caller} {
+		acc := env.acck.NewAccountWithAddress(ctx, addr)
+		env.acck.SetAccount(ctx, acc)
+		env.bankk.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+	}
+
+	// Create test package.
+	files := []*gnovm.MemFile{
+		{Name: "init.gno", Body: `
+package test
+
+func Sum(n int) int {
+	sum := 0
+	for i := 0; i < n; i++ {
+		sum += i
+	}
+	return sum
+}`},
+	}
+	pkgPath := "gno.land/r/test"
+	msg1 := NewMsgAddPackage(author, pkgPath, files)
+	require.NoError(t, env.vmk.AddPackage(ctx, msg1))
+	assert.Equal(t, author, env.vmk.getPkgCreator(ctx, pkgPath))
+
+	// Deploying is not rewarded.
+	assert.Empty(t, distk.gas)
+
+	// Call the realm.
+	msg2 := NewMsgCall(caller, nil, pkgPath, "Sum", []string{"100"})
+	_, err := env.vmk.Call(ctx, msg2)
+	require.NoError(t, err)
+
+	assert.Len(t, distk.gas, 1)
+	assert.Positive(t, distk.gas[author])
+}
+
 // Sending too much fails
 func TestVMKeeperOriginSend2(t *testing.T) {
 	env := setupTestEnv()
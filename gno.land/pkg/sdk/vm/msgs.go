@@ -9,6 +9,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/authz"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -22,7 +23,11 @@ type MsgAddPackage struct {
 	Deposit std.Coins         `json:"deposit" yaml:"deposit"`
 }
 
-var _ std.Msg = MsgAddPackage{}
+var (
+	_ std.Msg         = MsgAddPackage{}
+	_ authz.ScopedMsg = MsgAddPackage{}
+	_ authz.SpendMsg  = MsgAddPackage{}
+)
 
 // NewMsgAddPackage - upload a package with files.
 func NewMsgAddPackage(creator crypto.Address, pkgPath string, files []*gnovm.MemFile) MsgAddPackage {
@@ -79,6 +84,16 @@ func (msg MsgAddPackage) GetReceived() std.Coins {
 	return msg.Deposit
 }
 
+// Implements authz.ScopedMsg.
+func (msg MsgAddPackage) AuthzScope() (pkgPath, fn string) {
+	return msg.Package.Path, ""
+}
+
+// Implements authz.SpendMsg.
+func (msg MsgAddPackage) AuthzSpend() std.Coins {
+	return msg.Deposit
+}
+
 //----------------------------------------
 // MsgCall
 
@@ -91,7 +106,11 @@ type MsgCall struct {
 	Args    []string       `json:"args" yaml:"args"`
 }
 
-var _ std.Msg = MsgCall{}
+var (
+	_ std.Msg         = MsgCall{}
+	_ authz.ScopedMsg = MsgCall{}
+	_ authz.SpendMsg  = MsgCall{}
+)
 
 func NewMsgCall(caller crypto.Address, send sdk.Coins, pkgPath, fnc string, args []string) MsgCall {
 	return MsgCall{
@@ -144,6 +163,16 @@ func (msg MsgCall) GetReceived() std.Coins {
 	return msg.Send
 }
 
+// Implements authz.ScopedMsg.
+func (msg MsgCall) AuthzScope() (pkgPath, fn string) {
+	return msg.PkgPath, msg.Func
+}
+
+// Implements authz.SpendMsg.
+func (msg MsgCall) AuthzSpend() std.Coins {
+	return msg.Send
+}
+
 //----------------------------------------
 // MsgRun
 
@@ -154,7 +183,12 @@ type MsgRun struct {
 	Package *gnovm.MemPackage `json:"package" yaml:"package"`
 }
 
-var _ std.Msg = MsgRun{}
+// MsgRun is not an authz.ScopedMsg, as it runs arbitrary code:
+// it can only be authorized for its whole type.
+var (
+	_ std.Msg        = MsgRun{}
+	_ authz.SpendMsg = MsgRun{}
+)
 
 func NewMsgRun(caller crypto.Address, send std.Coins, files []*gnovm.MemFile) MsgRun {
 	for _, file := range files {
@@ -211,3 +245,8 @@ func (msg MsgRun) GetSigners() []crypto.Address {
 func (msg MsgRun) GetReceived() std.Coins {
 	return msg.Send
 }
+
+// Implements authz.SpendMsg.
+func (msg MsgRun) AuthzSpend() std.Coins {
+	return msg.Send
+}
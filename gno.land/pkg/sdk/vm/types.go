@@ -21,6 +21,11 @@ type BankKeeperI interface {
 	AddCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error)
 }
 
+// DistributionKeeperI is the limited interface only needed for VM.
+type DistributionKeeperI interface {
+	AddAuthorGas(ctx sdk.Context, author crypto.Address, gas int64)
+}
+
 // ParamsKeeperI is the limited interface only needed for VM.
 type ParamsKeeperI interface {
 	params.ParamsKeeperI
@@ -7,11 +7,15 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 
+	"github.com/gnolang/gno/gnovm"
 	"github.com/gnolang/gno/gnovm/pkg/doc"
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 )
 
@@ -20,6 +24,9 @@ type docCfg struct {
 	src        bool
 	unexported bool
 	short      bool
+	abi        bool
+	html       bool
+	output     string
 	rootDir    string
 }
 
@@ -30,7 +37,14 @@ func newDocCmd(io commands.IO) *commands.Command {
 			Name:       "doc",
 			ShortUsage: "doc [flags] <pkgsym>",
 			ShortHelp:  "show documentation for package or symbol",
-			LongHelp:   "get documentation for the specified package or symbol (type, function, method, or variable/constant)",
+			LongHelp: `get documentation for the specified package or symbol (type, function, method, or variable/constant)
+
+With -html, the arguments are package patterns, like './...', and a static
+documentation site for the matched packages is written to the directory given
+by -o. Declarations link to the other packages of the site, including the
+standard libraries they import, which are documented too. Examples are
+extracted from the Example functions of the "*_test.gno" files, and the
+symbols of all the packages can be searched from any page.`,
 		},
 		c,
 		func(_ context.Context, args []string) error {
@@ -68,6 +82,27 @@ func (c *docCfg) RegisterFlags(fs *flag.FlagSet) {
 		"show a one line representation for each symbol",
 	)
 
+	fs.BoolVar(
+		&c.abi,
+		"abi",
+		false,
+		"print the ABI of the package as JSON (functions, types and events)",
+	)
+
+	fs.BoolVar(
+		&c.html,
+		"html",
+		false,
+		"write a static HTML documentation site for the packages matching the arguments",
+	)
+
+	fs.StringVar(
+		&c.output,
+		"o",
+		"",
+		"output directory of -html",
+	)
+
 	fs.StringVar(
 		&c.rootDir,
 		"root-dir",
@@ -82,6 +117,10 @@ func execDoc(cfg *docCfg, args []string, io commands.IO) error {
 		cfg.rootDir = gnoenv.RootDir()
 	}
 
+	if cfg.html {
+		return execDocHTML(cfg, args, io)
+	}
+
 	wd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("could not determine working directory: %w", err)
@@ -113,6 +152,9 @@ func execDoc(cfg *docCfg, args []string, io commands.IO) error {
 	if err != nil {
 		io.Printfln("warning: error parsing some candidate packages:\n%v", err)
 	}
+	if cfg.abi {
+		return writeABI(res, io)
+	}
 	return res.WriteDocumentation(
 		io.Out(),
 		&doc.WriteDocumentationOptions{
@@ -124,6 +166,85 @@ func execDoc(cfg *docCfg, args []string, io commands.IO) error {
 	)
 }
 
+func execDocHTML(cfg *docCfg, args []string, io commands.IO) error {
+	if cfg.output == "" {
+		return errors.New("-html requires an output directory, set with -o")
+	}
+	if len(args) == 0 {
+		args = []string{"./..."}
+	}
+
+	paths, err := targetsFromPatterns(args)
+	if err != nil {
+		return fmt.Errorf("list targets from patterns: %w", err)
+	}
+	subPkgs, err := gnomod.SubPkgsFromPaths(paths)
+	if err != nil {
+		return fmt.Errorf("list sub packages: %w", err)
+	}
+
+	pkgs := make([]*gnovm.MemPackage, 0, len(subPkgs))
+	for _, pkg := range subPkgs {
+		if len(pkg.GnoFiles) == 0 {
+			continue
+		}
+		pkgPath := docPkgPath(pkg.Dir, cfg.rootDir)
+		memPkg, err := gno.ReadMemPackage(pkg.Dir, pkgPath)
+		if err != nil {
+			return err
+		}
+		pkgs = append(pkgs, memPkg)
+	}
+	if len(pkgs) == 0 {
+		return errors.New("no packages to document")
+	}
+
+	pkgErrs, err := doc.WriteHTML(cfg.output, pkgs, &doc.HTMLOptions{
+		Unexported: cfg.unexported,
+		StdlibDir:  filepath.Join(cfg.rootDir, "gnovm", "stdlibs"),
+	})
+	if pkgErrs != nil {
+		io.ErrPrintfln("warning: error documenting some packages:\n%v", pkgErrs)
+	}
+	if err != nil {
+		return fmt.Errorf("write documentation: %w", err)
+	}
+	io.ErrPrintfln("documentation of %d packages written to %s", len(pkgs), cfg.output)
+	return nil
+}
+
+// docPkgPath returns the package path of the package in dir: the path of its
+// module followed by the path of dir in the module, or else its path in the
+// gno root directory, or else the path of dir.
+func docPkgPath(dir, rootDir string) string {
+	absDir, err := filepath.Abs(dir)
+	if err == nil {
+		if modDir, err := gnomod.FindRootDir(absDir); err == nil {
+			if gm, err := gnomod.ParseAt(modDir); err == nil {
+				rel, _ := filepath.Rel(modDir, absDir)
+				return path.Join(gm.Module.Mod.Path, filepath.ToSlash(rel))
+			}
+		}
+	}
+	if pkgPath := pkgPathFromRootDir(dir, rootDir); pkgPath != "" {
+		return pkgPath
+	}
+	return filepath.ToSlash(filepath.Clean(dir))
+}
+
+func writeABI(res *doc.Documentable, io commands.IO) error {
+	abi, err := res.WriteABI()
+	if err != nil {
+		return err
+	}
+	bz, err := amino.MarshalJSONIndent(abi, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal ABI: %w", err)
+	}
+	io.Println(string(bz))
+	return nil
+}
+
 func findGnomodExamples(dir string) ([]string, error) {
 	dirs := make([]string, 0, 64) // "hint" about the size
 	err := filepath.WalkDir(dir, func(path string, e fs.DirEntry, err error) error {
@@ -16,6 +16,10 @@ func TestGnoDoc(t *testing.T) {
 			args:                []string{"doc", "-u", "avl.Node"},
 			stdoutShouldContain: "node *Node",
 		},
+		{
+			args:                []string{"doc", "-abi", "avl"},
+			stdoutShouldContain: `"ref": "gno.land/p/demo/avl.Tree"`,
+		},
 		{
 			args:             []string{"doc", "dkfdkfkdfjkdfj"},
 			errShouldContain: "package not found",
@@ -24,6 +28,15 @@ func TestGnoDoc(t *testing.T) {
 			args:             []string{"doc", "There.Are.Too.Many.Dots"},
 			errShouldContain: "invalid arguments",
 		},
+		{
+			args:             []string{"doc", "-html", "./..."},
+			errShouldContain: "-html requires an output directory",
+		},
+		{
+			// the output directory can't be created under a file
+			args:             []string{"doc", "-html", "-o", "doc_test.go/site", "../../tests/integ/run_package"},
+			errShouldContain: "write documentation: mkdir doc_test.go: not a directory",
+		},
 	}
 	testMainCaseRun(t, tc)
 }
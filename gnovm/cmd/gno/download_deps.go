@@ -17,11 +17,46 @@ import (
 
 // downloadDeps recursively fetches the imports of a local package while following a given gno.mod replace directives
 func downloadDeps(io commands.IO, pkgDir string, gnoMod *gnomod.File, fetcher pkgdownload.PackageFetcher) error {
-	if fetcher == nil {
+	d := &depsDownloader{
+		io:      io,
+		gnoMod:  gnoMod,
+		fetcher: fetcher,
+	}
+
+	return d.downloadDeps(pkgDir)
+}
+
+// depsDownloader downloads the dependencies of a local package
+// in the modules cache, and verifies them against a lock file
+type depsDownloader struct {
+	io      commands.IO
+	gnoMod  *gnomod.File
+	fetcher pkgdownload.PackageFetcher
+
+	// lock holds the hashes of the dependencies, if not nil.
+	// The hashes of new dependencies are added to it.
+	lock *gnomod.LockFile
+
+	// refetch fetches the dependencies even if they are
+	// in the modules cache, to verify them against the chain
+	refetch bool
+
+	// visited holds the downloaded dependencies
+	visited map[module.Version]bool
+}
+
+// downloadDeps recursively fetches the imports of a local package while
+// following the replace and require directives of the gno.mod
+func (d *depsDownloader) downloadDeps(pkgDir string) error {
+	if d.fetcher == nil {
 		return errors.New("fetcher is nil")
 	}
 
-	pkg, err := gnolang.ReadMemPackage(pkgDir, gnoMod.Module.Mod.Path)
+	if d.visited == nil {
+		d.visited = make(map[module.Version]bool)
+	}
+
+	pkg, err := gnolang.ReadMemPackage(pkgDir, d.gnoMod.Module.Mod.Path)
 	if err != nil {
 		return fmt.Errorf("read package at %q: %w", pkgDir, err)
 	}
@@ -32,20 +67,21 @@ func downloadDeps(io commands.IO, pkgDir string, gnoMod *gnomod.File, fetcher pk
 	imports := importsMap.Merge(packages.FileKindPackageSource, packages.FileKindTest, packages.FileKindXTest)
 
 	for _, pkgPath := range imports {
-		resolved := gnoMod.Resolve(module.Version{Path: pkgPath.PkgPath})
+		resolved := d.gnoMod.Resolve(module.Version{Path: pkgPath.PkgPath})
 		resolvedPkgPath := resolved.Path
 
-		if !isRemotePkgPath(resolvedPkgPath) {
+		if !isRemotePkgPath(resolvedPkgPath) || d.visited[resolved] {
 			continue
 		}
+		d.visited[resolved] = true
 
 		depDir := gnomod.PackageDir("", module.Version{Path: resolvedPkgPath})
 
-		if err := downloadPackage(io, resolvedPkgPath, depDir, fetcher); err != nil {
+		if err := d.downloadPackage(resolved, depDir); err != nil {
 			return fmt.Errorf("download import %q of %q: %w", resolvedPkgPath, pkgDir, err)
 		}
 
-		if err := downloadDeps(io, depDir, gnoMod, fetcher); err != nil {
+		if err := d.downloadDeps(depDir); err != nil {
 			return err
 		}
 	}
@@ -53,20 +89,35 @@ func downloadDeps(io commands.IO, pkgDir string, gnoMod *gnomod.File, fetcher pk
 	return nil
 }
 
-// downloadPackage downloads a remote gno package by pkg path and store it at dst
-func downloadPackage(io commands.IO, pkgPath string, dst string, fetcher pkgdownload.PackageFetcher) error {
-	modFilePath := filepath.Join(dst, "gno.mod")
+// downloadPackage downloads a remote gno package at the given version, and stores it at dst
+func (d *depsDownloader) downloadPackage(mod module.Version, dst string) error {
+	if !d.refetch {
+		cached, err := d.isCached(mod, dst)
+		if err != nil || cached {
+			return err
+		}
+	}
+
+	d.io.ErrPrintfln("gno: downloading %s", mod.Path)
 
-	if _, err := os.Stat(modFilePath); err == nil {
-		// modfile exists in modcache, do nothing
-		return nil
-	} else if !os.IsNotExist(err) {
-		return fmt.Errorf("stat downloaded module %q at %q: %w", pkgPath, dst, err)
+	files, err := pkgdownload.Fetch(mod.Path, gnomod.DeployHeight(mod.Version), d.fetcher)
+	if err != nil {
+		return err
 	}
 
-	io.ErrPrintfln("gno: downloading %s", pkgPath)
+	// Verify the package before storing it in the cache
+	if d.lock != nil {
+		hash, err := gnomod.HashMemPackage(files)
+		if err != nil {
+			return fmt.Errorf("hash package %q: %w", mod.Path, err)
+		}
 
-	if err := pkgdownload.Download(pkgPath, dst, fetcher); err != nil {
+		if err := d.lock.Verify(mod.Path, mod.Version, hash); err != nil {
+			return err
+		}
+	}
+
+	if err := pkgdownload.WriteFiles(files, dst); err != nil {
 		return err
 	}
 
@@ -74,13 +125,56 @@ func downloadPackage(io commands.IO, pkgPath string, dst string, fetcher pkgdown
 	// For example: if you first download gno.land/r/foo/bar then download gno.land/r/foo,
 	// we need to know that gno.land/r/foo is not downloaded yet.
 	// We do this by checking for the presence of gno.land/r/foo/gno.mod
-	if err := os.WriteFile(modFilePath, []byte("module "+pkgPath+"\n"), 0o644); err != nil {
+	modFilePath := filepath.Join(dst, "gno.mod")
+	if err := os.WriteFile(modFilePath, []byte("module "+mod.Path+"\n"), 0o644); err != nil {
 		return fmt.Errorf("write modfile at %q: %w", modFilePath, err)
 	}
 
 	return nil
 }
 
+// isCached returns true if the package is in the modules cache at dst,
+// and matches the hash of the lock file, if any
+func (d *depsDownloader) isCached(mod module.Version, dst string) (bool, error) {
+	modFilePath := filepath.Join(dst, "gno.mod")
+
+	if _, err := os.Stat(modFilePath); os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("stat downloaded module %q at %q: %w", mod.Path, dst, err)
+	}
+
+	if d.lock == nil {
+		return true, nil
+	}
+
+	entry, locked := d.lock.Get(mod.Path, mod.Version)
+
+	// The cache holds the latest version of the packages,
+	// which may not be the one deployed at a pinned height
+	if !locked && gnomod.DeployHeight(mod.Version) != 0 {
+		return false, nil
+	}
+
+	pkg, err := gnolang.ReadMemPackage(dst, mod.Path)
+	if err != nil {
+		return false, fmt.Errorf("read downloaded module %q at %q: %w", mod.Path, dst, err)
+	}
+
+	hash, err := gnomod.HashMemPackage(pkg.Files)
+	if err != nil {
+		return false, fmt.Errorf("hash package %q: %w", mod.Path, err)
+	}
+
+	if !locked {
+		d.lock.Set(mod.Path, mod.Version, hash)
+		return true, nil
+	}
+
+	// Download the package again if the cache doesn't match
+	return entry.Hash == hash, nil
+}
+
 // isRemotePkgPath determines whether s is a remote pkg path, i.e.: not a filepath nor a standard library
 func isRemotePkgPath(s string) bool {
 	return !strings.HasPrefix(s, ".") && !filepath.IsAbs(s) && !gnolang.IsStdlib(s)
@@ -152,3 +152,54 @@ func TestDownloadDeps(t *testing.T) {
 		})
 	}
 }
+
+func TestDownloadDepsLock(t *testing.T) {
+	mockErr := bytes.NewBufferString("")
+	io := commands.NewTestIO()
+	io.SetErr(commands.WriteNopCloser(mockErr))
+
+	dirPath := t.TempDir()
+	err := os.WriteFile(filepath.Join(dirPath, "main.gno"), []byte("package main\n\nimport \"gno.land/p/demo/avl\"\n"), 0o644)
+	require.NoError(t, err)
+
+	tmpGnoHome := t.TempDir()
+	t.Setenv("GNOHOME", tmpGnoHome)
+
+	modFile := &gnomod.File{
+		Module: &modfile.Module{
+			Mod: module.Version{Path: "testFetchDeps"},
+		},
+	}
+	require.NoError(t, modFile.AddRequire("gno.land/p/demo/avl", "v0.1.0"))
+
+	fetcher := examplespkgfetcher.New()
+	lock := &gnomod.LockFile{}
+
+	d := &depsDownloader{io: io, gnoMod: modFile, fetcher: fetcher, lock: lock}
+	require.NoError(t, d.downloadDeps(dirPath))
+
+	// The required package is locked at its version, its imports at the latest one
+	require.Len(t, lock.Entries, 2)
+	avlEntry, ok := lock.Get("gno.land/p/demo/avl", "v0.1.0")
+	require.True(t, ok)
+	_, ok = lock.Get("gno.land/p/demo/ufmt", "")
+	require.True(t, ok)
+
+	// A cached package that doesn't match the lock file is downloaded again
+	avlFile := filepath.Join(tmpGnoHome, "pkg", "mod", "gno.land", "p", "demo", "avl", "tree.gno")
+	require.NoError(t, os.WriteFile(avlFile, []byte("package avl\n"), 0o644))
+	mockErr.Reset()
+
+	d = &depsDownloader{io: io, gnoMod: modFile, fetcher: fetcher, lock: lock}
+	require.NoError(t, d.downloadDeps(dirPath))
+	assert.Contains(t, mockErr.String(), "gno: downloading gno.land/p/demo/avl")
+	assert.NotContains(t, mockErr.String(), "gno: downloading gno.land/p/demo/ufmt")
+
+	// A package that doesn't match the lock file is rejected
+	lock.Set("gno.land/p/demo/avl", "v0.1.0", "h1:invalid")
+
+	d = &depsDownloader{io: io, gnoMod: modFile, fetcher: fetcher, lock: lock, refetch: true}
+	err = d.downloadDeps(dirPath)
+	require.ErrorIs(t, err, gnomod.ErrChecksumMismatch)
+	assert.NotEqual(t, avlEntry.Hash, "h1:invalid")
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/gnolang/gno/gnovm"
 )
 
 // Download downloads the package identified by `pkgPath` in the directory at `dst` using the provided [PackageFetcher].
@@ -15,6 +17,12 @@ func Download(pkgPath string, dst string, fetcher PackageFetcher) error {
 		return err
 	}
 
+	return WriteFiles(files, dst)
+}
+
+// WriteFiles writes the fetched package files in the directory at `dst`.
+// The directory at `dst` is created if it does not exists.
+func WriteFiles(files []*gnovm.MemFile, dst string) error {
 	if err := os.MkdirAll(dst, 0o744); err != nil {
 		return err
 	}
@@ -1,7 +1,33 @@
 package pkgdownload
 
-import "github.com/gnolang/gno/gnovm"
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/gnovm"
+)
 
 type PackageFetcher interface {
 	FetchPackage(pkgPath string) ([]*gnovm.MemFile, error)
 }
+
+// HeightPackageFetcher is a [PackageFetcher] that can also fetch
+// packages as they were at a given block height.
+type HeightPackageFetcher interface {
+	PackageFetcher
+	FetchPackageAt(pkgPath string, height int64) ([]*gnovm.MemFile, error)
+}
+
+// Fetch fetches the package identified by `pkgPath` as it was at the given height,
+// or at the latest height if it is 0, using the provided [PackageFetcher].
+func Fetch(pkgPath string, height int64, fetcher PackageFetcher) ([]*gnovm.MemFile, error) {
+	if height == 0 {
+		return fetcher.FetchPackage(pkgPath)
+	}
+
+	hf, ok := fetcher.(HeightPackageFetcher)
+	if !ok {
+		return nil, fmt.Errorf("fetch %q at height %d: fetcher does not support heights", pkgPath, height)
+	}
+
+	return hf.FetchPackageAt(pkgPath, height)
+}
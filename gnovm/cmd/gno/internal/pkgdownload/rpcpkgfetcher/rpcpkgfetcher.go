@@ -16,7 +16,7 @@ type gnoPackageFetcher struct {
 	remoteOverrides map[string]string
 }
 
-var _ pkgdownload.PackageFetcher = (*gnoPackageFetcher)(nil)
+var _ pkgdownload.HeightPackageFetcher = (*gnoPackageFetcher)(nil)
 
 func New(remoteOverrides map[string]string) pkgdownload.PackageFetcher {
 	return &gnoPackageFetcher{
@@ -26,6 +26,12 @@ func New(remoteOverrides map[string]string) pkgdownload.PackageFetcher {
 
 // FetchPackage implements [pkgdownload.PackageFetcher].
 func (gpf *gnoPackageFetcher) FetchPackage(pkgPath string) ([]*gnovm.MemFile, error) {
+	return gpf.FetchPackageAt(pkgPath, 0)
+}
+
+// FetchPackageAt implements [pkgdownload.HeightPackageFetcher].
+// The package files are queried at the given height, or at the latest one if it is 0.
+func (gpf *gnoPackageFetcher) FetchPackageAt(pkgPath string, height int64) ([]*gnovm.MemFile, error) {
 	rpcURL, err := rpcURLFromPkgPath(pkgPath, gpf.remoteOverrides)
 	if err != nil {
 		return nil, fmt.Errorf("get rpc url for pkg path %q: %w", pkgPath, err)
@@ -37,7 +43,7 @@ func (gpf *gnoPackageFetcher) FetchPackage(pkgPath string) ([]*gnovm.MemFile, er
 	}
 	defer client.Close()
 
-	data, err := qfile(client, pkgPath)
+	data, err := qfile(client, pkgPath, height)
 	if err != nil {
 		return nil, fmt.Errorf("query files list for pkg %q: %w", pkgPath, err)
 	}
@@ -46,7 +52,7 @@ func (gpf *gnoPackageFetcher) FetchPackage(pkgPath string) ([]*gnovm.MemFile, er
 	res := make([]*gnovm.MemFile, len(files))
 	for i, file := range files {
 		filePath := path.Join(pkgPath, file)
-		data, err := qfile(client, filePath)
+		data, err := qfile(client, filePath, height)
 		if err != nil {
 			return nil, fmt.Errorf("query package file %q: %w", filePath, err)
 		}
@@ -73,11 +79,11 @@ func rpcURLFromPkgPath(pkgPath string, remoteOverrides map[string]string) (strin
 	return rpcURL, nil
 }
 
-func qfile(c client.Client, pkgPath string) ([]byte, error) {
+func qfile(c client.Client, pkgPath string, height int64) ([]byte, error) {
 	path := "vm/qfile"
 	data := []byte(pkgPath)
 
-	qres, err := c.ABCIQuery(path, data)
+	qres, err := c.ABCIQueryWithOptions(path, data, client.ABCIQueryOptions{Height: height})
 	if err != nil {
 		return nil, fmt.Errorf("query qfile: %w", err)
 	}
@@ -10,11 +10,13 @@ import (
 
 	"github.com/gnolang/gno/gnovm/cmd/gno/internal/pkgdownload"
 	"github.com/gnolang/gno/gnovm/cmd/gno/internal/pkgdownload/rpcpkgfetcher"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/pkg/gnomod"
 	"github.com/gnolang/gno/gnovm/pkg/packages"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"go.uber.org/multierr"
+	"golang.org/x/mod/module"
 )
 
 // testPackageFetcher allows to override the package fetcher during tests.
@@ -37,7 +39,7 @@ func newModCmd(io commands.IO) *commands.Command {
 		newModGraphCmd(io),
 		newModInitCmd(),
 		newModTidy(io),
-		// vendor
+		newModVendorCmd(io),
 		// verify
 		newModWhy(io),
 	)
@@ -106,6 +108,39 @@ func newModTidy(io commands.IO) *commands.Command {
 	)
 }
 
+func newModVendorCmd(io commands.IO) *commands.Command {
+	cfg := &modVendorCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "vendor",
+			ShortUsage: "vendor [flags]",
+			ShortHelp:  "make vendored copy of dependencies",
+			LongHelp: `Copies the dependencies of the package in the current directory, as
+resolved by its gno.mod, into its vendor directory.
+
+The dependencies are downloaded in the modules cache and verified against the
+gno.lock first. Only their source files are vendored, without tests. The
+vendor/modules.txt manifest lists the vendored packages with their required
+version and hash.
+
+When a vendor directory is found in the current directory or its parents,
+gno test, gno run and gno tool lint load the packages it holds instead of
+the examples, without the network. The vendor directories are skipped by
+./... patterns.
+
+With -verify, the vendor directory is checked against the gno.mod and the
+imports of the package instead, without the network: required versions,
+missing, unused and modified vendored packages are reported.
+`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execModVendor(cfg, args, io)
+		},
+	)
+}
+
 func newModWhy(io commands.IO) *commands.Command {
 	return commands.NewCommand(
 		commands.Metadata{
@@ -196,15 +231,9 @@ func execModDownload(cfg *modDownloadCfg, args []string, io commands.IO) error {
 		return flag.ErrHelp
 	}
 
-	fetcher := testPackageFetcher
-	if fetcher == nil {
-		remoteOverrides, err := parseRemoteOverrides(cfg.remoteOverrides)
-		if err != nil {
-			return fmt.Errorf("invalid %s flag: %w", remoteOverridesArgName, err)
-		}
-		fetcher = rpcpkgfetcher.New(remoteOverrides)
-	} else if len(cfg.remoteOverrides) != 0 {
-		return fmt.Errorf("can't use %s flag with a custom package fetcher", remoteOverridesArgName)
+	fetcher, err := newPackageFetcher(cfg.remoteOverrides)
+	if err != nil {
+		return err
 	}
 
 	path, err := os.Getwd()
@@ -235,11 +264,44 @@ func execModDownload(cfg *modDownloadCfg, args []string, io commands.IO) error {
 		return fmt.Errorf("validate: %w", err)
 	}
 
-	if err := downloadDeps(io, path, gnoMod, fetcher); err != nil {
+	lock, err := gnomod.ReadLockFile(path)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	d := &depsDownloader{
+		io:      io,
+		gnoMod:  gnoMod,
+		fetcher: fetcher,
+		lock:    lock,
+	}
+	if err := d.downloadDeps(path); err != nil {
+		return err
+	}
+
+	return lock.Write(path)
+}
+
+// newPackageFetcher returns the fetcher of the remote packages,
+// querying the chains of the given remote overrides
+func newPackageFetcher(remoteOverrides string) (pkgdownload.PackageFetcher, error) {
+	if testPackageFetcher != nil {
+		if len(remoteOverrides) != 0 {
+			return nil, fmt.Errorf("can't use %s flag with a custom package fetcher", remoteOverridesArgName)
+		}
+		return testPackageFetcher, nil
+	}
+
+	if remoteOverrides == "" {
+		return rpcpkgfetcher.New(nil), nil
+	}
+
+	overrides, err := parseRemoteOverrides(remoteOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s flag: %w", remoteOverridesArgName, err)
+	}
+
+	return rpcpkgfetcher.New(overrides), nil
 }
 
 func parseRemoteOverrides(arg string) (map[string]string, error) {
@@ -257,6 +319,94 @@ func parseRemoteOverrides(arg string) (map[string]string, error) {
 	return res, nil
 }
 
+type modVendorCfg struct {
+	verify          bool
+	remoteOverrides string
+}
+
+func (c *modVendorCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(
+		&c.verify,
+		"verify",
+		false,
+		"verify the vendor directory against gno.mod instead of writing it",
+	)
+	fs.StringVar(
+		&c.remoteOverrides,
+		remoteOverridesArgName,
+		"",
+		"chain-domain=rpc-url comma-separated list",
+	)
+}
+
+func execModVendor(cfg *modVendorCfg, args []string, io commands.IO) error {
+	if len(args) > 0 {
+		return flag.ErrHelp
+	}
+
+	path, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	gnoMod, err := gnomod.ParseGnoMod(filepath.Join(path, "gno.mod"))
+	if err != nil {
+		return err
+	}
+	gnoMod.Sanitize()
+
+	vendorDir := filepath.Join(path, gnomod.VendorDirName)
+
+	if cfg.verify {
+		return verifyVendor(io, gnoMod, path, vendorDir)
+	}
+
+	fetcher, err := newPackageFetcher(cfg.remoteOverrides)
+	if err != nil {
+		return err
+	}
+
+	// Download the missing dependencies in the modules cache
+	lock, err := gnomod.ReadLockFile(path)
+	if err != nil {
+		return err
+	}
+	d := &depsDownloader{
+		io:      io,
+		gnoMod:  gnoMod,
+		fetcher: fetcher,
+		lock:    lock,
+	}
+	if err := d.downloadDeps(path); err != nil {
+		return err
+	}
+	if err := lock.Write(path); err != nil {
+		return err
+	}
+
+	// Copy them in a fresh vendor directory
+	if err := os.RemoveAll(vendorDir); err != nil {
+		return fmt.Errorf("remove %q: %w", vendorDir, err)
+	}
+
+	v := &depsVendorer{
+		gnoMod:    gnoMod,
+		modDir:    path,
+		vendorDir: vendorDir,
+	}
+	err = v.vendorDeps(path, gnoMod.Module.Mod.Path,
+		packages.FileKindPackageSource, packages.FileKindTest, packages.FileKindXTest)
+	if err != nil {
+		return err
+	}
+
+	if len(v.manifest.Entries) == 0 {
+		io.ErrPrintln("gno: no dependencies to vendor")
+		return nil
+	}
+
+	return gnomod.WriteVendorManifest(vendorDir, v.manifest)
+}
+
 func execModInit(args []string) error {
 	if len(args) > 1 {
 		return flag.ErrHelp
@@ -277,8 +427,9 @@ func execModInit(args []string) error {
 }
 
 type modTidyCfg struct {
-	verbose   bool
-	recursive bool
+	verbose         bool
+	recursive       bool
+	remoteOverrides string
 }
 
 func (c *modTidyCfg) RegisterFlags(fs *flag.FlagSet) {
@@ -294,6 +445,12 @@ func (c *modTidyCfg) RegisterFlags(fs *flag.FlagSet) {
 		false,
 		"walk subdirs for gno.mod files",
 	)
+	fs.StringVar(
+		&c.remoteOverrides,
+		remoteOverridesArgName,
+		"",
+		"chain-domain=rpc-url comma-separated list",
+	)
 }
 
 func execModTidy(cfg *modTidyCfg, args []string, io commands.IO) error {
@@ -338,10 +495,89 @@ func modTidyOnce(cfg *modTidyCfg, wd, pkgdir string, io commands.IO) error {
 		return err
 	}
 
+	if err := dropUnusedRequires(gm, pkgdir, io, cfg.verbose); err != nil {
+		return err
+	}
+
 	gm.Write(fname)
+
+	return verifyLockFile(cfg, gm, pkgdir, io)
+}
+
+// dropUnusedRequires removes the require directives of
+// the packages that are not imported by the package
+func dropUnusedRequires(gm *gnomod.File, pkgdir string, io commands.IO, verbose bool) error {
+	if len(gm.Require) == 0 {
+		return nil
+	}
+
+	pkg, err := gno.ReadMemPackage(pkgdir, gm.Module.Mod.Path)
+	if err != nil {
+		return fmt.Errorf("read package at %q: %w", pkgdir, err)
+	}
+	importsMap, err := packages.Imports(pkg, nil)
+	if err != nil {
+		return fmt.Errorf("read imports at %q: %w", pkgdir, err)
+	}
+
+	imported := make(map[string]bool)
+	for _, imp := range importsMap.Merge(packages.FileKindPackageSource, packages.FileKindTest, packages.FileKindXTest, packages.FileKindFiletest) {
+		imported[imp.PkgPath] = true
+	}
+
+	for _, req := range gm.Require {
+		if imported[req.Mod.Path] {
+			continue
+		}
+
+		if verbose {
+			io.ErrPrintfln("gno: removing unused requirement %s %s", req.Mod.Path, req.Mod.Version)
+		}
+		gm.DropRequire(req.Mod.Path)
+	}
+	gm.Sanitize()
+
 	return nil
 }
 
+// verifyLockFile fetches the dependencies of the package from the chain,
+// verifies them against the lock file, and updates it with the missing
+// hashes. The hashes of the packages that are no longer dependencies are
+// removed.
+func verifyLockFile(cfg *modTidyCfg, gm *gnomod.File, pkgdir string, io commands.IO) error {
+	lock, err := gnomod.ReadLockFile(pkgdir)
+	if err != nil {
+		return err
+	}
+
+	// Packages without requirements nor lock file don't need the network
+	if len(gm.Require) == 0 && len(lock.Entries) == 0 {
+		return nil
+	}
+
+	fetcher, err := newPackageFetcher(cfg.remoteOverrides)
+	if err != nil {
+		return err
+	}
+
+	d := &depsDownloader{
+		io:      io,
+		gnoMod:  gm,
+		fetcher: fetcher,
+		lock:    lock,
+		refetch: true,
+	}
+	if err := d.downloadDeps(pkgdir); err != nil {
+		return err
+	}
+
+	lock.Prune(func(entry gnomod.LockEntry) bool {
+		return d.visited[module.Version{Path: entry.Path, Version: entry.Version}]
+	})
+
+	return lock.Write(pkgdir)
+}
+
 func execModWhy(args []string, io commands.IO) error {
 	if len(args) < 1 {
 		return flag.ErrHelp
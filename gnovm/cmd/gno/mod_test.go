@@ -1,7 +1,16 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
 )
 
 func TestModApp(t *testing.T) {
@@ -71,6 +80,19 @@ func TestModApp(t *testing.T) {
 			stderrShouldContain:  "gno: downloading gno.land/p/demo/notexists",
 			errShouldContain:     "query files list for pkg \"gno.land/p/demo/notexists\": package \"gno.land/p/demo/notexists\" is not available",
 		},
+		{
+			args:                 []string{"mod", "download"},
+			testDir:              "../../tests/integ/require_version",
+			simulateExternalRepo: true,
+			stderrShouldContain:  "gno: downloading gno.land/r/docs/minisocial/v2",
+		},
+		{
+			args:                 []string{"mod", "download"},
+			testDir:              "../../tests/integ/require_lock_mismatch",
+			simulateExternalRepo: true,
+			stderrShouldContain:  "gno: downloading gno.land/p/demo/avl",
+			errShouldContain:     "checksum mismatch for gno.land/p/demo/avl",
+		},
 
 		// test `gno mod init` with no module name
 		{
@@ -137,6 +159,38 @@ func TestModApp(t *testing.T) {
 			errShouldBe:          "create gno.mod file: gno.mod file already exists",
 		},
 
+		// test `gno mod vendor`
+		{
+			args:                 []string{"mod", "vendor", "arg1"},
+			testDir:              "../../tests/integ/minimalist_gnomod",
+			simulateExternalRepo: true,
+			errShouldContain:     "flag: help requested",
+		},
+		{
+			args:                 []string{"mod", "vendor"},
+			testDir:              "../../tests/integ/empty_dir",
+			simulateExternalRepo: true,
+			errShouldContain:     "could not read gno.mod file",
+		},
+		{
+			args:                 []string{"mod", "vendor"},
+			testDir:              "../../tests/integ/minimalist_gnomod",
+			simulateExternalRepo: true,
+			stderrShouldBe:       "gno: no dependencies to vendor\n",
+		},
+		{
+			args:                 []string{"mod", "vendor"},
+			testDir:              "../../tests/integ/require_remote_module",
+			simulateExternalRepo: true,
+			stderrShouldContain:  "gno: downloading gno.land/p/demo/avl",
+		},
+		{
+			args:                 []string{"mod", "vendor", "-verify"},
+			testDir:              "../../tests/integ/require_remote_module",
+			simulateExternalRepo: true,
+			errShouldContain:     "modules.txt not found, run gno mod vendor",
+		},
+
 		// test `gno mod tidy`
 		{
 			args:                 []string{"mod", "tidy", "arg1"},
@@ -165,6 +219,25 @@ func TestModApp(t *testing.T) {
 			testDir:              "../../tests/integ/valid2",
 			simulateExternalRepo: true,
 		},
+		{
+			args:                 []string{"mod", "tidy", "-v"},
+			testDir:              "../../tests/integ/require_unused",
+			simulateExternalRepo: true,
+			stderrShouldContain:  "gno: removing unused requirement gno.land/p/demo/avl v1.0.0",
+		},
+		{
+			args:                 []string{"mod", "tidy"},
+			testDir:              "../../tests/integ/require_version",
+			simulateExternalRepo: true,
+			stderrShouldContain:  "gno: downloading gno.land/r/docs/minisocial/v2",
+		},
+		{
+			args:                 []string{"mod", "tidy"},
+			testDir:              "../../tests/integ/require_lock_mismatch",
+			simulateExternalRepo: true,
+			stderrShouldContain:  "gno: downloading gno.land/p/demo/avl",
+			errShouldContain:     "checksum mismatch for gno.land/p/demo/avl",
+		},
 
 		// test `gno mod why`
 		{
@@ -244,3 +317,33 @@ valid.gno
 
 	testMainCaseRun(t, tc)
 }
+
+func TestDropUnusedRequires(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.gno":        "package main\n\nfunc main() {}\n",
+		"main_test.gno":   "package main\n\nimport \"gno.land/p/demo/ufmt\"\n\nvar _ = ufmt.Sprintf\n",
+		"z0_filetest.gno": "package main\n\nimport \"gno.land/p/demo/avl\"\n\nvar _ avl.Tree\n\nfunc main() {}\n",
+	}
+	for name, body := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644))
+	}
+
+	gm := &gnomod.File{
+		Module: &modfile.Module{
+			Mod: module.Version{Path: "gno.land/r/tests/dropunused"},
+		},
+	}
+	for _, pkgPath := range []string{"gno.land/p/demo/avl", "gno.land/p/demo/ufmt", "gno.land/p/demo/seqid"} {
+		require.NoError(t, gm.AddRequire(pkgPath, "v1.0.0"))
+	}
+
+	require.NoError(t, dropUnusedRequires(gm, dir, commands.NewTestIO(), false))
+
+	// The imports of the tests and filetests are kept
+	required := make([]string, 0, len(gm.Require))
+	for _, req := range gm.Require {
+		required = append(required, req.Mod.Path)
+	}
+	assert.ElementsMatch(t, []string{"gno.land/p/demo/avl", "gno.land/p/demo/ufmt"}, required)
+}
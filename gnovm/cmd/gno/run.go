@@ -12,6 +12,7 @@ import (
 
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
 	"github.com/gnolang/gno/gnovm/pkg/test"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -23,6 +24,7 @@ type runCfg struct {
 	expr      string
 	debug     bool
 	debugAddr string
+	dap       bool
 }
 
 func newRunCmd(io commands.IO) *commands.Command {
@@ -76,6 +78,13 @@ func (c *runCfg) RegisterFlags(fs *flag.FlagSet) {
 		"",
 		"enable interactive debugger using tcp address in the form [host]:port",
 	)
+
+	fs.BoolVar(
+		&c.dap,
+		"dap",
+		false,
+		"use the Debug Adapter Protocol for the debugger at -debug-addr, to debug from an IDE",
+	)
 }
 
 func execRun(cfg *runCfg, args []string, io commands.IO) error {
@@ -83,6 +92,10 @@ func execRun(cfg *runCfg, args []string, io commands.IO) error {
 		return flag.ErrHelp
 	}
 
+	if cfg.dap && cfg.debugAddr == "" {
+		return errors.New("-dap requires a debugger address, set with -debug-addr")
+	}
+
 	if cfg.rootDir == "" {
 		cfg.rootDir = gnoenv.RootDir()
 	}
@@ -91,10 +104,13 @@ func execRun(cfg *runCfg, args []string, io commands.IO) error {
 	stdout := io.Out()
 	stderr := io.Err()
 
-	// init store and machine
+	// init store and machine, resolving the imports from the
+	// vendor directory of the module of the files to run
 	output := test.OutputWithError(stdout, stderr)
-	_, testStore := test.Store(
-		cfg.rootDir, output)
+	_, testStore := test.StoreWithOptions(
+		cfg.rootDir, output,
+		test.StoreOptions{VendorDir: gnomod.FindVendorDir(args[0])},
+	)
 
 	if len(args) == 0 {
 		args = []string{"."}
@@ -125,7 +141,15 @@ func execRun(cfg *runCfg, args []string, io commands.IO) error {
 	defer m.Release()
 
 	// If the debug address is set, the debugger waits for a remote client to connect to it.
-	if cfg.debugAddr != "" {
+	switch {
+	case cfg.dap:
+		sess, err := gno.ListenDAP(cfg.debugAddr)
+		if err != nil {
+			return err
+		}
+		defer sess.Close()
+		m.Debugger.EnableDAP(sess)
+	case cfg.debugAddr != "":
 		if err := m.Debugger.Serve(cfg.debugAddr); err != nil {
 			return err
 		}
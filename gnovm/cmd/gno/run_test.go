@@ -78,6 +78,14 @@ func TestRunApp(t *testing.T) {
 			args:             []string{"run", "-debug-addr", "invalidhost:17538", "../../tests/integ/debugger/sample.gno"},
 			errShouldContain: "listen tcp",
 		},
+		{
+			args:        []string{"run", "-dap", "../../tests/integ/debugger/sample.gno"},
+			errShouldBe: "-dap requires a debugger address, set with -debug-addr",
+		},
+		{
+			args:             []string{"run", "-dap", "-debug-addr", "invalidhost:17538", "../../tests/integ/debugger/sample.gno"},
+			errShouldContain: "listen tcp",
+		},
 		{
 			args:                 []string{"run", "../../tests/integ/invalid_assign/main.gno"},
 			recoverShouldContain: "cannot use bool as main.C without explicit conversion",
@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	goio "io"
 	"log"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,10 +31,47 @@ type testCfg struct {
 	printEvents         bool
 	debug               bool
 	debugAddr           string
+	dap                 bool
+	fuzz                string
+	fuzzTime            timeOrCountFlag
+	bench               string
+	benchTime           timeOrCountFlag
+	count               int
+}
+
+// timeOrCountFlag is the value of the -fuzztime and -benchtime flags: either
+// a duration, or a number of iterations written as "Nx".
+type timeOrCountFlag struct {
+	d     time.Duration
+	iters int
+}
+
+func (f *timeOrCountFlag) String() string {
+	if f.iters > 0 {
+		return fmt.Sprintf("%dx", f.iters)
+	}
+	return f.d.String()
+}
+
+func (f *timeOrCountFlag) Set(s string) error {
+	if n, ok := strings.CutSuffix(s, "x"); ok {
+		iters, err := strconv.Atoi(n)
+		if err != nil || iters <= 0 {
+			return fmt.Errorf("invalid count %q", s)
+		}
+		*f = timeOrCountFlag{iters: iters}
+		return nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid duration %q", s)
+	}
+	*f = timeOrCountFlag{d: d}
+	return nil
 }
 
 func newTestCmd(io commands.IO) *commands.Command {
-	cfg := &testCfg{}
+	cfg := &testCfg{benchTime: timeOrCountFlag{d: time.Second}}
 
 	return commands.NewCommand(
 		commands.Metadata{
@@ -47,8 +86,25 @@ file pattern "*_test.gno" or "*_filetest.gno".
 The <package> can be directory or file path (relative or absolute).
 
 - "*_test.gno" files work like "*_test.go" files, but they contain only test
-functions. Benchmark and fuzz functions aren't supported yet. Similarly, only
-tests that belong to the same package are supported for now (no "xxx_test").
+functions, benchmarks and fuzz tests. Similarly, only tests that belong to the
+same package are supported for now (no "xxx_test").
+
+- Benchmarks are functions named "BenchmarkXxx" taking a *testing.B, run with
+the -bench flag. Each benchmark runs its code b.N times, with b.N increasing
+until it runs for -benchtime. The results are printed in the format of Go
+benchmarks, so that they can be compared with benchstat: besides the time per
+iteration, they report the VM cycles, the gas and the allocated bytes per
+iteration.
+
+- Fuzz tests are functions named "FuzzXxx" taking a *testing.F. They add the
+seed corpus with F.Add, and set the fuzz target with F.Fuzz; its arguments
+after the *testing.T can be strings, []byte, bools, integers and floats.
+'gno test' runs the fuzz target with each seed, and with each input stored in
+the "testdata/fuzz/FuzzXxx" directory of the package. With the -fuzz flag, it
+then generates new inputs by mutating the corpus, guided by the code coverage
+of the fuzz target, for the time given by -fuzztime. An input making the fuzz
+target fail is written to "testdata/fuzz/FuzzXxx", so that it is replayed by
+later runs.
 
 The package path used to execute the "*_test.gno" file is fetched from the
 module name found in 'gno.mod', or else it is randomly generated like
@@ -85,6 +141,15 @@ of the GnoVM, acting as a "golden test":
 	stacktrace of the error.
 	- "Events:" can be used to verify the emitted events against a JSON.
 
+- "*_test.golden" files, like "render_test.golden", hold the expected output
+of the Render function of the package. They are txtar archives, where each
+file is named after a call to Render, like Render("path"), and contains the
+markdown it should return. Render is called after running the init functions
+of the "*_test.gno" files, which can set up the state of the package.
+
+Like the directives of filetests, golden files can be updated with the actual
+results using the -update-golden-tests flag.
+
 To speed up execution, imports of pure packages are processed separately from
 the execution of the tests. This makes testing faster, but means that the
 initialization of imported pure packages cannot be checked in filetests.
@@ -116,7 +181,7 @@ func (c *testCfg) RegisterFlags(fs *flag.FlagSet) {
 		&c.updateGoldenTests,
 		"update-golden-tests",
 		false,
-		`writes actual as wanted for "golden" directives in filetests`,
+		`writes actual as wanted for "golden" directives in filetests and "*_test.golden" Render files`,
 	)
 
 	fs.StringVar(
@@ -167,6 +232,46 @@ func (c *testCfg) RegisterFlags(fs *flag.FlagSet) {
 		"",
 		"enable interactive debugger using tcp address in the form [host]:port",
 	)
+
+	fs.BoolVar(
+		&c.dap,
+		"dap",
+		false,
+		"use the Debug Adapter Protocol for the debugger at -debug-addr, to debug from an IDE",
+	)
+
+	fs.StringVar(
+		&c.bench,
+		"bench",
+		"",
+		"run the benchmarks matching the regular expression",
+	)
+
+	fs.Var(
+		&c.benchTime,
+		"benchtime",
+		`time to run each benchmark for, as a duration or as a number of iterations "Nx"`,
+	)
+
+	fs.IntVar(
+		&c.count,
+		"count",
+		1,
+		"run each test and benchmark n times",
+	)
+
+	fs.StringVar(
+		&c.fuzz,
+		"fuzz",
+		"",
+		"run the fuzz tests matching the regular expression, generating new inputs",
+	)
+
+	fs.Var(
+		&c.fuzzTime,
+		"fuzztime",
+		`time spent fuzzing each fuzz test, as a duration or as a number of inputs "Nx" (default unlimited)`,
+	)
 }
 
 func execTest(cfg *testCfg, args []string, io commands.IO) error {
@@ -175,6 +280,10 @@ func execTest(cfg *testCfg, args []string, io commands.IO) error {
 		args = []string{"."}
 	}
 
+	if cfg.dap && cfg.debugAddr == "" {
+		return errors.New("-dap requires a debugger address, set with -debug-addr")
+	}
+
 	// guess opts.RootDir
 	if cfg.rootDir == "" {
 		cfg.rootDir = gnoenv.RootDir()
@@ -208,6 +317,9 @@ func execTest(cfg *testCfg, args []string, io commands.IO) error {
 		stdout = io.Out()
 	}
 	opts := test.NewTestOptions(cfg.rootDir, stdout, io.Err())
+	// The imports are resolved from the vendor directory of the module of
+	// each package, so the stores are created again when it changes.
+	storeVendorDir := ""
 	opts.RunFlag = cfg.run
 	opts.Sync = cfg.updateGoldenTests
 	opts.Verbose = cfg.verbose
@@ -215,6 +327,22 @@ func execTest(cfg *testCfg, args []string, io commands.IO) error {
 	opts.Events = cfg.printEvents
 	opts.Debug = cfg.debug
 	opts.FailfastFlag = cfg.failfast
+	opts.Fuzz = cfg.fuzz
+	opts.FuzzTime = cfg.fuzzTime.d
+	opts.FuzzIters = cfg.fuzzTime.iters
+	opts.Bench = cfg.bench
+	opts.BenchTime = cfg.benchTime.d
+	opts.BenchIters = cfg.benchTime.iters
+	opts.Count = cfg.count
+	if cfg.dap {
+		// The session is shared by the tests of all the packages.
+		sess, err := gno.ListenDAP(cfg.debugAddr)
+		if err != nil {
+			return err
+		}
+		defer sess.Close()
+		opts.DAP = sess
+	}
 
 	buildErrCount := 0
 	testErrCount := 0
@@ -244,6 +372,14 @@ func execTest(cfg *testCfg, args []string, io commands.IO) error {
 
 		memPkg := gno.MustReadMemPackage(pkg.Dir, gnoPkgPath)
 
+		if vendorDir := gnomod.FindVendorDir(pkg.Dir); vendorDir != storeVendorDir {
+			opts.BaseStore, opts.TestStore = test.StoreWithOptions(
+				cfg.rootDir, opts.WriterForStore(),
+				test.StoreOptions{VendorDir: vendorDir},
+			)
+			storeVendorDir = vendorDir
+		}
+
 		var hasError bool
 
 		startedAt := time.Now()
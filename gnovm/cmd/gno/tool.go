@@ -27,7 +27,9 @@ func newToolCmd(io commands.IO) *commands.Command {
 		// gno specific commands:
 		//
 		// ast
+		newBindCmd(io),
 		newLintCmd(io),
+		newLSPCmd(io),
 		// publish/release
 		// render -- call render()?
 		newReplCmd(),
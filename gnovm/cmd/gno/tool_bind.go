@@ -0,0 +1,581 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/gnolang/gno/gnovm/pkg/doc"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+var errBindNotRealm = errors.New("bindings can only be generated for realms")
+
+type bindCfg struct {
+	remote  string
+	pkgPath string
+	pkgName string
+	output  string
+}
+
+func newBindCmd(io commands.IO) *commands.Command {
+	cfg := &bindCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "bind",
+			ShortUsage: "tool bind [flags] <dir | pkgpath>",
+			ShortHelp:  "generates typed Go bindings for a realm",
+			LongHelp: `Generates a Go package calling the exported functions of a realm through gnoclient.
+
+The realm is read from a local directory, or from a node with -remote,
+in which case the argument is the realm pkgpath.
+
+Each exported function Foo gets two methods: Foo, which calls the function
+in a transaction, and QueryFoo, which evaluates it with qeval, without a
+transaction. Arguments and results are converted from and to Go types.
+Functions using types other than booleans, numbers, strings and byte slices
+(results cannot be byte slices) are skipped.`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execBind(cfg, args, io)
+		},
+	)
+}
+
+func (c *bindCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.remote,
+		"remote",
+		"",
+		"address of the node to fetch the realm functions from (vm/qfuncs)",
+	)
+
+	fs.StringVar(
+		&c.pkgPath,
+		"pkgpath",
+		"",
+		"pkgpath of the local realm (defaults to the gno.mod module)",
+	)
+
+	fs.StringVar(
+		&c.pkgName,
+		"pkg",
+		"",
+		"name of the generated Go package (defaults to the last element of the pkgpath)",
+	)
+
+	fs.StringVar(
+		&c.output,
+		"o",
+		"",
+		"output file (defaults to stdout)",
+	)
+}
+
+// bindFunc is an exported realm function
+type bindFunc struct {
+	Name    string
+	Doc     string
+	Params  []bindField
+	Results []bindField
+}
+
+type bindField struct {
+	Name string
+	Type string
+}
+
+func execBind(cfg *bindCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	var (
+		pkgPath string
+		funcs   []bindFunc
+		err     error
+	)
+
+	if cfg.remote != "" {
+		pkgPath = args[0]
+		funcs, err = remoteBindFuncs(cfg.remote, pkgPath)
+	} else {
+		pkgPath, funcs, err = localBindFuncs(args[0], cfg.pkgPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !gnolang.IsRealmPath(pkgPath) {
+		return fmt.Errorf("%w: %s", errBindNotRealm, pkgPath)
+	}
+
+	pkgName := cfg.pkgName
+	if pkgName == "" {
+		pkgName = bindPkgName(pkgPath)
+	}
+
+	src, skipped, err := generateBindings(pkgName, pkgPath, funcs)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range skipped {
+		io.ErrPrintln("skipping " + s)
+	}
+
+	if cfg.output == "" {
+		_, err = io.Out().Write(src)
+		return err
+	}
+
+	return os.WriteFile(cfg.output, src, 0o644)
+}
+
+// localBindFuncs reads the exported functions of the realm in dir
+func localBindFuncs(dir, pkgPath string) (string, []bindFunc, error) {
+	if pkgPath == "" {
+		gm, err := gnomod.ParseAt(dir)
+		if err != nil {
+			return "", nil, fmt.Errorf("unable to read gno.mod, use -pkgpath: %w", err)
+		}
+
+		pkgPath = gm.Module.Mod.Path
+	}
+
+	memPkg, err := gnolang.ReadMemPackage(dir, pkgPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to read package: %w", err)
+	}
+
+	d, err := doc.NewDocumentableFromMemPkg(memPkg, false, "", "")
+	if err != nil {
+		return "", nil, err
+	}
+
+	jsonDoc, err := d.WriteJSONDocumentation()
+	if err != nil {
+		return "", nil, err
+	}
+
+	funcs := make([]bindFunc, 0, len(jsonDoc.Funcs))
+	for _, fn := range jsonDoc.Funcs {
+		if fn.Type != "" {
+			continue // methods can't be called
+		}
+
+		bf := bindFunc{
+			Name: fn.Name,
+			Doc:  fn.Doc,
+		}
+		for _, p := range fn.Params {
+			bf.Params = append(bf.Params, bindField{Name: p.Name, Type: p.Type})
+		}
+		for _, r := range fn.Results {
+			bf.Results = append(bf.Results, bindField{Name: r.Name, Type: r.Type})
+		}
+
+		funcs = append(funcs, bf)
+	}
+
+	return pkgPath, funcs, nil
+}
+
+// remoteBindFuncs fetches the exported functions of the realm from the node
+func remoteBindFuncs(remote, pkgPath string) ([]bindFunc, error) {
+	cli, err := rpcclient.NewHTTPClient(remote)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create RPC client: %w", err)
+	}
+
+	qres, err := cli.ABCIQuery("vm/qfuncs", []byte(pkgPath))
+	if err != nil {
+		return nil, fmt.Errorf("unable to query realm functions: %w", err)
+	}
+
+	if qres.Response.Error != nil {
+		return nil, fmt.Errorf("unable to query realm functions: %w", qres.Response.Error)
+	}
+
+	// vm.FunctionSignatures
+	var fsigs []struct {
+		FuncName string
+		Params   []bindField
+		Results  []bindField
+	}
+	if err := json.Unmarshal(qres.Response.Data, &fsigs); err != nil {
+		return nil, fmt.Errorf("unable to decode realm functions: %w", err)
+	}
+
+	funcs := make([]bindFunc, 0, len(fsigs))
+	for _, fsig := range fsigs {
+		funcs = append(funcs, bindFunc{
+			Name:    fsig.FuncName,
+			Params:  fsig.Params,
+			Results: fsig.Results,
+		})
+	}
+
+	return funcs, nil
+}
+
+// bindType is the Go type of a realm function parameter or result
+type bindType struct {
+	goType  string
+	callArg string // MsgCall argument format, %[1]s is the Go value
+	evalArg string // qeval expression format, %[1]s is the Go value
+	result  bool   // whether it can be decoded from a result
+	imports []string
+}
+
+var bindTypes = func() map[string]bindType {
+	types := map[string]bindType{
+		"bool": {
+			goType:  "bool",
+			callArg: "strconv.FormatBool(%[1]s)",
+			evalArg: "strconv.FormatBool(%[1]s)",
+			result:  true,
+			imports: []string{"strconv"},
+		},
+		"string": {
+			goType:  "string",
+			callArg: "%[1]s",
+			evalArg: "strconv.Quote(%[1]s)",
+			result:  true,
+			imports: []string{"strconv"},
+		},
+		"[]byte": {
+			goType:  "[]byte",
+			callArg: "base64.StdEncoding.EncodeToString(%[1]s)",
+			evalArg: `"[]byte(" + strconv.Quote(string(%[1]s)) + ")"`,
+			imports: []string{"encoding/base64", "strconv"},
+		},
+	}
+
+	// numberType formats values of type t with the given strconv function,
+	// converting them to the function parameter type if needed
+	numberType := func(t, conv, format string) bindType {
+		value := "%[1]s"
+		if t != conv {
+			value = conv + "(%[1]s)"
+		}
+		format = fmt.Sprintf(format, value)
+
+		return bindType{
+			goType:  t,
+			callArg: format,
+			evalArg: format,
+			result:  true,
+			imports: []string{"strconv"},
+		}
+	}
+
+	for _, t := range []string{"int", "int8", "int16", "int32", "int64"} {
+		types[t] = numberType(t, "int64", "strconv.FormatInt(%s, 10)")
+	}
+
+	for _, t := range []string{"uint", "uint8", "uint16", "uint32", "uint64"} {
+		types[t] = numberType(t, "uint64", "strconv.FormatUint(%s, 10)")
+	}
+
+	for _, t := range []string{"float32", "float64"} {
+		types[t] = numberType(t, "float64", "strconv.FormatFloat(%s, 'g', -1, "+t[len("float"):]+")")
+	}
+
+	// aliases
+	types["[]uint8"] = types["[]byte"]
+	types["byte"] = types["uint8"]
+	types["rune"] = types["int32"]
+	types["std.Address"] = types["string"]
+
+	return types
+}()
+
+// bindReservedNames are the identifiers used by the generated methods,
+// that can't be used as parameter names
+var bindReservedNames = []string{
+	"c", "results", "err",
+	"base64", "gnoclient", "std", "strconv", "strings", "vm",
+}
+
+var reBindResultName = regexp.MustCompile(`^r\d+$`)
+
+type bindTmplData struct {
+	PkgName string
+	PkgPath string
+	Imports [][]string // Standard library, then other imports
+	Funcs   []bindTmplFunc
+}
+
+type bindTmplFunc struct {
+	Name      string
+	QueryName string
+	Doc       []string
+	Params    []bindTmplParam
+	Results   []string // Go types
+}
+
+type bindTmplParam struct {
+	Name    string
+	GoType  string
+	CallArg string
+	EvalArg string
+}
+
+// generateBindings generates the Go source of the bindings,
+// and returns a description of the skipped functions
+func generateBindings(pkgName, pkgPath string, funcs []bindFunc) ([]byte, []string, error) {
+	data := bindTmplData{
+		PkgName: pkgName,
+		PkgPath: pkgPath,
+	}
+
+	imports := map[string]bool{
+		"github.com/gnolang/gno/gno.land/pkg/gnoclient": true,
+		"github.com/gnolang/gno/gno.land/pkg/sdk/vm":    true,
+		"github.com/gnolang/gno/tm2/pkg/std":            true,
+		"strings":                                       true,
+	}
+
+	names := map[string]bool{"WithSend": true}
+	for _, fn := range funcs {
+		names[fn.Name] = true
+	}
+
+	var skipped []string
+
+funcs:
+	for _, fn := range funcs {
+		if fn.Name == "WithSend" {
+			skipped = append(skipped, fn.Name+": name is reserved")
+			continue
+		}
+
+		tf := bindTmplFunc{
+			Name: fn.Name,
+		}
+
+		if queryName := "Query" + fn.Name; !names[queryName] {
+			tf.QueryName = queryName
+		}
+
+		if fn.Doc != "" {
+			tf.Doc = strings.Split(strings.TrimRight(fn.Doc, "\n"), "\n")
+		}
+
+		var fnImports []string
+		for i, p := range fn.Params {
+			bt, ok := bindTypes[p.Type]
+			if !ok {
+				skipped = append(skipped, fmt.Sprintf("%s: unsupported parameter type %s", fn.Name, p.Type))
+				continue funcs
+			}
+
+			name := p.Name
+			if name == "" || name == "_" ||
+				slices.Contains(bindReservedNames, name) || reBindResultName.MatchString(name) {
+				name = fmt.Sprintf("arg%d", i)
+			}
+
+			tf.Params = append(tf.Params, bindTmplParam{
+				Name:    name,
+				GoType:  bt.goType,
+				CallArg: fmt.Sprintf(bt.callArg, name),
+				EvalArg: fmt.Sprintf(bt.evalArg, name),
+			})
+			fnImports = append(fnImports, bt.imports...)
+		}
+
+		for _, r := range fn.Results {
+			bt, ok := bindTypes[r.Type]
+			if !ok || !bt.result {
+				skipped = append(skipped, fmt.Sprintf("%s: unsupported result type %s", fn.Name, r.Type))
+				continue funcs
+			}
+
+			tf.Results = append(tf.Results, bt.goType)
+		}
+
+		for _, imp := range fnImports {
+			imports[imp] = true
+		}
+		imports["fmt"] = true
+
+		data.Funcs = append(data.Funcs, tf)
+	}
+
+	var stdImports, otherImports []string
+	for imp := range imports {
+		if strings.Contains(imp, ".") {
+			otherImports = append(otherImports, imp)
+		} else {
+			stdImports = append(stdImports, imp)
+		}
+	}
+	slices.Sort(stdImports)
+	slices.Sort(otherImports)
+	data.Imports = [][]string{stdImports, otherImports}
+
+	var buf bytes.Buffer
+	if err := bindTemplate.Execute(&buf, data); err != nil {
+		return nil, nil, fmt.Errorf("unable to generate bindings: %w", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to format bindings: %w", err)
+	}
+
+	return src, skipped, nil
+}
+
+// bindPkgName returns a valid Go package name from the last element of the pkgpath
+func bindPkgName(pkgPath string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		default:
+			return -1
+		}
+	}, path.Base(pkgPath))
+
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return "realm"
+	}
+
+	return name
+}
+
+var bindTemplate = template.Must(template.New("bind").Parse(`// Code generated by "gno tool bind"; DO NOT EDIT.
+
+// Package {{.PkgName}} provides typed Go bindings for the {{.PkgPath}} realm.
+package {{.PkgName}}
+
+import (
+{{- range $i, $group := .Imports}}{{if $i}}
+{{end}}
+{{- range $group}}
+	{{printf "%q" .}}
+{{- end}}
+{{- end}}
+)
+
+// PkgPath is the path of the realm
+const PkgPath = {{printf "%q" .PkgPath}}
+
+// Client calls the functions of the realm through a gnoclient.Client
+type Client struct {
+	client *gnoclient.Client
+	txCfg  gnoclient.BaseTxCfg
+	send   std.Coins
+}
+
+// NewClient creates a realm client. Transactions are made using txCfg
+func NewClient(client *gnoclient.Client, txCfg gnoclient.BaseTxCfg) *Client {
+	return &Client{
+		client: client,
+		txCfg:  txCfg,
+	}
+}
+
+// WithSend returns a copy of the client, sending the given coins with each transaction
+func (c *Client) WithSend(send std.Coins) *Client {
+	cp := *c
+	cp.send = send
+
+	return &cp
+}
+
+// call calls the realm function in a transaction signed by the client Signer
+func (c *Client) call(fn string, args ...string) ([]gnoclient.CallResult, error) {
+	if c.client.Signer == nil {
+		return nil, gnoclient.ErrMissingSigner
+	}
+
+	caller, err := c.client.Signer.Info()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Call(c.txCfg, vm.MsgCall{
+		Caller:  caller.GetAddress(),
+		Send:    c.send,
+		PkgPath: PkgPath,
+		Func:    fn,
+		Args:    args,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return gnoclient.ParseCallResults(string(res.DeliverTx.Data))
+}
+
+// eval evaluates the realm function with qeval
+func (c *Client) eval(fn string, args ...string) ([]gnoclient.CallResult, error) {
+	res, _, err := c.client.QEval(PkgPath, fn+"("+strings.Join(args, ", ")+")")
+	if err != nil {
+		return nil, err
+	}
+
+	return gnoclient.ParseCallResults(res)
+}
+{{range .Funcs}}
+// {{.Name}} calls {{.Name}} in a transaction.
+{{- if .Doc}}
+//
+{{- range .Doc}}
+// {{.}}
+{{- end}}
+{{- end}}
+func (c *Client) {{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}} {{$p.GoType}}{{end}}) ({{range $i, $r := .Results}}r{{$i}} {{$r}}, {{end}}err error) {
+	results, err := c.call({{printf "%q" .Name}}{{range .Params}}, {{.CallArg}}{{end}})
+	if err != nil {
+		return
+	}
+
+	return decode{{.Name}}(results)
+}
+{{if .QueryName}}
+// {{.QueryName}} evaluates {{.Name}} with qeval, without a transaction.
+// State changes are discarded.
+func (c *Client) {{.QueryName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}} {{$p.GoType}}{{end}}) ({{range $i, $r := .Results}}r{{$i}} {{$r}}, {{end}}err error) {
+	results, err := c.eval({{printf "%q" .Name}}{{range .Params}}, {{.EvalArg}}{{end}})
+	if err != nil {
+		return
+	}
+
+	return decode{{.Name}}(results)
+}
+{{end}}
+func decode{{.Name}}(results []gnoclient.CallResult) ({{range $i, $r := .Results}}r{{$i}} {{$r}}, {{end}}err error) {
+	if len(results) != {{len .Results}} {
+		err = fmt.Errorf("%w: expected {{len .Results}} result(s), got %d", gnoclient.ErrInvalidCallResult, len(results))
+		return
+	}
+{{range $i, $r := .Results}}
+	if r{{$i}}, err = gnoclient.DecodeCallResult[{{$r}}](results[{{$i}}]); err != nil {
+		return
+	}
+{{end}}
+	return
+}
+{{end}}`))
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -17,6 +18,7 @@ import (
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/gnovm/pkg/lint"
 	"github.com/gnolang/gno/gnovm/pkg/test"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"go.uber.org/multierr"
@@ -25,6 +27,8 @@ import (
 type lintCfg struct {
 	verbose bool
 	rootDir string
+	format  string
+	checks  string
 	// min_confidence: minimum confidence of a problem to print it (default 0.8)
 	// auto-fix: apply suggested fixes automatically.
 }
@@ -37,6 +41,14 @@ func newLintCmd(io commands.IO) *commands.Command {
 			Name:       "lint",
 			ShortUsage: "lint [flags] <package> [<package>...]",
 			ShortHelp:  "runs the linter for the specified packages",
+			LongHelp: `Runs the linter for the specified packages.
+
+Besides the gno.mod, parser, type checking and preprocessing errors, the
+linter runs static analysis checks on the packages, reported as warnings:
+
+` + lintChecksHelp() + `
+Warnings don't make the command fail. Use -checks to select the checks to
+run, and -format to print the issues as JSON or SARIF, for CI annotations.`,
 		},
 		cfg,
 		func(_ context.Context, args []string) error {
@@ -50,33 +62,85 @@ func (c *lintCfg) RegisterFlags(fs *flag.FlagSet) {
 
 	fs.BoolVar(&c.verbose, "v", false, "verbose output when lintning")
 	fs.StringVar(&c.rootDir, "root-dir", rootdir, "clone location of github.com/gnolang/gno (gno tries to guess it)")
+	fs.StringVar(&c.format, "format", lintFormatText, "output format of the issues: text, json or sarif")
+	fs.StringVar(&c.checks, "checks", "all", "comma-separated list of the checks to run, all or none")
 }
 
-type lintCode int
+// lintChecksHelp returns the description of the built-in checks
+func lintChecksHelp() string {
+	var sb strings.Builder
 
-const (
-	lintUnknown lintCode = iota
-	lintGnoMod
-	lintGnoError
-	lintParserError
-	lintTypeCheckError
+	for _, analyzer := range lint.Analyzers() {
+		fmt.Fprintf(&sb, "  %-22s %s\n", analyzer.Name(), analyzer.Doc)
+	}
 
-	// TODO: add new linter codes here.
-)
+	return sb.String()
+}
+
+// lintAnalyzers returns the analyzers selected by the -checks flag
+func lintAnalyzers(checks string) ([]*lint.Analyzer, error) {
+	switch checks {
+	case "all":
+		return lint.Analyzers(), nil
+	case "none", "":
+		return nil, nil
+	}
+
+	byName := make(map[string]*lint.Analyzer)
+	for _, analyzer := range lint.Analyzers() {
+		byName[analyzer.Name()] = analyzer
+	}
+
+	var analyzers []*lint.Analyzer
+
+	for _, name := range strings.Split(checks, ",") {
+		analyzer, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown check %q", name)
+		}
+
+		analyzers = append(analyzers, analyzer)
+	}
+
+	return analyzers, nil
+}
 
 type lintIssue struct {
-	Code       lintCode
-	Msg        string
-	Confidence float64 // 1 is 100%
-	Location   string  // file:line, or equivalent
+	Code       lint.Code     `json:"code"`
+	Msg        string        `json:"message"`
+	Severity   lint.Severity `json:"severity"`   // empty is error
+	Confidence float64       `json:"confidence"` // 1 is 100%
+	Location   string        `json:"location"`   // file:line, or equivalent
 	// TODO: consider writing fix suggestions
 }
 
 func (i lintIssue) String() string {
 	// TODO: consider crafting a doc URL based on Code.
+	if i.Severity == lint.SeverityWarning {
+		return fmt.Sprintf("%s: warning: %s (code=%d)", i.Location, i.Msg, i.Code)
+	}
+
 	return fmt.Sprintf("%s: %s (code=%d)", i.Location, i.Msg, i.Code)
 }
 
+func (i lintIssue) MarshalJSON() ([]byte, error) {
+	type issue lintIssue // without the MarshalJSON method
+
+	if i.Severity == "" {
+		i.Severity = lint.SeverityError
+	}
+
+	return json.Marshal(struct {
+		issue
+		Rule string `json:"rule"`
+	}{issue(i), i.Code.String()})
+}
+
+// isError returns true if the issue makes the linter fail
+func (i lintIssue) isError() bool {
+	return i.Severity == "" || i.Severity == lint.SeverityError
+}
+
 func execLint(cfg *lintCfg, args []string, io commands.IO) error {
 	if len(args) < 1 {
 		return flag.ErrHelp
@@ -90,13 +154,24 @@ func execLint(cfg *lintCfg, args []string, io commands.IO) error {
 		rootDir = gnoenv.RootDir()
 	}
 
+	reporter, err := newLintReporter(io, cfg.format)
+	if err != nil {
+		return err
+	}
+
+	analyzers, err := lintAnalyzers(cfg.checks)
+	if err != nil {
+		return err
+	}
+
 	pkgPaths, err := gnoPackagesFromArgsRecursively(args)
 	if err != nil {
 		return fmt.Errorf("list packages from args: %w", err)
 	}
 
-	hasError := false
-
+	// The imports are resolved from the vendor directory of the module of
+	// each package, so the stores are created again when it changes.
+	storeVendorDir := ""
 	bs, ts := test.StoreWithOptions(
 		rootDir, goio.Discard,
 		test.StoreOptions{PreprocessOnly: true},
@@ -112,35 +187,46 @@ func execLint(cfg *lintCfg, args []string, io commands.IO) error {
 			pkgPath = filepath.Dir(pkgPath)
 		}
 
+		if vendorDir := gnomod.FindVendorDir(pkgPath); vendorDir != storeVendorDir {
+			bs, ts = test.StoreWithOptions(
+				rootDir, goio.Discard,
+				test.StoreOptions{PreprocessOnly: true, VendorDir: vendorDir},
+			)
+			storeVendorDir = vendorDir
+		}
+
 		// Check if 'gno.mod' exists
 		gmFile, err := gnomod.ParseAt(pkgPath)
 		if err != nil {
-			issue := lintIssue{
-				Code:       lintGnoMod,
+			reporter.report(lintIssue{
+				Code:       lint.CodeGnoMod,
 				Confidence: 1,
 				Location:   pkgPath,
 				Msg:        err.Error(),
-			}
-			io.ErrPrintln(issue)
-			hasError = true
+			})
 		}
 
 		memPkg, err := gno.ReadMemPackage(pkgPath, pkgPath)
 		if err != nil {
-			io.ErrPrintln(issueFromError(pkgPath, err).String())
-			hasError = true
+			reporter.report(issueFromError(pkgPath, err))
 			continue
 		}
 
 		// Perform imports using the parent store.
 		if err := test.LoadImports(ts, memPkg); err != nil {
-			io.ErrPrintln(issueFromError(pkgPath, err).String())
-			hasError = true
+			reporter.report(issueFromError(pkgPath, err))
 			continue
 		}
 
+		// The analyzers need the package path declared in gno.mod,
+		// to know whether the package is a realm
+		modPath := ""
+		if gmFile != nil && gmFile.Module != nil {
+			modPath = gmFile.Module.Mod.Path
+		}
+
 		// Handle runtime errors
-		hasRuntimeErr := catchRuntimeError(pkgPath, io.Err(), func() {
+		catchRuntimeIssues(pkgPath, reporter.report, func() {
 			// Wrap in cache wrap so execution of the linter doesn't impact
 			// other packages.
 			cw := bs.CacheWrap()
@@ -148,12 +234,14 @@ func execLint(cfg *lintCfg, args []string, io commands.IO) error {
 
 			// Run type checking
 			if gmFile == nil || !gmFile.Draft {
-				foundErr, err := lintTypeCheck(io, memPkg, gs)
+				issues, err := lintTypeCheckIssues(memPkg, gs, modPath, analyzers)
 				if err != nil {
 					io.ErrPrintln(err)
-					hasError = true
-				} else if foundErr {
-					hasError = true
+					reporter.failed = true
+				}
+
+				for _, issue := range issues {
+					reporter.report(issue)
 				}
 			} else if verbose {
 				io.ErrPrintfln("%s: module is draft, skipping type check", pkgPath)
@@ -168,12 +256,13 @@ func execLint(cfg *lintCfg, args []string, io commands.IO) error {
 
 			tm.PreprocessFiles(memPkg.Name, memPkg.Path, packageFiles, false, false)
 		})
-		if hasRuntimeErr {
-			hasError = true
-		}
 	}
 
-	if hasError {
+	if err := reporter.flush(); err != nil {
+		return err
+	}
+
+	if reporter.failed {
 		return commands.ExitCodeError(1)
 	}
 
@@ -181,42 +270,81 @@ func execLint(cfg *lintCfg, args []string, io commands.IO) error {
 }
 
 func lintTypeCheck(io commands.IO, memPkg *gnovm.MemPackage, testStore gno.Store) (errorsFound bool, err error) {
-	tcErr := gno.TypeCheckMemPackageTest(memPkg, testStore)
-	if tcErr == nil {
-		return false, nil
+	issues, err := lintTypeCheckIssues(memPkg, testStore, "", nil)
+	for _, issue := range issues {
+		io.ErrPrintln(issue)
 	}
 
-	errs := multierr.Errors(tcErr)
-	for _, err := range errs {
+	return len(issues) > 0, err
+}
+
+// lintTypeCheckIssues type checks the package, and returns the parser and
+// type checking issues. If the package can be parsed, the analyzers are run
+// on it, using modPath as package path.
+func lintTypeCheckIssues(
+	memPkg *gnovm.MemPackage,
+	store gno.Store,
+	modPath string,
+	analyzers []*lint.Analyzer,
+) ([]lintIssue, error) {
+	res, tcErr := gno.TypeCheckMemPackageInfo(memPkg, store)
+
+	var (
+		parseIssues []lintIssue
+		typeIssues  []lintIssue
+	)
+
+	for _, err := range multierr.Errors(tcErr) {
 		switch err := err.(type) {
 		case types.Error:
-			io.ErrPrintln(lintIssue{
-				Code:       lintTypeCheckError,
+			typeIssues = append(typeIssues, lintIssue{
+				Code:       lint.CodeTypeCheckError,
 				Msg:        err.Msg,
 				Confidence: 1,
 				Location:   err.Fset.Position(err.Pos).String(),
 			})
 		case scanner.ErrorList:
 			for _, scErr := range err {
-				io.ErrPrintln(lintIssue{
-					Code:       lintParserError,
+				parseIssues = append(parseIssues, lintIssue{
+					Code:       lint.CodeParserError,
 					Msg:        scErr.Msg,
 					Confidence: 1,
 					Location:   scErr.Pos.String(),
 				})
 			}
 		case scanner.Error:
-			io.ErrPrintln(lintIssue{
-				Code:       lintParserError,
+			parseIssues = append(parseIssues, lintIssue{
+				Code:       lint.CodeParserError,
 				Msg:        err.Msg,
 				Confidence: 1,
 				Location:   err.Pos.String(),
 			})
 		default:
-			return false, fmt.Errorf("unexpected error type: %T", err)
+			return nil, fmt.Errorf("unexpected error type: %T", err)
 		}
 	}
-	return true, nil
+
+	// The type checking errors of partially parsed files are noise
+	if len(parseIssues) > 0 {
+		return parseIssues, nil
+	}
+
+	if modPath == "" {
+		modPath = memPkg.Path
+	}
+
+	issues := typeIssues
+	for _, diag := range lint.Run(modPath, res, analyzers) {
+		issues = append(issues, lintIssue{
+			Code:       diag.Analyzer.Code,
+			Msg:        diag.Message,
+			Severity:   diag.Analyzer.Severity,
+			Confidence: diag.Analyzer.Confidence,
+			Location:   diag.Pos.String(),
+		})
+	}
+
+	return issues, nil
 }
 
 func sourceAndTestFileset(memPkg *gnovm.MemPackage) *gno.FileSet {
@@ -264,6 +392,14 @@ func guessSourcePath(pkg, source string) string {
 var reParseRecover = regexp.MustCompile(`^([^:]+)((?::(?:\d+)){1,2}):? *(.*)$`)
 
 func catchRuntimeError(pkgPath string, stderr goio.WriteCloser, action func()) (hasError bool) {
+	return catchRuntimeIssues(pkgPath, func(issue lintIssue) {
+		fmt.Fprintln(stderr, issue.String())
+	}, action)
+}
+
+// catchRuntimeIssues runs the action, and reports the issues
+// of the runtime errors it panics with
+func catchRuntimeIssues(pkgPath string, report func(lintIssue), action func()) (hasError bool) {
 	defer func() {
 		// Errors catched here mostly come from: gnovm/pkg/gnolang/preprocess.go
 		r := recover()
@@ -274,21 +410,21 @@ func catchRuntimeError(pkgPath string, stderr goio.WriteCloser, action func()) (
 		switch verr := r.(type) {
 		case *gno.PreprocessError:
 			err := verr.Unwrap()
-			fmt.Fprintln(stderr, issueFromError(pkgPath, err).String())
+			report(issueFromError(pkgPath, err))
 		case error:
 			errors := multierr.Errors(verr)
 			for _, err := range errors {
 				errList, ok := err.(scanner.ErrorList)
 				if ok {
 					for _, errorInList := range errList {
-						fmt.Fprintln(stderr, issueFromError(pkgPath, errorInList).String())
+						report(issueFromError(pkgPath, errorInList))
 					}
 				} else {
-					fmt.Fprintln(stderr, issueFromError(pkgPath, err).String())
+					report(issueFromError(pkgPath, err))
 				}
 			}
 		case string:
-			fmt.Fprintln(stderr, issueFromError(pkgPath, errors.New(verr)).String())
+			report(issueFromError(pkgPath, errors.New(verr)))
 		default:
 			panic(r)
 		}
@@ -301,7 +437,7 @@ func catchRuntimeError(pkgPath string, stderr goio.WriteCloser, action func()) (
 func issueFromError(pkgPath string, err error) lintIssue {
 	var issue lintIssue
 	issue.Confidence = 1
-	issue.Code = lintGnoError
+	issue.Code = lint.CodeGnoError
 
 	parsedError := strings.TrimSpace(err.Error())
 	parsedError = strings.TrimPrefix(parsedError, pkgPath+"/")
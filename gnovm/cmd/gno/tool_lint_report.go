@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/gnolang/gno/gnovm/pkg/lint"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+const (
+	lintFormatText  = "text"
+	lintFormatJSON  = "json"
+	lintFormatSARIF = "sarif"
+)
+
+// lintReporter prints the issues found by the linter, as they are found
+// with the text format, or all at once with the JSON and SARIF formats
+type lintReporter struct {
+	io     commands.IO
+	format string
+
+	issues []lintIssue
+	failed bool // true if an error was reported
+}
+
+func newLintReporter(io commands.IO, format string) (*lintReporter, error) {
+	switch format {
+	case lintFormatText, lintFormatJSON, lintFormatSARIF:
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected text, json or sarif", format)
+	}
+
+	return &lintReporter{
+		io:     io,
+		format: format,
+	}, nil
+}
+
+func (r *lintReporter) report(issue lintIssue) {
+	if issue.isError() {
+		r.failed = true
+	}
+
+	if r.format == lintFormatText {
+		r.io.ErrPrintln(issue)
+		return
+	}
+
+	r.issues = append(r.issues, issue)
+}
+
+// flush prints the issues collected with the JSON and SARIF formats
+func (r *lintReporter) flush() error {
+	var out any
+
+	switch r.format {
+	case lintFormatJSON:
+		issues := r.issues
+		if issues == nil {
+			issues = []lintIssue{}
+		}
+
+		out = issues
+	case lintFormatSARIF:
+		out = newSARIFLog(r.issues)
+	default:
+		return nil
+	}
+
+	bz, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal issues: %w", err)
+	}
+
+	r.io.Println(string(bz))
+
+	return nil
+}
+
+// SARIF 2.1.0 log, as consumed by the code scanning of CI services.
+// Only the properties used by the linter are defined.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+)
+
+// sarifRuleDescriptions are the descriptions of the rules that
+// are not reported by analyzers
+var sarifRuleDescriptions = map[lint.Code]string{
+	lint.CodeUnknown:        "unknown error",
+	lint.CodeGnoMod:         "missing or invalid gno.mod file",
+	lint.CodeGnoError:       "error found by the Gno preprocessor",
+	lint.CodeParserError:    "syntax error",
+	lint.CodeTypeCheckError: "type checking error",
+}
+
+// reLintLocation splits an issue location (file:line:column)
+var reLintLocation = regexp.MustCompile(`^(.*?)(?::(\d+)(?::(\d+))?)?$`)
+
+func newSARIFLog(issues []lintIssue) *sarifLog {
+	var rules []sarifRule
+
+	for code := lint.CodeUnknown; code <= lint.CodeTypeCheckError; code++ {
+		rules = append(rules, sarifRule{
+			ID:               code.String(),
+			ShortDescription: sarifMessage{Text: sarifRuleDescriptions[code]},
+		})
+	}
+
+	for _, analyzer := range lint.Analyzers() {
+		rules = append(rules, sarifRule{
+			ID:               analyzer.Name(),
+			ShortDescription: sarifMessage{Text: analyzer.Doc},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(issues))
+
+	for _, issue := range issues {
+		level := "error"
+		if !issue.isError() {
+			level = "warning"
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    issue.Code.String(),
+			Level:     level,
+			Message:   sarifMessage{Text: issue.Msg},
+			Locations: []sarifLocation{sarifLocationOf(issue.Location)},
+		})
+	}
+
+	return &sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "gno lint",
+						InformationURI: "https://github.com/gnolang/gno",
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
+
+func sarifLocationOf(location string) sarifLocation {
+	matches := reLintLocation.FindStringSubmatch(location)
+
+	loc := sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{
+				URI: filepath.ToSlash(filepath.Clean(matches[1])),
+			},
+		},
+	}
+
+	line, _ := strconv.Atoi(matches[2])
+	if line == 0 {
+		return loc
+	}
+
+	column, _ := strconv.Atoi(matches[3])
+	loc.PhysicalLocation.Region = &sarifRegion{
+		StartLine:   line,
+		StartColumn: column,
+	}
+
+	return loc
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/gnovm/pkg/lsp"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+type lspCfg struct {
+	rootDir string
+}
+
+func newLSPCmd(io commands.IO) *commands.Command {
+	cfg := &lspCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "lsp",
+			ShortUsage: "tool lsp [flags]",
+			ShortHelp:  "runs the Gno language server",
+			LongHelp: `Runs a language server, speaking the Language Server Protocol over stdin and stdout.
+
+The server provides diagnostics (parse, type check and gno.mod errors,
+with the codes of gno lint), hover documentation, completion,
+go-to-definition and formatting (with import fixing).
+
+Imports are resolved from the workspace packages, the standard libraries
+and examples of the root dir, and the modules downloaded with gno mod download.`,
+		},
+		cfg,
+		func(ctx context.Context, _ []string) error {
+			return execLSP(ctx, cfg, io)
+		},
+	)
+}
+
+func (c *lspCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.rootDir,
+		"root-dir",
+		"",
+		"clone location of github.com/gnolang/gno (gno tries to guess it)",
+	)
+}
+
+func execLSP(ctx context.Context, cfg *lspCfg, io commands.IO) error {
+	rootDir := cfg.rootDir
+	if rootDir == "" {
+		rootDir = gnoenv.RootDir()
+	}
+
+	return lsp.NewServer(rootDir).Serve(ctx, io.In(), io.Out())
+}
@@ -9,6 +9,8 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
 )
 
 func isGnoFile(f fs.DirEntry) bool {
@@ -100,6 +102,10 @@ func walkDirForGnoFiles(root string, addPath func(path string)) error {
 			return fmt.Errorf("%s: walk dir: %w", root, err)
 		}
 
+		if isVendorDir(root, currPath, f) {
+			return filepath.SkipDir
+		}
+
 		if f.IsDir() || !isGnoFile(f) {
 			return nil
 		}
@@ -186,6 +192,9 @@ func targetsFromPatterns(patterns []string) ([]string, error) {
 			if err != nil {
 				return fmt.Errorf("%s: walk dir: %w", dirToSearch, err)
 			}
+			if isVendorDir(dirToSearch, curpath, f) {
+				return filepath.SkipDir
+			}
 			// Skip directories and non ".gno" files.
 			if f.IsDir() || !isGnoFile(f) {
 				return nil
@@ -210,6 +219,12 @@ func targetsFromPatterns(patterns []string) ([]string, error) {
 	return paths, nil
 }
 
+// isVendorDir reports whether the entry at path is a vendor directory below
+// root, which holds dependencies and is excluded from the walks, like Go.
+func isVendorDir(root, path string, f fs.DirEntry) bool {
+	return f.IsDir() && f.Name() == gnomod.VendorDirName && filepath.Clean(path) != filepath.Clean(root)
+}
+
 // matchPattern(pattern)(name) reports whether
 // name matches pattern.  Pattern is a limited glob
 // pattern in which '...' means 'any string' and there
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm"
+	"github.com/gnolang/gno/gnovm/cmd/gno/internal/pkgdownload"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/gnovm/pkg/packages"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"golang.org/x/mod/module"
+)
+
+// errVendorOutOfSync is returned when the vendor directory doesn't match the gno.mod
+var errVendorOutOfSync = errors.New("vendor directory is out of sync with gno.mod, run gno mod vendor")
+
+// depsVendorer copies the dependencies of a local package, resolved
+// following the gno.mod, from the modules cache to a vendor directory
+type depsVendorer struct {
+	gnoMod    *gnomod.File
+	modDir    string // directory of the gno.mod, for local replacements
+	vendorDir string
+
+	// manifest holds the hashes of the vendored packages, by import path
+	manifest *gnomod.LockFile
+
+	// visited holds the import paths of the vendored packages
+	visited map[string]bool
+}
+
+// vendorDeps recursively copies the imports of the files of the given kinds
+// of a package to the vendor directory. The vendored packages only hold
+// their source files, so only their source imports are followed.
+func (v *depsVendorer) vendorDeps(pkgDir, pkgPath string, kinds ...packages.FileKind) error {
+	if v.visited == nil {
+		v.visited = make(map[string]bool)
+	}
+	if v.manifest == nil {
+		v.manifest = &gnomod.LockFile{}
+	}
+
+	imports, err := remoteImports(pkgDir, pkgPath, kinds...)
+	if err != nil {
+		return err
+	}
+
+	for _, imp := range imports {
+		if v.visited[imp] {
+			continue
+		}
+		v.visited[imp] = true
+
+		srcDir := v.sourceDir(imp)
+		dst := filepath.Join(v.vendorDir, filepath.FromSlash(imp))
+
+		if err := v.vendorPackage(imp, srcDir, dst); err != nil {
+			return fmt.Errorf("vendor import %q of %q: %w", imp, pkgDir, err)
+		}
+
+		if err := v.vendorDeps(dst, imp, packages.FileKindPackageSource); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sourceDir returns the directory of an imported package, in the modules cache
+// or in a local directory if the gno.mod replaces it with one
+func (v *depsVendorer) sourceDir(pkgPath string) string {
+	resolved := v.gnoMod.Resolve(module.Version{Path: pkgPath})
+	if isRemotePkgPath(resolved.Path) {
+		return gnomod.PackageDir("", module.Version{Path: resolved.Path})
+	}
+
+	if filepath.IsAbs(resolved.Path) {
+		return resolved.Path
+	}
+	return filepath.Join(v.modDir, resolved.Path)
+}
+
+// vendorPackage copies the source files of the package at srcDir to dst,
+// and records their hash in the manifest
+func (v *depsVendorer) vendorPackage(pkgPath, srcDir, dst string) error {
+	if _, err := os.Stat(srcDir); err != nil {
+		return fmt.Errorf("package not found at %q, run gno mod download: %w", srcDir, err)
+	}
+
+	pkg, err := gnolang.ReadMemPackage(srcDir, pkgPath)
+	if err != nil {
+		return fmt.Errorf("read package at %q: %w", srcDir, err)
+	}
+
+	files := sourceFiles(pkg.Files)
+	if len(files) == 0 {
+		return fmt.Errorf("no source files at %q", srcDir)
+	}
+
+	hash, err := gnomod.HashMemPackage(files)
+	if err != nil {
+		return fmt.Errorf("hash package %q: %w", pkgPath, err)
+	}
+
+	if err := pkgdownload.WriteFiles(files, dst); err != nil {
+		return err
+	}
+
+	version := ""
+	if req, ok := v.gnoMod.Required(pkgPath); ok {
+		version = req.Version
+	}
+	v.manifest.Set(pkgPath, version, hash)
+
+	return nil
+}
+
+// verifyVendor checks that the vendor directory holds all the dependencies
+// of the package at modDir, as resolved by its gno.mod and unmodified since
+// they were vendored. It doesn't need the modules cache nor the network.
+// Each difference is printed, and [errVendorOutOfSync] is returned if any.
+func verifyVendor(io commands.IO, gnoMod *gnomod.File, modDir, vendorDir string) error {
+	manifest, err := gnomod.ReadVendorManifest(vendorDir)
+	if err != nil {
+		return err
+	}
+
+	var drift bool
+	report := func(format string, args ...any) {
+		io.ErrPrintfln("gno: "+format, args...)
+		drift = true
+	}
+
+	// The required versions must match the vendored ones
+	for _, req := range gnoMod.Require {
+		if entry, ok := getVendored(manifest, req.Mod.Path); !ok {
+			report("%s %s is required in gno.mod but not vendored", req.Mod.Path, req.Mod.Version)
+		} else if entry.Version != req.Mod.Version {
+			report("%s is required at %s in gno.mod but vendored at %s", req.Mod.Path, req.Mod.Version, vendoredVersion(entry))
+		}
+	}
+	for _, entry := range manifest.Entries {
+		if _, ok := gnoMod.Required(entry.Path); !ok && entry.Version != "" {
+			report("%s %s is vendored but not required in gno.mod", entry.Path, entry.Version)
+		}
+	}
+
+	// All the imports must be vendored, and the vendored packages unmodified
+	imported := make(map[string]bool)
+	var walk func(pkgDir, pkgPath string, kinds ...packages.FileKind) error
+	walk = func(pkgDir, pkgPath string, kinds ...packages.FileKind) error {
+		imports, err := remoteImports(pkgDir, pkgPath, kinds...)
+		if err != nil {
+			return err
+		}
+
+		for _, imp := range imports {
+			if imported[imp] {
+				continue
+			}
+			imported[imp] = true
+
+			entry, ok := getVendored(manifest, imp)
+			if !ok {
+				report("%s is imported but not vendored", imp)
+				continue
+			}
+
+			dir := filepath.Join(vendorDir, filepath.FromSlash(imp))
+			pkg, err := gnolang.ReadMemPackage(dir, imp)
+			if err != nil {
+				report("%s is vendored but can't be read: %v", imp, err)
+				continue
+			}
+
+			hash, err := gnomod.HashMemPackage(sourceFiles(pkg.Files))
+			if err != nil {
+				return fmt.Errorf("hash package %q: %w", imp, err)
+			}
+			if hash != entry.Hash {
+				report("%s has been modified since it was vendored", imp)
+			}
+
+			if err := walk(dir, imp, packages.FileKindPackageSource); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+	if err := walk(modDir, gnoMod.Module.Mod.Path, packages.FileKindPackageSource, packages.FileKindTest, packages.FileKindXTest); err != nil {
+		return err
+	}
+
+	for _, entry := range manifest.Entries {
+		if !imported[entry.Path] {
+			report("%s is vendored but no longer imported", entry.Path)
+		}
+	}
+
+	if drift {
+		return errVendorOutOfSync
+	}
+
+	return nil
+}
+
+// remoteImports returns the remote import paths of the files of the
+// given kinds of the package at pkgDir
+func remoteImports(pkgDir, pkgPath string, kinds ...packages.FileKind) ([]string, error) {
+	pkg, err := gnolang.ReadMemPackage(pkgDir, pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("read package at %q: %w", pkgDir, err)
+	}
+	importsMap, err := packages.Imports(pkg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("read imports at %q: %w", pkgDir, err)
+	}
+
+	var imports []string
+	for _, imp := range importsMap.Merge(kinds...) {
+		if isRemotePkgPath(imp.PkgPath) {
+			imports = append(imports, imp.PkgPath)
+		}
+	}
+
+	return imports, nil
+}
+
+// sourceFiles returns the non-test gno files
+func sourceFiles(files []*gnovm.MemFile) []*gnovm.MemFile {
+	var res []*gnovm.MemFile
+	for _, file := range files {
+		if strings.HasSuffix(file.Name, ".gno") &&
+			!strings.HasSuffix(file.Name, "_test.gno") &&
+			!strings.HasSuffix(file.Name, "_filetest.gno") {
+			res = append(res, file)
+		}
+	}
+	return res
+}
+
+func getVendored(manifest *gnomod.LockFile, pkgPath string) (gnomod.LockEntry, bool) {
+	for _, entry := range manifest.Entries {
+		if entry.Path == pkgPath {
+			return entry, true
+		}
+	}
+	return gnomod.LockEntry{}, false
+}
+
+func vendoredVersion(entry gnomod.LockEntry) string {
+	if entry.Version == "" {
+		return "latest"
+	}
+	return entry.Version
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/gnovm/cmd/gno/internal/pkgdownload/examplespkgfetcher"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/gnovm/pkg/packages"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+func TestVendorDeps(t *testing.T) {
+	mockErr := bytes.NewBufferString("")
+	io := commands.NewTestIO()
+	io.SetErr(commands.WriteNopCloser(mockErr))
+
+	dirPath := t.TempDir()
+	err := os.WriteFile(filepath.Join(dirPath, "main.gno"), []byte("package main\n\nimport (\n\t\"gno.land/p/demo/avl\"\n\t\"gno.land/p/demo/avl/pager\"\n)\n"), 0o644)
+	require.NoError(t, err)
+
+	t.Setenv("GNOHOME", t.TempDir())
+
+	modFile := &gnomod.File{
+		Module: &modfile.Module{
+			Mod: module.Version{Path: "testVendorDeps"},
+		},
+	}
+	require.NoError(t, modFile.AddRequire("gno.land/p/demo/avl", "v0.1.0"))
+
+	d := &depsDownloader{io: io, gnoMod: modFile, fetcher: examplespkgfetcher.New()}
+	require.NoError(t, d.downloadDeps(dirPath))
+
+	vendorDir := filepath.Join(dirPath, gnomod.VendorDirName)
+	v := &depsVendorer{gnoMod: modFile, modDir: dirPath, vendorDir: vendorDir}
+	require.NoError(t, v.vendorDeps(dirPath, "testVendorDeps", packages.FileKindPackageSource, packages.FileKindTest, packages.FileKindXTest))
+	require.NoError(t, gnomod.WriteVendorManifest(vendorDir, v.manifest))
+
+	// The imports are vendored recursively, without their tests
+	avlDir := filepath.Join(vendorDir, "gno.land", "p", "demo", "avl")
+	assert.FileExists(t, filepath.Join(avlDir, "tree.gno"))
+	assert.NoFileExists(t, filepath.Join(avlDir, "tree_test.gno"))
+	assert.DirExists(t, filepath.Join(vendorDir, "gno.land", "p", "demo", "ufmt"))
+
+	require.Len(t, v.manifest.Entries, 4)
+	_, ok := v.manifest.Get("gno.land/p/demo/avl", "v0.1.0")
+	assert.True(t, ok)
+	for _, pkgPath := range []string{"gno.land/p/demo/avl/pager", "gno.land/p/demo/avl/rotree", "gno.land/p/demo/ufmt"} {
+		_, ok = v.manifest.Get(pkgPath, "")
+		assert.True(t, ok, pkgPath)
+	}
+
+	assert.Equal(t, vendorDir, gnomod.FindVendorDir(avlDir))
+
+	// A fresh vendor directory is in sync
+	require.NoError(t, verifyVendor(io, modFile, dirPath, vendorDir))
+
+	// A modified vendored package is reported
+	require.NoError(t, os.WriteFile(filepath.Join(avlDir, "tree.gno"), []byte("package avl\n"), 0o644))
+	mockErr.Reset()
+	require.ErrorIs(t, verifyVendor(io, modFile, dirPath, vendorDir), errVendorOutOfSync)
+	assert.Contains(t, mockErr.String(), "gno: gno.land/p/demo/avl has been modified since it was vendored")
+
+	// So is a new required version
+	require.NoError(t, modFile.AddRequire("gno.land/p/demo/avl", "v0.2.0"))
+	mockErr.Reset()
+	require.ErrorIs(t, verifyVendor(io, modFile, dirPath, vendorDir), errVendorOutOfSync)
+	assert.Contains(t, mockErr.String(), "gno: gno.land/p/demo/avl is required at v0.2.0 in gno.mod but vendored at v0.1.0")
+
+	// And an import that is not vendored
+	err = os.WriteFile(filepath.Join(dirPath, "seqid.gno"), []byte("package main\n\nimport \"gno.land/p/demo/seqid\"\n"), 0o644)
+	require.NoError(t, err)
+	mockErr.Reset()
+	require.ErrorIs(t, verifyVendor(io, modFile, dirPath, vendorDir), errVendorOutOfSync)
+	assert.Contains(t, mockErr.String(), "gno: gno.land/p/demo/seqid is imported but not vendored")
+}
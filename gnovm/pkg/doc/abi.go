@@ -0,0 +1,527 @@
+package doc
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+)
+
+// ABIVersion is the version of the ABI schema.
+// It is incremented on backward incompatible changes.
+const ABIVersion = "1"
+
+// ABI is a machine-readable description of the interface of a package,
+// suitable for generating clients: its exported functions, the types they use
+// and the events it emits.
+//
+// Types are described structurally. Named types are referenced with a
+// qualified "<pkgpath>.<name>" ref; those declared in the package are defined
+// in Types, with their underlying type.
+type ABI struct {
+	Version string          `json:"version"`
+	PkgPath string          `json:"pkg_path"`
+	Name    string          `json:"name"`
+	Funcs   []*ABIFunc      `json:"funcs"`  // exported top-level functions
+	Types   []*ABITypeDecl  `json:"types"`  // exported types, and types used by Funcs
+	Events  []*ABIEventDecl `json:"events"` // events emitted with std.Emit
+}
+
+type ABIFunc struct {
+	Name     string      `json:"name"`
+	Params   []*ABIField `json:"params"`
+	Results  []*ABIField `json:"results"`
+	Variadic bool        `json:"variadic,omitempty"` // the last param is variadic, its type is a slice
+}
+
+type ABIField struct {
+	Name     string   `json:"name"`
+	Type     *ABIType `json:"type"`
+	Embedded bool     `json:"embedded,omitempty"`
+}
+
+type ABITypeDecl struct {
+	Name     string   `json:"name"`
+	Ref      string   `json:"ref"` // "<pkgpath>.<name>"
+	Exported bool     `json:"exported"`
+	Type     *ABIType `json:"type"` // underlying type
+}
+
+// ABIKind is the kind of an ABIType
+type ABIKind string
+
+const (
+	ABIKindBool      ABIKind = "bool"
+	ABIKindString    ABIKind = "string"
+	ABIKindInt       ABIKind = "int"
+	ABIKindInt8      ABIKind = "int8"
+	ABIKindInt16     ABIKind = "int16"
+	ABIKindInt32     ABIKind = "int32"
+	ABIKindInt64     ABIKind = "int64"
+	ABIKindUint      ABIKind = "uint"
+	ABIKindUint8     ABIKind = "uint8"
+	ABIKindUint16    ABIKind = "uint16"
+	ABIKindUint32    ABIKind = "uint32"
+	ABIKindUint64    ABIKind = "uint64"
+	ABIKindFloat32   ABIKind = "float32"
+	ABIKindFloat64   ABIKind = "float64"
+	ABIKindError     ABIKind = "error"
+	ABIKindArray     ABIKind = "array"
+	ABIKindSlice     ABIKind = "slice"
+	ABIKindMap       ABIKind = "map"
+	ABIKindPointer   ABIKind = "pointer"
+	ABIKindStruct    ABIKind = "struct"
+	ABIKindInterface ABIKind = "interface"
+	ABIKindFunc      ABIKind = "func"
+	ABIKindChan      ABIKind = "chan"
+	ABIKindRef       ABIKind = "ref" // named type
+)
+
+// ABIType describes a type. Only the fields relevant to its Kind are set.
+type ABIType struct {
+	Kind    ABIKind     `json:"kind"`
+	Ref     string      `json:"ref,omitempty"`     // ref: "<pkgpath>.<name>"
+	Elem    *ABIType    `json:"elem,omitempty"`    // array, slice, map (value), pointer, chan
+	Key     *ABIType    `json:"key,omitempty"`     // map
+	Len     int32       `json:"len,omitempty"`     // array
+	Fields  []*ABIField `json:"fields,omitempty"`  // struct (exported fields only)
+	Params  []*ABIField `json:"params,omitempty"`  // func
+	Results []*ABIField `json:"results,omitempty"` // func
+}
+
+// ABIEventDecl is an event emitted by the package. Event attributes are
+// key-value pairs of strings.
+type ABIEventDecl struct {
+	Type  string   `json:"type"`
+	Attrs []string `json:"attrs"`          // attribute keys
+	Open  bool     `json:"open,omitempty"` // some attribute keys are not constant
+	Funcs []string `json:"funcs"`          // functions emitting the event
+}
+
+// JSON returns the ABI encoded as JSON
+func (abi *ABI) JSON() string {
+	bz := amino.MustMarshalJSON(abi)
+	return string(bz)
+}
+
+// WriteABI returns the ABI of the package
+func (d *Documentable) WriteABI() (*ABI, error) {
+	if d.pkgData == nil {
+		var err error
+		d.pkgData, err = newPkgData(d.bfsDir, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b := newABIBuilder(d.pkgData)
+	return b.build(), nil
+}
+
+// abiBuilder builds the ABI of a package from its AST
+type abiBuilder struct {
+	pkg   *pkgData
+	types map[string]*ast.TypeSpec  // package level types
+	specs map[string]*ast.ValueSpec // package level constants
+	decls map[string]*ABITypeDecl   // resolved package types
+}
+
+func newABIBuilder(pkg *pkgData) *abiBuilder {
+	b := &abiBuilder{
+		pkg:   pkg,
+		types: map[string]*ast.TypeSpec{},
+		specs: map[string]*ast.ValueSpec{},
+		decls: map[string]*ABITypeDecl{},
+	}
+
+	for _, file := range pkg.files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					b.types[s.Name.Name] = s
+				case *ast.ValueSpec:
+					if gen.Tok == token.CONST {
+						for _, name := range s.Names {
+							b.specs[name.Name] = s
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return b
+}
+
+func (b *abiBuilder) build() *ABI {
+	abi := &ABI{
+		Version: ABIVersion,
+		PkgPath: b.pkg.path,
+		Name:    b.pkg.name,
+		Funcs:   []*ABIFunc{},
+		Types:   []*ABITypeDecl{},
+		Events:  []*ABIEventDecl{},
+	}
+
+	for _, file := range b.pkg.files {
+		imports := fileImports(file)
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !fn.Name.IsExported() {
+				continue
+			}
+
+			params, variadic := b.fields(imports, fn.Type.Params)
+			results, _ := b.fields(imports, fn.Type.Results)
+			abi.Funcs = append(abi.Funcs, &ABIFunc{
+				Name:     fn.Name.Name,
+				Params:   params,
+				Results:  results,
+				Variadic: variadic,
+			})
+		}
+	}
+
+	for name := range b.types {
+		if ast.IsExported(name) {
+			b.ident(name)
+		}
+	}
+
+	for _, decl := range b.decls {
+		abi.Types = append(abi.Types, decl)
+	}
+
+	abi.Events = b.events()
+
+	slices.SortFunc(abi.Funcs, func(a, b *ABIFunc) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortFunc(abi.Types, func(a, b *ABITypeDecl) int { return strings.Compare(a.Name, b.Name) })
+
+	return abi
+}
+
+// fileImports returns the import paths of the file, by package name
+func fileImports(file *ast.File) map[string]string {
+	imports := map[string]string{}
+	for _, imp := range file.Imports {
+		impPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := impPath[strings.LastIndex(impPath, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = impPath
+	}
+
+	return imports
+}
+
+// fields resolves the types of a field list, and returns whether its last
+// field is variadic
+func (b *abiBuilder) fields(imports map[string]string, list *ast.FieldList) ([]*ABIField, bool) {
+	fields := []*ABIField{}
+	if list == nil {
+		return fields, false
+	}
+
+	variadic := false
+	for _, field := range list.List {
+		expr := field.Type
+		if ellipsis, ok := expr.(*ast.Ellipsis); ok {
+			variadic = true
+			expr = &ast.ArrayType{Elt: ellipsis.Elt}
+		}
+
+		typ := b.typ(imports, expr)
+		if len(field.Names) == 0 {
+			fields = append(fields, &ABIField{Type: typ})
+			continue
+		}
+
+		for _, name := range field.Names {
+			fields = append(fields, &ABIField{Name: name.Name, Type: typ})
+		}
+	}
+
+	return fields, variadic
+}
+
+// typ resolves the type expression, declaring the package types it uses
+func (b *abiBuilder) typ(imports map[string]string, expr ast.Expr) *ABIType {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return b.ident(x.Name)
+	case *ast.ParenExpr:
+		return b.typ(imports, x.X)
+	case *ast.SelectorExpr:
+		pkgName, ok := x.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+
+		pkgPath, ok := imports[pkgName.Name]
+		if !ok {
+			pkgPath = pkgName.Name
+		}
+
+		return &ABIType{Kind: ABIKindRef, Ref: pkgPath + "." + x.Sel.Name}
+	case *ast.StarExpr:
+		return &ABIType{Kind: ABIKindPointer, Elem: b.typ(imports, x.X)}
+	case *ast.ArrayType:
+		elem := b.typ(imports, x.Elt)
+		if x.Len == nil {
+			return &ABIType{Kind: ABIKindSlice, Elem: elem}
+		}
+
+		return &ABIType{Kind: ABIKindArray, Elem: elem, Len: int32(b.arrayLen(x.Len))}
+	case *ast.MapType:
+		return &ABIType{Kind: ABIKindMap, Key: b.typ(imports, x.Key), Elem: b.typ(imports, x.Value)}
+	case *ast.ChanType:
+		return &ABIType{Kind: ABIKindChan, Elem: b.typ(imports, x.Value)}
+	case *ast.FuncType:
+		params, _ := b.fields(imports, x.Params)
+		results, _ := b.fields(imports, x.Results)
+		return &ABIType{Kind: ABIKindFunc, Params: params, Results: results}
+	case *ast.InterfaceType:
+		return &ABIType{Kind: ABIKindInterface}
+	case *ast.StructType:
+		typ := &ABIType{Kind: ABIKindStruct, Fields: []*ABIField{}}
+		for _, field := range x.Fields.List {
+			ftyp := b.typ(imports, field.Type)
+			if len(field.Names) == 0 {
+				name := embeddedName(field.Type)
+				if ast.IsExported(name) {
+					typ.Fields = append(typ.Fields, &ABIField{Name: name, Type: ftyp, Embedded: true})
+				}
+				continue
+			}
+
+			for _, name := range field.Names {
+				if name.IsExported() {
+					typ.Fields = append(typ.Fields, &ABIField{Name: name.Name, Type: ftyp})
+				}
+			}
+		}
+
+		return typ
+	}
+
+	// Not a valid type expression
+	return &ABIType{Kind: ABIKindInterface}
+}
+
+// ident resolves a type name
+func (b *abiBuilder) ident(name string) *ABIType {
+	switch name {
+	case "bool", "string",
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64", "error":
+		return &ABIType{Kind: ABIKind(name)}
+	case "byte":
+		return &ABIType{Kind: ABIKindUint8}
+	case "rune":
+		return &ABIType{Kind: ABIKindInt32}
+	case "any":
+		return &ABIType{Kind: ABIKindInterface}
+	}
+
+	spec, ok := b.types[name]
+	if !ok {
+		// Unknown type, don't fail on invalid packages
+		return &ABIType{Kind: ABIKindInterface}
+	}
+
+	imports := b.specImports(spec)
+
+	// Aliases are replaced by the aliased type
+	if spec.Assign.IsValid() {
+		return b.typ(imports, spec.Type)
+	}
+
+	ref := b.pkg.path + "." + name
+	if _, ok := b.decls[name]; !ok {
+		decl := &ABITypeDecl{
+			Name:     name,
+			Ref:      ref,
+			Exported: ast.IsExported(name),
+		}
+
+		// Declare the type before resolving it, for recursive types
+		b.decls[name] = decl
+		decl.Type = b.typ(imports, spec.Type)
+	}
+
+	return &ABIType{Kind: ABIKindRef, Ref: ref}
+}
+
+// specImports returns the imports of the file declaring the type
+func (b *abiBuilder) specImports(spec *ast.TypeSpec) map[string]string {
+	for _, file := range b.pkg.files {
+		if file.Pos() <= spec.Pos() && spec.End() <= file.End() {
+			return fileImports(file)
+		}
+	}
+
+	return map[string]string{}
+}
+
+// arrayLen evaluates the length of an array type, which is either
+// an integer literal or a constant
+func (b *abiBuilder) arrayLen(expr ast.Expr) int {
+	val, ok := b.constValue(expr)
+	if !ok {
+		return 0
+	}
+
+	n, err := strconv.ParseInt(val, 0, 32)
+	if err != nil {
+		return 0
+	}
+
+	return int(n)
+}
+
+// constValue returns the value of a literal, or of a constant with a literal value
+func (b *abiBuilder) constValue(expr ast.Expr) (string, bool) {
+	switch x := expr.(type) {
+	case *ast.BasicLit:
+		if x.Kind == token.STRING {
+			s, err := strconv.Unquote(x.Value)
+			return s, err == nil
+		}
+
+		return x.Value, true
+	case *ast.ParenExpr:
+		return b.constValue(x.X)
+	case *ast.Ident:
+		spec, ok := b.specs[x.Name]
+		if !ok {
+			return "", false
+		}
+
+		for i, name := range spec.Names {
+			if name.Name == x.Name && i < len(spec.Values) {
+				return b.constValue(spec.Values[i])
+			}
+		}
+	}
+
+	return "", false
+}
+
+// events finds the events emitted with std.Emit in the package functions
+func (b *abiBuilder) events() []*ABIEventDecl {
+	events := map[string]*ABIEventDecl{}
+
+	for _, file := range b.pkg.files {
+		stdName := ""
+		for name, impPath := range fileImports(file) {
+			if impPath == "std" {
+				stdName = name
+			}
+		}
+		if stdName == "" {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+
+			fnName := fn.Name.Name
+			if fn.Recv != nil {
+				fnName = typeExprString(fn.Recv.List[0].Type) + "." + fnName
+			}
+
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || !isSelector(call.Fun, stdName, "Emit") || len(call.Args) == 0 {
+					return true
+				}
+
+				typ, ok := b.constValue(call.Args[0])
+				if !ok {
+					return true // dynamic event types can't be described
+				}
+
+				event, ok := events[typ]
+				if !ok {
+					event = &ABIEventDecl{Type: typ, Attrs: []string{}, Funcs: []string{}}
+					events[typ] = event
+				}
+
+				if !slices.Contains(event.Funcs, fnName) {
+					event.Funcs = append(event.Funcs, fnName)
+				}
+
+				if call.Ellipsis.IsValid() {
+					event.Open = true
+					return true
+				}
+
+				for i := 1; i < len(call.Args); i += 2 {
+					key, ok := b.constValue(call.Args[i])
+					if !ok {
+						event.Open = true
+						continue
+					}
+
+					if !slices.Contains(event.Attrs, key) {
+						event.Attrs = append(event.Attrs, key)
+					}
+				}
+
+				return true
+			})
+		}
+	}
+
+	res := make([]*ABIEventDecl, 0, len(events))
+	for _, event := range events {
+		res = append(res, event)
+	}
+	slices.SortFunc(res, func(a, b *ABIEventDecl) int { return strings.Compare(a.Type, b.Type) })
+
+	return res
+}
+
+// isSelector returns true if expr is <x>.<sel>
+func isSelector(expr ast.Expr, x, sel string) bool {
+	s, ok := expr.(*ast.SelectorExpr)
+	if !ok || s.Sel.Name != sel {
+		return false
+	}
+
+	ident, ok := s.X.(*ast.Ident)
+	return ok && ident.Name == x
+}
+
+// embeddedName returns the field name of an embedded type
+func embeddedName(expr ast.Expr) string {
+	switch x := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(x.X)
+	case *ast.SelectorExpr:
+		return x.Sel.Name
+	case *ast.Ident:
+		return x.Name
+	}
+
+	return ""
+}
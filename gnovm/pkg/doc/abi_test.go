@@ -0,0 +1,108 @@
+package doc
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestABI(t *testing.T) {
+	dir, err := filepath.Abs("./testdata/abi/board")
+	require.NoError(t, err)
+	pkgPath := "gno.land/r/demo/board"
+
+	postID := &ABIType{Kind: ABIKindRef, Ref: pkgPath + ".PostID"}
+	post := &ABIType{Kind: ABIKindRef, Ref: pkgPath + ".Post"}
+	str := &ABIType{Kind: ABIKindString}
+
+	expected := &ABI{
+		Version: ABIVersion,
+		PkgPath: pkgPath,
+		Name:    "board",
+		Funcs: []*ABIFunc{
+			{
+				Name: "CreatePost",
+				Params: []*ABIField{
+					{Name: "title", Type: str},
+					{Name: "category", Type: str},
+					{Name: "tags", Type: &ABIType{Kind: ABIKindSlice, Elem: str}},
+				},
+				Results:  []*ABIField{{Type: postID}},
+				Variadic: true,
+			},
+			{
+				Name:    "GetPost",
+				Params:  []*ABIField{{Name: "id", Type: postID}},
+				Results: []*ABIField{{Type: post}, {Type: &ABIType{Kind: ABIKindBool}}},
+			},
+			{
+				Name:    "Stats",
+				Params:  []*ABIField{},
+				Results: []*ABIField{{Type: &ABIType{Kind: ABIKindRef, Ref: pkgPath + ".stats"}}},
+			},
+		},
+		Types: []*ABITypeDecl{
+			{
+				Name:     "Board",
+				Ref:      pkgPath + ".Board",
+				Exported: true,
+				Type: &ABIType{Kind: ABIKindStruct, Fields: []*ABIField{
+					{Name: "Name", Type: str},
+					{Name: "Posts", Type: &ABIType{Kind: ABIKindMap, Key: postID, Elem: post}},
+				}},
+			},
+			{
+				Name:     "Post",
+				Ref:      pkgPath + ".Post",
+				Exported: true,
+				Type: &ABIType{Kind: ABIKindStruct, Fields: []*ABIField{
+					{Name: "ID", Type: postID},
+					{Name: "Author", Type: &ABIType{Kind: ABIKindRef, Ref: "std.Address"}},
+					{Name: "Title", Type: str},
+					{Name: "Tags", Type: &ABIType{Kind: ABIKindArray, Elem: str, Len: 4}},
+					{Name: "Replies", Type: &ABIType{Kind: ABIKindSlice, Elem: &ABIType{Kind: ABIKindPointer, Elem: post}}},
+				}},
+			},
+			{
+				Name:     "PostID",
+				Ref:      pkgPath + ".PostID",
+				Exported: true,
+				Type:     &ABIType{Kind: ABIKindUint64},
+			},
+			{
+				Name:     "stats",
+				Ref:      pkgPath + ".stats",
+				Exported: false,
+				Type: &ABIType{Kind: ABIKindStruct, Fields: []*ABIField{
+					{Name: "Count", Type: &ABIType{Kind: ABIKindInt}},
+				}},
+			},
+		},
+		Events: []*ABIEventDecl{
+			{
+				Type:  "PostCreated",
+				Attrs: []string{"id", "title", "tags"},
+				Funcs: []string{"CreatePost", "Board.Delete"},
+			},
+			{
+				Type:  "PostDeleted",
+				Attrs: []string{},
+				Open:  true,
+				Funcs: []string{"Board.Delete"},
+			},
+		},
+	}
+
+	// Get the ABI similar to VMKeeper.QueryABI
+	memPkg, err := gnolang.ReadMemPackage(dir, pkgPath)
+	require.NoError(t, err)
+	d, err := NewDocumentableFromMemPkg(memPkg, false, "", "")
+	require.NoError(t, err)
+	abi, err := d.WriteABI()
+	require.NoError(t, err)
+
+	assert.Equal(t, expected.JSON(), abi.JSON())
+}
@@ -0,0 +1,605 @@
+package doc
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/doc/comment"
+	"go/scanner"
+	"go/token"
+	"html/template"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gnolang/gno/gnovm"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"go.uber.org/multierr"
+)
+
+//go:embed html/*
+var htmlFS embed.FS
+
+var htmlTemplates = template.Must(template.ParseFS(htmlFS, "html/*.html"))
+
+// HTMLOptions are the options of [WriteHTML].
+type HTMLOptions struct {
+	// Unexported documents unexported symbols as well as exported.
+	Unexported bool
+	// StdlibDir is the directory of the standard libraries. If set, the
+	// standard libraries imported by the documented packages are documented
+	// too, so that they can be linked.
+	StdlibDir string
+}
+
+// WriteHTML writes a static documentation site for pkgs to outDir.
+//
+// Each package is documented in <outDir>/<pkgpath>/index.html, where its
+// declarations link to the declarations of the other packages of the site.
+// <outDir>/index.html lists the packages, and can search their symbols with
+// the index of <outDir>/search-index.js.
+//
+// Packages which cannot be documented are skipped, and their errors are
+// returned in pkgErrs. err is set if the site itself cannot be written, in
+// which case it may be incomplete.
+func WriteHTML(outDir string, pkgs []*gnovm.MemPackage, opts *HTMLOptions) (pkgErrs, err error) {
+	if opts == nil {
+		opts = &HTMLOptions{}
+	}
+
+	site := &htmlSite{pkgs: make(map[string]*htmlPackage)}
+	for _, memPkg := range pkgs {
+		pkgErrs = multierr.Append(pkgErrs, site.add(memPkg, opts))
+	}
+	if opts.StdlibDir != "" {
+		site.addStdlibs(opts)
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return pkgErrs, err
+	}
+	var index []htmlSearchEntry
+	for _, pkgPath := range site.paths() {
+		hp := site.pkgs[pkgPath]
+		view := hp.view(site)
+		if err := writeHTMLTemplate(filepath.Join(outDir, filepath.FromSlash(pkgPath), "index.html"), "package.html", view); err != nil {
+			return pkgErrs, err
+		}
+		index = append(index, view.searchEntries()...)
+	}
+	if err := site.writeIndex(outDir, index); err != nil {
+		return pkgErrs, err
+	}
+
+	return pkgErrs, nil
+}
+
+// htmlSite holds the packages of a documentation site, by path.
+type htmlSite struct {
+	pkgs map[string]*htmlPackage
+}
+
+// htmlPackage is a package documented in a site.
+type htmlPackage struct {
+	path    string
+	stdlib  bool
+	data    *pkgData
+	doc     *doc.Package
+	imports map[string]string // import name -> path
+	names   map[string]bool   // documented top-level names
+}
+
+func (s *htmlSite) add(memPkg *gnovm.MemPackage, opts *HTMLOptions) error {
+	pd, err := newPkgDataFromMemPkg(memPkg, opts.Unexported)
+	if err != nil {
+		return err
+	}
+
+	var mode doc.Mode
+	if opts.Unexported {
+		mode = doc.AllDecls
+	}
+	_, p, err := pd.newDocPackage(memPkg.Path, mode)
+	if err != nil {
+		return err
+	}
+	classifyExamples(p, doc.Examples(pd.testFiles...))
+
+	hp := &htmlPackage{
+		path:    memPkg.Path,
+		stdlib:  gnolang.IsStdlib(memPkg.Path),
+		data:    pd,
+		doc:     p,
+		imports: make(map[string]string),
+		names:   make(map[string]bool),
+	}
+	for _, file := range pd.files {
+		for _, imp := range file.Imports {
+			impPath, _ := strconv.Unquote(imp.Path.Value)
+			name := path.Base(impPath)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			hp.imports[name] = impPath
+		}
+	}
+	for _, v := range slices.Concat(p.Consts, p.Vars) {
+		for _, name := range v.Names {
+			hp.names[name] = true
+		}
+	}
+	for _, f := range p.Funcs {
+		hp.names[f.Name] = true
+	}
+	for _, t := range p.Types {
+		hp.names[t.Name] = true
+		for _, v := range slices.Concat(t.Consts, t.Vars) {
+			for _, name := range v.Names {
+				hp.names[name] = true
+			}
+		}
+		for _, f := range t.Funcs {
+			hp.names[f.Name] = true
+		}
+	}
+
+	s.pkgs[hp.path] = hp
+	return nil
+}
+
+// addStdlibs adds the standard libraries imported by the packages of the
+// site, recursively.
+func (s *htmlSite) addStdlibs(opts *HTMLOptions) {
+	queue := s.paths()
+	for len(queue) > 0 {
+		hp := s.pkgs[queue[0]]
+		queue = queue[1:]
+		for _, impPath := range hp.importPaths() {
+			if s.pkgs[impPath] != nil || !gnolang.IsStdlib(impPath) {
+				continue
+			}
+			memPkg, err := gnolang.ReadMemPackage(filepath.Join(opts.StdlibDir, filepath.FromSlash(impPath)), impPath)
+			if err != nil {
+				// Not all stdlibs have a directory, like those only
+				// defined for tests.
+				continue
+			}
+			if err := s.add(memPkg, opts); err != nil {
+				continue
+			}
+			queue = append(queue, impPath)
+		}
+	}
+}
+
+// paths returns the sorted paths of the packages of the site.
+func (s *htmlSite) paths() []string {
+	paths := make([]string, 0, len(s.pkgs))
+	for p := range s.pkgs {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (hp *htmlPackage) importPaths() []string {
+	paths := make([]string, 0, len(hp.imports))
+	for _, p := range hp.imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// root returns the relative path from the page of hp to the root of the site.
+func (hp *htmlPackage) root() string {
+	return strings.Repeat("../", strings.Count(hp.path, "/")+1)
+}
+
+// url returns the url of the documentation of name in pkgPath, relative to
+// the page of hp. It returns "" if pkgPath is not part of the site.
+func (s *htmlSite) url(hp *htmlPackage, pkgPath, name string) string {
+	if pkgPath == "" || pkgPath == hp.path {
+		return "#" + name
+	}
+	if s.pkgs[pkgPath] == nil {
+		return ""
+	}
+	u := hp.root() + pkgPath + "/index.html"
+	if name != "" {
+		u += "#" + name
+	}
+	return u
+}
+
+// docHTML renders the doc comment text of hp, resolving its doc links to the
+// packages of the site.
+func (s *htmlSite) docHTML(hp *htmlPackage, text string) template.HTML {
+	if text == "" {
+		return ""
+	}
+	p := hp.doc.Printer()
+	p.HeadingLevel = 4
+	p.DocLinkURL = func(link *comment.DocLink) string {
+		name := link.Name
+		if link.Recv != "" {
+			name = link.Recv + "." + name
+		}
+		return s.url(hp, link.ImportPath, name)
+	}
+	return template.HTML(p.HTML(hp.doc.Parser().Parse(text)))
+}
+
+// codeHTML renders the Gno code src of hp, linking its identifiers to their
+// documentation.
+func (s *htmlSite) codeHTML(hp *htmlPackage, src string) template.HTML {
+	type tok struct {
+		off int
+		tok token.Token
+		lit string
+	}
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(src))
+	var sc scanner.Scanner
+	sc.Init(file, []byte(src), nil, scanner.ScanComments)
+	var toks []tok
+	for {
+		pos, t, lit := sc.Scan()
+		if t == token.EOF {
+			break
+		}
+		if t == token.SEMICOLON && lit == "\n" {
+			// Automatically inserted.
+			continue
+		}
+		toks = append(toks, tok{off: file.Offset(pos), tok: t, lit: lit})
+	}
+
+	var buf bytes.Buffer
+	last := 0
+	link := func(start, end int, url, class string) {
+		template.HTMLEscape(&buf, []byte(src[last:start]))
+		switch {
+		case url != "":
+			fmt.Fprintf(&buf, `<a href="%s">`, template.HTMLEscapeString(url))
+			template.HTMLEscape(&buf, []byte(src[start:end]))
+			buf.WriteString("</a>")
+		case class != "":
+			fmt.Fprintf(&buf, `<span class="%s">`, class)
+			template.HTMLEscape(&buf, []byte(src[start:end]))
+			buf.WriteString("</span>")
+		default:
+			template.HTMLEscape(&buf, []byte(src[start:end]))
+		}
+		last = end
+	}
+	for i := 0; i < len(toks); i++ {
+		t := toks[i]
+		switch {
+		case t.tok == token.COMMENT:
+			link(t.off, t.off+len(t.lit), "", "comment")
+		case t.tok != token.IDENT:
+		case i > 0 && toks[i-1].tok == token.PERIOD:
+			// Selector, like a field or a method.
+		case i+2 < len(toks) && toks[i+1].tok == token.PERIOD && toks[i+2].tok == token.IDENT && hp.imports[t.lit] != "":
+			sel := toks[i+2]
+			url := s.url(hp, hp.imports[t.lit], sel.lit)
+			link(t.off, sel.off+len(sel.lit), url, "")
+			i += 2
+		case hp.names[t.lit]:
+			link(t.off, t.off+len(t.lit), "#"+t.lit, "")
+		}
+	}
+	template.HTMLEscape(&buf, []byte(src[last:]))
+	return template.HTML(buf.String())
+}
+
+// classifyExamples associates the examples to the package, or to the
+// function, type or method they are named after, like go/doc does for Go test
+// files. Examples not matching any of them are ignored.
+func classifyExamples(p *doc.Package, examples []*doc.Example) {
+	funcs := make(map[string]*doc.Func)
+	types := make(map[string]*doc.Type)
+	for _, f := range p.Funcs {
+		funcs[f.Name] = f
+	}
+	for _, t := range p.Types {
+		types[t.Name] = t
+		for _, f := range t.Funcs {
+			funcs[f.Name] = f
+		}
+		for _, m := range t.Methods {
+			funcs[t.Name+"_"+m.Name] = m
+		}
+	}
+
+	for _, ex := range examples {
+		name := ex.Name
+		// The suffix of the example starts with a lowercase letter.
+		if i := strings.LastIndexByte(name, '_'); i >= 0 {
+			r, _ := utf8.DecodeRuneInString(name[i+1:])
+			if i+1 < len(name) && !unicode.IsUpper(r) {
+				name, ex.Suffix = name[:i], name[i+1:]
+			}
+		}
+		switch {
+		case name == "":
+			p.Examples = append(p.Examples, ex)
+		case funcs[name] != nil:
+			funcs[name].Examples = append(funcs[name].Examples, ex)
+		case types[name] != nil:
+			types[name].Examples = append(types[name].Examples, ex)
+		}
+	}
+}
+
+// Views of the documentation, executed by the templates.
+type (
+	htmlPackageView struct {
+		Root     string // relative path to the root of the site
+		Path     string
+		Name     string
+		Synopsis string
+		Doc      template.HTML
+		Examples []*htmlExampleView
+		Consts   []*htmlValueView
+		Vars     []*htmlValueView
+		Funcs    []*htmlFuncView
+		Types    []*htmlTypeView
+		Imports  []htmlLinkView
+		Files    []string
+	}
+
+	htmlValueView struct {
+		Names []string
+		Decl  template.HTML
+		Doc   template.HTML
+	}
+
+	htmlFuncView struct {
+		Name     string
+		Recv     string // receiver of methods, like *Type
+		ID       string // anchor, like Type.Method for methods
+		Decl     template.HTML
+		Doc      template.HTML
+		Examples []*htmlExampleView
+	}
+
+	htmlTypeView struct {
+		Name     string
+		Decl     template.HTML
+		Doc      template.HTML
+		Examples []*htmlExampleView
+		Consts   []*htmlValueView
+		Vars     []*htmlValueView
+		Funcs    []*htmlFuncView
+		Methods  []*htmlFuncView
+	}
+
+	htmlExampleView struct {
+		Name   string
+		ID     string
+		Doc    template.HTML
+		Code   template.HTML
+		Output string
+	}
+
+	htmlLinkView struct {
+		Name string
+		URL  string
+	}
+
+	htmlIndexView struct {
+		Root     string
+		Packages []htmlIndexPackage
+	}
+
+	htmlIndexPackage struct {
+		Path     string
+		Synopsis string
+		Stdlib   bool
+	}
+
+	// htmlSearchEntry is an entry of the search index.
+	htmlSearchEntry struct {
+		Name string `json:"name"`
+		Kind string `json:"kind"` // package, const, var, func, type or method
+		Pkg  string `json:"pkg"`
+		URL  string `json:"url"` // relative to the root of the site
+	}
+)
+
+func (hp *htmlPackage) view(s *htmlSite) *htmlPackageView {
+	fset := hp.data.fset
+	p := hp.doc
+	v := &htmlPackageView{
+		Root:     hp.root(),
+		Path:     hp.path,
+		Name:     p.Name,
+		Synopsis: p.Synopsis(p.Doc),
+		Doc:      s.docHTML(hp, p.Doc),
+		Examples: s.examplesView(hp, "", p.Examples),
+	}
+
+	values := func(vals []*doc.Value) []*htmlValueView {
+		res := make([]*htmlValueView, 0, len(vals))
+		for _, val := range vals {
+			res = append(res, &htmlValueView{
+				Names: val.Names,
+				Decl:  s.codeHTML(hp, mustFormatNode(fset, val.Decl)),
+				Doc:   s.docHTML(hp, val.Doc),
+			})
+		}
+		return res
+	}
+	funcs := func(recv string, fns []*doc.Func) []*htmlFuncView {
+		res := make([]*htmlFuncView, 0, len(fns))
+		for _, fn := range fns {
+			id := fn.Name
+			if recv != "" {
+				id = recv + "." + fn.Name
+			}
+			res = append(res, &htmlFuncView{
+				Name:     fn.Name,
+				Recv:     fn.Recv,
+				ID:       id,
+				Decl:     s.codeHTML(hp, mustFormatNode(fset, fn.Decl)),
+				Doc:      s.docHTML(hp, fn.Doc),
+				Examples: s.examplesView(hp, id, fn.Examples),
+			})
+		}
+		return res
+	}
+
+	v.Consts = values(p.Consts)
+	v.Vars = values(p.Vars)
+	v.Funcs = funcs("", p.Funcs)
+	for _, t := range p.Types {
+		v.Types = append(v.Types, &htmlTypeView{
+			Name:     t.Name,
+			Decl:     s.codeHTML(hp, mustFormatNode(fset, t.Decl)),
+			Doc:      s.docHTML(hp, t.Doc),
+			Examples: s.examplesView(hp, t.Name, t.Examples),
+			Consts:   values(t.Consts),
+			Vars:     values(t.Vars),
+			Funcs:    funcs("", t.Funcs),
+			Methods:  funcs(t.Name, t.Methods),
+		})
+	}
+	for _, impPath := range hp.importPaths() {
+		v.Imports = append(v.Imports, htmlLinkView{Name: impPath, URL: s.url(hp, impPath, "")})
+	}
+	for _, file := range hp.data.files {
+		v.Files = append(v.Files, fset.File(file.Pos()).Name())
+	}
+	sort.Strings(v.Files)
+
+	return v
+}
+
+func (s *htmlSite) examplesView(hp *htmlPackage, id string, examples []*doc.Example) []*htmlExampleView {
+	res := make([]*htmlExampleView, 0, len(examples))
+	for _, ex := range examples {
+		name := "Example"
+		if id != "" {
+			name += " (" + id + ")"
+		}
+		exID := "example-" + id
+		if ex.Suffix != "" {
+			name += " (" + ex.Suffix + ")"
+			exID += "-" + ex.Suffix
+		}
+		res = append(res, &htmlExampleView{
+			Name:   name,
+			ID:     exID,
+			Doc:    s.docHTML(hp, ex.Doc),
+			Code:   s.codeHTML(hp, exampleCode(hp.data.fset, ex)),
+			Output: ex.Output,
+		})
+	}
+	return res
+}
+
+// exampleCode returns the body of the example function, unindented.
+func exampleCode(fset *token.FileSet, ex *doc.Example) string {
+	code := mustFormatNode(fset, ex.Code)
+	if _, ok := ex.Code.(*ast.BlockStmt); !ok {
+		return code
+	}
+	code = strings.TrimSuffix(strings.TrimPrefix(code, "{"), "}")
+	lines := strings.Split(strings.Trim(code, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, "\t")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (v *htmlPackageView) searchEntries() []htmlSearchEntry {
+	url := v.Path + "/index.html"
+	entries := []htmlSearchEntry{{Name: v.Path, Kind: "package", Pkg: v.Path, URL: url}}
+	add := func(name, kind, id string) {
+		entries = append(entries, htmlSearchEntry{Name: v.Name + "." + name, Kind: kind, Pkg: v.Path, URL: url + "#" + id})
+	}
+	addValues := func(vals []*htmlValueView, kind string) {
+		for _, val := range vals {
+			for _, name := range val.Names {
+				add(name, kind, name)
+			}
+		}
+	}
+	addValues(v.Consts, "const")
+	addValues(v.Vars, "var")
+	for _, fn := range v.Funcs {
+		add(fn.Name, "func", fn.ID)
+	}
+	for _, t := range v.Types {
+		add(t.Name, "type", t.Name)
+		addValues(t.Consts, "const")
+		addValues(t.Vars, "var")
+		for _, fn := range t.Funcs {
+			add(fn.Name, "func", fn.ID)
+		}
+		for _, m := range t.Methods {
+			add(m.ID, "method", m.ID)
+		}
+	}
+	return entries
+}
+
+func (s *htmlSite) writeIndex(outDir string, index []htmlSearchEntry) error {
+	v := &htmlIndexView{}
+	for _, pkgPath := range s.paths() {
+		hp := s.pkgs[pkgPath]
+		v.Packages = append(v.Packages, htmlIndexPackage{
+			Path:     pkgPath,
+			Synopsis: hp.doc.Synopsis(hp.doc.Doc),
+			Stdlib:   hp.stdlib,
+		})
+	}
+	if err := writeHTMLTemplate(filepath.Join(outDir, "index.html"), "index.html", v); err != nil {
+		return err
+	}
+
+	// The index is a script rather than JSON, so that the site can be
+	// browsed from the filesystem.
+	bz, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	js := "var searchIndex = " + string(bz) + ";\n"
+	if err := os.WriteFile(filepath.Join(outDir, "search-index.js"), []byte(js), 0o644); err != nil {
+		return err
+	}
+
+	for _, name := range []string{"style.css", "search.js"} {
+		bz, err := htmlFS.ReadFile("html/" + name)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(outDir, name), bz, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeHTMLTemplate(fname, name string, data any) error {
+	var buf bytes.Buffer
+	if err := htmlTemplates.ExecuteTemplate(&buf, name, data); err != nil {
+		return fmt.Errorf("executing template %s: %w", name, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(fname), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(fname, buf.Bytes(), 0o644)
+}
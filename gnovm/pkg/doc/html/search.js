@@ -0,0 +1,54 @@
+// Search of the symbols of the documentation, with the index of
+// search-index.js.
+(function () {
+	var input = document.getElementById("search");
+	var results = document.getElementById("search-results");
+	var root = input.dataset.root;
+	var max = 50;
+
+	// score returns how well the entry matches q, or -1.
+	function score(entry, q) {
+		var name = entry.name.toLowerCase();
+		var i = name.indexOf(q);
+		if (i < 0) {
+			return -1;
+		}
+		if (name === q || name.endsWith("." + q)) {
+			return 0;
+		}
+		if (i === 0 || name.charAt(i - 1) === ".") {
+			return 1;
+		}
+		return 2;
+	}
+
+	input.addEventListener("input", function () {
+		var q = input.value.trim().toLowerCase();
+		results.textContent = "";
+		if (q === "" || typeof searchIndex === "undefined") {
+			return;
+		}
+		var matches = [];
+		for (var i = 0; i < searchIndex.length; i++) {
+			var s = score(searchIndex[i], q);
+			if (s >= 0) {
+				matches.push({ s: s, entry: searchIndex[i] });
+			}
+		}
+		matches.sort(function (a, b) {
+			return a.s - b.s || a.entry.name.length - b.entry.name.length;
+		});
+		matches.slice(0, max).forEach(function (m) {
+			var li = document.createElement("li");
+			var a = document.createElement("a");
+			a.href = root + m.entry.url;
+			a.textContent = m.entry.name;
+			var kind = document.createElement("span");
+			kind.className = "kind";
+			kind.textContent = m.entry.kind + " in " + m.entry.pkg;
+			li.appendChild(a);
+			li.appendChild(kind);
+			results.appendChild(li);
+		});
+	});
+})();
@@ -0,0 +1,87 @@
+package doc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/gnovm"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteHTML(t *testing.T) {
+	read := func(dir, pkgPath string) *gnovm.MemPackage {
+		memPkg, err := gnolang.ReadMemPackage(filepath.Join("testdata/html", dir), pkgPath)
+		require.NoError(t, err)
+		return memPkg
+	}
+	pkgs := []*gnovm.MemPackage{
+		read("shapes", "gno.land/p/demo/shapes"),
+		read("draw", "gno.land/p/demo/draw"),
+	}
+
+	outDir := t.TempDir()
+	pkgErrs, err := WriteHTML(outDir, pkgs, &HTMLOptions{StdlibDir: "testdata/html/stdlibs"})
+	require.NoError(t, pkgErrs)
+	require.NoError(t, err)
+
+	readFile := func(name string) string {
+		bz, err := os.ReadFile(filepath.Join(outDir, filepath.FromSlash(name)))
+		require.NoError(t, err)
+		return string(bz)
+	}
+
+	index := readFile("index.html")
+	assert.Contains(t, index, `<a href="gno.land/p/demo/draw/index.html">gno.land/p/demo/draw</a>`)
+	assert.Contains(t, index, `<a href="strings/index.html">strings</a></td><td>Package strings implements functions to manipulate strings.</td>`)
+	for _, name := range []string{"style.css", "search.js"} {
+		assert.FileExists(t, filepath.Join(outDir, name))
+	}
+
+	shapes := readFile("gno.land/p/demo/shapes/index.html")
+	assert.Contains(t, shapes, `<link rel="stylesheet" href="../../../../style.css">`)
+	assert.Contains(t, shapes, `<h3 id="Square.Area">func (*Square) <a href="#Square.Area">Area</a></h3>`)
+	assert.Contains(t, shapes, `func <a href="#NewSquare">NewSquare</a>(side int) *<a href="#Square">Square</a>`)
+	assert.Contains(t, shapes, `<p>Square is a <a href="#Shape">Shape</a>.`)
+	assert.NotContains(t, shapes, "unexported")
+
+	draw := readFile("gno.land/p/demo/draw/index.html")
+	// Doc links and declarations link to the other packages.
+	assert.Contains(t, draw, `<a href="../../../../gno.land/p/demo/shapes/index.html#Shape">shapes.Shape</a>`)
+	assert.Contains(t, draw, `func <a href="#Draw">Draw</a>(s <a href="../../../../gno.land/p/demo/shapes/index.html#Shape">shapes.Shape</a>) string`)
+	// Standard libraries are documented and linked.
+	assert.Contains(t, draw, `<a href="../../../../strings/index.html">strings</a>`)
+	assert.Contains(t, readFile("strings/index.html"), `<h3 id="Repeat">`)
+	// Examples are attached to their function.
+	assert.Contains(t, draw, `<details class="example" id="example-Draw">`)
+	assert.Contains(t, draw, `println(<a href="#Draw">Draw</a>(<a href="../../../../gno.land/p/demo/shapes/index.html#NewSquare">shapes.NewSquare</a>(2)))`)
+	assert.Contains(t, draw, `<pre class="output">****`)
+
+	searchIndex := readFile("search-index.js")
+	assert.True(t, strings.HasPrefix(searchIndex, "var searchIndex = ["))
+	for _, entry := range []string{
+		`{"name":"gno.land/p/demo/shapes","kind":"package","pkg":"gno.land/p/demo/shapes","url":"gno.land/p/demo/shapes/index.html"}`,
+		`{"name":"shapes.NewSquare","kind":"func","pkg":"gno.land/p/demo/shapes","url":"gno.land/p/demo/shapes/index.html#NewSquare"}`,
+		`{"name":"shapes.Square.Area","kind":"method","pkg":"gno.land/p/demo/shapes","url":"gno.land/p/demo/shapes/index.html#Square.Area"}`,
+		`{"name":"strings.Repeat","kind":"func","pkg":"strings","url":"strings/index.html#Repeat"}`,
+	} {
+		assert.Contains(t, searchIndex, entry)
+	}
+}
+
+func TestWriteHTML_Unexported(t *testing.T) {
+	memPkg, err := gnolang.ReadMemPackage("testdata/html/shapes", "gno.land/p/demo/shapes")
+	require.NoError(t, err)
+
+	outDir := t.TempDir()
+	pkgErrs, err := WriteHTML(outDir, []*gnovm.MemPackage{memPkg}, &HTMLOptions{Unexported: true})
+	require.NoError(t, pkgErrs)
+	require.NoError(t, err)
+
+	bz, err := os.ReadFile(filepath.Join(outDir, "gno.land/p/demo/shapes/index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(bz), `<h3 id="unexported">`)
+}
@@ -15,6 +15,7 @@ import (
 
 type pkgData struct {
 	name      string
+	path      string // import path
 	dir       bfsDir
 	fset      *token.FileSet
 	files     []*ast.File
@@ -51,6 +52,7 @@ func newPkgDataFromMemPkg(memPkg *gnovm.MemPackage, unexported bool) (*pkgData,
 			importPath: memPkg.Name,
 			dir:        memPkg.Path,
 		},
+		path: memPkg.Path,
 		fset: token.NewFileSet(),
 	}
 	for _, file := range memPkg.Files {
@@ -187,18 +189,6 @@ func typeExprString(expr ast.Expr) string {
 }
 
 func (pkg *pkgData) docPackage(opts *WriteDocumentationOptions) (*ast.Package, *doc.Package, error) {
-	// largely taken from go/doc.NewFromFiles source
-
-	// Collect .gno files in a map for ast.NewPackage.
-	fileMap := make(map[string]*ast.File)
-	for i, file := range pkg.files {
-		f := pkg.fset.File(file.Pos())
-		if f == nil {
-			return nil, nil, fmt.Errorf("commands/doc: file pkg.files[%d] is not found in the provided file set", i)
-		}
-		fileMap[f.Name()] = file
-	}
-
 	// from cmd/doc/pkg.go:
 	// go/doc does not include typed constants in the constants
 	// list, which is what we want. For instance, time.Sunday is of type
@@ -211,10 +201,28 @@ func (pkg *pkgData) docPackage(opts *WriteDocumentationOptions) (*ast.Package, *
 		mode |= doc.PreserveAST
 	}
 
+	return pkg.newDocPackage(pkg.dir.importPath, mode)
+}
+
+// newDocPackage computes the documentation of pkg, with the given import
+// path and mode.
+func (pkg *pkgData) newDocPackage(importPath string, mode doc.Mode) (*ast.Package, *doc.Package, error) {
+	// largely taken from go/doc.NewFromFiles source
+
+	// Collect .gno files in a map for ast.NewPackage.
+	fileMap := make(map[string]*ast.File)
+	for i, file := range pkg.files {
+		f := pkg.fset.File(file.Pos())
+		if f == nil {
+			return nil, nil, fmt.Errorf("commands/doc: file pkg.files[%d] is not found in the provided file set", i)
+		}
+		fileMap[f.Name()] = file
+	}
+
 	// Compute package documentation.
 	// Assign to blank to ignore errors that can happen due to unresolved identifiers.
 	astpkg, _ := ast.NewPackage(pkg.fset, fileMap, simpleImporter, nil)
-	p := doc.New(astpkg, pkg.dir.importPath, mode)
+	p := doc.New(astpkg, importPath, mode)
 	// TODO: classifyExamples(p, Examples(testGoFiles...))
 
 	return astpkg, p, nil
@@ -0,0 +1,51 @@
+package gnolang
+
+// Coverage records the code paths executed by a [Machine], as the edges
+// between the statements it executes one after the other. It is used to
+// guide fuzzing, keeping the inputs that exercise new edges.
+//
+// Coverage is enabled by setting [Machine.Coverage]; it is not safe for
+// concurrent use.
+type Coverage struct {
+	edges map[coverageEdge]struct{}
+	last  Stmt
+}
+
+type coverageEdge struct {
+	from, to Stmt
+}
+
+// NewCoverage returns an empty Coverage.
+func NewCoverage() *Coverage {
+	return &Coverage{edges: make(map[coverageEdge]struct{})}
+}
+
+// hit records the execution of s.
+func (c *Coverage) hit(s Stmt) {
+	c.edges[coverageEdge{from: c.last, to: s}] = struct{}{}
+	c.last = s
+}
+
+// Len returns the number of edges recorded.
+func (c *Coverage) Len() int {
+	return len(c.edges)
+}
+
+// Reset clears the recorded edges.
+func (c *Coverage) Reset() {
+	clear(c.edges)
+	c.last = nil
+}
+
+// Merge adds the edges recorded by other to c, and returns the number of
+// edges that c didn't have.
+func (c *Coverage) Merge(other *Coverage) int {
+	added := 0
+	for edge := range other.edges {
+		if _, ok := c.edges[edge]; !ok {
+			c.edges[edge] = struct{}{}
+			added++
+		}
+	}
+	return added
+}
@@ -0,0 +1,68 @@
+package gnolang
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/gnovm"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	stypes "github.com/gnolang/gno/tm2/pkg/store/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoverage(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewMemDB()
+	baseStore := dbadapter.StoreConstructor(db, stypes.StoreOptions{})
+	iavlStore := iavl.StoreConstructor(db, stypes.StoreOptions{})
+	store := NewStore(nil, baseStore, iavlStore)
+	m := NewMachine("cov", store)
+	m.RunMemPackage(&gnovm.MemPackage{
+		Name: "cov",
+		Path: "cov",
+		Files: []*gnovm.MemFile{
+			{Name: "a.gno", Body: `package cov
+var x int
+func Set(v int) { x = v }
+func Sign(x int) int {
+	if x < 0 {
+		return -1
+	}
+	if x > 0 {
+		return 1
+	}
+	return 0
+}`},
+		},
+	}, true)
+
+	// The same statement is run each time, so that only the path taken by
+	// Sign differs.
+	call := S(Call(X("Sign"), X("x")))
+	run := func(x int) *Coverage {
+		m.RunStatement(S(Call(X("Set"), x)))
+
+		cov := NewCoverage()
+		m.Coverage = cov
+		defer func() { m.Coverage = nil }()
+		m.RunStatement(call)
+		return cov
+	}
+
+	total := NewCoverage()
+	pos := run(1)
+	assert.NotZero(t, pos.Len())
+	assert.Equal(t, pos.Len(), total.Merge(pos))
+
+	// Same path: no new edges.
+	assert.Zero(t, total.Merge(run(2)))
+
+	// Other paths add edges.
+	assert.NotZero(t, total.Merge(run(-1)))
+	assert.NotZero(t, total.Merge(run(0)))
+
+	pos.Reset()
+	assert.Zero(t, pos.Len())
+}
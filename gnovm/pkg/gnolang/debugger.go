@@ -59,6 +59,7 @@ type Debugger struct {
 	nextDepth   int                         // function call depth at the 'next' command
 	getSrc      func(string, string) string // helper to access source from repl or others
 	rootDir     string
+	dap         *DAPSession // if set, the debugger is driven by a DAP client instead of commands
 }
 
 // Enable makes the debugger d active, using in as input reader, out as output writer and f as a source helper.
@@ -137,14 +138,27 @@ loop:
 		switch m.Debugger.state {
 		case DebugAtInit:
 			debugUpdateLocation(m)
+			if m.Debugger.dap != nil {
+				m.Debugger.dap.init(m)
+				continue loop
+			}
 			fmt.Fprintln(m.Debugger.out, "Welcome to the Gnovm debugger. Type 'help' for list of commands.")
 			m.Debugger.scanner = bufio.NewScanner(m.Debugger.in)
 			m.Debugger.state = DebugAtCmd
 		case DebugAtCmd:
-			if err := debugCmd(m); err != nil {
+			if m.Debugger.dap != nil {
+				m.Debugger.dap.cmd(m)
+			} else if err := debugCmd(m); err != nil {
 				fmt.Fprintln(m.Debugger.out, "Command failed:", err)
 			}
 		case DebugAtRun:
+			if m.Debugger.dap != nil {
+				// Handle the requests received while running, like pause.
+				m.Debugger.dap.poll(m)
+				if m.Debugger.state != DebugAtRun {
+					continue loop
+				}
+			}
 			if !m.Debugger.enabled {
 				break loop
 			}
@@ -153,10 +167,12 @@ loop:
 				m.Debugger.state = DebugAtCmd
 				debugLineInfo(m)
 			case "s", "step":
-				if m.Debugger.loc != m.Debugger.prevLoc && m.Debugger.loc.File != "" {
+				// DAP clients step by line rather than by expression.
+				if m.Debugger.loc != m.Debugger.prevLoc && m.Debugger.loc.File != "" &&
+					(m.Debugger.dap == nil || !sameLine(m.Debugger.loc, m.Debugger.prevLoc)) {
 					m.Debugger.state = DebugAtCmd
 					m.Debugger.prevLoc = m.Debugger.loc
-					debugList(m, "")
+					debugStop(m, "step")
 					continue loop
 				}
 			case "n", "next":
@@ -164,21 +180,21 @@ loop:
 					(m.Debugger.nextDepth == 0 || !sameLine(m.Debugger.loc, m.Debugger.nextLoc) && callDepth(m) <= m.Debugger.nextDepth) {
 					m.Debugger.state = DebugAtCmd
 					m.Debugger.prevLoc = m.Debugger.loc
-					debugList(m, "")
+					debugStop(m, "step")
 					continue loop
 				}
 			case "stepout", "so":
 				if callDepth(m) < m.Debugger.nextDepth {
 					m.Debugger.state = DebugAtCmd
 					m.Debugger.prevLoc = m.Debugger.loc
-					debugList(m, "")
+					debugStop(m, "step")
 					continue loop
 				}
 			default:
 				if atBreak(m) {
 					m.Debugger.state = DebugAtCmd
 					m.Debugger.prevLoc = m.Debugger.loc
-					debugList(m, "")
+					debugStop(m, "breakpoint")
 					continue loop
 				}
 			}
@@ -217,8 +233,21 @@ func sameLine(loc1, loc2 Location) bool {
 	return loc1.PkgPath == loc2.PkgPath && loc1.File == loc2.File && loc1.Line == loc2.Line
 }
 
+// debugStop reports that the program stopped at the current location, for
+// the given reason.
+func debugStop(m *Machine, reason string) {
+	if m.Debugger.dap != nil {
+		m.Debugger.dap.stopped(reason)
+		return
+	}
+	debugList(m, "")
+}
+
 // atBreak returns true if current machine location matches a breakpoint, false otherwise.
 func atBreak(m *Machine) bool {
+	if m.Debugger.dap != nil {
+		return m.Debugger.dap.atBreak(m)
+	}
 	loc := m.Debugger.loc
 	if loc == m.Debugger.prevLoc {
 		return false
@@ -642,10 +671,12 @@ func debugPrint(m *Machine, arg string) (err error) {
 // debugEvalExpr evaluates a Go expression in the context of the VM and returns
 // the corresponding typed value, or an error.
 // The supported expression syntax is a small subset of Go expressions:
-// basic literals, identifiers, selectors, index expressions, or a combination
-// of those are supported, but none of function calls, arithmetic, logic or
-// assign operations, type assertions of convertions.
-// This is sufficient for a debugger to perform 'print (*f).S[x][y]' for example.
+// basic literals, identifiers, selectors, index expressions, comparisons,
+// logical operations, or a combination of those are supported, but none of
+// function calls, arithmetic or assign operations, type assertions or
+// conversions.
+// This is sufficient for a debugger to perform 'print (*f).S[x][y]', or to
+// evaluate a breakpoint condition like 'i > 2 && s.Name == "foo"' for example.
 func debugEvalExpr(m *Machine, node ast.Node) (tv TypedValue, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -680,7 +711,24 @@ func debugEvalExpr(m *Machine, node ast.Node) (tv TypedValue, err error) {
 		if tv, ok := debugLookup(m, n.Name); ok {
 			return tv, nil
 		}
+		switch n.Name {
+		case "true", "false":
+			return untypedBool(n.Name == "true"), nil
+		case "nil":
+			return tv, nil
+		}
 		return tv, fmt.Errorf("could not find symbol value for %s", n.Name)
+	case *ast.UnaryExpr:
+		if n.Op != token.NOT {
+			return tv, fmt.Errorf("expression not supported: %v", n)
+		}
+		x, err := debugEvalBool(m, n.X)
+		if err != nil {
+			return tv, err
+		}
+		return untypedBool(!x), nil
+	case *ast.BinaryExpr:
+		return debugEvalBinary(m, n)
 	case *ast.ParenExpr:
 		return debugEvalExpr(m, n.X)
 	case *ast.StarExpr:
@@ -728,10 +776,126 @@ func debugEvalExpr(m *Machine, node ast.Node) (tv TypedValue, err error) {
 	return tv, err
 }
 
+// debugEvalBool evaluates the boolean Go expression node.
+func debugEvalBool(m *Machine, node ast.Expr) (bool, error) {
+	tv, err := debugEvalExpr(m, node)
+	if err != nil {
+		return false, err
+	}
+	if tv.T == nil || tv.T.Kind() != BoolKind {
+		return false, fmt.Errorf("not a boolean value: %v", tv)
+	}
+	return tv.GetBool(), nil
+}
+
+// debugEvalBinary evaluates a comparison or a logical operation.
+func debugEvalBinary(m *Machine, n *ast.BinaryExpr) (tv TypedValue, err error) {
+	switch n.Op {
+	case token.LAND, token.LOR:
+		x, err := debugEvalBool(m, n.X)
+		if err != nil {
+			return tv, err
+		}
+		if x == (n.Op == token.LOR) {
+			return untypedBool(x), nil
+		}
+		y, err := debugEvalBool(m, n.Y)
+		if err != nil {
+			return tv, err
+		}
+		return untypedBool(y), nil
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+	default:
+		return tv, fmt.Errorf("expression not supported: %v", n)
+	}
+
+	x, err := debugEvalExpr(m, n.X)
+	if err != nil {
+		return tv, err
+	}
+	y, err := debugEvalExpr(m, n.Y)
+	if err != nil {
+		return tv, err
+	}
+
+	// Comparison with nil.
+	if x.T == nil || y.T == nil {
+		if n.Op != token.EQL && n.Op != token.NEQ {
+			return tv, errors.New("invalid comparison with nil")
+		}
+		for _, t := range []Type{x.T, y.T} {
+			if t != nil && !maybeNil(t) {
+				return tv, fmt.Errorf("mismatched types %s and nil", t)
+			}
+		}
+		isNil := x.T == nil && y.T == nil || x.T == nil && y.V == nil || y.T == nil && x.V == nil
+		return untypedBool(isNil == (n.Op == token.EQL)), nil
+	}
+
+	// Literals, which are typed by debugEvalExpr, and untyped values take
+	// the type of the other operand, like untyped constants.
+	if _, ok := n.X.(*ast.BasicLit); (ok || isUntyped(x.T)) && !isUntyped(y.T) {
+		ConvertTo(m.Alloc, m.Store, &x, y.T, false)
+	} else if _, ok := n.Y.(*ast.BasicLit); (ok || isUntyped(y.T)) && !isUntyped(x.T) {
+		ConvertTo(m.Alloc, m.Store, &y, x.T, false)
+	}
+	if x.T.TypeID() != y.T.TypeID() {
+		return tv, fmt.Errorf("mismatched types %s and %s", x.T, y.T)
+	}
+
+	var res bool
+	switch n.Op {
+	case token.EQL:
+		res = isEql(m.Store, &x, &y)
+	case token.NEQ:
+		res = !isEql(m.Store, &x, &y)
+	case token.LSS:
+		res = isLss(&x, &y)
+	case token.LEQ:
+		res = isLeq(&x, &y)
+	case token.GTR:
+		res = isGtr(&x, &y)
+	case token.GEQ:
+		res = isGeq(&x, &y)
+	}
+	return untypedBool(res), nil
+}
+
 // debugLookup returns the current VM value corresponding to name ident in
 // the current function call frame, or the global frame if not found.
 // Note: the commands 'up' and 'down' change the frame level to start from.
 func debugLookup(m *Machine, name string) (tv TypedValue, ok bool) {
+	sblocks := debugFrameBlocks(m, m.Debugger.frameLevel)
+	if len(sblocks) == 0 {
+		return tv, false
+	}
+
+	// Search value in current frame level blocks, or main scope.
+	for _, b := range sblocks {
+		switch t := b.Source.(type) {
+		case *IfStmt:
+			for i, s := range ifBody(m, t).Source.GetBlockNames() {
+				if string(s) == name {
+					return b.Values[i], true
+				}
+			}
+		}
+		for i, s := range b.Source.GetBlockNames() {
+			if string(s) == name {
+				return b.Values[i], true
+			}
+		}
+	}
+	// Fallback: search a global value.
+	if v := sblocks[0].Source.GetValueRef(m.Store, Name(name), true); v != nil {
+		return *v, true
+	}
+	return tv, false
+}
+
+// debugFrameBlocks returns the blocks of the function call frame at level,
+// from the innermost, followed by the global block.
+func debugFrameBlocks(m *Machine, level int) []*Block {
 	// Position to the right frame.
 	ncall := 0
 	var i int
@@ -741,7 +905,7 @@ func debugLookup(m *Machine, name string) (tv TypedValue, ok bool) {
 		if m.Frames[i].Func != nil {
 			funBlock = m.Frames[i].Func.Source
 		}
-		if ncall == m.Debugger.frameLevel {
+		if ncall == level {
 			break
 		}
 		if m.Frames[i].Func != nil {
@@ -750,7 +914,7 @@ func debugLookup(m *Machine, name string) (tv TypedValue, ok bool) {
 		}
 	}
 	if i < 0 {
-		return tv, false
+		return nil
 	}
 
 	// Position to the right block, i.e the first after the last fblock (if any).
@@ -763,7 +927,7 @@ func debugLookup(m *Machine, name string) (tv TypedValue, ok bool) {
 		}
 	}
 	if i < 0 {
-		return tv, false
+		return nil
 	}
 
 	// get SourceBlocks in the same frame level.
@@ -777,28 +941,7 @@ func debugLookup(m *Machine, name string) (tv TypedValue, ok bool) {
 	if i > 0 {
 		sblocks = append(sblocks, m.Blocks[0]) // Add global block
 	}
-
-	// Search value in current frame level blocks, or main scope.
-	for _, b := range sblocks {
-		switch t := b.Source.(type) {
-		case *IfStmt:
-			for i, s := range ifBody(m, t).Source.GetBlockNames() {
-				if string(s) == name {
-					return b.Values[i], true
-				}
-			}
-		}
-		for i, s := range b.Source.GetBlockNames() {
-			if string(s) == name {
-				return b.Values[i], true
-			}
-		}
-	}
-	// Fallback: search a global value.
-	if v := sblocks[0].Source.GetValueRef(m.Store, Name(name), true); v != nil {
-		return *v, true
-	}
-	return tv, false
+	return sblocks
 }
 
 // ifBody returns the Then or Else body corresponding to the current location.
@@ -823,18 +966,20 @@ func debugStack(m *Machine, arg string) error {
 		if ff == nil {
 			break
 		}
-		var fname string
-		if ff.IsMethod {
-			fname = fmt.Sprintf("%v.(%v).%v", ff.PkgPath, ff.Type.(*FuncType).Params[0].Type, ff.Name)
-		} else {
-			fname = fmt.Sprintf("%v.%v", ff.PkgPath, ff.Name)
-		}
-		fmt.Fprintf(m.Debugger.out, "%d\tin %s\n\tat %s\n", i, fname, loc)
+		fmt.Fprintf(m.Debugger.out, "%d\tin %s\n\tat %s\n", i, debugFuncName(ff), loc)
 		i++
 	}
 	return nil
 }
 
+// debugFuncName returns the qualified name of function ff, like pkg.(T).Method.
+func debugFuncName(ff *FuncValue) string {
+	if ff.IsMethod {
+		return fmt.Sprintf("%v.(%v).%v", ff.PkgPath, ff.Type.(*FuncType).Params[0].Type, ff.Name)
+	}
+	return fmt.Sprintf("%v.%v", ff.PkgPath, ff.Name)
+}
+
 func debugFrameFunc(m *Machine, n int) *FuncValue {
 	for ncall, i := 0, len(m.Frames)-1; i >= 0; i-- {
 		f := m.Frames[i]
@@ -0,0 +1,945 @@
+package gnolang
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+)
+
+// DAPSession is a debugging session driven by a client of the Debug Adapter
+// Protocol (https://microsoft.github.io/debug-adapter-protocol/), like an IDE.
+//
+// The session maps the requests of the client to the state of the Debugger:
+// breakpoints by file and line, possibly conditional, stepping, stack traces,
+// scopes and variables. It can be shared by the successive machines of a
+// program, like those running each test of gno test: the breakpoints of the
+// client then apply to all of them.
+//
+// The program has a single thread, with id dapThreadID.
+type DAPSession struct {
+	conn io.ReadWriteCloser
+	reqs chan *dapRequest // requests read from conn
+	seq  int              // sequence number of the last message sent
+
+	rootDir     string
+	srcDirs     map[string]string                // source directories, by package path
+	paths       map[Location]string              // cache of source paths, by location of file
+	breakpoints map[string][]dapSourceBreakpoint // breakpoints, by source path
+
+	configured  bool // the client is done with the initial configuration
+	stopOnEntry bool // stop when the program starts
+	closed      bool
+
+	// References to variables and sources, valid while the program is
+	// stopped. Reference i+1 is at index i.
+	refs    []func() []dapVariable
+	sources []Location
+}
+
+const (
+	dapThreadID    = 1
+	dapMaxChildren = 1000 // maximum number of children of a variable
+)
+
+// NewDAPSession returns a DAP session with the client connected to conn.
+func NewDAPSession(conn io.ReadWriteCloser) *DAPSession {
+	s := &DAPSession{
+		conn:        conn,
+		reqs:        make(chan *dapRequest),
+		rootDir:     gnoenv.RootDir(),
+		srcDirs:     make(map[string]string),
+		paths:       make(map[Location]string),
+		breakpoints: make(map[string][]dapSourceBreakpoint),
+	}
+	go s.read()
+	return s
+}
+
+// ListenDAP waits for a DAP client to connect to addr, and returns the
+// session with this client.
+func ListenDAP(addr string) (*DAPSession, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+	print("Waiting for DAP client to connect at ", addr)
+	conn, err := l.Accept()
+	if err != nil {
+		return nil, err
+	}
+	println(" connected!")
+	return NewDAPSession(conn), nil
+}
+
+// SetSourceDir sets the directory holding the source files of pkgPath, for
+// packages whose files are not found from their location, like the packages
+// tested by gno test.
+func (s *DAPSession) SetSourceDir(pkgPath, dir string) {
+	s.srcDirs[pkgPath] = dir
+}
+
+// Close notifies the client that the program terminated, and closes the
+// connection.
+func (s *DAPSession) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.event("terminated", nil)
+	return s.conn.Close()
+}
+
+// EnableDAP makes the debugger d active, driven by the client of session s.
+func (d *Debugger) EnableDAP(s *DAPSession) {
+	d.enabled = true
+	d.state = DebugAtInit
+	d.rootDir = gnoenv.RootDir()
+	d.dap = s
+}
+
+// ----------------------------------------
+// Protocol messages
+
+type dapRequest struct {
+	Seq       int             `json:"seq"`
+	Type      string          `json:"type"`
+	Command   string          `json:"command"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+type dapResponse struct {
+	Seq        int    `json:"seq"`
+	Type       string `json:"type"`
+	RequestSeq int    `json:"request_seq"`
+	Success    bool   `json:"success"`
+	Command    string `json:"command"`
+	Message    string `json:"message,omitempty"`
+	Body       any    `json:"body,omitempty"`
+}
+
+type dapEvent struct {
+	Seq   int    `json:"seq"`
+	Type  string `json:"type"`
+	Event string `json:"event"`
+	Body  any    `json:"body,omitempty"`
+}
+
+type dapSource struct {
+	Name            string `json:"name,omitempty"`
+	Path            string `json:"path,omitempty"`
+	SourceReference int    `json:"sourceReference,omitempty"`
+}
+
+type dapSourceBreakpoint struct {
+	line      int
+	condition ast.Expr // nil if unconditional
+}
+
+type dapBreakpoint struct {
+	Verified bool   `json:"verified"`
+	Line     int    `json:"line"`
+	Message  string `json:"message,omitempty"`
+}
+
+type dapStackFrame struct {
+	ID     int        `json:"id"`
+	Name   string     `json:"name"`
+	Source *dapSource `json:"source,omitempty"`
+	Line   int        `json:"line"`
+	Column int        `json:"column"`
+}
+
+type dapScope struct {
+	Name               string `json:"name"`
+	VariablesReference int    `json:"variablesReference"`
+	Expensive          bool   `json:"expensive"`
+}
+
+type dapVariable struct {
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	Type               string `json:"type,omitempty"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+// read reads the requests of the client, until the connection is closed.
+func (s *DAPSession) read() {
+	defer close(s.reqs)
+	r := bufio.NewReader(s.conn)
+	for {
+		req, err := readDAPRequest(r)
+		if err != nil {
+			return
+		}
+		s.reqs <- req
+	}
+}
+
+// readDAPRequest reads a message made of a Content-Length header and a JSON
+// body.
+func readDAPRequest(r *bufio.Reader) (*dapRequest, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if length >= 0 {
+				break
+			}
+			continue
+		}
+		if v, ok := strings.CutPrefix(line, "Content-Length:"); ok {
+			if length, err = strconv.Atoi(strings.TrimSpace(v)); err != nil {
+				return nil, fmt.Errorf("invalid Content-Length: %w", err)
+			}
+		}
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	req := &dapRequest{}
+	if err := json.Unmarshal(buf, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (s *DAPSession) send(msg any) {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err) // should not happen, messages are plain structs.
+	}
+	// Errors are ignored: the session ends when the client disconnects.
+	fmt.Fprintf(s.conn, "Content-Length: %d\r\n\r\n%s", len(bz), bz)
+}
+
+func (s *DAPSession) respond(req *dapRequest, body any) {
+	s.seq++
+	s.send(&dapResponse{Seq: s.seq, Type: "response", RequestSeq: req.Seq, Success: true, Command: req.Command, Body: body})
+}
+
+func (s *DAPSession) fail(req *dapRequest, err error) {
+	s.seq++
+	s.send(&dapResponse{Seq: s.seq, Type: "response", RequestSeq: req.Seq, Success: false, Command: req.Command, Message: err.Error()})
+}
+
+func (s *DAPSession) event(name string, body any) {
+	s.seq++
+	s.send(&dapEvent{Seq: s.seq, Type: "event", Event: name, Body: body})
+}
+
+func (s *DAPSession) output(msg string) {
+	s.event("output", map[string]string{"category": "console", "output": msg})
+}
+
+// ----------------------------------------
+// Debugger states
+
+// init starts the debugging of m: the program waits for the configuration of
+// the client, or runs if it is already done.
+func (s *DAPSession) init(m *Machine) {
+	if !s.configured {
+		m.Debugger.state = DebugAtCmd
+		return
+	}
+	s.resume(m, "continue")
+}
+
+// cmd waits for a request of the client and handles it.
+func (s *DAPSession) cmd(m *Machine) {
+	req, ok := <-s.reqs
+	if !ok {
+		// The client is gone, resume the program.
+		s.detach(m)
+		return
+	}
+	s.handle(m, req)
+}
+
+// poll handles the pending requests of the client, without waiting.
+func (s *DAPSession) poll(m *Machine) {
+	for {
+		select {
+		case req, ok := <-s.reqs:
+			if !ok {
+				s.detach(m)
+				return
+			}
+			s.handle(m, req)
+			if m.Debugger.state != DebugAtRun || !m.Debugger.enabled {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// resume runs the program until the condition of the debugger command cmd,
+// like "continue" or "next".
+func (s *DAPSession) resume(m *Machine, cmd string) {
+	s.refs, s.sources = nil, nil
+	m.Debugger.lastCmd = cmd
+	debugContinue(m, "")
+}
+
+// stopped notifies the client that the program stopped for reason.
+func (s *DAPSession) stopped(reason string) {
+	s.event("stopped", map[string]any{
+		"reason":            reason,
+		"threadId":          dapThreadID,
+		"allThreadsStopped": true,
+	})
+}
+
+// detach ends the session and lets the program run to completion.
+func (s *DAPSession) detach(m *Machine) {
+	m.Debugger.enabled = false
+	m.Debugger.state = DebugAtRun
+	if !s.closed {
+		s.closed = true
+		s.conn.Close()
+	}
+}
+
+// atBreak returns true if the current location of m is at a breakpoint
+// whose condition, if any, is true.
+func (s *DAPSession) atBreak(m *Machine) bool {
+	loc := m.Debugger.loc
+	if len(s.breakpoints) == 0 || loc.File == "" || sameLine(loc, m.Debugger.prevLoc) {
+		return false
+	}
+	for _, b := range s.breakpoints[s.sourcePath(loc)] {
+		if b.line != loc.Line {
+			continue
+		}
+		if b.condition == nil {
+			return true
+		}
+		ok, err := debugEvalBool(m, b.condition)
+		if err != nil {
+			// Stop, so that the user can fix the condition.
+			s.output(fmt.Sprintf("error evaluating breakpoint condition at %s: %v\n", loc, err))
+			return true
+		}
+		return ok
+	}
+	return false
+}
+
+// ----------------------------------------
+// Requests
+
+func (s *DAPSession) handle(m *Machine, req *dapRequest) {
+	var err error
+	switch req.Command {
+	case "initialize":
+		s.respond(req, map[string]bool{
+			"supportsConfigurationDoneRequest": true,
+			"supportsConditionalBreakpoints":   true,
+			"supportsEvaluateForHovers":        true,
+			"supportsTerminateRequest":         true,
+		})
+		s.event("initialized", nil)
+	case "launch", "attach":
+		// The program is given on the command line; only the options of
+		// the debugger are read.
+		var args struct {
+			StopOnEntry bool `json:"stopOnEntry"`
+		}
+		if err = unmarshalDAPArgs(req, &args); err == nil {
+			s.stopOnEntry = args.StopOnEntry
+			s.respond(req, nil)
+		}
+	case "setBreakpoints":
+		err = s.setBreakpoints(req)
+	case "setExceptionBreakpoints", "setFunctionBreakpoints":
+		s.respond(req, map[string]any{"breakpoints": []dapBreakpoint{}})
+	case "configurationDone":
+		s.configured = true
+		s.respond(req, nil)
+		if s.stopOnEntry {
+			m.Debugger.state = DebugAtCmd
+			s.stopped("entry")
+		} else {
+			s.resume(m, "continue")
+		}
+	case "threads":
+		s.respond(req, map[string]any{"threads": []map[string]any{{"id": dapThreadID, "name": "main"}}})
+	case "stackTrace":
+		err = s.stackTrace(m, req)
+	case "scopes":
+		err = s.scopes(m, req)
+	case "variables":
+		err = s.variables(req)
+	case "evaluate":
+		err = s.evaluate(m, req)
+	case "source":
+		err = s.source(m, req)
+	case "continue":
+		s.respond(req, map[string]bool{"allThreadsContinued": true})
+		s.resume(m, "continue")
+	case "next":
+		s.respond(req, nil)
+		s.resume(m, "next")
+	case "stepIn":
+		s.respond(req, nil)
+		s.resume(m, "step")
+	case "stepOut":
+		s.respond(req, nil)
+		s.resume(m, "stepout")
+	case "pause":
+		s.respond(req, nil)
+		if m.Debugger.state == DebugAtRun {
+			m.Debugger.state = DebugAtCmd
+			m.Debugger.prevLoc = m.Debugger.loc
+			s.stopped("pause")
+		}
+	case "disconnect":
+		var args struct {
+			TerminateDebuggee bool `json:"terminateDebuggee"`
+		}
+		_ = unmarshalDAPArgs(req, &args)
+		s.respond(req, nil)
+		if args.TerminateDebuggee {
+			s.terminate(m)
+		} else {
+			s.detach(m)
+		}
+	case "terminate":
+		s.respond(req, nil)
+		s.terminate(m)
+	default:
+		err = errors.New("unsupported request: " + req.Command)
+	}
+	if err != nil {
+		s.fail(req, err)
+	}
+}
+
+func unmarshalDAPArgs(req *dapRequest, args any) error {
+	if len(req.Arguments) == 0 {
+		return nil
+	}
+	return json.Unmarshal(req.Arguments, args)
+}
+
+// terminate ends the session and exits the program.
+func (s *DAPSession) terminate(m *Machine) {
+	s.Close()
+	m.Debugger.state = DebugAtExit
+}
+
+func (s *DAPSession) setBreakpoints(req *dapRequest) error {
+	var args struct {
+		Source      dapSource `json:"source"`
+		Breakpoints []struct {
+			Line      int    `json:"line"`
+			Condition string `json:"condition"`
+		} `json:"breakpoints"`
+	}
+	if err := unmarshalDAPArgs(req, &args); err != nil {
+		return err
+	}
+	if args.Source.Path == "" {
+		return errors.New("missing source path")
+	}
+	srcPath, err := filepath.Abs(args.Source.Path)
+	if err != nil {
+		return err
+	}
+
+	bps := make([]dapSourceBreakpoint, 0, len(args.Breakpoints))
+	res := make([]dapBreakpoint, 0, len(args.Breakpoints))
+	for _, b := range args.Breakpoints {
+		bp := dapSourceBreakpoint{line: b.Line}
+		if b.Condition != "" {
+			if bp.condition, err = parser.ParseExpr(b.Condition); err != nil {
+				res = append(res, dapBreakpoint{Line: b.Line, Message: "invalid condition: " + err.Error()})
+				continue
+			}
+		}
+		bps = append(bps, bp)
+		res = append(res, dapBreakpoint{Verified: true, Line: b.Line})
+	}
+	if len(bps) == 0 {
+		delete(s.breakpoints, srcPath)
+	} else {
+		s.breakpoints[srcPath] = bps
+	}
+	s.respond(req, map[string]any{"breakpoints": res})
+	return nil
+}
+
+func (s *DAPSession) stackTrace(m *Machine, req *dapRequest) error {
+	var args struct {
+		StartFrame int `json:"startFrame"`
+		Levels     int `json:"levels"`
+	}
+	if err := unmarshalDAPArgs(req, &args); err != nil {
+		return err
+	}
+
+	var frames []dapStackFrame
+	for i := 0; i <= len(m.Debugger.call); i++ {
+		ff := debugFrameFunc(m, i)
+		if ff == nil {
+			break
+		}
+		loc := debugFrameLoc(m, i)
+		frames = append(frames, dapStackFrame{
+			ID:     i + 1,
+			Name:   debugFuncName(ff),
+			Source: s.dapSource(loc),
+			Line:   loc.Line,
+			Column: loc.Column,
+		})
+	}
+	if len(frames) == 0 {
+		// Not in a function, like when initializing the package.
+		loc := m.Debugger.loc
+		frames = append(frames, dapStackFrame{ID: 1, Name: m.Package.PkgPath, Source: s.dapSource(loc), Line: loc.Line, Column: loc.Column})
+	}
+
+	total := len(frames)
+	frames = frames[min(args.StartFrame, total):]
+	if args.Levels > 0 && args.Levels < len(frames) {
+		frames = frames[:args.Levels]
+	}
+	s.respond(req, map[string]any{"stackFrames": frames, "totalFrames": total})
+	return nil
+}
+
+func (s *DAPSession) scopes(m *Machine, req *dapRequest) error {
+	var args struct {
+		FrameID int `json:"frameId"`
+	}
+	if err := unmarshalDAPArgs(req, &args); err != nil {
+		return err
+	}
+	level := max(args.FrameID-1, 0)
+	m.Debugger.frameLevel = level
+
+	// Local values, from the innermost block. Names shadowed by an inner
+	// block are skipped.
+	var (
+		names  []string
+		values []TypedValue
+	)
+	seen := make(map[Name]bool)
+	add := func(b *Block, bnames []Name) {
+		for i, name := range bnames {
+			if seen[name] || name == "" || name == blankIdentifier || strings.HasPrefix(string(name), ".") || i >= len(b.Values) {
+				continue
+			}
+			seen[name] = true
+			names = append(names, string(name))
+			values = append(values, b.Values[i])
+		}
+	}
+	for _, b := range debugFrameBlocks(m, level) {
+		if len(m.Blocks) > 0 && b == m.Blocks[0] {
+			continue // global block
+		}
+		if t, ok := b.Source.(*IfStmt); ok {
+			add(b, ifBody(m, t).Source.GetBlockNames())
+		}
+		add(b, b.Source.GetBlockNames())
+	}
+	locals := s.ref(func() []dapVariable {
+		vars := make([]dapVariable, len(names))
+		for i, name := range names {
+			vars[i] = s.variable(m, name, values[i])
+		}
+		return vars
+	})
+
+	// Global variables of the package of the function of the frame.
+	pkgPath := m.Package.PkgPath
+	if ff := debugFrameFunc(m, level); ff != nil {
+		pkgPath = ff.PkgPath
+	}
+	globals := s.ref(func() []dapVariable {
+		pv := m.Store.GetPackage(pkgPath, false)
+		if pv == nil {
+			return nil
+		}
+		b := pv.GetBlock(m.Store)
+		var vars []dapVariable
+		for i, name := range b.Source.GetBlockNames() {
+			if i >= len(b.Values) || name == blankIdentifier {
+				continue
+			}
+			tv := b.Values[i]
+			switch tv.T.(type) {
+			case *TypeType, *PackageType:
+				continue
+			}
+			if fv, ok := tv.V.(*FuncValue); ok && fv.Name == name {
+				continue // function declaration
+			}
+			vars = append(vars, s.variable(m, string(name), tv))
+		}
+		return vars
+	})
+
+	s.respond(req, map[string]any{"scopes": []dapScope{
+		{Name: "Locals", VariablesReference: locals},
+		{Name: "Globals", VariablesReference: globals},
+	}})
+	return nil
+}
+
+func (s *DAPSession) variables(req *dapRequest) error {
+	var args struct {
+		VariablesReference int `json:"variablesReference"`
+		Start              int `json:"start"`
+		Count              int `json:"count"`
+	}
+	if err := unmarshalDAPArgs(req, &args); err != nil {
+		return err
+	}
+	ref := args.VariablesReference
+	if ref <= 0 || ref > len(s.refs) {
+		return fmt.Errorf("invalid variables reference: %d", ref)
+	}
+
+	vars := s.refs[ref-1]()
+	vars = vars[min(args.Start, len(vars)):]
+	if args.Count > 0 && args.Count < len(vars) {
+		vars = vars[:args.Count]
+	}
+	if vars == nil {
+		vars = []dapVariable{}
+	}
+	s.respond(req, map[string]any{"variables": vars})
+	return nil
+}
+
+func (s *DAPSession) evaluate(m *Machine, req *dapRequest) error {
+	var args struct {
+		Expression string `json:"expression"`
+		FrameID    int    `json:"frameId"`
+	}
+	if err := unmarshalDAPArgs(req, &args); err != nil {
+		return err
+	}
+	if args.FrameID > 0 {
+		m.Debugger.frameLevel = args.FrameID - 1
+	}
+	expr, err := parser.ParseExpr(args.Expression)
+	if err != nil {
+		return err
+	}
+	tv, err := debugEvalExpr(m, expr)
+	if err != nil {
+		return err
+	}
+	v := s.variable(m, args.Expression, tv)
+	s.respond(req, map[string]any{
+		"result":             v.Value,
+		"type":               v.Type,
+		"variablesReference": v.VariablesReference,
+	})
+	return nil
+}
+
+func (s *DAPSession) source(m *Machine, req *dapRequest) error {
+	var args struct {
+		SourceReference int `json:"sourceReference"`
+	}
+	if err := unmarshalDAPArgs(req, &args); err != nil {
+		return err
+	}
+	ref := args.SourceReference
+	if ref <= 0 || ref > len(s.sources) {
+		return fmt.Errorf("invalid source reference: %d", ref)
+	}
+	loc := s.sources[ref-1]
+	src, err := fileContent(m.Store, loc.PkgPath, loc.File)
+	if err != nil && m.Debugger.getSrc != nil {
+		src, err = m.Debugger.getSrc(loc.PkgPath, loc.File), nil
+	}
+	if err != nil || src == "" {
+		return fmt.Errorf("source not available: %s", loc)
+	}
+	s.respond(req, map[string]string{"content": src})
+	return nil
+}
+
+// ----------------------------------------
+// Sources
+
+// dapSource returns the source of the file of loc. Files which are not found
+// on the filesystem are referenced, to be fetched with a source request.
+func (s *DAPSession) dapSource(loc Location) *dapSource {
+	if loc.File == "" {
+		return nil
+	}
+	src := &dapSource{Name: filepath.Base(loc.File)}
+	if p := s.sourcePath(loc); p != "" {
+		src.Path = p
+	} else {
+		s.sources = append(s.sources, loc)
+		src.SourceReference = len(s.sources)
+	}
+	return src
+}
+
+// sourcePath returns the absolute path of the file of loc, or "" if it is
+// not found.
+func (s *DAPSession) sourcePath(loc Location) string {
+	key := Location{PkgPath: loc.PkgPath, File: loc.File}
+	if p, ok := s.paths[key]; ok {
+		return p
+	}
+	p := s.findSource(loc)
+	s.paths[key] = p
+	return p
+}
+
+func (s *DAPSession) findSource(loc Location) string {
+	if filepath.IsAbs(loc.File) {
+		return filepath.Clean(loc.File)
+	}
+	var candidates []string
+	if dir, ok := s.srcDirs[loc.PkgPath]; ok {
+		candidates = append(candidates, filepath.Join(dir, loc.File))
+	}
+	if s.rootDir != "" && loc.PkgPath != "" {
+		for _, dir := range []string{"gnovm/stdlibs", "gnovm/tests/stdlibs", "examples"} {
+			candidates = append(candidates, filepath.Join(s.rootDir, filepath.FromSlash(dir), filepath.FromSlash(loc.PkgPath), loc.File))
+		}
+	}
+	// Relative to the working directory, like the files of gno run.
+	candidates = append(candidates, loc.File)
+
+	for _, c := range candidates {
+		if _, err := os.Stat(c); err != nil {
+			continue
+		}
+		if p, err := filepath.Abs(c); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+// ----------------------------------------
+// Variables
+
+// ref registers the children of a variable, and returns its reference.
+func (s *DAPSession) ref(children func() []dapVariable) int {
+	s.refs = append(s.refs, children)
+	return len(s.refs)
+}
+
+// variable returns the variable name of value tv, referencing its children
+// if it has any.
+func (s *DAPSession) variable(m *Machine, name string, tv TypedValue) dapVariable {
+	tv = dapDeref(m, tv)
+	v := dapVariable{Name: name, Value: dapValueString(m, tv)}
+	if tv.T != nil {
+		v.Type = tv.T.String()
+	}
+	if children := s.children(m, tv); children != nil {
+		v.VariablesReference = s.ref(children)
+	}
+	return v
+}
+
+// children returns a function returning the children of tv, like the fields
+// of a struct, or nil if tv has none.
+func (s *DAPSession) children(m *Machine, tv TypedValue) func() []dapVariable {
+	if tv.T == nil || tv.V == nil {
+		return nil
+	}
+	switch bt := baseOf(tv.T).(type) {
+	case *PointerType:
+		// Show the children of the pointed value, if any.
+		elem := dapDeref(m, tv.V.(PointerValue).Deref())
+		if children := s.children(m, elem); children != nil {
+			return children
+		}
+		return func() []dapVariable {
+			return []dapVariable{s.variable(m, "*", elem)}
+		}
+	case *StructType:
+		sv, ok := tv.V.(*StructValue)
+		if !ok {
+			return nil
+		}
+		return func() []dapVariable {
+			var vars []dapVariable
+			if entries := s.avlEntries(m, tv); entries != nil {
+				vars = append(vars, dapVariable{Name: "[entries]", VariablesReference: s.ref(entries)})
+			}
+			for i, f := range bt.Fields {
+				vars = append(vars, s.variable(m, string(f.Name), sv.Fields[i]))
+			}
+			return vars
+		}
+	case *ArrayType, *SliceType:
+		n := tv.GetLength()
+		if n == 0 {
+			return nil
+		}
+		return func() []dapVariable {
+			vars := make([]dapVariable, 0, min(n, dapMaxChildren))
+			for i := range min(n, dapMaxChildren) {
+				elem := tv.GetPointerAtIndexInt(m.Store, i).Deref()
+				vars = append(vars, s.variable(m, "["+strconv.Itoa(i)+"]", elem))
+			}
+			return vars
+		}
+	case *MapType:
+		mv, ok := tv.V.(*MapValue)
+		if !ok || mv.List == nil || mv.GetLength() == 0 {
+			return nil
+		}
+		return func() []dapVariable {
+			var vars []dapVariable
+			for item := mv.List.Head; item != nil && len(vars) < dapMaxChildren; item = item.Next {
+				key := dapValueString(m, dapDeref(m, item.Key))
+				vars = append(vars, s.variable(m, "["+key+"]", item.Value))
+			}
+			return vars
+		}
+	}
+	return nil
+}
+
+// avlPkgPath is the path of the avl package, whose trees are shown as their
+// list of entries.
+const avlPkgPath = "gno.land/p/demo/avl"
+
+// avlEntries returns a function returning the entries of tv, if it is an
+// avl.Tree or an avl.Node, or else nil.
+func (s *DAPSession) avlEntries(m *Machine, tv TypedValue) func() []dapVariable {
+	dt, ok := tv.T.(*DeclaredType)
+	if !ok || dt.PkgPath != avlPkgPath {
+		return nil
+	}
+	var node TypedValue
+	switch dt.Name {
+	case "Tree":
+		node = dapField(m, tv, "node")
+	case "Node":
+		// Point to the node, to walk it like its children.
+		node = TypedValue{T: &PointerType{Elt: tv.T}, V: PointerValue{TV: &tv}}
+	default:
+		return nil
+	}
+	return func() []dapVariable {
+		var vars []dapVariable
+		s.walkAVL(m, node, &vars)
+		return vars
+	}
+}
+
+// walkAVL appends the entries of the leaves of the avl node ptr to vars, in
+// order.
+func (s *DAPSession) walkAVL(m *Machine, ptr TypedValue, vars *[]dapVariable) {
+	ptr = dapDeref(m, ptr)
+	if ptr.V == nil || len(*vars) >= dapMaxChildren {
+		return
+	}
+	node := dapDeref(m, ptr.V.(PointerValue).Deref())
+	left, right := dapField(m, node, "leftNode"), dapField(m, node, "rightNode")
+	if left.V == nil && right.V == nil {
+		key := dapField(m, node, "key")
+		*vars = append(*vars, s.variable(m, strconv.Quote(key.GetString()), dapField(m, node, "value")))
+		return
+	}
+	s.walkAVL(m, left, vars)
+	s.walkAVL(m, right, vars)
+}
+
+// dapField returns the field name of the struct tv.
+func dapField(m *Machine, tv TypedValue, name string) TypedValue {
+	st, ok := baseOf(tv.T).(*StructType)
+	if !ok {
+		return TypedValue{}
+	}
+	sv, ok := tv.V.(*StructValue)
+	if !ok {
+		return TypedValue{}
+	}
+	for i, f := range st.Fields {
+		if string(f.Name) == name {
+			return dapDeref(m, sv.Fields[i])
+		}
+	}
+	return TypedValue{}
+}
+
+// dapDeref returns tv with its value loaded from the store, and unwrapped
+// from its heap item if any.
+func dapDeref(m *Machine, tv TypedValue) TypedValue {
+	fillValueTV(m.Store, &tv)
+	if hiv, ok := tv.V.(*HeapItemValue); ok {
+		tv = hiv.Value
+		fillValueTV(m.Store, &tv)
+	}
+	return tv
+}
+
+// dapValueString returns a short representation of tv. Composite values are
+// summarized, their content being shown by their children.
+func dapValueString(m *Machine, tv TypedValue) string {
+	if tv.T == nil {
+		return "nil"
+	}
+	switch bt := baseOf(tv.T).(type) {
+	case PrimitiveType:
+		if bt.Kind() == StringKind {
+			return strconv.Quote(tv.GetString())
+		}
+		return tv.ProtectedSprint(newSeenValues(), false)
+	case *PointerType:
+		if tv.V == nil {
+			return "nil"
+		}
+		elem := dapDeref(m, tv.V.(PointerValue).Deref())
+		return "&" + dapValueString(m, elem)
+	case *StructType:
+		return tv.T.String() + "{...}"
+	case *ArrayType:
+		return fmt.Sprintf("%s len: %d", tv.T, bt.Len)
+	case *SliceType:
+		if tv.V == nil {
+			return "nil"
+		}
+		return fmt.Sprintf("%s len: %d", tv.T, tv.GetLength())
+	case *MapType:
+		if tv.V == nil {
+			return "nil"
+		}
+		return fmt.Sprintf("%s len: %d", tv.T, tv.GetLength())
+	case *FuncType:
+		if tv.V == nil {
+			return "nil"
+		}
+		return tv.V.(fmt.Stringer).String()
+	case *InterfaceType:
+		return "nil"
+	default:
+		return tv.T.String()
+	}
+}
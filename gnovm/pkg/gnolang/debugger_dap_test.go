@@ -0,0 +1,216 @@
+package gnolang_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dapClient is a minimal DAP client, to drive the debugger in tests.
+type dapClient struct {
+	t    *testing.T
+	conn net.Conn
+	seq  int
+	msgs chan map[string]any
+}
+
+func newDAPClient(t *testing.T, conn net.Conn) *dapClient {
+	t.Helper()
+
+	c := &dapClient{t: t, conn: conn, msgs: make(chan map[string]any, 100)}
+	go func() {
+		defer close(c.msgs)
+		r := bufio.NewReader(conn)
+		for {
+			header, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			length, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "Content-Length:")))
+			if err != nil {
+				return
+			}
+			if _, err := r.ReadString('\n'); err != nil {
+				return
+			}
+			buf := make([]byte, length)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+			var msg map[string]any
+			if err := json.Unmarshal(buf, &msg); err != nil {
+				return
+			}
+			c.msgs <- msg
+		}
+	}()
+	return c
+}
+
+// request sends a request, and returns the body of its response.
+func (c *dapClient) request(command string, args any) map[string]any {
+	c.t.Helper()
+
+	c.seq++
+	bz, err := json.Marshal(map[string]any{"seq": c.seq, "type": "request", "command": command, "arguments": args})
+	require.NoError(c.t, err)
+	_, err = fmt.Fprintf(c.conn, "Content-Length: %d\r\n\r\n%s", len(bz), bz)
+	require.NoError(c.t, err)
+
+	resp := c.expect("response", command)
+	require.True(c.t, resp["success"].(bool), "%s failed: %v", command, resp["message"])
+	body, _ := resp["body"].(map[string]any)
+	return body
+}
+
+// expect returns the next message, which must be of type typ and be the
+// response to command, or the event, name.
+func (c *dapClient) expect(typ, name string) map[string]any {
+	c.t.Helper()
+
+	select {
+	case msg, ok := <-c.msgs:
+		require.True(c.t, ok, "connection closed, want %s %s", typ, name)
+		key := "event"
+		if typ == "response" {
+			key = "command"
+		}
+		require.Equal(c.t, typ, msg["type"], "%v", msg)
+		require.Equal(c.t, name, msg[key], "%v", msg)
+		return msg
+	case <-time.After(10 * time.Second):
+		c.t.Fatalf("timeout, want %s %s", typ, name)
+		return nil
+	}
+}
+
+// variables returns the values of the variables of ref, by name.
+func (c *dapClient) variables(ref any) map[string]map[string]any {
+	c.t.Helper()
+
+	body := c.request("variables", map[string]any{"variablesReference": ref})
+	vars := make(map[string]map[string]any)
+	for _, v := range body["variables"].([]any) {
+		v := v.(map[string]any)
+		vars[v["name"].(string)] = v
+	}
+	return vars
+}
+
+// evalDAPTest runs the main function of file, debugged by the client of sess.
+func evalDAPTest(sess *gnolang.DAPSession, file string) string {
+	bout := bytes.NewBufferString("")
+	output := test.OutputWithError(writeNopCloser{bout}, writeNopCloser{bout})
+	_, testStore := test.Store(gnoenv.RootDir(), output)
+
+	f := gnolang.MustReadFile(file)
+	m := gnolang.NewMachineWithOptions(gnolang.MachineOptions{
+		PkgPath: string(f.PkgName),
+		Output:  output,
+		Store:   testStore,
+		Context: test.Context(string(f.PkgName), nil),
+		Debug:   true,
+	})
+	defer m.Release()
+	m.Debugger.EnableDAP(sess)
+
+	m.RunFiles(f)
+	ex, _ := gnolang.ParseExpr("main()")
+	m.Eval(ex)
+	sess.Close()
+	return bout.String()
+}
+
+func TestDAPDebug(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	c := newDAPClient(t, client)
+
+	done := make(chan string)
+	go func() { done <- evalDAPTest(gnolang.NewDAPSession(server), debugTarget) }()
+
+	body := c.request("initialize", map[string]any{"adapterID": "gno"})
+	assert.Equal(t, true, body["supportsConditionalBreakpoints"])
+	c.expect("event", "initialized")
+	c.request("launch", map[string]any{})
+
+	target, err := filepath.Abs(debugTarget)
+	require.NoError(t, err)
+	body = c.request("setBreakpoints", map[string]any{
+		"source": map[string]any{"path": target},
+		"breakpoints": []map[string]any{
+			{"line": 7, "condition": "i =="},
+			{"line": 43, "condition": "i == 2"},
+		},
+	})
+	bps := body["breakpoints"].([]any)
+	require.Len(t, bps, 2)
+	assert.Equal(t, false, bps[0].(map[string]any)["verified"])
+	assert.Equal(t, true, bps[1].(map[string]any)["verified"])
+
+	c.request("configurationDone", nil)
+	stopped := c.expect("event", "stopped")
+	assert.Equal(t, "breakpoint", stopped["body"].(map[string]any)["reason"])
+
+	// Stack.
+	body = c.request("stackTrace", map[string]any{"threadId": 1})
+	frames := body["stackFrames"].([]any)
+	require.Len(t, frames, 1)
+	frame := frames[0].(map[string]any)
+	assert.Equal(t, "main.main", frame["name"])
+	assert.Equal(t, float64(43), frame["line"])
+	assert.Equal(t, target, frame["source"].(map[string]any)["path"])
+
+	// Variables.
+	body = c.request("scopes", map[string]any{"frameId": frame["id"]})
+	scopes := body["scopes"].([]any)
+	require.Len(t, scopes, 2)
+	locals := c.variables(scopes[0].(map[string]any)["variablesReference"])
+	assert.Equal(t, "2", locals["i"]["value"])
+	assert.Equal(t, "1", locals["x"]["value"])
+	assert.Equal(t, "5", locals["num"]["value"])
+	assert.Equal(t, "main.T", locals["t"]["type"])
+	fields := c.variables(locals["t"]["variablesReference"])
+	assert.Equal(t, "[]int len: 3", fields["A"]["value"])
+	elems := c.variables(fields["A"]["variablesReference"])
+	assert.Equal(t, "3", elems["[2]"]["value"])
+	globals := c.variables(scopes[1].(map[string]any)["variablesReference"])
+	assert.Equal(t, `"test"`, globals["global"]["value"])
+	assert.NotContains(t, globals, "main")
+
+	// Expressions.
+	body = c.request("evaluate", map[string]any{"expression": "t.A[i] > x && global == \"test\"", "frameId": frame["id"]})
+	assert.Equal(t, "true", body["result"])
+
+	// Stepping.
+	c.request("next", map[string]any{"threadId": 1})
+	stopped = c.expect("event", "stopped")
+	assert.Equal(t, "step", stopped["body"].(map[string]any)["reason"])
+	body = c.request("stackTrace", map[string]any{"threadId": 1})
+	assert.Equal(t, float64(42), body["stackFrames"].([]any)[0].(map[string]any)["line"])
+
+	// The condition is false for the next iterations.
+	c.request("continue", map[string]any{"threadId": 1})
+	c.expect("event", "terminated")
+
+	select {
+	case out := <-done:
+		assert.Contains(t, out, "bye 4")
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for the program to end")
+	}
+}
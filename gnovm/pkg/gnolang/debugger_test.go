@@ -107,6 +107,9 @@ func TestDebug(t *testing.T) {
 		{in: cont + "p i\n", out: "(3 int)"},
 		{in: cont + "up\np global\n", out: `("test" string)`},
 		{in: cont + "bp\n", out: "Breakpoint 0 at main "},
+		{in: cont + "p i == 3\n", out: "(true <untyped> bool)"},
+		{in: cont + "p name != \"hello\" || i >= 3\n", out: "(true <untyped> bool)"},
+		{in: cont + "p !(i < 3) && name == nil\n", out: "mismatched types"},
 		{in: "p 3\n", out: "(3 int)"},
 		{in: "p 'a'\n", out: "(97 int32)"},
 		{in: "p '界'\n", out: "(30028 int32)"},
@@ -42,19 +42,54 @@ func TypeCheckMemPackageTest(mempkg *gnovm.MemPackage, getter MemPackageGetter)
 	return typeCheckMemPackage(mempkg, getter, true, false)
 }
 
-func typeCheckMemPackage(mempkg *gnovm.MemPackage, getter MemPackageGetter, testing, format bool) error {
+// TypeCheckResult holds the parsed files and the type information
+// of a package checked by [TypeCheckMemPackageInfo].
+type TypeCheckResult struct {
+	// Fset is shared by the files of the package and of its imports.
+	Fset  *token.FileSet
+	Files []*ast.File
+	Pkg   *types.Package
+	Info  *types.Info
+}
+
+// TypeCheckMemPackageInfo performs the same checks as [TypeCheckMemPackageTest],
+// additionally returning the parsed files and the type information of mempkg,
+// for tooling such as language servers.
+//
+// Files with syntax errors are still type checked, as far as they could be
+// parsed, so the result is available (if partial) even if an error is returned.
+func TypeCheckMemPackageInfo(mempkg *gnovm.MemPackage, getter MemPackageGetter) (*TypeCheckResult, error) {
 	var errs error
-	imp := &gnoImporter{
-		getter: getter,
-		cache:  map[string]gnoImporterResult{},
-		cfg: &types.Config{
-			Error: func(err error) {
-				errs = multierr.Append(errs, err)
-			},
+	imp := newGnoImporter(getter, true, func(err error) {
+		errs = multierr.Append(errs, err)
+	})
+
+	res := &TypeCheckResult{
+		Fset: imp.fset,
+		Info: &types.Info{
+			Types:      map[ast.Expr]types.TypeAndValue{},
+			Defs:       map[*ast.Ident]types.Object{},
+			Uses:       map[*ast.Ident]types.Object{},
+			Implicits:  map[ast.Node]types.Object{},
+			Selections: map[*ast.SelectorExpr]*types.Selection{},
+			Scopes:     map[ast.Node]*types.Scope{},
 		},
-		allowRedefinitions: testing,
 	}
-	imp.cfg.Importer = imp
+
+	files, parseErrs := imp.parseMemPackage(mempkg, false)
+	res.Files = files
+
+	// The type checking errors are collected in errs
+	res.Pkg, _ = imp.cfg.Check(mempkg.Path, imp.fset, files, res.Info)
+
+	return res, multierr.Combine(parseErrs, errs)
+}
+
+func typeCheckMemPackage(mempkg *gnovm.MemPackage, getter MemPackageGetter, testing, format bool) error {
+	var errs error
+	imp := newGnoImporter(getter, testing, func(err error) {
+		errs = multierr.Append(errs, err)
+	})
 
 	_, err := imp.parseCheckMemPackage(mempkg, format)
 	// prefer to return errs instead of err:
@@ -65,6 +100,21 @@ func typeCheckMemPackage(mempkg *gnovm.MemPackage, getter MemPackageGetter, test
 	return err
 }
 
+func newGnoImporter(getter MemPackageGetter, allowRedefinitions bool, onError func(error)) *gnoImporter {
+	imp := &gnoImporter{
+		getter: getter,
+		cache:  map[string]gnoImporterResult{},
+		fset:   token.NewFileSet(),
+		cfg: &types.Config{
+			Error: onError,
+		},
+		allowRedefinitions: allowRedefinitions,
+	}
+	imp.cfg.Importer = imp
+
+	return imp
+}
+
 type gnoImporterResult struct {
 	pkg *types.Package
 	err error
@@ -74,6 +124,7 @@ type gnoImporter struct {
 	getter MemPackageGetter
 	cache  map[string]gnoImporterResult
 	cfg    *types.Config
+	fset   *token.FileSet // shared by all the imported packages
 
 	// allow symbol redefinitions? (test standard libraries)
 	allowRedefinitions bool
@@ -107,6 +158,17 @@ func (g *gnoImporter) ImportFrom(path, _ string, _ types.ImportMode) (*types.Pac
 }
 
 func (g *gnoImporter) parseCheckMemPackage(mpkg *gnovm.MemPackage, fmt bool) (*types.Package, error) {
+	files, errs := g.parseMemPackage(mpkg, fmt)
+	if errs != nil {
+		return nil, errs
+	}
+
+	return g.cfg.Check(mpkg.Path, g.fset, files, nil)
+}
+
+// parseMemPackage parses the source files of mpkg. Files with syntax errors
+// are returned as far as they could be parsed, along with the errors.
+func (g *gnoImporter) parseMemPackage(mpkg *gnovm.MemPackage, fmt bool) ([]*ast.File, error) {
 	// This map is used to allow for function re-definitions, which are allowed
 	// in Gno (testing context) but not in Go.
 	// This map links each function identifier with a closure to remove its
@@ -116,7 +178,6 @@ func (g *gnoImporter) parseCheckMemPackage(mpkg *gnovm.MemPackage, fmt bool) (*t
 		delFunc = make(map[string]func())
 	}
 
-	fset := token.NewFileSet()
 	files := make([]*ast.File, 0, len(mpkg.Files))
 	var errs error
 	for _, file := range mpkg.Files {
@@ -130,9 +191,12 @@ func (g *gnoImporter) parseCheckMemPackage(mpkg *gnovm.MemPackage, fmt bool) (*t
 		}
 
 		const parseOpts = parser.ParseComments | parser.DeclarationErrors | parser.SkipObjectResolution
-		f, err := parser.ParseFile(fset, path.Join(mpkg.Path, file.Name), file.Body, parseOpts)
+		f, err := parser.ParseFile(g.fset, path.Join(mpkg.Path, file.Name), file.Body, parseOpts)
 		if err != nil {
 			errs = multierr.Append(errs, err)
+			if f != nil {
+				files = append(files, f)
+			}
 			continue
 		}
 
@@ -143,7 +207,7 @@ func (g *gnoImporter) parseCheckMemPackage(mpkg *gnovm.MemPackage, fmt bool) (*t
 		// enforce formatting
 		if fmt {
 			var buf bytes.Buffer
-			err = format.Node(&buf, fset, f)
+			err = format.Node(&buf, g.fset, f)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 				continue
@@ -153,11 +217,8 @@ func (g *gnoImporter) parseCheckMemPackage(mpkg *gnovm.MemPackage, fmt bool) (*t
 
 		files = append(files, f)
 	}
-	if errs != nil {
-		return nil, errs
-	}
 
-	return g.cfg.Check(mpkg.Path, fset, files, nil)
+	return files, errs
 }
 
 func deleteOldIdents(idents map[string]func(), f *ast.File) {
@@ -1,6 +1,7 @@
 package gnolang
 
 import (
+	"go/types"
 	"testing"
 
 	"github.com/gnolang/gno/gnovm"
@@ -417,3 +418,61 @@ func Hello(name string) string {
 	assert.NotEqual(t, input, pkg.Files[0].Body)
 	assert.Equal(t, expected, pkg.Files[0].Body)
 }
+
+func TestTypeCheckMemPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	getter := mockPackageGetter{
+		&gnovm.MemPackage{
+			Name: "std",
+			Path: "std",
+			Files: []*gnovm.MemFile{
+				{
+					Name: "std.gno",
+					Body: `package std
+
+type Address string`,
+				},
+			},
+		},
+	}
+
+	pkg := &gnovm.MemPackage{
+		Name: "hello",
+		Path: "gno.land/p/demo/hello",
+		Files: []*gnovm.MemFile{
+			{
+				Name: "hello.gno",
+				Body: `package hello
+
+import "std"
+
+func Hello() std.Address { return "hello" }`,
+			},
+			{
+				Name: "broken.gno",
+				Body: `package hello
+
+func Broken() int { return Hello(). }`,
+			},
+		},
+	}
+
+	res, err := TypeCheckMemPackageInfo(pkg, getter)
+	require.Error(t, err)
+
+	// The file with a syntax error is still type checked
+	require.Len(t, res.Files, 2)
+	require.NotNil(t, res.Pkg)
+	assert.NotNil(t, res.Pkg.Scope().Lookup("Broken"))
+
+	// Objects of imported packages share the same file set
+	var address types.Object
+	for ident, obj := range res.Info.Uses {
+		if ident.Name == "Address" {
+			address = obj
+		}
+	}
+	require.NotNil(t, address)
+	assert.Equal(t, "std/std.gno", res.Fset.Position(address.Pos()).Filename)
+}
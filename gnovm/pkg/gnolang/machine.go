@@ -40,6 +40,9 @@ type Machine struct {
 
 	Debugger Debugger
 
+	// Coverage, if set, records the statements executed by the machine.
+	Coverage *Coverage
+
 	// Configuration
 	PreprocessorMode bool // this is used as a flag when const values are evaluated during preprocessing
 	Output           io.Writer
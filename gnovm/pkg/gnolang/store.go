@@ -3,6 +3,7 @@ package gnolang
 import (
 	"fmt"
 	"io"
+	"maps"
 	"slices"
 	"strconv"
 	"strings"
@@ -250,6 +251,127 @@ func CopyFromCachedStore(destStore, cachedStore Store, cachedBase, cachedIavl st
 	}
 }
 
+// StoreSavepoint records the state of a store, so that the changes made to it
+// afterwards can either be committed or reverted.
+type StoreSavepoint struct {
+	ds        *defaultStore
+	baseStore store.Store
+	iavlStore store.Store
+	objects   map[ObjectID]Object
+}
+
+// BeginSavepoint caches the writes to the backing stores of st until the
+// returned savepoint is committed or reverted. Savepoints may be nested, and
+// must be committed or reverted in reverse order.
+// This is mostly useful for testing, to simulate transactions within a
+// transaction store.
+func BeginSavepoint(st Store) *StoreSavepoint {
+	var ds *defaultStore
+	switch st := st.(type) {
+	case transactionStore:
+		ds = st.defaultStore
+	case *defaultStore:
+		ds = st
+	default:
+		panic(fmt.Sprintf("unexpected store type %T", st))
+	}
+
+	sp := &StoreSavepoint{
+		ds:        ds,
+		baseStore: ds.baseStore,
+		iavlStore: ds.iavlStore,
+		objects:   maps.Clone(ds.cacheObjects),
+	}
+	ds.baseStore = sp.baseStore.CacheWrap()
+	if sp.iavlStore == sp.baseStore {
+		ds.iavlStore = ds.baseStore
+	} else {
+		ds.iavlStore = sp.iavlStore.CacheWrap()
+	}
+	return sp
+}
+
+// Commit writes the changes made since the savepoint to the backing stores.
+func (sp *StoreSavepoint) Commit() {
+	ds := sp.ds
+	ds.baseStore.Write()
+	if ds.iavlStore != ds.baseStore {
+		ds.iavlStore.Write()
+	}
+	ds.baseStore, ds.iavlStore = sp.baseStore, sp.iavlStore
+}
+
+// Revert discards the changes made since the savepoint. As the cached objects
+// may be referenced by values in memory, the ones which were modified are
+// restored in place from the backing stores, while the objects created since
+// the savepoint are dropped. The pending changes of the realms are discarded.
+func (sp *StoreSavepoint) Revert() {
+	ds := sp.ds
+	ds.baseStore, ds.iavlStore = sp.baseStore, sp.iavlStore
+
+	// Objects loaded after the savepoint may be referenced by the cached
+	// objects, so they are kept if they were persisted.
+	objects := maps.Clone(sp.objects)
+	for oid, oo := range ds.cacheObjects {
+		if _, ok := objects[oid]; !ok {
+			objects[oid] = oo
+		}
+	}
+	for oid, oo := range objects {
+		hashbz := ds.baseStore.Get([]byte(backendObjectKey(oid)))
+		if hashbz == nil {
+			if _, ok := sp.objects[oid]; !ok {
+				delete(objects, oid)
+			}
+			continue
+		}
+		hash := ValueHash{NewHashlet(hashbz[:HashSize])}
+		if oo.GetHash() == hash && !oo.GetIsDirty() && !oo.GetIsDeleted() &&
+			!oo.GetIsNewDeleted() && !oo.GetIsNewEscaped() {
+			continue
+		}
+		var lo Object
+		amino.MustUnmarshal(hashbz[HashSize:], &lo)
+		lo.SetHash(hash)
+		_ = fillTypesOfValue(ds, lo)
+		switch oo := oo.(type) {
+		case *ArrayValue:
+			*oo = *lo.(*ArrayValue)
+		case *StructValue:
+			*oo = *lo.(*StructValue)
+		case *BoundMethodValue:
+			*oo = *lo.(*BoundMethodValue)
+		case *MapValue:
+			*oo = *lo.(*MapValue)
+		case *Block:
+			*oo = *lo.(*Block)
+		case *HeapItemValue:
+			*oo = *lo.(*HeapItemValue)
+		case *PackageValue:
+			// The blocks of a package are separate objects.
+			oo.ObjectInfo = lo.(*PackageValue).ObjectInfo
+		default:
+			panic(fmt.Sprintf("unexpected object type %T", oo))
+		}
+	}
+	ds.cacheObjects = objects
+
+	for _, oo := range objects {
+		pv, ok := oo.(*PackageValue)
+		if !ok || pv.Realm == nil {
+			continue
+		}
+		rlm := pv.Realm
+		if bz := ds.baseStore.Get([]byte(backendRealmKey(ObjectIDFromPkgPath(rlm.Path)))); bz != nil {
+			var prlm *Realm
+			amino.MustUnmarshal(bz, &prlm)
+			rlm.Time = prlm.Time
+		}
+		rlm.newCreated, rlm.newEscaped, rlm.newDeleted = nil, nil, nil
+		rlm.created, rlm.updated, rlm.deleted, rlm.escaped = nil, nil, nil, nil
+	}
+}
+
 func (ds *defaultStore) GetAllocator() *Allocator {
 	return ds.alloc
 }
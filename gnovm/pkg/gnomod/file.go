@@ -23,6 +23,7 @@ type File struct {
 	Draft   bool
 	Module  *modfile.Module
 	Go      *modfile.Go
+	Require []*modfile.Require
 	Replace []*modfile.Replace
 
 	Syntax *modfile.FileSyntax
@@ -59,6 +60,39 @@ func (f *File) AddComment(text string) {
 	})
 }
 
+// AddRequire adds a require directive for path at version vers,
+// or updates the version of the existing one.
+func (f *File) AddRequire(path, vers string) error {
+	if f.Syntax == nil {
+		f.Syntax = new(modfile.FileSyntax)
+	}
+	return addRequire(f.Syntax, &f.Require, path, vers)
+}
+
+// DropRequire removes the require directive of path, if any.
+func (f *File) DropRequire(path string) error {
+	for _, r := range f.Require {
+		if r.Mod.Path == path {
+			markLineAsRemoved(r.Syntax)
+			*r = modfile.Require{}
+		}
+	}
+	return nil
+}
+
+// Required returns the required version of path, if any.
+func (f *File) Required(path string) (module.Version, bool) {
+	if f == nil {
+		return module.Version{}, false
+	}
+	for _, r := range f.Require {
+		if r.Mod.Path == path {
+			return r.Mod, true
+		}
+	}
+	return module.Version{}, false
+}
+
 func (f *File) AddReplace(oldPath, oldVers, newPath, newVers string) error {
 	return addReplace(f.Syntax, &f.Replace, oldPath, oldVers, newPath, newVers)
 }
@@ -83,7 +117,8 @@ func (f *File) Validate() error {
 }
 
 // Resolve takes a module version and returns any adequate replacement
-// following the Replace directives.
+// following the Replace directives. Otherwise, if the module is required,
+// it returns its on-chain path at the required version (see [OnChainPath]).
 func (f *File) Resolve(m module.Version) module.Version {
 	if f == nil {
 		return m
@@ -92,6 +127,9 @@ func (f *File) Resolve(m module.Version) module.Version {
 	if replaced {
 		return mod
 	}
+	if req, ok := f.Required(m.Path); ok {
+		return module.Version{Path: OnChainPath(req), Version: req.Version}
+	}
 	return m
 }
 
@@ -107,5 +145,5 @@ func (f *File) Write(fname string) error {
 }
 
 func (f *File) Sanitize() {
-	removeDups(f.Syntax, &f.Replace)
+	removeDups(f.Syntax, &f.Require, &f.Replace)
 }
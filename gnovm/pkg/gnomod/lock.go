@@ -0,0 +1,216 @@
+package gnomod
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// LockFileName is the name of the lock file, next to gno.mod.
+const LockFileName = "gno.lock"
+
+// ErrChecksumMismatch is returned by [LockFile.Verify] when the content
+// of a package doesn't match the hash recorded in the lock file.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// LockFile records the content hashes of the dependencies of a module,
+// so that builds are reproducible against a known dependency set.
+//
+// Each line holds the on-chain package path, the required version if
+// any, and the hash of the package, as computed by [HashMemPackage]:
+//
+//	gno.land/p/demo/avl/v2 v2.1.0 h1:...
+//	gno.land/p/demo/ufmt h1:...
+type LockFile struct {
+	Entries []LockEntry
+}
+
+// LockEntry is the hash of a dependency in a [LockFile].
+type LockEntry struct {
+	Path    string
+	Version string // empty if not required
+	Hash    string
+}
+
+// ReadLockFile reads the lock file in dir.
+// If there is none, it returns an empty lock file.
+func ReadLockFile(dir string) (*LockFile, error) {
+	fname := lockFilePath(dir)
+
+	data, err := os.ReadFile(fname)
+	if errors.Is(err, os.ErrNotExist) {
+		return &LockFile{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("readfile %q: %w", fname, err)
+	}
+
+	return ParseLockFile(fname, data)
+}
+
+// ParseLockFile parses the lock file data.
+// The file name is only used in errors.
+func ParseLockFile(fname string, data []byte) (*LockFile, error) {
+	lf := &LockFile{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var entry LockEntry
+
+		fields := strings.Fields(line)
+		switch len(fields) {
+		case 2:
+			entry = LockEntry{Path: fields[0], Hash: fields[1]}
+		case 3:
+			entry = LockEntry{Path: fields[0], Version: fields[1], Hash: fields[2]}
+		default:
+			return nil, fmt.Errorf("%s:%d: malformed line %q", fname, lineno, line)
+		}
+
+		if !strings.HasPrefix(entry.Hash, "h1:") {
+			return nil, fmt.Errorf("%s:%d: unknown hash %q", fname, lineno, entry.Hash)
+		}
+
+		lf.Entries = append(lf.Entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %q: %w", fname, err)
+	}
+
+	return lf, nil
+}
+
+// Get returns the entry of the package path at the given version.
+func (lf *LockFile) Get(path, version string) (LockEntry, bool) {
+	for _, entry := range lf.Entries {
+		if entry.Path == path && entry.Version == version {
+			return entry, true
+		}
+	}
+
+	return LockEntry{}, false
+}
+
+// Set records the hash of the package path at the given version.
+func (lf *LockFile) Set(path, version, hash string) {
+	for i, entry := range lf.Entries {
+		if entry.Path == path && entry.Version == version {
+			lf.Entries[i].Hash = hash
+			return
+		}
+	}
+
+	lf.Entries = append(lf.Entries, LockEntry{Path: path, Version: version, Hash: hash})
+}
+
+// Verify checks the hash of the package path at the given version against
+// the recorded one, and records it if there is none. It returns
+// [ErrChecksumMismatch] if the hashes differ.
+func (lf *LockFile) Verify(path, version, hash string) error {
+	entry, ok := lf.Get(path, version)
+	if !ok {
+		lf.Set(path, version, hash)
+		return nil
+	}
+
+	if entry.Hash != hash {
+		return fmt.Errorf("%w for %s: %s locked, got %s", ErrChecksumMismatch, lockKey(path, version), entry.Hash, hash)
+	}
+
+	return nil
+}
+
+// Prune removes the entries for which keep returns false.
+func (lf *LockFile) Prune(keep func(LockEntry) bool) {
+	entries := lf.Entries[:0]
+	for _, entry := range lf.Entries {
+		if keep(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	lf.Entries = entries
+}
+
+// Format returns the content of the lock file, sorted by path and version.
+func (lf *LockFile) Format() []byte {
+	entries := append([]LockEntry(nil), lf.Entries...)
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Path != entries[j].Path {
+			return entries[i].Path < entries[j].Path
+		}
+		return entries[i].Version < entries[j].Version
+	})
+
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		fmt.Fprintf(&buf, "%s %s\n", lockKey(entry.Path, entry.Version), entry.Hash)
+	}
+
+	return buf.Bytes()
+}
+
+// Write writes the lock file in dir. If it has no entries, any
+// existing lock file is removed instead.
+func (lf *LockFile) Write(dir string) error {
+	fname := lockFilePath(dir)
+
+	if len(lf.Entries) == 0 {
+		if err := os.Remove(fname); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove %q: %w", fname, err)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(fname, lf.Format(), 0o644); err != nil {
+		return fmt.Errorf("writefile %q: %w", fname, err)
+	}
+
+	return nil
+}
+
+// HashMemPackage returns the hash of the Gno source files of a package,
+// in the "h1:" format of Go module hashes. Other files, like the gno.mod
+// written in the modules cache, are ignored.
+func HashMemPackage(files []*gnovm.MemFile) (string, error) {
+	bodies := make(map[string]string, len(files))
+	names := make([]string, 0, len(files))
+
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name, ".gno") {
+			continue
+		}
+
+		bodies[file.Name] = file.Body
+		names = append(names, file.Name)
+	}
+
+	return dirhash.Hash1(names, func(name string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(bodies[name])), nil
+	})
+}
+
+func lockFilePath(dir string) string {
+	return filepath.Join(dir, LockFileName)
+}
+
+func lockKey(path, version string) string {
+	if version == "" {
+		return path
+	}
+	return path + " " + version
+}
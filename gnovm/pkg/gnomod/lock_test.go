@@ -0,0 +1,74 @@
+package gnomod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/gnovm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockFile(t *testing.T) {
+	dir := t.TempDir()
+
+	lf, err := ReadLockFile(dir)
+	require.NoError(t, err)
+	assert.Empty(t, lf.Entries)
+
+	// Write without entries doesn't create the file
+	require.NoError(t, lf.Write(dir))
+	assert.NoFileExists(t, filepath.Join(dir, LockFileName))
+
+	require.NoError(t, lf.Verify("gno.land/p/demo/ufmt", "", "h1:ufmt"))
+	require.NoError(t, lf.Verify("gno.land/p/demo/avl/v2", "v2.1.0", "h1:avl"))
+	require.NoError(t, lf.Verify("gno.land/p/demo/avl/v2", "v2.1.0", "h1:avl"))
+	require.ErrorIs(t, lf.Verify("gno.land/p/demo/avl/v2", "v2.1.0", "h1:other"), ErrChecksumMismatch)
+
+	require.NoError(t, lf.Write(dir))
+
+	data, err := os.ReadFile(filepath.Join(dir, LockFileName))
+	require.NoError(t, err)
+	assert.Equal(t, "gno.land/p/demo/avl/v2 v2.1.0 h1:avl\ngno.land/p/demo/ufmt h1:ufmt\n", string(data))
+
+	lf, err = ReadLockFile(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []LockEntry{
+		{Path: "gno.land/p/demo/avl/v2", Version: "v2.1.0", Hash: "h1:avl"},
+		{Path: "gno.land/p/demo/ufmt", Hash: "h1:ufmt"},
+	}, lf.Entries)
+
+	lf.Prune(func(entry LockEntry) bool { return entry.Version != "" })
+	assert.Len(t, lf.Entries, 1)
+}
+
+func TestParseLockFile(t *testing.T) {
+	_, err := ParseLockFile("gno.lock", []byte("gno.land/p/demo/avl\n"))
+	require.ErrorContains(t, err, "gno.lock:1: malformed line")
+
+	_, err = ParseLockFile("gno.lock", []byte("\ngno.land/p/demo/avl md5:abc\n"))
+	require.ErrorContains(t, err, "gno.lock:2: unknown hash")
+}
+
+func TestHashMemPackage(t *testing.T) {
+	files := []*gnovm.MemFile{
+		{Name: "b.gno", Body: "package a\n"},
+		{Name: "a.gno", Body: "package a\n"},
+		{Name: "gno.mod", Body: "module a\n"},
+	}
+
+	hash, err := HashMemPackage(files)
+	require.NoError(t, err)
+	assert.Regexp(t, `^h1:[A-Za-z0-9+/]{43}=$`, hash)
+
+	// The order of the files and the non-gno files don't matter
+	other, err := HashMemPackage([]*gnovm.MemFile{files[1], files[0]})
+	require.NoError(t, err)
+	assert.Equal(t, hash, other)
+
+	// The content does
+	other, err = HashMemPackage([]*gnovm.MemFile{files[0], {Name: "a.gno", Body: "package b\n"}})
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+}
@@ -105,7 +105,7 @@ func Parse(file string, data []byte) (*File, error) {
 					Err:      fmt.Errorf("unknown block type: %s", strings.Join(x.Token, " ")),
 				})
 				continue
-			case "module", "replace":
+			case "module", "require", "replace":
 				for _, l := range x.Line {
 					f.add(&errs, x, l, x.Token[0], l.Token)
 				}
@@ -184,6 +184,14 @@ func (f *File) add(errs *modfile.ErrorList, block *modfile.LineBlock, line *modf
 		}
 		f.Module.Mod = module.Version{Path: s}
 
+	case "require":
+		req, wrappederr := parseRequire(f.Syntax.Name, line, verb, args)
+		if wrappederr != nil {
+			*errs = append(*errs, *wrappederr)
+			return
+		}
+		f.Require = append(f.Require, req)
+
 	case "replace":
 		replace, wrappederr := parseReplace(f.Syntax.Name, line, verb, args)
 		if wrappederr != nil {
@@ -212,11 +212,11 @@ func TestParseGnoMod(t *testing.T) {
 			errShouldContain: "requires module",
 		},
 		{
-			desc: "error gno.mod with require",
+			desc: "error gno.mod with invalid require",
 			modData: `module foo
-			require bar v0.0.0`,
+			require bar latest`,
 			modPath:          filepath.Join(pkgDir, "gno.mod"),
-			errShouldContain: "unknown directive: require",
+			errShouldContain: "must be of the form v1.2.3",
 		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
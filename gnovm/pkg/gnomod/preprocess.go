@@ -5,13 +5,42 @@ import (
 	"golang.org/x/mod/module"
 )
 
-func removeDups(syntax *modfile.FileSyntax, replace *[]*modfile.Replace) {
+func removeDups(syntax *modfile.FileSyntax, require *[]*modfile.Require, replace *[]*modfile.Replace) {
+	if require != nil {
+		purged := removeRequireDups(require)
+		cleanSyntaxTree(syntax, purged)
+	}
 	if replace != nil {
 		purged := removeReplaceDups(replace)
 		cleanSyntaxTree(syntax, purged)
 	}
 }
 
+// removeRequireDups removes duplicate requirements of a same path.
+// Later requirements take priority over earlier ones.
+func removeRequireDups(require *[]*modfile.Require) map[*modfile.Line]bool {
+	purge := make(map[*modfile.Line]bool)
+
+	haveRequire := make(map[string]bool)
+	for i := len(*require) - 1; i >= 0; i-- {
+		x := (*require)[i]
+		if x.Syntax == nil || haveRequire[x.Mod.Path] { // dropped or duplicate
+			purge[x.Syntax] = true
+			continue
+		}
+		haveRequire[x.Mod.Path] = true
+	}
+	var req []*modfile.Require
+	for _, r := range *require {
+		if !purge[r.Syntax] {
+			req = append(req, r)
+		}
+	}
+	*require = req
+
+	return purge
+}
+
 // removeReplaceDups removes duplicate replacements.
 // Later replacements take priority over earlier ones.
 func removeReplaceDups(replace *[]*modfile.Replace) map[*modfile.Line]bool {
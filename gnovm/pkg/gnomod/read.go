@@ -718,6 +718,50 @@ func parseVersion(verb string, path string, s *string) (string, error) {
 	return *s, nil
 }
 
+func parseRequire(filename string, line *modfile.Line, verb string, args []string) (*modfile.Require, *modfile.Error) {
+	wrapModPathError := func(modPath string, err error) *modfile.Error {
+		return &modfile.Error{
+			Filename: filename,
+			Pos:      line.Start,
+			ModPath:  modPath,
+			Verb:     verb,
+			Err:      err,
+		}
+	}
+	wrapError := func(err error) *modfile.Error {
+		return &modfile.Error{
+			Filename: filename,
+			Pos:      line.Start,
+			Err:      err,
+		}
+	}
+	errorf := func(format string, args ...any) *modfile.Error {
+		return wrapError(fmt.Errorf(format, args...))
+	}
+
+	if len(args) != 2 {
+		return nil, errorf("usage: %s module/path v1.2.3", verb)
+	}
+	s, err := parseString(&args[0])
+	if err != nil {
+		return nil, errorf("invalid quoted string: %v", err)
+	}
+	if err := module.CheckImportPath(s); err != nil {
+		return nil, wrapModPathError(s, err)
+	}
+	v, err := parseVersion(verb, s, &args[1])
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	if err := checkRequiredVersion(s, v); err != nil {
+		return nil, wrapModPathError(s, err)
+	}
+	return &modfile.Require{
+		Mod:    module.Version{Path: s, Version: v},
+		Syntax: line,
+	}, nil
+}
+
 func parseReplace(filename string, line *modfile.Line, verb string, args []string) (*modfile.Replace, *modfile.Error) {
 	wrapModPathError := func(modPath string, err error) *modfile.Error {
 		return &modfile.Error{
@@ -955,6 +999,46 @@ func addLine(x *modfile.FileSyntax, hint modfile.Expr, tokens ...string) *modfil
 	return newl
 }
 
+func addRequire(syntax *modfile.FileSyntax, require *[]*modfile.Require, path, vers string) error {
+	if err := module.CheckImportPath(path); err != nil {
+		return err
+	}
+	cv := module.CanonicalVersion(vers)
+	if cv == "" {
+		return &module.InvalidVersionError{
+			Version: vers,
+			Err:     errors.New("must be of the form v1.2.3"),
+		}
+	}
+	if err := checkRequiredVersion(path, cv); err != nil {
+		return err
+	}
+
+	need := true
+	tokens := []string{"require", modfile.AutoQuote(path), cv}
+	for _, r := range *require {
+		if r.Mod.Path != path {
+			continue
+		}
+		if need {
+			r.Mod.Version = cv
+			updateLine(r.Syntax, tokens...)
+			need = false
+			continue
+		}
+		// Already updated; delete other requirements for same.
+		markLineAsRemoved(r.Syntax)
+		*r = modfile.Require{}
+	}
+	if need {
+		*require = append(*require, &modfile.Require{
+			Mod:    module.Version{Path: path, Version: cv},
+			Syntax: addLine(syntax, nil, tokens...),
+		})
+	}
+	return nil
+}
+
 func addReplace(syntax *modfile.FileSyntax, replace *[]*modfile.Replace, oldPath, oldVers, newPath, newVers string) error {
 	need := true
 	oldv := module.Version{Path: oldPath, Version: oldVers}
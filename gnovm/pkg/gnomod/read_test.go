@@ -275,6 +275,93 @@ var addReplaceTests = []struct {
 	},
 }
 
+var addRequireTests = []struct {
+	desc string
+	in   string
+	path string
+	vers string
+	out  string
+}{
+	{
+		`new`,
+		`
+		module m
+		`,
+		"x.y/z",
+		"v1.5.6",
+		`
+		module m
+		require x.y/z v1.5.6
+		`,
+	},
+	{
+		`update`,
+		`
+		module m
+		require x.y/z v1.2.3
+		`,
+		"x.y/z",
+		"v2.0.0-height.42",
+		`
+		module m
+		require x.y/z v2.0.0-height.42
+		`,
+	},
+	{
+		`block`,
+		`
+		module m
+		require (
+			a.b/c v1.0.0
+			x.y/z v1.2.3
+		)
+		`,
+		"x.y/z",
+		"v1.3.0",
+		`
+		module m
+		require (
+			a.b/c v1.0.0
+			x.y/z v1.3.0
+		)
+		`,
+	},
+}
+
+var dropRequireTests = []struct {
+	desc string
+	in   string
+	path string
+	out  string
+}{
+	{
+		`existing`,
+		`
+		module m
+
+		require x.y/z v1.2.3
+		`,
+		"x.y/z",
+		`
+		module m
+		`,
+	},
+	{
+		`not_exists`,
+		`
+		module m
+
+		require x.y/z v1.2.3
+		`,
+		"a.b/c",
+		`
+		module m
+
+		require x.y/z v1.2.3
+		`,
+	},
+}
+
 var dropReplaceTests = []struct {
 	desc string
 	in   string
@@ -336,6 +423,35 @@ func TestAddReplace(t *testing.T) {
 	}
 }
 
+func TestAddRequire(t *testing.T) {
+	for _, tt := range addRequireTests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := testEdit(t, tt.in, tt.out, func(f *File) error {
+				err := f.AddRequire(tt.path, tt.vers)
+				f.Syntax.Cleanup()
+				return err
+			})
+
+			mod, ok := f.Required(tt.path)
+			if !ok || mod.Version != tt.vers {
+				t.Errorf("Required(%q) = %v, %v, want %s", tt.path, mod, ok, tt.vers)
+			}
+		})
+	}
+}
+
+func TestDropRequire(t *testing.T) {
+	for _, tt := range dropRequireTests {
+		t.Run(tt.desc, func(t *testing.T) {
+			testEdit(t, tt.in, tt.out, func(f *File) error {
+				err := f.DropRequire(tt.path)
+				f.Syntax.Cleanup()
+				return err
+			})
+		})
+	}
+}
+
 func TestDropReplace(t *testing.T) {
 	for _, tt := range dropReplaceTests {
 		t.Run(tt.desc, func(t *testing.T) {
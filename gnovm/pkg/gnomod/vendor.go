@@ -0,0 +1,68 @@
+package gnomod
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// VendorDirName is the name of the directory holding the vendored
+	// dependencies of a module, next to its gno.mod.
+	VendorDirName = "vendor"
+
+	// VendorManifestName is the name of the manifest of the vendor
+	// directory. It lists the vendored packages in the [LockFile] format,
+	// keyed by import path.
+	VendorManifestName = "modules.txt"
+)
+
+// FindVendorDir returns the vendor directory of the module containing dir,
+// looking for a vendor manifest in dir and its parents. It returns an empty
+// string if there is none.
+func FindVendorDir(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		vendorDir := filepath.Join(dir, VendorDirName)
+		if _, err := os.Stat(filepath.Join(vendorDir, VendorManifestName)); err == nil {
+			return vendorDir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ReadVendorManifest reads the manifest of the vendor directory.
+func ReadVendorManifest(vendorDir string) (*LockFile, error) {
+	fname := filepath.Join(vendorDir, VendorManifestName)
+
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s not found, run gno mod vendor", fname)
+		}
+		return nil, fmt.Errorf("readfile %q: %w", fname, err)
+	}
+
+	return ParseLockFile(fname, data)
+}
+
+// WriteVendorManifest writes the manifest of the vendor directory.
+func WriteVendorManifest(vendorDir string, manifest *LockFile) error {
+	fname := filepath.Join(vendorDir, VendorManifestName)
+
+	if err := os.WriteFile(fname, manifest.Format(), 0o644); err != nil {
+		return fmt.Errorf("writefile %q: %w", fname, err)
+	}
+
+	return nil
+}
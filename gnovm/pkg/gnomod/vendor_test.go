@@ -0,0 +1,35 @@
+package gnomod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVendorManifest(t *testing.T) {
+	dir := t.TempDir()
+	vendorDir := filepath.Join(dir, VendorDirName)
+	pkgDir := filepath.Join(dir, "sub", "pkg")
+	require.NoError(t, os.MkdirAll(vendorDir, 0o755))
+	require.NoError(t, os.MkdirAll(pkgDir, 0o755))
+
+	// Without manifest, the vendor directory is ignored
+	assert.Equal(t, "", FindVendorDir(pkgDir))
+	_, err := ReadVendorManifest(vendorDir)
+	require.ErrorContains(t, err, "modules.txt not found, run gno mod vendor")
+
+	manifest := &LockFile{}
+	manifest.Set("gno.land/p/demo/avl", "v1.0.0", "h1:avl")
+	manifest.Set("gno.land/p/demo/ufmt", "", "h1:ufmt")
+	require.NoError(t, WriteVendorManifest(vendorDir, manifest))
+
+	assert.Equal(t, vendorDir, FindVendorDir(pkgDir))
+	assert.Equal(t, vendorDir, FindVendorDir(dir))
+
+	read, err := ReadVendorManifest(vendorDir)
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Entries, read.Entries)
+}
@@ -0,0 +1,73 @@
+package gnomod
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// heightPrerelease is the pre-release prefix of the versions pinning
+// a deploy height, like v1.2.3-height.1234.
+const heightPrerelease = "-height."
+
+// OnChainPath returns the path where the required module version is
+// deployed on chain. Like Go modules, major versions v2 and above are
+// deployed with a /vN suffix: gno.land/p/demo/avl at v2.1.0 is
+// gno.land/p/demo/avl/v2, unless the path already has the suffix.
+func OnChainPath(m module.Version) string {
+	major := semver.Major(m.Version)
+	if major == "" || major == "v0" || major == "v1" {
+		return m.Path
+	}
+
+	if _, pathMajor, ok := module.SplitPathVersion(m.Path); ok && pathMajor != "" {
+		return m.Path
+	}
+
+	return m.Path + "/" + major
+}
+
+// DeployHeight returns the block height pinned by the version, with its
+// height pre-release (v1.2.3-height.1234), or 0 if it doesn't pin one.
+// The package is then fetched as it was deployed at this height.
+func DeployHeight(version string) int64 {
+	prerelease := semver.Prerelease(version)
+	if !strings.HasPrefix(prerelease, heightPrerelease) {
+		return 0
+	}
+
+	height, err := strconv.ParseInt(strings.TrimPrefix(prerelease, heightPrerelease), 10, 64)
+	if err != nil || height <= 0 {
+		return 0
+	}
+
+	return height
+}
+
+// checkRequiredVersion checks that the version of a require directive
+// matches the major version suffix of the path, and pins a valid height.
+func checkRequiredVersion(path, version string) error {
+	_, pathMajor, ok := module.SplitPathVersion(path)
+	if !ok {
+		return fmt.Errorf("invalid module path")
+	}
+	if pathMajor != "" && semver.Major(version) != strings.TrimPrefix(pathMajor, "/") {
+		return &module.InvalidVersionError{
+			Version: version,
+			Err:     fmt.Errorf("should be %s, not %s", strings.TrimPrefix(pathMajor, "/"), semver.Major(version)),
+		}
+	}
+
+	prerelease := semver.Prerelease(version)
+	if strings.HasPrefix(prerelease, heightPrerelease) && DeployHeight(version) == 0 {
+		return &module.InvalidVersionError{
+			Version: version,
+			Err:     fmt.Errorf("invalid deploy height %q", strings.TrimPrefix(prerelease, heightPrerelease)),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package gnomod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/module"
+)
+
+func TestOnChainPath(t *testing.T) {
+	for _, tc := range []struct {
+		path, version, expected string
+	}{
+		{"gno.land/p/demo/avl", "v0.1.0", "gno.land/p/demo/avl"},
+		{"gno.land/p/demo/avl", "v1.2.3", "gno.land/p/demo/avl"},
+		{"gno.land/p/demo/avl", "v2.1.0", "gno.land/p/demo/avl/v2"},
+		{"gno.land/p/demo/avl", "v3.0.0-height.42", "gno.land/p/demo/avl/v3"},
+		{"gno.land/r/gov/dao/v3", "v3.0.0", "gno.land/r/gov/dao/v3"},
+		{"gno.land/p/demo/avl", "", "gno.land/p/demo/avl"},
+	} {
+		t.Run(tc.path+"@"+tc.version, func(t *testing.T) {
+			assert.Equal(t, tc.expected, OnChainPath(module.Version{Path: tc.path, Version: tc.version}))
+		})
+	}
+}
+
+func TestDeployHeight(t *testing.T) {
+	assert.Equal(t, int64(0), DeployHeight("v1.2.3"))
+	assert.Equal(t, int64(0), DeployHeight("v1.2.3-rc1"))
+	assert.Equal(t, int64(1234), DeployHeight("v1.2.3-height.1234"))
+	assert.Equal(t, int64(0), DeployHeight("v1.2.3-height.abc"))
+}
+
+func TestParseRequire(t *testing.T) {
+	for _, tc := range []struct {
+		desc, in    string
+		errContains string
+		expected    []module.Version
+	}{
+		{
+			desc: "line",
+			in:   "module m\nrequire gno.land/p/demo/avl v1.0.0\n",
+			expected: []module.Version{
+				{Path: "gno.land/p/demo/avl", Version: "v1.0.0"},
+			},
+		},
+		{
+			desc: "block",
+			in:   "module m\nrequire (\n\tgno.land/p/demo/avl v2.0\n\tgno.land/r/gov/dao/v3 v3.1.0-height.42\n)\n",
+			expected: []module.Version{
+				{Path: "gno.land/p/demo/avl", Version: "v2.0.0"},
+				{Path: "gno.land/r/gov/dao/v3", Version: "v3.1.0-height.42"},
+			},
+		},
+		{
+			desc:        "missing_version",
+			in:          "module m\nrequire gno.land/p/demo/avl\n",
+			errContains: "usage: require module/path v1.2.3",
+		},
+		{
+			desc:        "invalid_version",
+			in:          "module m\nrequire gno.land/p/demo/avl latest\n",
+			errContains: "must be of the form v1.2.3",
+		},
+		{
+			desc:        "major_mismatch",
+			in:          "module m\nrequire gno.land/r/gov/dao/v3 v2.0.0\n",
+			errContains: "should be v3, not v2",
+		},
+		{
+			desc:        "invalid_height",
+			in:          "module m\nrequire gno.land/p/demo/avl v1.0.0-height.0\n",
+			errContains: "invalid deploy height",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			f, err := Parse("gno.mod", []byte(tc.in))
+			if tc.errContains != "" {
+				require.ErrorContains(t, err, tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+
+			var mods []module.Version
+			for _, r := range f.Require {
+				mods = append(mods, r.Mod)
+			}
+			assert.Equal(t, tc.expected, mods)
+		})
+	}
+}
+
+func TestResolveRequire(t *testing.T) {
+	f, err := Parse("gno.mod", []byte(`module m
+
+require (
+	gno.land/p/demo/avl v2.1.0
+	gno.land/p/demo/ufmt v1.0.0
+)
+
+replace gno.land/p/demo/ufmt => ../ufmt
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		module.Version{Path: "gno.land/p/demo/avl/v2", Version: "v2.1.0"},
+		f.Resolve(module.Version{Path: "gno.land/p/demo/avl"}),
+	)
+	// Replace directives take precedence
+	assert.Equal(t,
+		module.Version{Path: "../ufmt"},
+		f.Resolve(module.Version{Path: "gno.land/p/demo/ufmt"}),
+	)
+	assert.Equal(t,
+		module.Version{Path: "gno.land/p/demo/seqid"},
+		f.Resolve(module.Version{Path: "gno.land/p/demo/seqid"}),
+	)
+}
@@ -0,0 +1,240 @@
+package lint
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// callerChecks are the std functions used to authenticate the caller of a realm
+var callerChecks = []string{
+	"PreviousRealm",
+	"OriginCaller",
+	"CallerAt",
+	"AssertOriginCall",
+}
+
+// callee returns the function or method called, or nil
+// if the call is a conversion, a builtin or a func value call
+func callee(info *types.Info, call *ast.CallExpr) *types.Func {
+	var obj types.Object
+
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		obj = info.Uses[fun]
+	case *ast.SelectorExpr:
+		if sel, ok := info.Selections[fun]; ok {
+			obj = sel.Obj()
+		} else {
+			obj = info.Uses[fun.Sel]
+		}
+	case *ast.IndexExpr: // generic instantiation
+		if id, ok := ast.Unparen(fun.X).(*ast.Ident); ok {
+			obj = info.Uses[id]
+		}
+	}
+
+	fn, _ := obj.(*types.Func)
+
+	return fn
+}
+
+// isStdFunc returns true if fn is one of the given
+// functions or methods of the std package
+func isStdFunc(fn *types.Func, names ...string) bool {
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "std" {
+		return false
+	}
+
+	for _, name := range names {
+		if fn.Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isBuiltin returns true if the call is a call to the given builtin
+func isBuiltin(info *types.Info, call *ast.CallExpr, name string) bool {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	b, ok := info.Uses[id].(*types.Builtin)
+
+	return ok && b.Name() == name
+}
+
+// isAVLTree returns true if typ is an avl.Tree, or a pointer to it
+func isAVLTree(typ types.Type) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+	if obj.Name() != "Tree" || obj.Pkg() == nil {
+		return false
+	}
+
+	path := obj.Pkg().Path()
+
+	return path == "avl" || strings.HasSuffix(path, "/avl")
+}
+
+// funcDecls returns the declarations of the functions
+// and methods of the package, by object
+func funcDecls(pass *Pass) map[*types.Func]*ast.FuncDecl {
+	decls := make(map[*types.Func]*ast.FuncDecl)
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+
+			if fn, ok := pass.Info.Defs[fd.Name].(*types.Func); ok {
+				decls[fn] = fd
+			}
+		}
+	}
+
+	return decls
+}
+
+// exportedRealmFuncs returns the exported top-level functions of the
+// package, which can be called by users and other realms, except Render
+func exportedRealmFuncs(pass *Pass) []*ast.FuncDecl {
+	var fns []*ast.FuncDecl
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil || fd.Recv != nil {
+				continue
+			}
+
+			if !fd.Name.IsExported() || fd.Name.Name == "Render" {
+				continue
+			}
+
+			fns = append(fns, fd)
+		}
+	}
+
+	return fns
+}
+
+// reachable returns the given function and the functions and methods of
+// the package that it calls, transitively. If closures is false, the calls
+// in function literals, which may run later, are ignored.
+func reachable(pass *Pass, decls map[*types.Func]*ast.FuncDecl, root *ast.FuncDecl, closures bool) []*ast.FuncDecl {
+	var (
+		visited = map[*ast.FuncDecl]bool{root: true}
+		queue   = []*ast.FuncDecl{root}
+	)
+
+	for i := 0; i < len(queue); i++ {
+		ast.Inspect(queue[i].Body, func(n ast.Node) bool {
+			if _, ok := n.(*ast.FuncLit); ok {
+				return closures
+			}
+
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			fn := callee(pass.Info, call)
+			if fn == nil {
+				return true
+			}
+
+			if fd, ok := decls[fn.Origin()]; ok && !visited[fd] {
+				visited[fd] = true
+				queue = append(queue, fd)
+			}
+
+			return true
+		})
+	}
+
+	return queue
+}
+
+// findCall returns the first call in the bodies of fns that matches,
+// or nil if there is none
+func findCall(pass *Pass, fns []*ast.FuncDecl, match func(*ast.CallExpr, *types.Func) bool) *ast.CallExpr {
+	var found *ast.CallExpr
+
+	for _, fd := range fns {
+		ast.Inspect(fd.Body, func(n ast.Node) bool {
+			if found != nil {
+				return false
+			}
+
+			if call, ok := n.(*ast.CallExpr); ok && match(call, callee(pass.Info, call)) {
+				found = call
+			}
+
+			return found == nil
+		})
+
+		if found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// checksCaller returns true if one of the functions checks the caller with
+// the std package, or with an assertion of another package, assumed to check
+// the caller, like ownable.Ownable.AssertCallerIsOwner
+func checksCaller(pass *Pass, fns []*ast.FuncDecl) bool {
+	return findCall(pass, fns, func(_ *ast.CallExpr, fn *types.Func) bool {
+		if fn == nil || fn.Pkg() == nil || fn.Pkg() == pass.Pkg {
+			return false
+		}
+
+		return isStdFunc(fn, callerChecks...) || strings.HasPrefix(fn.Name(), "Assert")
+	}) != nil
+}
+
+// packageVar returns the package-level variable at the root of the
+// expression (x in x.a[0].b), or nil if it is not one
+func packageVar(pass *Pass, expr ast.Expr) *types.Var {
+	for {
+		switch e := expr.(type) {
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			// Qualified identifier of another package
+			if _, ok := pass.Info.Uses[e.Sel].(*types.Var); ok && pass.Info.Selections[e] == nil {
+				return nil
+			}
+
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.Ident:
+			v, ok := pass.Info.Uses[e].(*types.Var)
+			if !ok || v.Pkg() != pass.Pkg || v.Parent() != pass.Pkg.Scope() {
+				return nil
+			}
+
+			return v
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Package lint implements the static analysis passes of gno lint.
+//
+// An [Analyzer] inspects a type checked package, and reports the issues it
+// finds through its [Pass], similarly to golang.org/x/tools/go/analysis.
+// The built-in analyzers look for Gno-specific mistakes, such as realm
+// state mutated without checking the caller.
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"sort"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// Code identifies the kind of an issue reported by gno lint
+type Code int
+
+const (
+	CodeUnknown Code = iota
+	CodeGnoMod
+	CodeGnoError
+	CodeParserError
+	CodeTypeCheckError
+	CodeRealmAuth
+	CodeRealmPointerGlobal
+	CodeUncheckedSendCoins
+	CodeOriginCall
+	CodeUnboundedRenderLoop
+
+	// TODO: add new linter codes here.
+)
+
+var codeNames = map[Code]string{
+	CodeUnknown:             "unknown",
+	CodeGnoMod:              "gno-mod",
+	CodeGnoError:            "gno-error",
+	CodeParserError:         "parser-error",
+	CodeTypeCheckError:      "typecheck-error",
+	CodeRealmAuth:           "realm-auth",
+	CodeRealmPointerGlobal:  "realm-pointer-global",
+	CodeUncheckedSendCoins:  "unchecked-send-coins",
+	CodeOriginCall:          "origin-call",
+	CodeUnboundedRenderLoop: "unbounded-render-loop",
+}
+
+// String returns the name of the code, used as rule ID
+func (c Code) String() string {
+	if name, ok := codeNames[c]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("code-%d", int(c))
+}
+
+// Severity is the severity of an issue
+type Severity string
+
+const (
+	// SeverityError issues make gno lint fail
+	SeverityError Severity = "error"
+
+	// SeverityWarning issues are reported, but don't make gno lint fail
+	SeverityWarning Severity = "warning"
+)
+
+// Analyzer is a static analysis pass, run on type checked packages
+type Analyzer struct {
+	Code       Code
+	Doc        string
+	Severity   Severity
+	Confidence float64 // 1 is 100%
+
+	Run func(pass *Pass)
+}
+
+// Name returns the name of the analyzer
+func (a *Analyzer) Name() string {
+	return a.Code.String()
+}
+
+// Pass is the input of an analyzer, run on a package
+type Pass struct {
+	Analyzer *Analyzer
+
+	// PkgPath is the package path, as declared in gno.mod
+	PkgPath string
+
+	Fset  *token.FileSet
+	Files []*ast.File
+	Pkg   *types.Package
+	Info  *types.Info
+
+	diagnostics []Diagnostic
+}
+
+// Diagnostic is an issue reported by an analyzer
+type Diagnostic struct {
+	Analyzer *Analyzer
+	Pos      token.Position
+	Message  string
+}
+
+// Reportf reports an issue at the given position
+func (p *Pass) Reportf(pos token.Pos, format string, args ...any) {
+	p.diagnostics = append(p.diagnostics, Diagnostic{
+		Analyzer: p.Analyzer,
+		Pos:      p.Fset.Position(pos),
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// position returns the short position (file:line:column) of pos,
+// used to refer to other locations in the messages
+func (p *Pass) position(pos token.Pos) string {
+	position := p.Fset.Position(pos)
+
+	return fmt.Sprintf("%s:%d:%d", path.Base(position.Filename), position.Line, position.Column)
+}
+
+// IsRealm returns true if the analyzed package is a realm
+func (p *Pass) IsRealm() bool {
+	return gno.IsRealmPath(p.PkgPath)
+}
+
+// Analyzers returns the built-in analyzers
+func Analyzers() []*Analyzer {
+	return []*Analyzer{
+		RealmAuthAnalyzer,
+		RealmPointerGlobalAnalyzer,
+		UncheckedSendCoinsAnalyzer,
+		OriginCallAnalyzer,
+		UnboundedRenderLoopAnalyzer,
+	}
+}
+
+// Run runs the analyzers on the type checked package,
+// and returns their diagnostics, sorted by position
+func Run(pkgPath string, res *gno.TypeCheckResult, analyzers []*Analyzer) []Diagnostic {
+	if res == nil || res.Pkg == nil {
+		return nil
+	}
+
+	var diagnostics []Diagnostic
+
+	for _, analyzer := range analyzers {
+		pass := &Pass{
+			Analyzer: analyzer,
+			PkgPath:  pkgPath,
+			Fset:     res.Fset,
+			Files:    res.Files,
+			Pkg:      res.Pkg,
+			Info:     res.Info,
+		}
+
+		analyzer.Run(pass)
+
+		diagnostics = append(diagnostics, pass.diagnostics...)
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i].Pos, diagnostics[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+
+		return a.Offset < b.Offset
+	})
+
+	return diagnostics
+}
@@ -0,0 +1,412 @@
+package lint
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gnolang/gno/gnovm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPackageGetter []*gnovm.MemPackage
+
+func (mi mockPackageGetter) GetMemPackage(path string) *gnovm.MemPackage {
+	for _, pkg := range mi {
+		if pkg.Path == path {
+			return pkg
+		}
+	}
+	return nil
+}
+
+var testGetter = mockPackageGetter{
+	{
+		Name: "std",
+		Path: "std",
+		Files: []*gnovm.MemFile{
+			{
+				Name: "std.gno",
+				Body: `
+package std
+
+type Address string
+type Coins []int64
+type Realm struct{}
+
+func (r Realm) Address() Address { return "" }
+
+type Banker interface {
+	SendCoins(from, to Address, amt Coins)
+}
+
+func NewBanker(bt int) Banker { return nil }
+
+func AssertOriginCall()           {}
+func OriginCaller() Address       { return "" }
+func CurrentRealm() Realm         { return Realm{} }
+func PreviousRealm() Realm        { return Realm{} }
+func CallerAt(n int) Address      { return "" }`,
+			},
+		},
+	},
+	{
+		Name: "avl",
+		Path: "gno.land/p/demo/avl",
+		Files: []*gnovm.MemFile{
+			{
+				Name: "avl.gno",
+				Body: `
+package avl
+
+type Tree struct{}
+
+func NewTree() *Tree { return &Tree{} }
+
+func (t *Tree) Size() int                                       { return 0 }
+func (t *Tree) Get(key string) (any, bool)                      { return nil, false }
+func (t *Tree) Set(key string, value any) bool                  { return false }
+func (t *Tree) Remove(key string) (any, bool)                   { return nil, false }
+func (t *Tree) GetByIndex(i int) (string, any)                  { return "", nil }
+func (t *Tree) Iterate(start, end string, cb func(string, any) bool) bool { return false }
+func (t *Tree) ReverseIterate(start, end string, cb func(string, any) bool) bool { return false }
+func (t *Tree) IterateByOffset(offset, count int, cb func(string, any) bool) bool { return false }`,
+			},
+		},
+	},
+	{
+		Name: "ownable",
+		Path: "gno.land/p/demo/ownable",
+		Files: []*gnovm.MemFile{
+			{
+				Name: "ownable.gno",
+				Body: `
+package ownable
+
+type Ownable struct{}
+
+func (o *Ownable) AssertCallerIsOwner() {}`,
+			},
+		},
+	},
+}
+
+// runAnalyzer type checks the source as a package with the given
+// path, and returns the diagnostics of the analyzer
+func runAnalyzer(t *testing.T, analyzer *Analyzer, pkgPath, source string) []string {
+	t.Helper()
+
+	mpkg := &gnovm.MemPackage{
+		Name: "test",
+		Path: pkgPath,
+		Files: []*gnovm.MemFile{
+			{Name: "test.gno", Body: "package test" + source},
+		},
+	}
+
+	res, err := gno.TypeCheckMemPackageInfo(mpkg, testGetter)
+	require.NoError(t, err)
+
+	var diags []string
+	for _, d := range Run(pkgPath, res, []*Analyzer{analyzer}) {
+		assert.Equal(t, analyzer, d.Analyzer)
+		diags = append(diags, fmt.Sprintf("%d: %s", d.Pos.Line, d.Message))
+	}
+
+	return diags
+}
+
+const (
+	realmPath   = "gno.land/r/demo/test"
+	packagePath = "gno.land/p/demo/test"
+)
+
+func TestAnalyzers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		analyzer *Analyzer
+		pkgPath  string
+		source   string
+		expected []string
+	}{
+		{
+			"realm-auth: unchecked assignment",
+			RealmAuthAnalyzer,
+			realmPath,
+			`
+var owner string
+
+func SetOwner(o string) {
+	owner = o
+}`,
+			[]string{"4: SetOwner mutates realm state (owner, at test.gno:5:2) without checking its caller"},
+		},
+		{
+			"realm-auth: checked assignment",
+			RealmAuthAnalyzer,
+			realmPath,
+			`
+import "std"
+
+var owner std.Address
+
+func SetOwner(o std.Address) {
+	if std.PreviousRealm().Address() != owner {
+		panic("unauthorized")
+	}
+	owner = o
+}`,
+			nil,
+		},
+		{
+			"realm-auth: check in callee",
+			RealmAuthAnalyzer,
+			realmPath,
+			`
+import "std"
+
+var count int
+
+func assertAdmin() {
+	if std.OriginCaller() != "admin" {
+		panic("unauthorized")
+	}
+}
+
+func Inc() {
+	assertAdmin()
+	count++
+}`,
+			nil,
+		},
+		{
+			"realm-auth: mutation in callee",
+			RealmAuthAnalyzer,
+			realmPath,
+			`
+import "gno.land/p/demo/avl"
+
+var users = avl.NewTree()
+
+func register(name string) {
+	users.Set(name, true)
+}
+
+func Register(name string) {
+	register(name)
+}`,
+			[]string{"10: Register mutates realm state (users, at test.gno:7:2) without checking its caller"},
+		},
+		{
+			"realm-auth: map delete",
+			RealmAuthAnalyzer,
+			realmPath,
+			`
+var m = map[string]int{}
+
+func Delete(k string) {
+	delete(m, k)
+}`,
+			[]string{"4: Delete mutates realm state (m, at test.gno:5:2) without checking its caller"},
+		},
+		{
+			"realm-auth: assertion of another package",
+			RealmAuthAnalyzer,
+			realmPath,
+			`
+import "gno.land/p/demo/ownable"
+
+var (
+	owner ownable.Ownable
+	count int
+)
+
+func Inc() {
+	owner.AssertCallerIsOwner()
+	count++
+}`,
+			nil,
+		},
+		{
+			"realm-auth: mutation in closure",
+			RealmAuthAnalyzer,
+			realmPath,
+			`
+var price int
+
+func set(p int) { price = p }
+
+func ProposePrice(p int) func() {
+	return func() {
+		set(p)
+		price = p
+	}
+}`,
+			nil,
+		},
+		{
+			"realm-auth: read only",
+			RealmAuthAnalyzer,
+			realmPath,
+			`
+import "gno.land/p/demo/avl"
+
+var users = avl.NewTree()
+
+func Has(name string) bool {
+	_, ok := users.Get(name)
+	local := 0
+	local++
+	return ok
+}`,
+			nil,
+		},
+		{
+			"realm-auth: not a realm",
+			RealmAuthAnalyzer,
+			packagePath,
+			`
+var count int
+
+func Inc() { count++ }`,
+			nil,
+		},
+		{
+			"realm-pointer-global",
+			RealmPointerGlobalAnalyzer,
+			realmPath,
+			`
+import "gno.land/p/demo/avl"
+
+type Config struct{}
+
+var (
+	Users  = avl.NewTree()
+	Conf   *Config
+	users  = avl.NewTree()
+	Values []int
+)`,
+			[]string{
+				"7: exported realm variable Users has pointer type *gno.land/p/demo/avl.Tree",
+				"8: exported realm variable Conf has pointer type *Config",
+			},
+		},
+		{
+			"unchecked-send-coins",
+			UncheckedSendCoinsAnalyzer,
+			realmPath,
+			`
+import "std"
+
+func Withdraw(to std.Address) {
+	send(to)
+}
+
+func send(to std.Address) {
+	banker := std.NewBanker(0)
+	banker.SendCoins("realm", to, std.Coins{1})
+}
+
+func Claim() {
+	caller := std.PreviousRealm().Address()
+	std.NewBanker(0).SendCoins("realm", caller, std.Coins{1})
+}`,
+			[]string{"4: Withdraw sends coins (at test.gno:10:2) without checking its caller"},
+		},
+		{
+			"origin-call",
+			OriginCallAnalyzer,
+			realmPath,
+			`
+import "std"
+
+type T struct{}
+
+func Good() { std.AssertOriginCall() }
+
+func bad() { std.AssertOriginCall() }
+
+func (T) Bad() { std.AssertOriginCall() }
+
+func init() { std.AssertOriginCall() }
+
+func Closure() {
+	func() { std.AssertOriginCall() }()
+}`,
+			[]string{
+				"8: std.AssertOriginCall called in unexported function bad",
+				"10: std.AssertOriginCall called in method Bad",
+				"12: std.AssertOriginCall called in init",
+				"15: std.AssertOriginCall called in a closure",
+			},
+		},
+		{
+			"origin-call: not a realm",
+			OriginCallAnalyzer,
+			packagePath,
+			`
+import "std"
+
+func Assert() { std.AssertOriginCall() }`,
+			[]string{"4: std.AssertOriginCall called outside of a realm"},
+		},
+		{
+			"unbounded-render-loop",
+			UnboundedRenderLoopAnalyzer,
+			realmPath,
+			`
+import "gno.land/p/demo/avl"
+
+var tree = avl.NewTree()
+
+func Render(path string) string {
+	out := ""
+	tree.Iterate("", "", func(k string, v any) bool {
+		out += k
+		return false
+	})
+	tree.Iterate("a", "b", func(k string, v any) bool { return false })
+	tree.IterateByOffset(0, 10, func(k string, v any) bool { return false })
+	return out + list()
+}
+
+func list() string {
+	out := ""
+	for i := 0; i < tree.Size(); i++ {
+		k, _ := tree.GetByIndex(i)
+		out += k
+	}
+	for i := 0; i < min(10, tree.Size()); i++ {}
+	return out
+}
+
+func Other() {
+	tree.ReverseIterate("", "", func(k string, v any) bool { return false })
+}`,
+			[]string{
+				"8: unbounded Iterate over an avl.Tree in Render",
+				"19: loop over the size of an avl.Tree in Render",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := runAnalyzer(t, tc.analyzer, tc.pkgPath, tc.source)
+			assert.Equal(t, tc.expected, diags)
+		})
+	}
+}
+
+func TestCodeString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "typecheck-error", CodeTypeCheckError.String())
+	assert.Equal(t, "realm-auth", RealmAuthAnalyzer.Name())
+	assert.Equal(t, "code-42", Code(42).String())
+}
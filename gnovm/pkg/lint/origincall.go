@@ -0,0 +1,81 @@
+package lint
+
+import (
+	"go/ast"
+)
+
+// OriginCallAnalyzer reports calls to std.AssertOriginCall which always
+// panic: the check only passes when called directly by the exported realm
+// function invoked by the transaction
+var OriginCallAnalyzer = &Analyzer{
+	Code:       CodeOriginCall,
+	Doc:        "std.AssertOriginCall only passes when called directly by an exported realm function",
+	Severity:   SeverityWarning,
+	Confidence: 0.9,
+	Run:        runOriginCall,
+}
+
+func runOriginCall(pass *Pass) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fd, _ := decl.(*ast.FuncDecl)
+			if fd != nil && fd.Body == nil {
+				continue
+			}
+
+			reason := originCallMisuse(pass, fd)
+
+			inspectOriginCalls(pass, decl, func(call *ast.CallExpr, inClosure bool) {
+				switch {
+				case reason != "":
+					pass.Reportf(call.Pos(), "std.AssertOriginCall %s", reason)
+				case inClosure:
+					pass.Reportf(call.Pos(), "std.AssertOriginCall called in a closure")
+				}
+			})
+		}
+	}
+}
+
+// originCallMisuse returns why calling AssertOriginCall in the
+// function always fails, or an empty string if it can succeed
+func originCallMisuse(pass *Pass, fd *ast.FuncDecl) string {
+	switch {
+	case !pass.IsRealm():
+		return "called outside of a realm"
+	case fd == nil:
+		return "called in a package-level declaration"
+	case fd.Recv != nil:
+		return "called in method " + fd.Name.Name
+	case fd.Name.Name == "init" || fd.Name.Name == "Render":
+		return "called in " + fd.Name.Name
+	case !fd.Name.IsExported():
+		return "called in unexported function " + fd.Name.Name
+	}
+
+	return ""
+}
+
+// inspectOriginCalls calls fn for the calls to std.AssertOriginCall in
+// the declaration, with whether they are in a function literal
+func inspectOriginCalls(pass *Pass, decl ast.Decl, fn func(call *ast.CallExpr, inClosure bool)) {
+	var visit func(n ast.Node, inClosure bool)
+
+	visit = func(root ast.Node, inClosure bool) {
+		ast.Inspect(root, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				visit(n.Body, true)
+				return false
+			case *ast.CallExpr:
+				if isStdFunc(callee(pass.Info, n), "AssertOriginCall") {
+					fn(n, inClosure)
+				}
+			}
+
+			return true
+		})
+	}
+
+	visit(decl, false)
+}
@@ -0,0 +1,131 @@
+package lint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// RealmAuthAnalyzer reports exported realm functions that
+// mutate the realm state without checking their caller
+var RealmAuthAnalyzer = &Analyzer{
+	Code:       CodeRealmAuth,
+	Doc:        "exported realm functions mutating package state should check their caller",
+	Severity:   SeverityWarning,
+	Confidence: 0.6,
+	Run:        runRealmAuth,
+}
+
+// mutatingMethods are the names of the pointer methods
+// assumed to mutate their receiver, like avl.Tree.Set
+var mutatingMethods = map[string]bool{
+	"Set":    true,
+	"Remove": true,
+	"Delete": true,
+	"Insert": true,
+	"Append": true,
+	"Push":   true,
+	"Pop":    true,
+	"Clear":  true,
+}
+
+func runRealmAuth(pass *Pass) {
+	if !pass.IsRealm() {
+		return
+	}
+
+	decls := funcDecls(pass)
+
+	for _, fd := range exportedRealmFuncs(pass) {
+		fns := reachable(pass, decls, fd, false)
+
+		pos, v := findMutation(pass, fns)
+		if v == nil || checksCaller(pass, fns) {
+			continue
+		}
+
+		pass.Reportf(fd.Name.Pos(),
+			"%s mutates realm state (%s, at %s) without checking its caller",
+			fd.Name.Name, v.Name(), pass.position(pos),
+		)
+	}
+}
+
+// findMutation returns the first mutation of a package-level
+// variable in the bodies of fns, and the mutated variable
+func findMutation(pass *Pass, fns []*ast.FuncDecl) (token.Pos, *types.Var) {
+	var (
+		pos token.Pos
+		v   *types.Var
+	)
+
+	for _, fd := range fns {
+		ast.Inspect(fd.Body, func(n ast.Node) bool {
+			if v != nil {
+				return false
+			}
+
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				// Closures may run later, like the executors of proposals
+				return false
+			case *ast.AssignStmt:
+				if n.Tok == token.DEFINE {
+					return true
+				}
+
+				for _, lhs := range n.Lhs {
+					if v = packageVar(pass, lhs); v != nil {
+						pos = n.Pos()
+						break
+					}
+				}
+			case *ast.IncDecStmt:
+				if v = packageVar(pass, n.X); v != nil {
+					pos = n.Pos()
+				}
+			case *ast.CallExpr:
+				if v = mutatingCall(pass, n); v != nil {
+					pos = n.Pos()
+				}
+			}
+
+			return v == nil
+		})
+
+		if v != nil {
+			return pos, v
+		}
+	}
+
+	return token.NoPos, nil
+}
+
+// mutatingCall returns the package-level variable mutated by
+// the call to delete or to a mutating method, if any
+func mutatingCall(pass *Pass, call *ast.CallExpr) *types.Var {
+	if isBuiltin(pass.Info, call, "delete") && len(call.Args) > 0 {
+		return packageVar(pass, call.Args[0])
+	}
+
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	fn := callee(pass.Info, call)
+	if fn == nil || !mutatingMethods[fn.Name()] {
+		return nil
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return nil
+	}
+
+	if _, ok := recv.Type().(*types.Pointer); !ok {
+		return nil
+	}
+
+	return packageVar(pass, sel.X)
+}
@@ -0,0 +1,54 @@
+package lint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// RealmPointerGlobalAnalyzer reports exported package-level variables of
+// pointer type in realms, through which other realms can reach the state
+var RealmPointerGlobalAnalyzer = &Analyzer{
+	Code:       CodeRealmPointerGlobal,
+	Doc:        "realms should not export package-level variables of pointer type",
+	Severity:   SeverityWarning,
+	Confidence: 0.8,
+	Run:        runRealmPointerGlobal,
+}
+
+func runRealmPointerGlobal(pass *Pass) {
+	if !pass.IsRealm() {
+		return
+	}
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.VAR {
+				continue
+			}
+
+			for _, spec := range gd.Specs {
+				for _, name := range spec.(*ast.ValueSpec).Names {
+					if !name.IsExported() {
+						continue
+					}
+
+					v, ok := pass.Info.Defs[name].(*types.Var)
+					if !ok {
+						continue
+					}
+
+					if _, ok := v.Type().Underlying().(*types.Pointer); !ok {
+						continue
+					}
+
+					pass.Reportf(name.Pos(),
+						"exported realm variable %s has pointer type %s",
+						name.Name, types.TypeString(v.Type(), types.RelativeTo(pass.Pkg)),
+					)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package lint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+)
+
+// UnboundedRenderLoopAnalyzer reports loops over all the elements of an
+// avl.Tree in Render, whose gas cost grows with the size of the realm state
+var UnboundedRenderLoopAnalyzer = &Analyzer{
+	Code:       CodeUnboundedRenderLoop,
+	Doc:        "Render should paginate the elements of an avl.Tree, instead of iterating over all of them",
+	Severity:   SeverityWarning,
+	Confidence: 0.7,
+	Run:        runUnboundedRenderLoop,
+}
+
+func runUnboundedRenderLoop(pass *Pass) {
+	decls := funcDecls(pass)
+
+	render, ok := pass.Pkg.Scope().Lookup("Render").(*types.Func)
+	if !ok || decls[render] == nil {
+		return
+	}
+
+	for _, fd := range reachable(pass, decls, decls[render], true) {
+		ast.Inspect(fd.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				fn := callee(pass.Info, n)
+				if !isTreeMethod(fn, "Iterate", "ReverseIterate") || len(n.Args) < 2 {
+					return true
+				}
+
+				if isEmptyString(pass.Info, n.Args[0]) && isEmptyString(pass.Info, n.Args[1]) {
+					pass.Reportf(n.Pos(), "unbounded %s over an avl.Tree in Render", fn.Name())
+				}
+			case *ast.ForStmt:
+				if n.Cond != nil && boundedBySize(pass, n.Cond) {
+					pass.Reportf(n.Pos(), "loop over the size of an avl.Tree in Render")
+				}
+			}
+
+			return true
+		})
+	}
+}
+
+// isTreeMethod returns true if fn is one of the given avl.Tree methods
+func isTreeMethod(fn *types.Func, names ...string) bool {
+	if fn == nil {
+		return false
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil || !isAVLTree(recv.Type()) {
+		return false
+	}
+
+	for _, name := range names {
+		if fn.Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// boundedBySize returns true if the loop condition compares
+// with the size of an avl.Tree, like i < tree.Size()
+func boundedBySize(pass *Pass, cond ast.Expr) bool {
+	bin, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok {
+		return false
+	}
+
+	for _, operand := range []ast.Expr{bin.X, bin.Y} {
+		call, ok := ast.Unparen(operand).(*ast.CallExpr)
+		if ok && isTreeMethod(callee(pass.Info, call), "Size") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isEmptyString returns true if expr is the constant ""
+func isEmptyString(info *types.Info, expr ast.Expr) bool {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return false
+	}
+
+	return constant.StringVal(tv.Value) == ""
+}
@@ -0,0 +1,40 @@
+package lint
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// UncheckedSendCoinsAnalyzer reports exported realm functions that
+// send coins with a banker without checking their caller
+var UncheckedSendCoinsAnalyzer = &Analyzer{
+	Code:       CodeUncheckedSendCoins,
+	Doc:        "exported realm functions sending coins should check their caller",
+	Severity:   SeverityWarning,
+	Confidence: 0.7,
+	Run:        runUncheckedSendCoins,
+}
+
+func runUncheckedSendCoins(pass *Pass) {
+	if !pass.IsRealm() {
+		return
+	}
+
+	decls := funcDecls(pass)
+
+	for _, fd := range exportedRealmFuncs(pass) {
+		fns := reachable(pass, decls, fd, false)
+
+		call := findCall(pass, fns, func(_ *ast.CallExpr, fn *types.Func) bool {
+			return isStdFunc(fn, "SendCoins")
+		})
+		if call == nil || checksCaller(pass, fns) {
+			continue
+		}
+
+		pass.Reportf(fd.Name.Pos(),
+			"%s sends coins (at %s) without checking its caller",
+			fd.Name.Name, pass.position(call.Pos()),
+		)
+	}
+}
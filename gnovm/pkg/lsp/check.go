@@ -0,0 +1,183 @@
+package lsp
+
+import (
+	"errors"
+	"go/ast"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/gnovm/pkg/lint"
+	"go.uber.org/multierr"
+)
+
+const diagnosticSource = "gno"
+
+// snapshot is the latest type check of a package
+type snapshot struct {
+	pkgPath string
+	dir     string
+	mpkg    *gnovm.MemPackage
+
+	// nil if the package is a draft, or has no files
+	*gno.TypeCheckResult
+}
+
+// file returns the parsed file with the given file path
+func (s *snapshot) file(filePath string) *ast.File {
+	if s.TypeCheckResult == nil {
+		return nil
+	}
+
+	name := path.Join(s.pkgPath, filepath.Base(filePath))
+
+	for _, f := range s.Files {
+		if s.Fset.File(f.Pos()).Name() == name {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// check type checks the package in the given directory,
+// and returns the diagnostics of its files, by file path
+func (s *Server) check(dir string) (*snapshot, map[string][]Diagnostic) {
+	pkgPath := s.loader.packageOf(dir)
+	s.loader.invalidate(pkgPath)
+
+	snap := &snapshot{
+		pkgPath: pkgPath,
+		dir:     dir,
+		mpkg:    s.loader.readPackage(pkgPath, dir),
+	}
+	s.snapshots[dir] = snap
+
+	diags := make(map[string][]Diagnostic)
+	for _, file := range snap.mpkg.Files {
+		diags[filepath.Join(dir, file.Name)] = []Diagnostic{}
+	}
+
+	if len(snap.mpkg.Files) == 0 {
+		return snap, diags
+	}
+
+	gm, err := gnomod.ParseAt(dir)
+	if err != nil {
+		// Report the missing or invalid gno.mod on all the files
+		for file := range diags {
+			diags[file] = append(diags[file], Diagnostic{
+				Severity: SeverityWarning,
+				Code:     lint.CodeGnoMod,
+				Source:   diagnosticSource,
+				Message:  err.Error(),
+			})
+		}
+	}
+
+	if gm != nil && gm.Draft {
+		return snap, diags
+	}
+
+	res, err := gno.TypeCheckMemPackageInfo(snap.mpkg, s.loader)
+	snap.TypeCheckResult = res
+
+	parsed := true
+	for _, err := range multierr.Errors(err) {
+		if !s.addDiagnostics(diags, pkgPath, err) {
+			parsed = false
+		}
+	}
+
+	// Like gno lint, only run the analyzers on packages that can be parsed
+	if !parsed {
+		return snap, diags
+	}
+
+	modPath := pkgPath
+	if gm != nil && gm.Module != nil {
+		modPath = gm.Module.Mod.Path
+	}
+
+	for _, d := range lint.Run(modPath, res, lint.Analyzers()) {
+		severity := SeverityError
+		if d.Analyzer.Severity == lint.SeverityWarning {
+			severity = SeverityWarning
+		}
+
+		s.addDiagnostic(diags, pkgPath, d.Pos, severity, d.Analyzer.Code, d.Message)
+	}
+
+	return snap, diags
+}
+
+// addDiagnostics converts the type checking error to diagnostics,
+// ignoring the errors of other packages. It returns false for parser errors.
+func (s *Server) addDiagnostics(diags map[string][]Diagnostic, pkgPath string, err error) bool {
+	var (
+		scErrs scanner.ErrorList
+		scErr  scanner.Error
+		tcErr  types.Error
+	)
+
+	switch {
+	case errors.As(err, &scErrs):
+		for _, scErr := range scErrs {
+			s.addDiagnostic(diags, pkgPath, scErr.Pos, SeverityError, lint.CodeParserError, scErr.Msg)
+		}
+		return false
+	case errors.As(err, &scErr):
+		s.addDiagnostic(diags, pkgPath, scErr.Pos, SeverityError, lint.CodeParserError, scErr.Msg)
+		return false
+	case errors.As(err, &tcErr):
+		s.addDiagnostic(diags, pkgPath, tcErr.Fset.Position(tcErr.Pos), SeverityError, lint.CodeTypeCheckError, tcErr.Msg)
+	}
+
+	return true
+}
+
+// addDiagnostic adds a diagnostic at the given position,
+// if it is part of the files of the package
+func (s *Server) addDiagnostic(
+	diags map[string][]Diagnostic,
+	pkgPath string,
+	pos token.Position,
+	severity int,
+	code lint.Code,
+	msg string,
+) {
+	if !strings.HasPrefix(pos.Filename, pkgPath+"/") {
+		return
+	}
+
+	file, ok := s.loader.filePath(pos.Filename)
+	if !ok {
+		return
+	}
+
+	if _, ok := diags[file]; !ok {
+		return
+	}
+
+	content, _ := s.loader.content(file)
+
+	start := toPosition(content, pos.Line, pos.Column)
+	end := offsetToPosition(content, wordEnd(content, toOffset(content, start)))
+
+	diags[file] = append(diags[file], Diagnostic{
+		Range: Range{
+			Start: start,
+			End:   end,
+		},
+		Severity: severity,
+		Code:     code,
+		Source:   diagnosticSource,
+		Message:  msg,
+	})
+}
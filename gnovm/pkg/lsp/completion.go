@@ -0,0 +1,198 @@
+package lsp
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+func (s *Server) completion(params TextDocumentPositionParams) (*CompletionList, error) {
+	list := &CompletionList{
+		Items: []CompletionItem{},
+	}
+
+	c := s.locate(params)
+	if c == nil {
+		return list, nil
+	}
+
+	start := wordStart(c.content, c.offset)
+	prefix := c.content[start:c.offset]
+
+	var items []CompletionItem
+	if start > 0 && c.content[start-1] == '.' {
+		items = c.selectorCompletions(c.pos - token.Pos(c.offset-start+1))
+	} else {
+		items = c.scopeCompletions()
+	}
+
+	for _, item := range items {
+		if strings.HasPrefix(item.Label, prefix) {
+			list.Items = append(list.Items, item)
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].Label < list.Items[j].Label
+	})
+
+	return list, nil
+}
+
+// selectorCompletions returns the members of the
+// expression preceding the dot at the given position
+func (c *cursor) selectorCompletions(dot token.Pos) []CompletionItem {
+	var x ast.Expr
+
+	ast.Inspect(c.file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok && sel.X.End() == dot {
+			x = sel.X
+		}
+
+		return x == nil
+	})
+
+	if x == nil {
+		return nil
+	}
+
+	// Package members
+	if ident, ok := x.(*ast.Ident); ok {
+		if pkgName, ok := c.snap.Info.Uses[ident].(*types.PkgName); ok {
+			scope := pkgName.Imported().Scope()
+
+			var items []CompletionItem
+			for _, name := range scope.Names() {
+				if obj := scope.Lookup(name); obj.Exported() {
+					items = append(items, c.item(obj))
+				}
+			}
+
+			return items
+		}
+	}
+
+	tv, ok := c.snap.Info.Types[x]
+	if !ok || tv.Type == nil {
+		return nil
+	}
+
+	return c.memberCompletions(tv.Type)
+}
+
+// memberCompletions returns the fields and methods of the given type
+func (c *cursor) memberCompletions(typ types.Type) []CompletionItem {
+	var (
+		items []CompletionItem
+		seen  = make(map[string]bool)
+	)
+
+	add := func(obj types.Object) {
+		if seen[obj.Name()] || (!obj.Exported() && obj.Pkg() != c.snap.Pkg) {
+			return
+		}
+
+		seen[obj.Name()] = true
+		items = append(items, c.item(obj))
+	}
+
+	// Methods are looked up on the pointer, as variables are addressable
+	mtyp := typ
+	if _, isPtr := typ.Underlying().(*types.Pointer); !isPtr && !types.IsInterface(typ) {
+		mtyp = types.NewPointer(typ)
+	}
+
+	mset := types.NewMethodSet(mtyp)
+	for i := 0; i < mset.Len(); i++ {
+		add(mset.At(i).Obj())
+	}
+
+	// Fields, including the promoted ones
+	var addFields func(typ types.Type, depth int)
+	addFields = func(typ types.Type, depth int) {
+		if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+
+		st, ok := typ.Underlying().(*types.Struct)
+		if !ok || depth > 4 {
+			return
+		}
+
+		for i := 0; i < st.NumFields(); i++ {
+			field := st.Field(i)
+			add(field)
+
+			if field.Embedded() {
+				addFields(field.Type(), depth+1)
+			}
+		}
+	}
+	addFields(typ, 0)
+
+	return items
+}
+
+// scopeCompletions returns the identifiers in scope at the cursor
+func (c *cursor) scopeCompletions() []CompletionItem {
+	var (
+		items []CompletionItem
+		seen  = make(map[string]bool)
+	)
+
+	scope := c.snap.Pkg.Scope().Innermost(c.pos)
+	if scope == nil {
+		scope = c.snap.Pkg.Scope()
+	}
+
+	for ; scope != nil; scope = scope.Parent() {
+		local := scope != c.snap.Pkg.Scope() && scope != types.Universe
+
+		for _, name := range scope.Names() {
+			obj := scope.Lookup(name)
+
+			// Local identifiers are only visible after their declaration
+			if seen[name] || name == "_" || (local && obj.Pos() > c.pos) {
+				continue
+			}
+
+			seen[name] = true
+			items = append(items, c.item(obj))
+		}
+	}
+
+	return items
+}
+
+// item returns the completion item of the given object
+func (c *cursor) item(obj types.Object) CompletionItem {
+	item := CompletionItem{
+		Label:  obj.Name(),
+		Detail: types.ObjectString(obj, types.RelativeTo(c.snap.Pkg)),
+	}
+
+	switch obj := obj.(type) {
+	case *types.Func:
+		item.Kind = completionFunction
+		if isMethod(obj) {
+			item.Kind = completionMethod
+		}
+	case *types.Var:
+		item.Kind = completionVariable
+		if obj.IsField() {
+			item.Kind = completionField
+		}
+	case *types.Const:
+		item.Kind = completionConstant
+	case *types.TypeName:
+		item.Kind = completionStruct
+	case *types.PkgName:
+		item.Kind = completionModule
+	case *types.Builtin:
+		item.Kind = completionFunction
+	}
+
+	return item
+}
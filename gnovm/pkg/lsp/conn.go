@@ -0,0 +1,119 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"sync"
+)
+
+var errMissingContentLength = errors.New("missing Content-Length header")
+
+// conn reads and writes JSON-RPC messages, framed with
+// the LSP base protocol headers (Content-Length)
+type conn struct {
+	r *bufio.Reader
+
+	mux sync.Mutex
+	w   io.Writer
+}
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	return &conn{
+		r: bufio.NewReader(r),
+		w: w,
+	}
+}
+
+// read reads the next message
+func (c *conn) read() (*message, error) {
+	header, err := textproto.NewReader(c.r).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, errMissingContentLength
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.r, body); err != nil {
+		return nil, err
+	}
+
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, &responseError{
+			Code:    codeParseError,
+			Message: err.Error(),
+		}
+	}
+
+	return &msg, nil
+}
+
+// write writes the message
+func (c *conn) write(msg *message) error {
+	msg.JSONRPC = "2.0"
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("unable to marshal message: %w", err)
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if _, err := fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+
+	_, err = c.w.Write(body)
+
+	return err
+}
+
+// reply responds to the request with the given id
+func (c *conn) reply(id *json.RawMessage, result any, err error) error {
+	msg := &message{ID: id}
+
+	if err == nil {
+		// A successful response always has a result, even if null
+		msg.Result = json.RawMessage("null")
+		if result != nil {
+			msg.Result = result
+		}
+
+		return c.write(msg)
+	}
+
+	var respErr *responseError
+	if !errors.As(err, &respErr) {
+		respErr = &responseError{
+			Code:    codeInternalError,
+			Message: err.Error(),
+		}
+	}
+
+	msg.Error = respErr
+
+	return c.write(msg)
+}
+
+// notify sends a notification to the client
+func (c *conn) notify(method string, params any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("unable to marshal params: %w", err)
+	}
+
+	return c.write(&message{
+		Method: method,
+		Params: raw,
+	})
+}
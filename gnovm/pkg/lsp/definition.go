@@ -0,0 +1,67 @@
+package lsp
+
+import (
+	"go/types"
+	"path"
+)
+
+func (s *Server) definition(params TextDocumentPositionParams) (*Location, error) {
+	c := s.locate(params)
+	if c == nil {
+		return nil, nil
+	}
+
+	_, obj := c.object()
+	if obj == nil {
+		return nil, nil
+	}
+
+	// Imports lead to the files of the imported package
+	if pkgName, ok := obj.(*types.PkgName); ok {
+		return s.packageLocation(pkgName.Imported().Path()), nil
+	}
+
+	if !obj.Pos().IsValid() {
+		// Builtin
+		return nil, nil
+	}
+
+	pos := c.snap.Fset.Position(obj.Pos())
+
+	file, ok := s.loader.filePath(pos.Filename)
+	if !ok {
+		return nil, nil
+	}
+
+	content, _ := s.loader.content(file)
+
+	start := toPosition(content, pos.Line, pos.Column)
+	end := start
+	end.Character += utf16Len(obj.Name())
+
+	return &Location{
+		URI: pathToURI(file),
+		Range: Range{
+			Start: start,
+			End:   end,
+		},
+	}, nil
+}
+
+// packageLocation returns the location of the
+// first file of the given package, if found
+func (s *Server) packageLocation(pkgPath string) *Location {
+	mpkg := s.loader.GetMemPackage(pkgPath)
+	if mpkg == nil || len(mpkg.Files) == 0 {
+		return nil
+	}
+
+	file, ok := s.loader.filePath(path.Join(pkgPath, mpkg.Files[0].Name))
+	if !ok {
+		return nil
+	}
+
+	return &Location{
+		URI: pathToURI(file),
+	}
+}
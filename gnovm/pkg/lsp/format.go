@@ -0,0 +1,103 @@
+package lsp
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm"
+	"github.com/gnolang/gno/gnovm/pkg/gnofmt"
+)
+
+var errFileNotFound = errors.New("file not found")
+
+func (s *Server) formatting(params DocumentFormattingParams) ([]TextEdit, error) {
+	filePath := uriToPath(params.TextDocument.URI)
+	dir := filepath.Dir(filePath)
+
+	content, ok := s.loader.content(filePath)
+	if !ok {
+		return nil, errFileNotFound
+	}
+
+	pkgPath := s.loader.packageOf(dir)
+	pkg := fmtPackage{s.loader.readPackage(pkgPath, dir)}
+
+	// The processor caches the parsed packages, so a new
+	// one is used for every request, sharing the resolver
+	formatted, err := gnofmt.NewProcessor(s.fmtResolver()).FormatPackageFile(pkg, filepath.Base(filePath))
+	if err != nil {
+		return nil, err
+	}
+
+	if string(formatted) == content {
+		return []TextEdit{}, nil
+	}
+
+	return []TextEdit{
+		{
+			Range: Range{
+				End: offsetToPosition(content, len(content)),
+			},
+			NewText: string(formatted),
+		},
+	}, nil
+}
+
+// fmtResolver returns the resolver of the packages that can be
+// imported while formatting, loading them on the first call
+func (s *Server) fmtResolver() *gnofmt.FSResolver {
+	if s.resolver != nil {
+		return s.resolver
+	}
+
+	s.resolver = gnofmt.NewFSResolver()
+
+	roots := []string{
+		filepath.Join(s.loader.rootDir, "gnovm", "stdlibs"),
+		filepath.Join(s.loader.rootDir, "examples"),
+	}
+	for _, dir := range s.loader.workspace {
+		roots = append(roots, dir)
+	}
+
+	for _, root := range roots {
+		// Packages that can't be parsed are skipped
+		//nolint:errcheck
+		s.resolver.LoadPackages(root, func(string, error) error { return nil })
+	}
+
+	return s.resolver
+}
+
+// fmtPackage adapts a MemPackage to a gnofmt.Package
+type fmtPackage struct {
+	mpkg *gnovm.MemPackage
+}
+
+func (p fmtPackage) Path() string {
+	return p.mpkg.Path
+}
+
+func (p fmtPackage) Name() string {
+	return p.mpkg.Name
+}
+
+func (p fmtPackage) Files() []string {
+	files := make([]string, 0, len(p.mpkg.Files))
+	for _, file := range p.mpkg.Files {
+		files = append(files, file.Name)
+	}
+
+	return files
+}
+
+func (p fmtPackage) Read(filename string) (io.ReadCloser, error) {
+	file := p.mpkg.GetFile(filename)
+	if file == nil {
+		return nil, errFileNotFound
+	}
+
+	return io.NopCloser(strings.NewReader(file.Body)), nil
+}
@@ -0,0 +1,179 @@
+package lsp
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm/pkg/doc"
+)
+
+// cursor is a position in a type checked file
+type cursor struct {
+	snap    *snapshot
+	file    *ast.File
+	content string
+	offset  int // byte offset in content
+	pos     token.Pos
+}
+
+// locate returns the cursor at the given document position,
+// or nil if the document is not type checked
+func (s *Server) locate(params TextDocumentPositionParams) *cursor {
+	filePath := uriToPath(params.TextDocument.URI)
+
+	snap := s.snapshotOf(filePath)
+	if snap == nil {
+		return nil
+	}
+
+	file := snap.file(filePath)
+	if file == nil {
+		return nil
+	}
+
+	content, _ := s.loader.content(filePath)
+	offset := toOffset(content, params.Position)
+
+	tokFile := snap.Fset.File(file.Pos())
+	if offset > tokFile.Size() {
+		return nil
+	}
+
+	return &cursor{
+		snap:    snap,
+		file:    file,
+		content: content,
+		offset:  offset,
+		pos:     tokFile.Pos(offset),
+	}
+}
+
+// ident returns the identifier under the cursor, if any
+func (c *cursor) ident() *ast.Ident {
+	var ident *ast.Ident
+
+	ast.Inspect(c.file, func(n ast.Node) bool {
+		if n == nil || ident != nil || c.pos < n.Pos() || c.pos > n.End() {
+			return false
+		}
+
+		if id, ok := n.(*ast.Ident); ok {
+			ident = id
+		}
+
+		return true
+	})
+
+	return ident
+}
+
+// object returns the object defined or used by the identifier under the cursor
+func (c *cursor) object() (*ast.Ident, types.Object) {
+	ident := c.ident()
+	if ident == nil {
+		return nil, nil
+	}
+
+	if obj := c.snap.Info.Uses[ident]; obj != nil {
+		return ident, obj
+	}
+
+	return ident, c.snap.Info.Defs[ident]
+}
+
+func (s *Server) hover(params TextDocumentPositionParams) (*Hover, error) {
+	c := s.locate(params)
+	if c == nil {
+		return nil, nil
+	}
+
+	ident, obj := c.object()
+	if obj == nil {
+		return nil, nil
+	}
+
+	text := s.objectDoc(obj)
+	if text == "" {
+		text = types.ObjectString(obj, types.RelativeTo(c.snap.Pkg))
+	}
+
+	start := offsetToPosition(c.content, c.snap.Fset.Position(ident.Pos()).Offset)
+	end := offsetToPosition(c.content, c.snap.Fset.Position(ident.End()).Offset)
+
+	return &Hover{
+		Contents: MarkupContent{
+			Kind:  "markdown",
+			Value: "```gno\n" + strings.TrimSpace(text) + "\n```",
+		},
+		Range: &Range{
+			Start: start,
+			End:   end,
+		},
+	}, nil
+}
+
+// objectDoc returns the documentation of the given package-level
+// symbol or method, as printed by gno doc
+func (s *Server) objectDoc(obj types.Object) string {
+	if obj.Pkg() == nil {
+		return ""
+	}
+
+	var symbol, accessible string
+
+	switch {
+	case obj.Parent() == obj.Pkg().Scope():
+		symbol = obj.Name()
+	case isMethod(obj):
+		recv := obj.Type().(*types.Signature).Recv().Type()
+		if ptr, ok := recv.(*types.Pointer); ok {
+			recv = ptr.Elem()
+		}
+
+		named, ok := recv.(*types.Named)
+		if !ok {
+			return ""
+		}
+
+		symbol, accessible = named.Obj().Name(), obj.Name()
+	default:
+		return ""
+	}
+
+	mpkg := s.loader.GetMemPackage(obj.Pkg().Path())
+	if mpkg == nil {
+		return ""
+	}
+
+	d, err := doc.NewDocumentableFromMemPkg(mpkg, true, symbol, accessible)
+	if err != nil {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := d.WriteDocumentation(&buf, &doc.WriteDocumentationOptions{Unexported: true}); err != nil {
+		return ""
+	}
+
+	// Drop the package clause, printed for symbols of other packages
+	text := buf.String()
+	if strings.HasPrefix(text, "package ") {
+		if _, rest, ok := strings.Cut(text, "\n\n"); ok {
+			text = rest
+		}
+	}
+
+	return text
+}
+
+func isMethod(obj types.Object) bool {
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	return fn.Type().(*types.Signature).Recv() != nil
+}
@@ -0,0 +1,260 @@
+package lsp
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"golang.org/x/mod/module"
+)
+
+// loader resolves package paths to their source files, for type checking.
+// The content of open documents takes precedence over the files on disk
+type loader struct {
+	rootDir string // GNOROOT
+
+	docs      map[string]string            // file path -> content of open documents
+	workspace map[string]string            // pkgpath -> dir, of the workspace packages
+	cache     map[string]*gnovm.MemPackage // pkgpath -> package, of the stdlibs, examples and downloaded modules
+	files     map[string]string            // pkgpath/filename, as type checked -> file path
+}
+
+func newLoader(rootDir string) *loader {
+	return &loader{
+		rootDir:   rootDir,
+		docs:      make(map[string]string),
+		workspace: make(map[string]string),
+		cache:     make(map[string]*gnovm.MemPackage),
+		files:     make(map[string]string),
+	}
+}
+
+// addWorkspace registers the packages found in the given directory,
+// identified by their gno.mod
+func (l *loader) addWorkspace(root string) {
+	//nolint:errcheck // unreadable directories are skipped
+	filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if d.IsDir() {
+			if p != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if d.Name() != "gno.mod" {
+			return nil
+		}
+
+		gm, err := gnomod.ParseGnoMod(p)
+		if err != nil || gm.Module == nil {
+			return nil
+		}
+
+		l.workspace[gm.Module.Mod.Path] = filepath.Dir(p)
+
+		return nil
+	})
+}
+
+// packageOf returns the package path of the given directory. It is
+// derived from the closest gno.mod, or from the location of the directory
+// in the stdlibs and examples, and defaults to the directory itself
+func (l *loader) packageOf(dir string) string {
+	for pkgPath, pkgDir := range l.workspace {
+		if pkgDir == dir {
+			return pkgPath
+		}
+	}
+
+	if root, err := gnomod.FindRootDir(dir); err == nil {
+		gm, err := gnomod.ParseGnoMod(filepath.Join(root, "gno.mod"))
+		if err == nil && gm.Module != nil {
+			rel, _ := filepath.Rel(root, dir)
+			pkgPath := path.Join(gm.Module.Mod.Path, filepath.ToSlash(rel))
+
+			l.workspace[pkgPath] = dir
+
+			return pkgPath
+		}
+	}
+
+	for _, base := range []string{
+		filepath.Join(l.rootDir, "gnovm", "stdlibs"),
+		filepath.Join(l.rootDir, "gnovm", "tests", "stdlibs"),
+		filepath.Join(l.rootDir, "examples"),
+	} {
+		if rel, err := filepath.Rel(base, dir); err == nil && !strings.HasPrefix(rel, "..") && rel != "." {
+			return filepath.ToSlash(rel)
+		}
+	}
+
+	pkgPath := filepath.ToSlash(dir)
+	l.workspace[pkgPath] = dir
+
+	return pkgPath
+}
+
+// dirs returns the directories holding the files of the given package
+func (l *loader) dirs(pkgPath string) []string {
+	if dir, ok := l.workspace[pkgPath]; ok {
+		return []string{dir}
+	}
+
+	// Stdlib definitions may be overridden for testing
+	stdlibs := []string{
+		filepath.Join(l.rootDir, "gnovm", "stdlibs", filepath.FromSlash(pkgPath)),
+		filepath.Join(l.rootDir, "gnovm", "tests", "stdlibs", filepath.FromSlash(pkgPath)),
+	}
+
+	var dirs []string
+	for _, dir := range stdlibs {
+		if isDir(dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	if len(dirs) > 0 {
+		return dirs
+	}
+
+	for _, dir := range []string{
+		filepath.Join(l.rootDir, "examples", filepath.FromSlash(pkgPath)),
+		gnomod.PackageDir("", module.Version{Path: pkgPath}),
+	} {
+		if isDir(dir) {
+			return []string{dir}
+		}
+	}
+
+	return nil
+}
+
+// GetMemPackage returns the package with the given path, or nil if not found.
+// It implements gnolang.MemPackageGetter
+func (l *loader) GetMemPackage(pkgPath string) *gnovm.MemPackage {
+	if mpkg, ok := l.cache[pkgPath]; ok {
+		return mpkg
+	}
+
+	dirs := l.dirs(pkgPath)
+	if len(dirs) == 0 {
+		return nil
+	}
+
+	mpkg := l.readPackage(pkgPath, dirs...)
+
+	// Only the workspace packages are edited
+	if _, ok := l.workspace[pkgPath]; !ok {
+		l.cache[pkgPath] = mpkg
+	}
+
+	return mpkg
+}
+
+// invalidate drops the cached package with the given path,
+// after one of its files has been edited
+func (l *loader) invalidate(pkgPath string) {
+	delete(l.cache, pkgPath)
+}
+
+// readPackage reads the gno files of the package found in the given dirs
+func (l *loader) readPackage(pkgPath string, dirs ...string) *gnovm.MemPackage {
+	mpkg := &gnovm.MemPackage{Path: pkgPath}
+
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || !isGnoFile(entry.Name()) {
+				continue
+			}
+
+			file := filepath.Join(dir, entry.Name())
+
+			body, ok := l.content(file)
+			if !ok {
+				continue
+			}
+
+			mpkg.Files = append(mpkg.Files, &gnovm.MemFile{
+				Name: entry.Name(),
+				Body: body,
+			})
+			l.files[path.Join(pkgPath, entry.Name())] = file
+		}
+	}
+
+	// Include the open documents that are not saved yet
+	for file, body := range l.docs {
+		if !slices.Contains(dirs, filepath.Dir(file)) || mpkg.GetFile(filepath.Base(file)) != nil {
+			continue
+		}
+
+		mpkg.Files = append(mpkg.Files, &gnovm.MemFile{
+			Name: filepath.Base(file),
+			Body: body,
+		})
+		l.files[path.Join(pkgPath, filepath.Base(file))] = file
+	}
+
+	sort.Slice(mpkg.Files, func(i, j int) bool {
+		return mpkg.Files[i].Name < mpkg.Files[j].Name
+	})
+
+	for _, file := range mpkg.Files {
+		name, err := gno.PackageNameFromFileBody(file.Name, file.Body)
+		if err == nil {
+			mpkg.Name = strings.TrimSuffix(string(name), "_test")
+			break
+		}
+	}
+
+	return mpkg
+}
+
+// content returns the content of the given file,
+// from the open documents or from disk
+func (l *loader) content(file string) (string, bool) {
+	if body, ok := l.docs[file]; ok {
+		return body, true
+	}
+
+	body, err := os.ReadFile(file)
+	if err != nil {
+		return "", false
+	}
+
+	return string(body), true
+}
+
+// filePath returns the path of a type checked file
+func (l *loader) filePath(name string) (string, bool) {
+	file, ok := l.files[name]
+
+	return file, ok
+}
+
+func isGnoFile(name string) bool {
+	return strings.HasSuffix(name, ".gno") && !strings.HasPrefix(name, ".")
+}
+
+func isDir(dir string) bool {
+	info, err := os.Stat(dir)
+
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,151 @@
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// uriToPath converts a file URI to a file path
+func uriToPath(uri DocumentURI) string {
+	u, err := url.Parse(string(uri))
+	if err != nil || u.Scheme != "file" {
+		return string(uri)
+	}
+
+	return filepath.FromSlash(u.Path)
+}
+
+// pathToURI converts a file path to a file URI
+func pathToURI(path string) DocumentURI {
+	u := url.URL{
+		Scheme: "file",
+		Path:   filepath.ToSlash(path),
+	}
+
+	return DocumentURI(u.String())
+}
+
+// lineStart returns the byte offset of the given (zero-based) line
+func lineStart(content string, line int) (int, bool) {
+	offset := 0
+
+	for ; line > 0; line-- {
+		i := strings.IndexByte(content[offset:], '\n')
+		if i < 0 {
+			return len(content), false
+		}
+
+		offset += i + 1
+	}
+
+	return offset, true
+}
+
+// toPosition converts a one-based line and byte column, as reported
+// by go/token, to an LSP position, counting UTF-16 code units
+func toPosition(content string, line, column int) Position {
+	if line < 1 {
+		return Position{}
+	}
+
+	start, _ := lineStart(content, line-1)
+
+	end := start + column - 1
+	if end > len(content) {
+		end = len(content)
+	}
+
+	if i := strings.IndexByte(content[start:end], '\n'); i >= 0 {
+		end = start + i
+	}
+
+	return Position{
+		Line:      line - 1,
+		Character: utf16Len(content[start:end]),
+	}
+}
+
+// toOffset converts an LSP position to a byte offset in content
+func toOffset(content string, pos Position) int {
+	offset, ok := lineStart(content, pos.Line)
+	if !ok {
+		return offset
+	}
+
+	for units := 0; units < pos.Character && offset < len(content); {
+		r, size := utf8.DecodeRuneInString(content[offset:])
+		if r == '\n' {
+			break
+		}
+
+		units += utf16.RuneLen(r)
+		offset += size
+	}
+
+	return offset
+}
+
+// offsetToPosition converts a byte offset in content to an LSP position
+func offsetToPosition(content string, offset int) Position {
+	if offset > len(content) {
+		offset = len(content)
+	}
+
+	line := strings.Count(content[:offset], "\n")
+	start := strings.LastIndexByte(content[:offset], '\n') + 1
+
+	return Position{
+		Line:      line,
+		Character: utf16Len(content[start:offset]),
+	}
+}
+
+// wordEnd returns the offset of the end of the identifier starting at offset
+func wordEnd(content string, offset int) int {
+	end := offset
+
+	for end < len(content) {
+		r, size := utf8.DecodeRuneInString(content[end:])
+		if !isIdentRune(r) {
+			break
+		}
+
+		end += size
+	}
+
+	return end
+}
+
+// wordStart returns the offset of the start of the identifier ending at offset
+func wordStart(content string, offset int) int {
+	start := offset
+
+	for start > 0 {
+		r, size := utf8.DecodeLastRuneInString(content[:start])
+		if !isIdentRune(r) {
+			break
+		}
+
+		start -= size
+	}
+
+	return start
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func utf16Len(s string) int {
+	n := 0
+
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+
+	return n
+}
@@ -0,0 +1,296 @@
+// Package lsp implements a language server for Gno, speaking the
+// Language Server Protocol over a stream (usually stdin and stdout).
+//
+// The server type checks the edited packages on every change, and provides
+// diagnostics, hover documentation, completion, go-to-definition and formatting.
+// Imported packages are resolved from the workspace, the standard libraries and
+// examples of GNOROOT, and the downloaded modules.
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnofmt"
+)
+
+var errExitWithoutShutdown = errors.New("exit received before shutdown")
+
+// Server is a Gno language server
+type Server struct {
+	conn   *conn
+	loader *loader
+
+	snapshots map[string]*snapshot // package dir -> latest type check
+	resolver  *gnofmt.FSResolver   // lazily loaded, for formatting
+
+	initialized bool
+	shutdown    bool
+}
+
+// NewServer creates a new language server,
+// resolving the standard libraries and examples from rootDir (GNOROOT)
+func NewServer(rootDir string) *Server {
+	return &Server{
+		loader:    newLoader(rootDir),
+		snapshots: make(map[string]*snapshot),
+	}
+}
+
+type handlerFunc func(s *Server, params json.RawMessage) (any, error)
+
+var handlers = map[string]handlerFunc{
+	"initialize":              handle((*Server).initialize),
+	"initialized":             handle((*Server).noop),
+	"shutdown":                handle((*Server).shutdownServer),
+	"textDocument/didOpen":    handle((*Server).didOpen),
+	"textDocument/didChange":  handle((*Server).didChange),
+	"textDocument/didSave":    handle((*Server).didSave),
+	"textDocument/didClose":   handle((*Server).didClose),
+	"textDocument/hover":      handle((*Server).hover),
+	"textDocument/completion": handle((*Server).completion),
+	"textDocument/definition": handle((*Server).definition),
+	"textDocument/formatting": handle((*Server).formatting),
+}
+
+// handle adapts a typed handler to a handlerFunc, decoding its params
+func handle[P, R any](fn func(*Server, P) (R, error)) handlerFunc {
+	return func(s *Server, raw json.RawMessage) (any, error) {
+		var params P
+
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &params); err != nil {
+				return nil, &responseError{
+					Code:    codeInvalidParams,
+					Message: err.Error(),
+				}
+			}
+		}
+
+		return fn(s, params)
+	}
+}
+
+// Serve handles the messages read from r, writing responses and
+// notifications to w, until the client exits or ctx is done
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.conn = newConn(r, w)
+
+	for ctx.Err() == nil {
+		msg, err := s.conn.read()
+		if err != nil {
+			var respErr *responseError
+
+			switch {
+			case errors.Is(err, io.EOF):
+				return nil
+			case errors.As(err, &respErr):
+				// The message could not be decoded, so it can't be answered by id
+				if err := s.conn.reply(nil, nil, err); err != nil {
+					return err
+				}
+
+				continue
+			default:
+				return fmt.Errorf("unable to read message: %w", err)
+			}
+		}
+
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return errExitWithoutShutdown
+			}
+
+			return nil
+		}
+
+		result, err := s.dispatch(msg)
+
+		if msg.isNotification() {
+			if err != nil {
+				s.logMessage(fmt.Sprintf("%s: %s", msg.Method, err))
+			}
+
+			continue
+		}
+
+		if err := s.conn.reply(msg.ID, result, err); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+// dispatch calls the handler of the message method
+func (s *Server) dispatch(msg *message) (result any, err error) {
+	handler, ok := handlers[msg.Method]
+	if !ok {
+		return nil, &responseError{
+			Code:    codeMethodNotFound,
+			Message: "method not found: " + msg.Method,
+		}
+	}
+
+	if !s.initialized && msg.Method != "initialize" {
+		return nil, &responseError{
+			Code:    codeServerNotInitialized,
+			Message: "server not initialized",
+		}
+	}
+
+	if s.shutdown {
+		return nil, &responseError{
+			Code:    codeInvalidRequest,
+			Message: "server is shutting down",
+		}
+	}
+
+	// Malformed sources should never bring the server down
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while handling %s: %v", msg.Method, r)
+		}
+	}()
+
+	return handler(s, msg.Params)
+}
+
+// logMessage shows a message in the client logs
+func (s *Server) logMessage(msg string) {
+	const logTypeError = 1
+
+	//nolint:errcheck // best effort
+	s.conn.notify("window/logMessage", map[string]any{
+		"type":    logTypeError,
+		"message": msg,
+	})
+}
+
+func (s *Server) initialize(params InitializeParams) (*InitializeResult, error) {
+	s.initialized = true
+
+	roots := make([]DocumentURI, 0, len(params.WorkspaceFolders)+1)
+	for _, folder := range params.WorkspaceFolders {
+		roots = append(roots, folder.URI)
+	}
+
+	if len(roots) == 0 && params.RootURI != "" {
+		roots = append(roots, params.RootURI)
+	}
+
+	for _, root := range roots {
+		s.loader.addWorkspace(uriToPath(root))
+	}
+
+	return &InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    syncFull,
+				Save: SaveOptions{
+					IncludeText: true,
+				},
+			},
+			HoverProvider:              true,
+			DefinitionProvider:         true,
+			DocumentFormattingProvider: true,
+			CompletionProvider: CompletionOptions{
+				TriggerCharacters: []string{"."},
+			},
+		},
+		ServerInfo: ServerInfo{
+			Name: "gno",
+		},
+	}, nil
+}
+
+func (s *Server) noop(struct{}) (any, error) {
+	return nil, nil
+}
+
+func (s *Server) shutdownServer(struct{}) (any, error) {
+	s.shutdown = true
+
+	return nil, nil
+}
+
+func (s *Server) didOpen(params DidOpenTextDocumentParams) (any, error) {
+	file := uriToPath(params.TextDocument.URI)
+	s.loader.docs[file] = params.TextDocument.Text
+
+	return nil, s.update(filepath.Dir(file))
+}
+
+func (s *Server) didChange(params DidChangeTextDocumentParams) (any, error) {
+	if len(params.ContentChanges) == 0 {
+		return nil, nil
+	}
+
+	// Only full document synchronization is supported,
+	// so the latest change holds the whole content
+	file := uriToPath(params.TextDocument.URI)
+	s.loader.docs[file] = params.ContentChanges[len(params.ContentChanges)-1].Text
+
+	return nil, s.update(filepath.Dir(file))
+}
+
+func (s *Server) didSave(params DidSaveTextDocumentParams) (any, error) {
+	file := uriToPath(params.TextDocument.URI)
+	if params.Text != nil {
+		s.loader.docs[file] = *params.Text
+	}
+
+	return nil, s.update(filepath.Dir(file))
+}
+
+func (s *Server) didClose(params DidCloseTextDocumentParams) (any, error) {
+	file := uriToPath(params.TextDocument.URI)
+	delete(s.loader.docs, file)
+	delete(s.snapshots, filepath.Dir(file))
+
+	// Clear the diagnostics of the closed document
+	return nil, s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         params.TextDocument.URI,
+		Diagnostics: []Diagnostic{},
+	})
+}
+
+// update type checks the package in dir, and publishes its diagnostics
+func (s *Server) update(dir string) error {
+	_, diags := s.check(dir)
+
+	for file, fileDiags := range diags {
+		// Only report the diagnostics of the open documents
+		if _, ok := s.loader.docs[file]; !ok {
+			continue
+		}
+
+		err := s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+			URI:         pathToURI(file),
+			Diagnostics: fileDiags,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// snapshotOf returns the latest type check of the package holding the given file
+func (s *Server) snapshotOf(file string) *snapshot {
+	dir := filepath.Dir(file)
+
+	if snap, ok := s.snapshots[dir]; ok {
+		return snap
+	}
+
+	snap, _ := s.check(dir)
+
+	return snap
+}
@@ -0,0 +1,385 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/gnovm/pkg/lint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const helloSource = `package hello
+
+import "strings"
+
+// Hello greets the given name
+func Hello(name string) string {
+	return "hello " + strings.ToUpper(name)
+}
+`
+
+// testClient is a language client, connected to a test server
+type testClient struct {
+	t *testing.T
+
+	conn     *conn
+	messages chan *message // messages received from the server
+	nextID   int
+
+	// received notifications, by method
+	notifications map[string][]json.RawMessage
+}
+
+// connectTestClient connects a client to the given server streams
+func connectTestClient(t *testing.T, r io.Reader, w io.Writer) *testClient {
+	t.Helper()
+
+	c := &testClient{
+		t:             t,
+		conn:          newConn(r, w),
+		messages:      make(chan *message),
+		notifications: make(map[string][]json.RawMessage),
+	}
+
+	// The server may block while writing notifications,
+	// so they are read continuously
+	go func() {
+		defer close(c.messages)
+
+		for {
+			msg, err := c.conn.read()
+			if err != nil {
+				return
+			}
+
+			c.messages <- msg
+		}
+	}()
+
+	return c
+}
+
+func newTestClient(t *testing.T) *testClient {
+	t.Helper()
+
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- NewServer(gnoenv.RootDir()).Serve(ctx, serverR, serverW)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		clientW.Close()
+		<-done
+		clientR.Close()
+	})
+
+	return connectTestClient(t, clientR, clientW)
+}
+
+// call sends a request, and decodes its result in result
+func (c *testClient) call(method string, params, result any) {
+	c.t.Helper()
+
+	c.nextID++
+	id := json.RawMessage(strings.Repeat("1", c.nextID))
+
+	raw, err := json.Marshal(params)
+	require.NoError(c.t, err)
+
+	require.NoError(c.t, c.conn.write(&message{
+		ID:     &id,
+		Method: method,
+		Params: raw,
+	}))
+
+	for msg := range c.messages {
+		if msg.isNotification() {
+			c.notifications[msg.Method] = append(c.notifications[msg.Method], msg.Params)
+
+			continue
+		}
+
+		require.Nil(c.t, msg.Error)
+		require.Equal(c.t, string(id), string(*msg.ID))
+
+		if result != nil {
+			body, err := json.Marshal(msg.Result)
+			require.NoError(c.t, err)
+			require.NoError(c.t, json.Unmarshal(body, result))
+		}
+
+		return
+	}
+
+	c.t.Fatal("connection closed")
+}
+
+// notify sends a notification
+func (c *testClient) notify(method string, params any) {
+	c.t.Helper()
+
+	require.NoError(c.t, c.conn.notify(method, params))
+}
+
+// diagnostics returns the latest diagnostics published for the given document.
+// A request is sent first, to make sure the previous notifications were handled
+func (c *testClient) diagnostics(uri DocumentURI) []Diagnostic {
+	c.t.Helper()
+
+	c.call("textDocument/hover", TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+	}, nil)
+
+	published := c.notifications["textDocument/publishDiagnostics"]
+	for i := len(published) - 1; i >= 0; i-- {
+		var params PublishDiagnosticsParams
+		require.NoError(c.t, json.Unmarshal(published[i], &params))
+
+		if params.URI == uri {
+			return params.Diagnostics
+		}
+	}
+
+	c.t.Fatalf("no diagnostics published for %s", uri)
+
+	return nil
+}
+
+// setupPackage creates a package in a temporary workspace,
+// opens its file and returns its URI
+func setupPackage(t *testing.T, c *testClient, source string) DocumentURI {
+	t.Helper()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "hello.gno")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gno.mod"), []byte("module gno.land/r/demo/hello\n"), 0o644))
+	require.NoError(t, os.WriteFile(file, []byte(source), 0o644))
+
+	c.call("initialize", InitializeParams{RootURI: pathToURI(dir)}, nil)
+	c.notify("initialized", struct{}{})
+
+	uri := pathToURI(file)
+	c.notify("textDocument/didOpen", DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{
+			URI:        uri,
+			LanguageID: "gno",
+			Version:    1,
+			Text:       source,
+		},
+	})
+
+	return uri
+}
+
+// positionOf returns the position of the first occurrence of substr in source
+func positionOf(t *testing.T, source, substr string) Position {
+	t.Helper()
+
+	offset := strings.Index(source, substr)
+	require.GreaterOrEqual(t, offset, 0)
+
+	return offsetToPosition(source, offset)
+}
+
+func TestServer_Diagnostics(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t)
+	uri := setupPackage(t, c, helloSource)
+
+	assert.Empty(t, c.diagnostics(uri))
+
+	// Type error
+	broken := strings.Replace(helloSource, "strings.ToUpper(name)", "name + 1", 1)
+	c.notify("textDocument/didChange", DidChangeTextDocumentParams{
+		TextDocument:   VersionedTextDocumentIdentifier{URI: uri, Version: 2},
+		ContentChanges: []TextDocumentContentChangeEvent{{Text: broken}},
+	})
+
+	diags := c.diagnostics(uri)
+	require.NotEmpty(t, diags)
+	assert.Equal(t, lint.CodeTypeCheckError, diags[0].Code)
+	assert.Equal(t, SeverityError, diags[0].Severity)
+
+	// Syntax error
+	c.notify("textDocument/didChange", DidChangeTextDocumentParams{
+		TextDocument:   VersionedTextDocumentIdentifier{URI: uri, Version: 3},
+		ContentChanges: []TextDocumentContentChangeEvent{{Text: helloSource + "func {"}},
+	})
+
+	diags = c.diagnostics(uri)
+	require.NotEmpty(t, diags)
+	assert.Equal(t, lint.CodeParserError, diags[0].Code)
+	assert.Equal(t, 8, diags[0].Range.Start.Line)
+
+	// Lint warning
+	c.notify("textDocument/didChange", DidChangeTextDocumentParams{
+		TextDocument:   VersionedTextDocumentIdentifier{URI: uri, Version: 4},
+		ContentChanges: []TextDocumentContentChangeEvent{{Text: helloSource + "\nvar Counter = new(int)\n"}},
+	})
+
+	diags = c.diagnostics(uri)
+	require.Len(t, diags, 1)
+	assert.Equal(t, lint.CodeRealmPointerGlobal, diags[0].Code)
+	assert.Equal(t, SeverityWarning, diags[0].Severity)
+}
+
+func TestServer_Hover(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t)
+	uri := setupPackage(t, c, helloSource)
+
+	var hover Hover
+	c.call("textDocument/hover", TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     positionOf(t, helloSource, "Hello("),
+	}, &hover)
+
+	assert.Equal(t, "markdown", hover.Contents.Kind)
+	assert.Contains(t, hover.Contents.Value, "func Hello(name string) string")
+	assert.Contains(t, hover.Contents.Value, "Hello greets the given name")
+
+	// Stdlib documentation
+	c.call("textDocument/hover", TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     positionOf(t, helloSource, "ToUpper"),
+	}, &hover)
+
+	assert.Contains(t, hover.Contents.Value, "func ToUpper(s string) string")
+}
+
+func TestServer_Definition(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t)
+	uri := setupPackage(t, c, helloSource)
+
+	var loc Location
+	c.call("textDocument/definition", TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     positionOf(t, helloSource, "ToUpper"),
+	}, &loc)
+
+	stdlibs := filepath.Join(gnoenv.RootDir(), "gnovm", "stdlibs", "strings")
+	assert.True(t, strings.HasPrefix(uriToPath(loc.URI), stdlibs), loc.URI)
+
+	// The location points to the declaration
+	content, err := os.ReadFile(uriToPath(loc.URI))
+	require.NoError(t, err)
+
+	offset := toOffset(string(content), loc.Range.Start)
+	assert.True(t, strings.HasPrefix(string(content[offset:]), "ToUpper("))
+}
+
+func TestServer_Completion(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t)
+
+	source := strings.Replace(helloSource, "strings.ToUpper(name)", "strings.ToU", 1)
+	uri := setupPackage(t, c, source)
+
+	var list CompletionList
+	c.call("textDocument/completion", TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     positionOf(t, source, "ToU\n"),
+	}, &list)
+
+	// The position is at the start of the identifier
+	labels := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		labels = append(labels, item.Label)
+	}
+	assert.Contains(t, labels, "ToUpper")
+	assert.Contains(t, labels, "ToLower")
+
+	pos := positionOf(t, source, "ToU\n")
+	pos.Character += len("ToU")
+
+	c.call("textDocument/completion", TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     pos,
+	}, &list)
+
+	// Only the members matching the prefix are completed
+	require.NotEmpty(t, list.Items)
+	for _, item := range list.Items {
+		assert.True(t, strings.HasPrefix(item.Label, "ToU"), item.Label)
+	}
+	assert.Equal(t, "ToUpper", list.Items[0].Label)
+	assert.Equal(t, completionFunction, list.Items[0].Kind)
+
+	// Identifiers in scope
+	pos = positionOf(t, source, "\"hello \"")
+
+	c.call("textDocument/completion", TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     pos,
+	}, &list)
+
+	labels = labels[:0]
+	for _, item := range list.Items {
+		labels = append(labels, item.Label)
+	}
+	assert.Contains(t, labels, "name")
+	assert.Contains(t, labels, "Hello")
+	assert.Contains(t, labels, "strings")
+}
+
+func TestServer_Formatting(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t)
+
+	// Unformatted, with a missing import
+	source := `package hello
+
+func Hello(name string) string {
+return "hello "+strings.ToUpper(name)
+}
+`
+	uri := setupPackage(t, c, source)
+
+	var edits []TextEdit
+	c.call("textDocument/formatting", DocumentFormattingParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+	}, &edits)
+
+	require.Len(t, edits, 1)
+	assert.Equal(t, helloSource, strings.Replace(edits[0].NewText, "func Hello", "// Hello greets the given name\nfunc Hello", 1))
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- NewServer(gnoenv.RootDir()).Serve(context.Background(), serverR, serverW)
+	}()
+
+	c := connectTestClient(t, clientR, clientW)
+
+	c.call("initialize", InitializeParams{}, nil)
+	c.call("shutdown", nil, nil)
+	c.notify("exit", nil)
+
+	assert.NoError(t, <-done)
+}
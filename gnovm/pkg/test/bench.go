@@ -0,0 +1,200 @@
+package test
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// defaultBenchTime is the time each benchmark runs for, when
+// [TestOptions.BenchTime] is not set.
+const defaultBenchTime = time.Second
+
+// benchMaxN is the maximum b.N of a benchmark.
+const benchMaxN = 1_000_000_000
+
+// benchResult holds the measures of a run of a benchmark.
+type benchResult struct {
+	n        int
+	duration time.Duration // measured by the timer of testing.B
+	cycles   int64         // VM cycles
+	gas      int64         // gas consumed, by the VM and the store
+	alloc    int64         // allocated bytes
+	bytes    int64         // bytes processed by an iteration, set with SetBytes
+
+	// Metrics reported with ReportMetric.
+	extraUnits  []string
+	extraValues []float64
+}
+
+// String formats the result like Go benchmarks, so that it can be read by
+// tools like benchstat.
+func (r *benchResult) String() string {
+	var buf strings.Builder
+	n := float64(r.n)
+	fmt.Fprintf(&buf, "%8d", r.n)
+	prettyPrint(&buf, float64(r.duration.Nanoseconds())/n, "ns/op")
+	if r.bytes > 0 && r.duration > 0 {
+		mbs := float64(r.bytes) * n / 1e6 / r.duration.Seconds()
+		fmt.Fprintf(&buf, "\t%7.2f MB/s", mbs)
+	}
+	prettyPrint(&buf, float64(r.cycles)/n, "cycles/op")
+	prettyPrint(&buf, float64(r.gas)/n, "gas/op")
+	for i, unit := range r.extraUnits {
+		prettyPrint(&buf, r.extraValues[i], unit)
+	}
+	fmt.Fprintf(&buf, "\t%8d B/op", r.alloc/int64(r.n))
+	return buf.String()
+}
+
+// prettyPrint writes a metric with a precision depending on its magnitude,
+// like the Go testing package.
+func prettyPrint(w io.Writer, x float64, unit string) {
+	var format string
+	switch y := math.Abs(x); {
+	case y == 0 || y >= 999.95:
+		format = "\t%10.0f %s"
+	case y >= 99.995:
+		format = "\t%12.1f %s"
+	case y >= 9.9995:
+		format = "\t%13.2f %s"
+	case y >= 0.99995:
+		format = "\t%14.3f %s"
+	case y >= 0.099995:
+		format = "\t%15.4f %s"
+	case y >= 0.0099995:
+		format = "\t%16.5f %s"
+	default:
+		format = "\t%17.6f %s"
+	}
+	fmt.Fprintf(w, format, x, unit)
+}
+
+// runBenchmark runs the benchmark name opts.Count times, and prints each
+// result.
+func (opts *TestOptions) runBenchmark(m *gno.Machine, name string) error {
+	for range max(opts.Count, 1) {
+		res, err := opts.benchmark(m, name)
+		if err != nil {
+			return err
+		}
+		if res == nil {
+			if opts.Verbose {
+				fmt.Fprintf(opts.Error, "--- SKIP: %s\n", name)
+			}
+			return nil
+		}
+		fmt.Fprintf(opts.Error, "%s\t%s\n", name, res)
+	}
+	return nil
+}
+
+// benchmark runs the benchmark name with increasing values of b.N, until
+// it runs for opts.BenchTime or opts.BenchIters iterations, and returns the
+// result of the last run. The result is nil if the benchmark was skipped.
+func (opts *TestOptions) benchmark(m *gno.Machine, name string) (*benchResult, error) {
+	benchTime := cmp.Or(opts.BenchTime, defaultBenchTime)
+
+	n := 1
+	for {
+		res, err := opts.runBenchmarkN(m, name, n)
+		if err != nil || res == nil {
+			return nil, err
+		}
+
+		switch {
+		case opts.BenchIters > 0:
+			if n >= opts.BenchIters {
+				return res, nil
+			}
+			n = opts.BenchIters
+		case res.duration >= benchTime || n >= benchMaxN:
+			return res, nil
+		default:
+			n = predictN(benchTime, n, res.duration)
+		}
+	}
+}
+
+// predictN returns the b.N of the next run of a benchmark, which took d for
+// the previous b.N of last, to run for goal.
+func predictN(goal time.Duration, last int, d time.Duration) int {
+	prevns := max(d.Nanoseconds(), 1)
+	// Run for 20% more than the goal, to avoid ending just short of it.
+	n := float64(goal.Nanoseconds()) * float64(last) / float64(prevns) * 1.2
+	n = min(n, 100*float64(last))
+	return int(min(max(n, float64(last+1)), benchMaxN))
+}
+
+// runBenchmarkN runs the benchmark name once with b.N set to n.
+func (opts *TestOptions) runBenchmarkN(m *gno.Machine, name string, n int) (*benchResult, error) {
+	testingpv := m.Store.GetPackage("testing", false)
+	testingtv := gno.TypedValue{T: &gno.PackageType{}, V: testingpv}
+	testingcx := &gno.ConstExpr{TypedValue: testingtv}
+
+	m.Alloc = gno.NewAllocator(math.MaxInt64)
+	m.GasMeter = opts.gasMeter
+	defer func() { m.GasMeter = nil }()
+
+	cycles := m.Cycles
+	var gas int64
+	if m.GasMeter != nil {
+		gas = m.GasMeter.GasConsumed()
+	}
+
+	eval := m.Eval(gno.Call(
+		gno.Sel(testingcx, "RunBenchmark"),
+		gno.Num(strconv.Itoa(n)),
+		&gno.CompositeLitExpr{
+			Type: gno.Sel(testingcx, "InternalBenchmark"),
+			Elts: gno.KeyValueExprs{
+				{Key: gno.X("Name"), Value: gno.Str(name)},
+				{Key: gno.X("F"), Value: gno.Nx(name)},
+			},
+		},
+	))
+
+	var rep report
+	if err := json.Unmarshal([]byte(eval[1].GetString()), &rep); err != nil {
+		fmt.Fprintf(opts.Error, "--- FAIL: %s [internal gno testing error]", name)
+		return nil, err
+	}
+	if rep.Failed {
+		return nil, fmt.Errorf("failed: %q", name)
+	}
+	if rep.Skipped {
+		return nil, nil
+	}
+
+	b := eval[0]
+	duration := structField(b, "duration")
+	bytes := structField(b, "bytes")
+	res := &benchResult{
+		n:        n,
+		duration: time.Duration(duration.GetInt64()),
+		cycles:   m.Cycles - cycles,
+		bytes:    bytes.GetInt64(),
+	}
+	if m.GasMeter != nil {
+		res.gas = m.GasMeter.GasConsumed() - gas
+	}
+	_, res.alloc = m.Alloc.Status()
+
+	units := structField(b, "extraUnits")
+	values := structField(b, "extraValues")
+	for i := range units.GetLength() {
+		unit := units.GetPointerAtIndexInt(m.Store, i).Deref()
+		value := values.GetPointerAtIndexInt(m.Store, i).Deref()
+		res.extraUnits = append(res.extraUnits, unit.GetString())
+		res.extraValues = append(res.extraValues, math.Float64frombits(value.GetFloat64()))
+	}
+
+	return res, nil
+}
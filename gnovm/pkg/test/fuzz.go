@@ -0,0 +1,715 @@
+package test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// fuzzCorpusHeader is the first line of the files of a fuzz corpus, stored
+// under testdata/fuzz/FuzzXxx.
+const fuzzCorpusHeader = "gno test fuzz v1"
+
+// fuzzLogInterval is the interval between the progress logs while fuzzing.
+const fuzzLogInterval = 3 * time.Second
+
+// fuzzMaxLen is the maximum length of the strings and byte slices generated
+// while fuzzing.
+const fuzzMaxLen = 1 << 12
+
+// fuzzTypes are the types of the arguments that can be fuzzed, after the
+// *testing.T, with their Go equivalent.
+var fuzzTypes = map[gno.Type]reflect.Type{
+	gno.StringType:  reflect.TypeOf(""),
+	gno.BoolType:    reflect.TypeOf(false),
+	gno.IntType:     reflect.TypeOf(int(0)),
+	gno.Int8Type:    reflect.TypeOf(int8(0)),
+	gno.Int16Type:   reflect.TypeOf(int16(0)),
+	gno.Int32Type:   reflect.TypeOf(int32(0)),
+	gno.Int64Type:   reflect.TypeOf(int64(0)),
+	gno.UintType:    reflect.TypeOf(uint(0)),
+	gno.Uint8Type:   reflect.TypeOf(uint8(0)),
+	gno.Uint16Type:  reflect.TypeOf(uint16(0)),
+	gno.Uint32Type:  reflect.TypeOf(uint32(0)),
+	gno.Uint64Type:  reflect.TypeOf(uint64(0)),
+	gno.Float32Type: reflect.TypeOf(float32(0)),
+	gno.Float64Type: reflect.TypeOf(float64(0)),
+}
+
+var (
+	bytesType    = reflect.TypeOf([]byte(nil))
+	anySliceType = &gno.SliceType{Elt: &gno.InterfaceType{}}
+)
+
+// fuzzInput is an input of a fuzz target, from its corpus or generated.
+type fuzzInput struct {
+	name   string // seed#N, or the name of the corpus file
+	values []any
+}
+
+// fuzzTarget is the fuzz target of a fuzz test, set with F.Fuzz.
+type fuzzTarget struct {
+	name  string // name of the fuzz test
+	types []reflect.Type
+
+	testingcx *gno.ConstExpr // the testing package
+	fcx       *gno.ConstExpr // the *testing.F of the fuzz test
+	bindcx    *gno.ConstExpr // binds the inputs to the fuzz target
+}
+
+// runFuzzTest runs the fuzz test name, and then its fuzz target on each
+// input of its corpus: the seeds added with F.Add, and the files of
+// fsDir/testdata/fuzz/<name>. If name matches opts.Fuzz, it then generates
+// new inputs until opts.FuzzTime or opts.FuzzIters is reached, or an input
+// makes the fuzz target fail.
+func (opts *TestOptions) runFuzzTest(m *gno.Machine, name, fsDir string) error {
+	filter := splitRegexp(opts.RunFlag)
+	if !shouldRun(filter, name) {
+		return nil
+	}
+
+	testingpv := m.Store.GetPackage("testing", false)
+	testingtv := gno.TypedValue{T: &gno.PackageType{}, V: testingpv}
+	testingcx := &gno.ConstExpr{TypedValue: testingtv}
+
+	eval := m.Eval(gno.Call(
+		gno.Sel(testingcx, "RunFuzzTarget"),
+		gno.Nx(strconv.FormatBool(opts.Verbose)),
+		&gno.CompositeLitExpr{
+			Type: gno.Sel(testingcx, "InternalFuzzTarget"),
+			Elts: gno.KeyValueExprs{
+				{Key: gno.X("Name"), Value: gno.Str(name)},
+				{Key: gno.X("F"), Value: gno.Nx(name)},
+			},
+		},
+	))
+
+	var rep report
+	if err := json.Unmarshal([]byte(eval[1].GetString()), &rep); err != nil {
+		return opts.fuzzFail(name, fmt.Errorf("internal gno testing error: %w", err))
+	}
+	if rep.Failed {
+		return fmt.Errorf("failed: %q", name)
+	}
+	if rep.Skipped {
+		return nil
+	}
+
+	f := eval[0]
+	fn := structField(f, "fn")
+	if fn.T == nil {
+		// F.Fuzz was not called.
+		return nil
+	}
+
+	types, err := fuzzArgTypes(fn.T)
+	if err != nil {
+		return opts.fuzzFail(name, err)
+	}
+
+	target := &fuzzTarget{
+		name:      name,
+		types:     types,
+		testingcx: testingcx,
+		fcx:       &gno.ConstExpr{TypedValue: f},
+	}
+	target.bindcx = &gno.ConstExpr{TypedValue: m.Eval(target.bind(fn))[0]}
+
+	// Load the corpus.
+	var corpus []fuzzInput
+	seeds := structField(f, "seeds")
+	for i := range seeds.GetLength() {
+		seed := seeds.GetPointerAtIndexInt(m.Store, i).Deref()
+		values, err := fuzzSeedValues(m.Store, seed, fn.T.(*gno.FuncType), types)
+		if err != nil {
+			return opts.fuzzFail(name, fmt.Errorf("seed#%d: %w", i, err))
+		}
+		corpus = append(corpus, fuzzInput{name: fmt.Sprintf("seed#%d", i), values: values})
+	}
+	corpusDir := filepath.Join(fsDir, "testdata", "fuzz", name)
+	files, err := readFuzzCorpus(corpusDir, types)
+	if err != nil {
+		return opts.fuzzFail(name, err)
+	}
+	corpus = append(corpus, files...)
+
+	fuzzing := false
+	if opts.Fuzz != "" {
+		re, err := regexp.Compile(opts.Fuzz)
+		if err != nil {
+			return fmt.Errorf("invalid -fuzz regexp %q: %w", opts.Fuzz, err)
+		}
+		fuzzing = re.MatchString(name)
+	}
+
+	// Replay the corpus, gathering the baseline coverage if fuzzing.
+	var cov *gno.Coverage
+	if fuzzing {
+		cov = gno.NewCoverage()
+	}
+	for _, in := range corpus {
+		if !fuzzing && !shouldRun(filter, name+"/"+in.name) {
+			continue
+		}
+		failed, err := opts.runFuzzInput(m, target, in.name, in.values, opts.Verbose, cov)
+		if err != nil {
+			return opts.fuzzFail(name, err)
+		}
+		if failed {
+			return fmt.Errorf("failed: %q", name+"/"+in.name)
+		}
+	}
+
+	if !fuzzing {
+		return nil
+	}
+	return opts.fuzz(m, target, corpus, cov, corpusDir)
+}
+
+// fuzz generates inputs by mutating the corpus, and keeps those which
+// execute new code paths, as recorded by the coverage of the machine.
+func (opts *TestOptions) fuzz(m *gno.Machine, target *fuzzTarget, corpus []fuzzInput, cov *gno.Coverage, corpusDir string) error {
+	if len(corpus) == 0 {
+		values := make([]any, len(target.types))
+		for i, typ := range target.types {
+			values[i] = reflect.Zero(typ).Interface()
+		}
+		corpus = append(corpus, fuzzInput{name: "zero", values: values})
+	}
+
+	mut := &fuzzMutator{r: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	local := gno.NewCoverage()
+	start := time.Now()
+	lastLog := start
+	var execs, interesting int
+
+	logf := func() {
+		elapsed := time.Since(start)
+		fmt.Fprintf(opts.Error, "fuzz: elapsed: %s, execs: %d (%.0f/sec), new interesting: %d (total: %d)\n",
+			elapsed.Round(time.Second), execs, float64(execs)/elapsed.Seconds(), interesting, len(corpus))
+	}
+	fmt.Fprintf(opts.Error, "fuzz: elapsed: 0s, execs: 0 (0/sec), new interesting: 0 (total: %d)\n", len(corpus))
+
+	for {
+		if opts.FuzzIters > 0 {
+			if execs >= opts.FuzzIters {
+				break
+			}
+		} else if opts.FuzzTime > 0 && time.Since(start) >= opts.FuzzTime {
+			break
+		}
+
+		values := mut.mutate(corpus[mut.r.Intn(len(corpus))].values)
+		local.Reset()
+		failed, err := opts.runFuzzInput(m, target, "fuzz", values, false, local)
+		execs++
+		if err != nil {
+			fmt.Fprintf(opts.Error, "--- FAIL: %s/fuzz\n    %v\n", target.name, err)
+			failed = true
+		}
+		if failed {
+			logf()
+			path, err := writeFuzzCorpusFile(corpusDir, values)
+			if err != nil {
+				return opts.fuzzFail(target.name, fmt.Errorf("write failing input: %w", err))
+			}
+			fmt.Fprintf(opts.Error, "--- FAIL: %s\n", target.name)
+			fmt.Fprintf(opts.Error, "    Failing input written to %s\n", path)
+			fmt.Fprintf(opts.Error, "    To re-run:\n    gno test -run=%s/%s\n", target.name, filepath.Base(path))
+			return fmt.Errorf("failed: %q", target.name)
+		}
+
+		if cov.Merge(local) > 0 {
+			corpus = append(corpus, fuzzInput{name: "fuzz", values: values})
+			interesting++
+		}
+
+		if time.Since(lastLog) >= fuzzLogInterval {
+			logf()
+			lastLog = time.Now()
+		}
+	}
+
+	logf()
+	return nil
+}
+
+// runFuzzInput runs the fuzz target on the given values, recording the
+// executed code paths in cov if not nil. The returned error is set if the
+// machine panicked, in which case it can't be reused.
+func (opts *TestOptions) runFuzzInput(m *gno.Machine, target *fuzzTarget, name string, values []any, verbose bool, cov *gno.Coverage) (failed bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	list := make([]gno.TypedValue, len(values))
+	for i, v := range values {
+		list[i] = fuzzGnoValue(m, v)
+	}
+	args := gno.TypedValue{T: anySliceType, V: m.Alloc.NewSliceFromList(list)}
+
+	m.Coverage = cov
+	defer func() { m.Coverage = nil }()
+
+	eval := m.Eval(gno.Call(
+		gno.Sel(target.testingcx, "RunFuzzInput"),
+		target.fcx,
+		gno.Str(name),
+		gno.Nx(strconv.FormatBool(verbose)),
+		gno.Call(target.bindcx, &gno.ConstExpr{TypedValue: args}),
+	))
+
+	var rep report
+	if err := json.Unmarshal([]byte(eval[0].GetString()), &rep); err != nil {
+		return false, fmt.Errorf("internal gno testing error: %w", err)
+	}
+	return rep.Failed, nil
+}
+
+// bind returns the function binding a slice of inputs to the fuzz target fn:
+//
+//	func(args []any) func(*testing.T) {
+//		return func(t *testing.T) { fn(t, args[0].(string), ...) }
+//	}
+func (target *fuzzTarget) bind(fn gno.TypedValue) gno.Expr {
+	tT := gno.Ptr(gno.Sel(target.testingcx, "T"))
+
+	callArgs := []any{gno.Nx("t")}
+	for i, typ := range target.types {
+		var texpr gno.Expr
+		if typ == bytesType {
+			texpr = gno.SliceT(gno.Nx("uint8"))
+		} else {
+			texpr = gno.Nx(typ.Name())
+		}
+		callArgs = append(callArgs, gno.TypeAssert(gno.Idx(gno.Nx("args"), gno.Num(strconv.Itoa(i))), texpr))
+	}
+
+	return gno.Fn(
+		gno.Flds("args", gno.SliceT(gno.AnyT())),
+		gno.Flds("", gno.FuncT(gno.Flds("t", tT), nil)),
+		gno.Ss(gno.Return(gno.Fn(
+			gno.Flds("t", tT),
+			nil,
+			gno.Ss(gno.S(gno.Call(&gno.ConstExpr{TypedValue: fn}, callArgs...))),
+		))),
+	)
+}
+
+// fuzzFail prints the failure of a fuzz test, for errors which don't come
+// from the test itself.
+func (opts *TestOptions) fuzzFail(name string, err error) error {
+	fmt.Fprintf(opts.Error, "--- FAIL: %s\n    %v\n", name, err)
+	return fmt.Errorf("failed: %q", name)
+}
+
+// fuzzArgTypes checks the type of the fuzz target, and returns the Go types
+// of the arguments to fuzz.
+func fuzzArgTypes(t gno.Type) ([]reflect.Type, error) {
+	ft, ok := t.(*gno.FuncType)
+	if !ok {
+		return nil, fmt.Errorf("fuzz target must be a function, got %s", t.String())
+	}
+	if len(ft.Results) > 0 {
+		return nil, errors.New("fuzz target must not return a value")
+	}
+	if len(ft.Params) == 0 || !isTestingT(ft.Params[0].Type) {
+		return nil, errors.New("fuzz target must take a *testing.T as its first argument")
+	}
+	if ft.HasVarg() {
+		return nil, errors.New("fuzz target must not be variadic")
+	}
+
+	types := make([]reflect.Type, 0, len(ft.Params)-1)
+	for _, p := range ft.Params[1:] {
+		typ, ok := fuzzGoType(p.Type)
+		if !ok {
+			return nil, fmt.Errorf("fuzzing arguments can only have the following types: "+
+				"string, bool, float32, float64, int, int8, int16, int32, int64, "+
+				"uint, uint8, uint16, uint32, uint64, []byte; got %s", p.Type.String())
+		}
+		types = append(types, typ)
+	}
+	return types, nil
+}
+
+func isTestingT(t gno.Type) bool {
+	pt, ok := t.(*gno.PointerType)
+	if !ok {
+		return false
+	}
+	dt, ok := pt.Elt.(*gno.DeclaredType)
+	return ok && dt.PkgPath == "testing" && dt.Name == "T"
+}
+
+func fuzzGoType(t gno.Type) (reflect.Type, bool) {
+	if st, ok := t.(*gno.SliceType); ok {
+		return bytesType, st.Elt == gno.Uint8Type
+	}
+	typ, ok := fuzzTypes[t]
+	return typ, ok
+}
+
+// fuzzSeedValues converts a seed added with F.Add to Go values, checking it
+// matches the arguments of the fuzz target.
+func fuzzSeedValues(store gno.Store, seed gno.TypedValue, ft *gno.FuncType, types []reflect.Type) ([]any, error) {
+	n := seed.GetLength()
+	if n != len(types) {
+		return nil, fmt.Errorf("wrong number of values in corpus entry: %d, want %d", n, len(types))
+	}
+
+	values := make([]any, n)
+	for i := range n {
+		tv := seed.GetPointerAtIndexInt(store, i).Deref()
+		want := ft.Params[i+1].Type
+		if tv.T == nil || tv.T.TypeID() != want.TypeID() {
+			got := "nil"
+			if tv.T != nil {
+				got = tv.T.String()
+			}
+			return nil, fmt.Errorf("mismatched types in corpus entry: argument %d is %s, want %s", i, got, want.String())
+		}
+		rv := reflect.New(types[i]).Elem()
+		gno.Gno2GoValue(&tv, rv)
+		values[i] = rv.Interface()
+	}
+	return values, nil
+}
+
+// fuzzGnoValue converts a fuzzed Go value to Gno.
+func fuzzGnoValue(m *gno.Machine, v any) gno.TypedValue {
+	if b, ok := v.([]byte); ok {
+		return gno.TypedValue{
+			T: &gno.SliceType{Elt: gno.Uint8Type},
+			V: m.Alloc.NewSliceFromData(bytes.Clone(b)),
+		}
+	}
+	return gno.Go2GnoValue(m.Alloc, m.Store, reflect.ValueOf(v))
+}
+
+// readFuzzCorpus reads the corpus files in dir, if any.
+func readFuzzCorpus(dir string, types []reflect.Type) ([]fuzzInput, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var corpus []fuzzInput
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		values, err := unmarshalFuzzInput(data, types)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Join(dir, entry.Name()), err)
+		}
+		corpus = append(corpus, fuzzInput{name: entry.Name(), values: values})
+	}
+	return corpus, nil
+}
+
+// writeFuzzCorpusFile writes values to a file of dir named after their hash,
+// and returns its path.
+func writeFuzzCorpusFile(dir string, values []any) (string, error) {
+	data := marshalFuzzInput(values)
+	sum := sha256.Sum256(data)
+	path := filepath.Join(dir, hex.EncodeToString(sum[:])[:16])
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// marshalFuzzInput encodes the values of an input in the corpus file format:
+// a header line, followed by a line per value written as a Gno conversion,
+// like string("abc") or int(42).
+func marshalFuzzInput(values []any) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(fuzzCorpusHeader + "\n")
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			fmt.Fprintf(&buf, "string(%q)\n", v)
+		case []byte:
+			fmt.Fprintf(&buf, "[]byte(%q)\n", v)
+		case float32:
+			fmt.Fprintf(&buf, "float32(%s)\n", strconv.FormatFloat(float64(v), 'g', -1, 32))
+		case float64:
+			fmt.Fprintf(&buf, "float64(%s)\n", strconv.FormatFloat(v, 'g', -1, 64))
+		default:
+			fmt.Fprintf(&buf, "%T(%v)\n", v, v)
+		}
+	}
+	return buf.Bytes()
+}
+
+// unmarshalFuzzInput decodes a corpus file, whose values must have the
+// given types.
+func unmarshalFuzzInput(data []byte, types []reflect.Type) ([]any, error) {
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if lines[0] != fuzzCorpusHeader {
+		return nil, fmt.Errorf("must begin with %q", fuzzCorpusHeader)
+	}
+	lines = lines[1:]
+	if len(lines) != len(types) {
+		return nil, fmt.Errorf("wrong number of values in corpus entry: %d, want %d", len(lines), len(types))
+	}
+
+	values := make([]any, len(lines))
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		typ, lit, ok := strings.Cut(line, "(")
+		if !ok || !strings.HasSuffix(lit, ")") {
+			return nil, fmt.Errorf("malformed value %q", line)
+		}
+		lit = strings.TrimSuffix(lit, ")")
+		switch typ {
+		case "byte":
+			typ = "uint8"
+		case "rune":
+			typ = "int32"
+		}
+
+		want := types[i]
+		if want == bytesType {
+			if typ != "[]byte" && typ != "[]uint8" {
+				return nil, fmt.Errorf("mismatched types in corpus entry: %s, want []byte", typ)
+			}
+		} else if typ != want.Name() {
+			return nil, fmt.Errorf("mismatched types in corpus entry: %s, want %s", typ, want.Name())
+		}
+
+		v, err := parseFuzzValue(lit, want)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q: %w", line, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func parseFuzzValue(lit string, typ reflect.Type) (any, error) {
+	rv := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.String:
+		s, err := strconv.Unquote(lit)
+		if err != nil {
+			return nil, err
+		}
+		rv.SetString(s)
+	case reflect.Slice:
+		s, err := strconv.Unquote(lit)
+		if err != nil {
+			return nil, err
+		}
+		rv.SetBytes([]byte(s))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(lit)
+		if err != nil {
+			return nil, err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if c, err := strconv.Unquote(lit); err == nil && typ.Kind() == reflect.Int32 && len([]rune(c)) == 1 {
+			rv.SetInt(int64([]rune(c)[0]))
+			break
+		}
+		n, err := strconv.ParseInt(lit, 0, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(lit, 0, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(lit, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		rv.SetFloat(f)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", typ)
+	}
+	return rv.Interface(), nil
+}
+
+// fuzzMutator generates new inputs from the corpus.
+type fuzzMutator struct {
+	r *rand.Rand
+}
+
+// interestingInts are the values most likely to trigger edge cases.
+var interestingInts = []int64{
+	0, 1, -1, 2, 7, 8, 10, 16, 100, 127, -128, 128, 255, 256, 1000, 1024,
+	4096, 32767, -32768, 65535, 65536, math.MaxInt32, math.MinInt32,
+	math.MaxUint32, math.MaxInt64, math.MinInt64,
+}
+
+var interestingBytes = []byte{
+	0, 1, 0x7f, 0x80, 0xff, ' ', '\n', '"', '\'', '\\', '/', '%', '.', ',',
+	':', '-', '0', '9', 'a', 'z', 'A', 'Z', '{', '}', '[', ']', '<', '>',
+}
+
+// mutate returns a copy of values with one to four mutations applied.
+func (mut *fuzzMutator) mutate(values []any) []any {
+	res := make([]any, len(values))
+	copy(res, values)
+	if len(res) == 0 {
+		return res
+	}
+	for range 1 + mut.r.Intn(4) {
+		i := mut.r.Intn(len(res))
+		res[i] = mut.mutateValue(res[i])
+	}
+	return res
+}
+
+func (mut *fuzzMutator) mutateValue(v any) any {
+	switch v := v.(type) {
+	case bool:
+		return !v
+	case string:
+		return string(mut.mutateBytes([]byte(v)))
+	case []byte:
+		return mut.mutateBytes(bytes.Clone(v))
+	case float32:
+		if f := float32(mut.mutateFloat(float64(v))); !math.IsInf(float64(f), 0) {
+			return f
+		}
+		return v
+	case float64:
+		return mut.mutateFloat(v)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := mut.mutateInt(rv.Int(), rv.Type().Bits())
+		return reflect.ValueOf(n).Convert(rv.Type()).Interface()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := mut.mutateInt(int64(rv.Uint()), rv.Type().Bits())
+		return reflect.ValueOf(uint64(n)).Convert(rv.Type()).Interface()
+	}
+	panic(fmt.Sprintf("unexpected fuzz value of type %T", v))
+}
+
+// mutateInt mutates an integer of the given size in bits; the result is
+// truncated by the conversion to the actual type.
+func (mut *fuzzMutator) mutateInt(n int64, bits int) int64 {
+	switch mut.r.Intn(6) {
+	case 0:
+		return n + 1 + mut.r.Int63n(16)
+	case 1:
+		return n - 1 - mut.r.Int63n(16)
+	case 2:
+		return n ^ (1 << mut.r.Intn(bits))
+	case 3:
+		return interestingInts[mut.r.Intn(len(interestingInts))]
+	case 4:
+		return -n
+	default:
+		return int64(mut.r.Uint64() >> (64 - bits))
+	}
+}
+
+// mutateFloat mutates a float, keeping it finite.
+func (mut *fuzzMutator) mutateFloat(f float64) float64 {
+	var res float64
+	switch mut.r.Intn(6) {
+	case 0:
+		res = f + mut.r.NormFloat64()
+	case 1:
+		res = f * 2
+	case 2:
+		res = f / 2
+	case 3:
+		res = -f
+	case 4:
+		res = float64(interestingInts[mut.r.Intn(len(interestingInts))])
+	default:
+		res = float64(mut.mutateInt(int64(f), 64)) + mut.r.Float64()
+	}
+	if math.IsNaN(res) || math.IsInf(res, 0) {
+		return f
+	}
+	return res
+}
+
+func (mut *fuzzMutator) mutateBytes(b []byte) []byte {
+	if len(b) == 0 {
+		return append(b, mut.randByte())
+	}
+
+	switch mut.r.Intn(8) {
+	case 0: // insert a byte
+		if len(b) < fuzzMaxLen {
+			i := mut.r.Intn(len(b) + 1)
+			b = append(b[:i], append([]byte{mut.randByte()}, b[i:]...)...)
+		}
+	case 1: // delete a range
+		i := mut.r.Intn(len(b))
+		j := i + 1 + mut.r.Intn(len(b)-i)
+		b = append(b[:i], b[j:]...)
+	case 2: // flip a bit
+		b[mut.r.Intn(len(b))] ^= 1 << mut.r.Intn(8)
+	case 3: // set a byte
+		b[mut.r.Intn(len(b))] = mut.randByte()
+	case 4: // duplicate a range
+		i := mut.r.Intn(len(b))
+		j := i + 1 + mut.r.Intn(len(b)-i)
+		if len(b)+j-i <= fuzzMaxLen {
+			chunk := bytes.Clone(b[i:j])
+			k := mut.r.Intn(len(b) + 1)
+			b = append(b[:k], append(chunk, b[k:]...)...)
+		}
+	case 5: // swap two bytes
+		i, j := mut.r.Intn(len(b)), mut.r.Intn(len(b))
+		b[i], b[j] = b[j], b[i]
+	case 6: // truncate
+		b = b[:mut.r.Intn(len(b))]
+	default: // append a byte
+		if len(b) < fuzzMaxLen {
+			b = append(b, mut.randByte())
+		}
+	}
+	return b
+}
+
+func (mut *fuzzMutator) randByte() byte {
+	if mut.r.Intn(2) == 0 {
+		return interestingBytes[mut.r.Intn(len(interestingBytes))]
+	}
+	return byte(mut.r.Intn(256))
+}
@@ -0,0 +1,177 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gnolang/gno/gnovm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"go.uber.org/multierr"
+	"golang.org/x/tools/txtar"
+)
+
+// RenderGoldenSuffix is the suffix of the files holding the expected output
+// of the Render function of a package, like render_test.golden.
+//
+// These files are txtar archives, with a file for each path passed to
+// Render, named like Render("path"), whose content is the expected output
+// followed by a newline. As txtar files always end with a newline, this
+// records exactly whether the output ends with one: an output ending with a
+// newline is followed by an empty line.
+const RenderGoldenSuffix = "_test.golden"
+
+// runRenderGoldens checks the Render output of memPkg against the golden
+// files in fsDir, updating them if opts.Sync is set.
+func (opts *TestOptions) runRenderGoldens(memPkg *gnovm.MemPackage, fsDir string) (errs error) {
+	goldens, err := filepath.Glob(filepath.Join(fsDir, "*"+RenderGoldenSuffix))
+	if err != nil {
+		return err
+	}
+
+	filter := splitRegexp(opts.RunFlag)
+	for _, golden := range goldens {
+		testName := "golden/" + filepath.Base(golden)
+		if !shouldRun(filter, testName) {
+			continue
+		}
+
+		startedAt := time.Now()
+		if opts.Verbose {
+			fmt.Fprintf(opts.Error, "=== RUN   %s\n", testName)
+		}
+
+		err := opts.runRenderGolden(memPkg, golden)
+
+		dstr := fmtDuration(time.Since(startedAt))
+		if err != nil {
+			fmt.Fprintf(opts.Error, "--- FAIL: %s (%s)\n", testName, dstr)
+			fmt.Fprintln(opts.Error, err.Error())
+			errs = multierr.Append(errs, fmt.Errorf("%s failed", testName))
+		} else if opts.Verbose {
+			fmt.Fprintf(opts.Error, "--- PASS: %s (%s)\n", testName, dstr)
+		}
+	}
+
+	return errs
+}
+
+// runRenderGolden checks the Render output of memPkg for each path of the
+// golden file. Render is called after the package and its test files are
+// loaded, so the init functions of the test files can set up its state.
+func (opts *TestOptions) runRenderGolden(memPkg *gnovm.MemPackage, golden string) (errs error) {
+	archive, err := txtar.ParseFile(golden)
+	if err != nil {
+		return err
+	}
+
+	// Parse the paths first, to not run anything on a malformed file.
+	paths := make([]string, len(archive.Files))
+	for i, f := range archive.Files {
+		paths[i], err = parseRenderCall(f.Name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(golden), err)
+		}
+	}
+
+	cw := opts.BaseStore.CacheWrap()
+	gs := opts.TestStore.BeginTransaction(cw, cw, nil)
+	pv, err := opts.loadRenderPackage(memPkg, gs)
+	if err != nil {
+		return err
+	}
+
+	updated := false
+	for i, path := range paths {
+		got, err := opts.render(gs, pv, path)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("Render(%q): %w", path, err))
+			continue
+		}
+
+		want := strings.TrimSuffix(string(archive.Files[i].Data), "\n")
+		if got == want {
+			continue
+		}
+
+		if opts.Sync {
+			archive.Files[i].Data = []byte(got + "\n")
+			updated = true
+			continue
+		}
+		errs = multierr.Append(errs, fmt.Errorf("Render(%q) output mismatch:\n%s", path, unifiedDiff(want+"\n", got+"\n")))
+	}
+
+	if updated {
+		if err := os.WriteFile(golden, txtar.Format(archive), 0o644); err != nil {
+			panic(fmt.Errorf("could not fix golden file: %w", err))
+		}
+	}
+
+	return errs
+}
+
+// loadRenderPackage loads memPkg and its test files in gs, and returns the
+// package value.
+func (opts *TestOptions) loadRenderPackage(memPkg *gnovm.MemPackage, gs gno.TransactionStore) (pv *gno.PackageValue, err error) {
+	m := Machine(gs, opts.WriterForStore(), memPkg.Path, opts.Debug)
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("loading package: %v", r)
+		}
+	}()
+
+	if gs.GetMemPackage(memPkg.Path) == nil {
+		m.RunMemPackage(memPkg, true)
+	} else {
+		m.SetActivePackage(gs.GetPackage(memPkg.Path, false))
+	}
+	tset, _, _, _ := parseMemPackageTests(memPkg)
+	m.RunFiles(tset.Files...)
+
+	if _, ok := m.Package.GetPackageNode(gs).GetLocalIndex("Render"); !ok {
+		return nil, errors.New("package has no Render function")
+	}
+	return m.Package, nil
+}
+
+// render calls the Render function of pv with path.
+func (opts *TestOptions) render(gs gno.TransactionStore, pv *gno.PackageValue, path string) (out string, err error) {
+	m := Machine(gs, opts.WriterForStore(), pv.PkgPath, opts.Debug)
+	m.SetActivePackage(pv)
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+			if st := m.ExceptionsStacktrace(); st != "" {
+				err = fmt.Errorf("panic: %v\n%s", r, st)
+			}
+		}
+	}()
+
+	res := m.Eval(gno.Call(gno.X("Render"), gno.Str(path)))
+	if len(res) != 1 || res[0].T == nil || res[0].T.Kind() != gno.StringKind {
+		return "", errors.New("Render must be a func(string) string")
+	}
+	return res[0].GetString(), nil
+}
+
+// parseRenderCall parses the name of a file of a golden archive, like
+// Render("path"), and returns the path.
+func parseRenderCall(name string) (string, error) {
+	arg, ok := strings.CutPrefix(name, "Render(")
+	if ok {
+		arg, ok = strings.CutSuffix(arg, ")")
+	}
+	if !ok {
+		return "", fmt.Errorf(`invalid section %q, want Render("path")`, name)
+	}
+	path, err := strconv.Unquote(arg)
+	if err != nil {
+		return "", fmt.Errorf("invalid path in section %q: %w", name, err)
+	}
+	return path, nil
+}
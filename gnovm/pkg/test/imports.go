@@ -31,6 +31,11 @@ type StoreOptions struct {
 	// [gno.Machine.PreprocessFiles]. It avoids executing code for contexts
 	// which only intend to perform a type check, ie. `gno lint`.
 	PreprocessOnly bool
+
+	// VendorDir is the vendor directory of the module being processed, as
+	// written by `gno mod vendor`. Packages found in it take precedence over
+	// the examples.
+	VendorDir string
 }
 
 // NOTE: this isn't safe, should only be used for testing.
@@ -92,10 +97,13 @@ func StoreWithOptions(
 			return
 		}
 
-		// if examples package...
-		examplePath := filepath.Join(rootDir, "examples", pkgPath)
-		if osm.DirExists(examplePath) {
-			memPkg := gno.MustReadMemPackage(examplePath, pkgPath)
+		// if vendored or examples package...
+		pkgDir := filepath.Join(rootDir, "examples", pkgPath)
+		if vendorPath := filepath.Join(opts.VendorDir, pkgPath); opts.VendorDir != "" && osm.DirExists(vendorPath) {
+			pkgDir = vendorPath
+		}
+		if osm.DirExists(pkgDir) {
+			memPkg := gno.MustReadMemPackage(pkgDir, pkgPath)
 			if memPkg.IsEmpty() {
 				panic(fmt.Sprintf("found an empty package %q", pkgPath))
 			}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime/debug"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -125,6 +126,9 @@ type TestOptions struct {
 	Error io.Writer
 	// Debug enables the interactive debugger on gno tests.
 	Debug bool
+	// DAP, if set, drives the debugger of gno tests from a DAP client,
+	// instead of stdin and stdout.
+	DAP *gno.DAPSession
 
 	// Not set by NewTestOptions:
 
@@ -141,9 +145,28 @@ type TestOptions struct {
 	Metrics bool
 	// Uses Error to print the events emitted.
 	Events bool
+	// Regexp selecting the fuzz tests to fuzz. Other fuzz tests only run
+	// their corpus.
+	Fuzz string
+	// Time spent fuzzing each fuzz test; 0 means no limit.
+	FuzzTime time.Duration
+	// If positive, the number of inputs to generate for each fuzz test,
+	// instead of FuzzTime.
+	FuzzIters int
+	// Regexp selecting the benchmarks to run; empty to run none.
+	Bench string
+	// Time each benchmark runs for; defaults to 1s.
+	BenchTime time.Duration
+	// If positive, the number of iterations of each benchmark, instead of
+	// BenchTime.
+	BenchIters int
+	// Number of times to run each test and benchmark; defaults to 1.
+	Count int
 
 	filetestBuffer bytes.Buffer
 	outWriter      proxyWriter
+	// gasMeter of the store of the tests, measuring the gas of benchmarks.
+	gasMeter storetypes.GasMeter
 }
 
 // WriterForStore is the writer that should be passed to [Store], so that
@@ -225,17 +248,23 @@ func Test(memPkg *gnovm.MemPackage, fsDir string, opts *TestOptions) error {
 	// not necessarily integration tests, it's just for our internal reference.)
 	tset, itset, itfiles, ftfiles := parseMemPackageTests(memPkg)
 
+	if opts.DAP != nil && fsDir != "" {
+		opts.DAP.SetSourceDir(memPkg.Path, fsDir)
+		opts.DAP.SetSourceDir(memPkg.Path+"_test", fsDir)
+	}
+
 	// Testing with *_test.gno
 	if len(tset.Files)+len(itset.Files) > 0 {
 		// Create a common cw/gs for both the `pkg` tests as well as the `pkg_test`
 		// tests. This allows us to "export" symbols from the pkg tests and
 		// import them from the `pkg_test` tests.
 		cw := opts.BaseStore.CacheWrap()
-		gs := opts.TestStore.BeginTransaction(cw, cw, nil)
+		opts.gasMeter = storetypes.NewInfiniteGasMeter()
+		gs := opts.TestStore.BeginTransaction(cw, cw, opts.gasMeter)
 
 		// Run test files in pkg.
 		if len(tset.Files) > 0 {
-			err := opts.runTestFiles(memPkg, tset, gs)
+			err := opts.runTestFiles(memPkg, tset, gs, fsDir)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -249,13 +278,20 @@ func Test(memPkg *gnovm.MemPackage, fsDir string, opts *TestOptions) error {
 				Files: itfiles,
 			}
 
-			err := opts.runTestFiles(itPkg, itset, gs)
+			err := opts.runTestFiles(itPkg, itset, gs, fsDir)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
 		}
 	}
 
+	// Testing Render with *_test.golden.
+	if fsDir != "" {
+		if err := opts.runRenderGoldens(memPkg, fsDir); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
 	// Testing with *_filetest.gno.
 	if len(ftfiles) > 0 {
 		filter := splitRegexp(opts.RunFlag)
@@ -302,6 +338,7 @@ func (opts *TestOptions) runTestFiles(
 	memPkg *gnovm.MemPackage,
 	files *gno.FileSet,
 	gs gno.TransactionStore,
+	fsDir string,
 ) (errs error) {
 	var m *gno.Machine
 	defer func() {
@@ -318,6 +355,13 @@ func (opts *TestOptions) runTestFiles(
 	}()
 
 	tests := loadTestFuncs(memPkg.Name, files)
+	benchmarks := loadBenchmarkFuncs(memPkg.Name, files)
+	fuzzTests := loadFuzzFuncs(memPkg.Name, files)
+
+	// Each test runs opts.Count times, like in Go.
+	if opts.Count > 1 {
+		tests = slices.Repeat(tests, opts.Count)
+	}
 
 	var alloc *gno.Allocator
 	if opts.Metrics {
@@ -355,7 +399,9 @@ func (opts *TestOptions) runTestFiles(
 		testingtv := gno.TypedValue{T: &gno.PackageType{}, V: testingpv}
 		testingcx := &gno.ConstExpr{TypedValue: testingtv}
 
-		if opts.Debug {
+		if opts.DAP != nil {
+			m.Debugger.EnableDAP(opts.DAP)
+		} else if opts.Debug {
 			fileContent := func(ppath, name string) string {
 				p := filepath.Join(opts.RootDir, ppath, name)
 				b, err := os.ReadFile(p)
@@ -440,10 +486,64 @@ func (opts *TestOptions) runTestFiles(
 		}
 	}
 
+	if opts.Bench != "" {
+		filter := splitRegexp(opts.Bench)
+		printedPkg := false
+		for _, tf := range benchmarks {
+			if !shouldRun(filter, tf.Name) {
+				continue
+			}
+			if !printedPkg {
+				// Header read by benchstat.
+				fmt.Fprintf(opts.Error, "pkg: %s\n", strings.TrimSuffix(memPkg.Path, "_test"))
+				printedPkg = true
+			}
+
+			m = Machine(gs, opts.WriterForStore(), memPkg.Path, opts.Debug)
+			m.SetActivePackage(pv)
+
+			err := opts.runBenchmark(m, tf.Name)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				if opts.FailfastFlag {
+					return errs
+				}
+			}
+		}
+	}
+
+	for _, tf := range fuzzTests {
+		m = Machine(gs, opts.WriterForStore(), memPkg.Path, opts.Debug)
+		m.Alloc = alloc.Reset()
+		m.SetActivePackage(pv)
+
+		err := opts.runFuzzTest(m, tf.Name, fsDir)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			if opts.FailfastFlag {
+				return errs
+			}
+		}
+	}
+
 	return errs
 }
 
 // report is a mirror of Gno's stdlibs/testing.Report.
+// structField returns the field with the given name of the struct pointed
+// to by ptr, like the *testing.F or *testing.B returned to run fuzz tests and
+// benchmarks.
+func structField(ptr gno.TypedValue, name string) gno.TypedValue {
+	st := gno.BaseOf(ptr.T.Elem()).(*gno.StructType)
+	sv := ptr.V.(gno.PointerValue).Deref().V.(*gno.StructValue)
+	for i, fld := range st.Fields {
+		if string(fld.Name) == name {
+			return sv.Fields[i]
+		}
+	}
+	panic(fmt.Sprintf("%s has no field %q", ptr.T.Elem().String(), name))
+}
+
 type report struct {
 	Failed  bool
 	Skipped bool
@@ -455,11 +555,23 @@ type testFunc struct {
 }
 
 func loadTestFuncs(pkgName string, tfiles *gno.FileSet) (rt []testFunc) {
+	return loadFuncsWithPrefix(pkgName, tfiles, "Test")
+}
+
+func loadBenchmarkFuncs(pkgName string, tfiles *gno.FileSet) (rt []testFunc) {
+	return loadFuncsWithPrefix(pkgName, tfiles, "Benchmark")
+}
+
+func loadFuzzFuncs(pkgName string, tfiles *gno.FileSet) (rt []testFunc) {
+	return loadFuncsWithPrefix(pkgName, tfiles, "Fuzz")
+}
+
+func loadFuncsWithPrefix(pkgName string, tfiles *gno.FileSet, prefix string) (rt []testFunc) {
 	for _, tf := range tfiles.Files {
 		for _, d := range tf.Decls {
 			if fd, ok := d.(*gno.FuncDecl); ok {
 				fname := string(fd.Name)
-				if strings.HasPrefix(fname, "Test") {
+				if strings.HasPrefix(fname, prefix) {
 					tf := testFunc{
 						Package: pkgName,
 						Name:    fname,
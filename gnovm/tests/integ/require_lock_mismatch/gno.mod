@@ -0,0 +1 @@
+module gno.land/tests/requirelockmismatch
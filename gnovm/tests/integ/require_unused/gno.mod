@@ -0,0 +1,3 @@
+module gno.land/tests/requireunused
+
+require gno.land/p/demo/avl v1.0.0
@@ -0,0 +1,3 @@
+module gno.land/tests/requireversion
+
+require gno.land/r/docs/minisocial v2.0.0
@@ -429,6 +429,42 @@ var nativeFuncs = [...]NativeFunc{
 			))
 		},
 	},
+	{
+		"testing",
+		"beginTx",
+		[]gno.FieldTypeExpr{},
+		[]gno.FieldTypeExpr{},
+		true,
+		func(m *gno.Machine) {
+			testlibs_testing.X_beginTx(
+				m,
+			)
+		},
+	},
+	{
+		"testing",
+		"commitTx",
+		[]gno.FieldTypeExpr{},
+		[]gno.FieldTypeExpr{},
+		true,
+		func(m *gno.Machine) {
+			testlibs_testing.X_commitTx(
+				m,
+			)
+		},
+	},
+	{
+		"testing",
+		"revertTx",
+		[]gno.FieldTypeExpr{},
+		[]gno.FieldTypeExpr{},
+		true,
+		func(m *gno.Machine) {
+			testlibs_testing.X_revertTx(
+				m,
+			)
+		},
+	},
 	{
 		"testing",
 		"getContext",
@@ -0,0 +1,51 @@
+package testing
+
+import (
+	"sync"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// savepoints holds the savepoints of the transactions being executed, for
+// each store. They are nested when Exec is called within a transaction.
+var savepoints = struct {
+	sync.Mutex
+	m map[gno.Store][]*gno.StoreSavepoint
+}{m: make(map[gno.Store][]*gno.StoreSavepoint)}
+
+func X_beginTx(m *gno.Machine) {
+	savepoints.Lock()
+	defer savepoints.Unlock()
+
+	savepoints.m[m.Store] = append(savepoints.m[m.Store], gno.BeginSavepoint(m.Store))
+}
+
+func popSavepoint(st gno.Store) *gno.StoreSavepoint {
+	savepoints.Lock()
+	defer savepoints.Unlock()
+
+	sps := savepoints.m[st]
+	if len(sps) == 0 {
+		panic("testing: no transaction in progress")
+	}
+	sp := sps[len(sps)-1]
+	if len(sps) == 1 {
+		delete(savepoints.m, st)
+	} else {
+		savepoints.m[st] = sps[:len(sps)-1]
+	}
+	return sp
+}
+
+func X_commitTx(m *gno.Machine) {
+	// The realms called by the transaction are finalized when their calls
+	// return; only the realm of the test remains to be.
+	if m.Realm != nil {
+		m.Realm.FinalizeRealmTransaction(m.Store)
+	}
+	popSavepoint(m.Store).Commit()
+}
+
+func X_revertTx(m *gno.Machine) {
+	popSavepoint(m.Store).Revert()
+}
@@ -25,7 +25,10 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto/merkle"
 	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/authz"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/distribution"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -57,7 +60,10 @@ func execGen(_ context.Context, _ []string) error {
 		std.Package,
 		gnovm.Package,
 		sdk.Package,
+		auth.Package,
+		authz.Package,
 		bank.Package,
+		distribution.Package,
 		vm.Package,
 		gno.Package,
 		tests.Package,
@@ -61,7 +61,7 @@ type RequestBeginBlock struct {
 	Hash           []byte
 	Header         Header
 	LastCommitInfo *LastCommitInfo
-	// Violations     []Violation
+	Violations     []Violation
 }
 
 type CheckTxType int
@@ -277,7 +277,11 @@ type VoteInfo struct {
 	SignedLastBlock bool
 }
 
-/*
+// Evidence is the proof of a consensus violation (ie. double signing)
+type Evidence interface {
+	AssertABCIEvidence()
+}
+
 // unstable
 type Validator struct {
 	Address crypto.Address
@@ -285,12 +289,13 @@ type Validator struct {
 	Power   int64
 }
 
+// Violation is a verified consensus violation,
+// committed by the given validators at the given height
 // unstable
 type Violation struct {
-	Evidence
+	Evidence         Evidence
 	Validators       []Validator
 	Height           int64
 	Time             time.Time
 	TotalVotingPower int64
 }
-*/
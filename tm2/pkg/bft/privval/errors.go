@@ -19,6 +19,8 @@ var (
 
 	ErrReadTimeout  = fmt.Errorf("endpoint read timed out")
 	ErrWriteTimeout = fmt.Errorf("endpoint write timed out")
+
+	ErrUnknownChainID = fmt.Errorf("refusing to sign for unknown chain ID")
 )
 
 // RemoteSignerError allows (remote) validators to include meaningful error descriptions in their reply.
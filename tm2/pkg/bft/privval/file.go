@@ -222,6 +222,41 @@ func LoadOrGenFilePV(keyFilePath, stateFilePath string) *FilePV {
 	return pv
 }
 
+// NewFilePVWithKey returns a FilePV wrapping the given private key, which
+// is managed outside of the FilePV (ie. in an encrypted keybase). The key is
+// never written to disk; only the last sign state is persisted to stateFilePath,
+// and it is loaded from there if the file already exists.
+func NewFilePVWithKey(privKey crypto.PrivKey, stateFilePath string) (*FilePV, error) {
+	pvState := FilePVLastSignState{
+		Step:     stepNone,
+		filePath: stateFilePath,
+	}
+
+	if osm.FileExists(stateFilePath) {
+		stateJSONBytes, err := os.ReadFile(stateFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read PrivValidator state, %w", err)
+		}
+
+		if err := amino.UnmarshalJSON(stateJSONBytes, &pvState); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal PrivValidator state from %v, %w", stateFilePath, err)
+		}
+
+		pvState.filePath = stateFilePath
+	} else {
+		pvState.Save()
+	}
+
+	return &FilePV{
+		Key: FilePVKey{
+			Address: privKey.PubKey().Address(),
+			PubKey:  privKey.PubKey(),
+			PrivKey: privKey,
+		},
+		LastSignState: pvState,
+	}, nil
+}
+
 // GetAddress returns the address of the validator.
 // Implements PrivValidator.
 func (pv *FilePV) GetAddress() types.Address {
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -340,3 +341,28 @@ func newProposal(height int64, round int, blockID types.BlockID) *types.Proposal
 		Timestamp: tmtime.Now(),
 	}
 }
+
+func TestNewFilePVWithKey(t *testing.T) {
+	t.Parallel()
+
+	stateFile := filepath.Join(t.TempDir(), "priv_validator_state.json")
+	privKey := ed25519.GenPrivKey()
+
+	// The state file is created when missing
+	privVal, err := NewFilePVWithKey(privKey, stateFile)
+	require.NoError(t, err)
+	require.FileExists(t, stateFile)
+	assert.Equal(t, privKey.PubKey().Address(), privVal.GetAddress())
+
+	blockID := types.BlockID{Hash: []byte{1, 2, 3}, PartsHeader: types.PartSetHeader{}}
+	vote := newVote(privVal.Key.Address, 0, 10, 1, byte(types.PrecommitType), blockID)
+	require.NoError(t, privVal.SignVote("mychainid", vote))
+
+	// The sign state is restored on reload, preventing regressions
+	privVal, err = NewFilePVWithKey(privKey, stateFile)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), privVal.LastSignState.Height)
+
+	vote = newVote(privVal.Key.Address, 0, 9, 1, byte(types.PrecommitType), blockID)
+	assert.Error(t, privVal.SignVote("mychainid", vote))
+}
@@ -21,7 +21,8 @@ type PubKeyResponse struct {
 
 // SignVoteRequest is a request to sign a vote
 type SignVoteRequest struct {
-	Vote *types.Vote
+	Vote    *types.Vote
+	ChainID string
 }
 
 // SignedVoteResponse is a response containing a signed vote or an error
@@ -33,6 +34,7 @@ type SignedVoteResponse struct {
 // SignProposalRequest is a request to sign a proposal
 type SignProposalRequest struct {
 	Proposal *types.Proposal
+	ChainID  string
 }
 
 // SignedProposalResponse is response containing a signed proposal or an error
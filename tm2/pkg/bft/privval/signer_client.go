@@ -87,7 +87,7 @@ func (sc *SignerClient) GetPubKey() crypto.PubKey {
 
 // SignVote requests a remote signer to sign a vote
 func (sc *SignerClient) SignVote(chainID string, vote *types.Vote) error {
-	response, err := sc.endpoint.SendRequest(&SignVoteRequest{Vote: vote})
+	response, err := sc.endpoint.SendRequest(&SignVoteRequest{Vote: vote, ChainID: chainID})
 	if err != nil {
 		sc.endpoint.Logger.Error("SignerClient::SignVote", "err", err)
 		return err
@@ -109,7 +109,7 @@ func (sc *SignerClient) SignVote(chainID string, vote *types.Vote) error {
 
 // SignProposal requests a remote signer to sign a proposal
 func (sc *SignerClient) SignProposal(chainID string, proposal *types.Proposal) error {
-	response, err := sc.endpoint.SendRequest(&SignProposalRequest{Proposal: proposal})
+	response, err := sc.endpoint.SendRequest(&SignProposalRequest{Proposal: proposal, ChainID: chainID})
 	if err != nil {
 		sc.endpoint.Logger.Error("SignerClient::SignProposal", "err", err)
 		return err
@@ -279,3 +279,24 @@ func TestSignerUnexpectedResponse(t *testing.T) {
 		assert.EqualError(t, e, "received unexpected response")
 	}
 }
+
+func TestSignerUnknownChainID(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range getSignerTestCases(t) {
+		defer tc.signerServer.Stop()
+		defer tc.signerClient.Close()
+
+		ts := time.Now()
+		vote := &types.Vote{Timestamp: ts, Type: types.PrecommitType}
+		proposal := &types.Proposal{Timestamp: ts}
+
+		err := tc.signerClient.SignVote("other-"+tc.chainID, vote)
+		require.ErrorContains(t, err, ErrUnknownChainID.Error())
+		assert.Nil(t, vote.Signature)
+
+		err = tc.signerClient.SignProposal("other-"+tc.chainID, proposal)
+		require.ErrorContains(t, err, ErrUnknownChainID.Error())
+		assert.Nil(t, proposal.Signature)
+	}
+}
@@ -39,10 +39,11 @@ type SignerDialerEndpoint struct {
 
 // NewSignerDialerEndpoint returns a SignerDialerEndpoint that will dial using the given
 // dialer and respond to any signature requests over the connection
-// using the given privVal.
+// using the given privVal. The given options are applied in order.
 func NewSignerDialerEndpoint(
 	logger *slog.Logger,
 	dialer SocketDialer,
+	options ...SignerServiceEndpointOption,
 ) *SignerDialerEndpoint {
 	sd := &SignerDialerEndpoint{
 		dialer:         dialer,
@@ -53,6 +54,10 @@ func NewSignerDialerEndpoint(
 	sd.BaseService = *service.NewBaseService(logger, "SignerDialerEndpoint", sd)
 	sd.signerEndpoint.timeoutReadWrite = defaultTimeoutReadWriteSeconds * time.Second
 
+	for _, optionFunc := range options {
+		optionFunc(sd)
+	}
+
 	return sd
 }
 
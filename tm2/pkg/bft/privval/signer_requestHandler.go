@@ -18,6 +18,12 @@ func DefaultValidationRequestHandler(privVal types.PrivValidator, req SignerMess
 		res = &PubKeyResponse{p, nil}
 
 	case *SignVoteRequest:
+		if r.ChainID != chainID {
+			err = fmt.Errorf("%w: %q", ErrUnknownChainID, r.ChainID)
+			res = &SignedVoteResponse{nil, &RemoteSignerError{0, err.Error()}}
+			break
+		}
+
 		err = privVal.SignVote(chainID, r.Vote)
 		if err != nil {
 			res = &SignedVoteResponse{nil, &RemoteSignerError{0, err.Error()}}
@@ -26,6 +32,12 @@ func DefaultValidationRequestHandler(privVal types.PrivValidator, req SignerMess
 		}
 
 	case *SignProposalRequest:
+		if r.ChainID != chainID {
+			err = fmt.Errorf("%w: %q", ErrUnknownChainID, r.ChainID)
+			res = &SignedProposalResponse{nil, &RemoteSignerError{0, err.Error()}}
+			break
+		}
+
 		err = privVal.SignProposal(chainID, r.Proposal)
 		if err != nil {
 			res = &SignedProposalResponse{nil, &RemoteSignerError{0, err.Error()}}
@@ -68,6 +68,10 @@ func (ss *SignerServer) servicePendingRequest() {
 		if !errors.Is(err, io.EOF) {
 			ss.Logger.Error("SignerServer: HandleMessage", "err", err)
 		}
+
+		// The connection is no longer usable (ie. the node went away),
+		// drop it so the next iteration of the service loop redials
+		ss.endpoint.DropConnection()
 		return
 	}
 
@@ -44,6 +44,7 @@ const (
 // RPCClient encompasses common RPC client methods
 type RPCClient struct {
 	requestTimeout time.Duration
+	ctx            context.Context // parent context of the requests, if any
 
 	caller rpcclient.Client
 }
@@ -94,6 +95,25 @@ func NewWSClient(rpcURL string) (*RPCClient, error) {
 	return NewRPCClient(wsClient), nil
 }
 
+// WithContext returns a shallow copy of the client, whose requests
+// are derived from the given context. Over HTTP, the trace context
+// of ctx is propagated to the node
+func (c *RPCClient) WithContext(ctx context.Context) *RPCClient {
+	c2 := *c
+	c2.ctx = ctx
+
+	return &c2
+}
+
+// Context returns the parent context of the client requests
+func (c *RPCClient) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
 // Close attempts to gracefully close the RPC client
 func (c *RPCClient) Close() error {
 	return c.caller.Close()
@@ -109,6 +129,7 @@ func (c *RPCClient) NewBatch() *RPCBatch {
 
 func (c *RPCClient) Status() (*ctypes.ResultStatus, error) {
 	return sendRequestCommon[ctypes.ResultStatus](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		statusMethod,
@@ -118,6 +139,7 @@ func (c *RPCClient) Status() (*ctypes.ResultStatus, error) {
 
 func (c *RPCClient) ABCIInfo() (*ctypes.ResultABCIInfo, error) {
 	return sendRequestCommon[ctypes.ResultABCIInfo](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		abciInfoMethod,
@@ -131,6 +153,7 @@ func (c *RPCClient) ABCIQuery(path string, data []byte) (*ctypes.ResultABCIQuery
 
 func (c *RPCClient) ABCIQueryWithOptions(path string, data []byte, opts ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
 	return sendRequestCommon[ctypes.ResultABCIQuery](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		abciQueryMethod,
@@ -145,6 +168,7 @@ func (c *RPCClient) ABCIQueryWithOptions(path string, data []byte, opts ABCIQuer
 
 func (c *RPCClient) BroadcastTxCommit(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
 	return sendRequestCommon[ctypes.ResultBroadcastTxCommit](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		broadcastTxCommitMethod,
@@ -162,6 +186,7 @@ func (c *RPCClient) BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, err
 
 func (c *RPCClient) broadcastTX(route string, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return sendRequestCommon[ctypes.ResultBroadcastTx](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		route,
@@ -171,6 +196,7 @@ func (c *RPCClient) broadcastTX(route string, tx types.Tx) (*ctypes.ResultBroadc
 
 func (c *RPCClient) UnconfirmedTxs(limit int) (*ctypes.ResultUnconfirmedTxs, error) {
 	return sendRequestCommon[ctypes.ResultUnconfirmedTxs](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		unconfirmedTxsMethod,
@@ -180,6 +206,7 @@ func (c *RPCClient) UnconfirmedTxs(limit int) (*ctypes.ResultUnconfirmedTxs, err
 
 func (c *RPCClient) NumUnconfirmedTxs() (*ctypes.ResultUnconfirmedTxs, error) {
 	return sendRequestCommon[ctypes.ResultUnconfirmedTxs](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		numUnconfirmedTxsMethod,
@@ -189,6 +216,7 @@ func (c *RPCClient) NumUnconfirmedTxs() (*ctypes.ResultUnconfirmedTxs, error) {
 
 func (c *RPCClient) NetInfo() (*ctypes.ResultNetInfo, error) {
 	return sendRequestCommon[ctypes.ResultNetInfo](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		netInfoMethod,
@@ -198,6 +226,7 @@ func (c *RPCClient) NetInfo() (*ctypes.ResultNetInfo, error) {
 
 func (c *RPCClient) DumpConsensusState() (*ctypes.ResultDumpConsensusState, error) {
 	return sendRequestCommon[ctypes.ResultDumpConsensusState](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		dumpConsensusStateMethod,
@@ -207,6 +236,7 @@ func (c *RPCClient) DumpConsensusState() (*ctypes.ResultDumpConsensusState, erro
 
 func (c *RPCClient) ConsensusState() (*ctypes.ResultConsensusState, error) {
 	return sendRequestCommon[ctypes.ResultConsensusState](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		consensusStateMethod,
@@ -221,6 +251,7 @@ func (c *RPCClient) ConsensusParams(height *int64) (*ctypes.ResultConsensusParam
 	}
 
 	return sendRequestCommon[ctypes.ResultConsensusParams](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		consensusParamsMethod,
@@ -230,6 +261,7 @@ func (c *RPCClient) ConsensusParams(height *int64) (*ctypes.ResultConsensusParam
 
 func (c *RPCClient) Health() (*ctypes.ResultHealth, error) {
 	return sendRequestCommon[ctypes.ResultHealth](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		healthMethod,
@@ -239,6 +271,7 @@ func (c *RPCClient) Health() (*ctypes.ResultHealth, error) {
 
 func (c *RPCClient) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
 	return sendRequestCommon[ctypes.ResultBlockchainInfo](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		blockchainMethod,
@@ -251,6 +284,7 @@ func (c *RPCClient) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBl
 
 func (c *RPCClient) Genesis() (*ctypes.ResultGenesis, error) {
 	return sendRequestCommon[ctypes.ResultGenesis](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		genesisMethod,
@@ -265,6 +299,7 @@ func (c *RPCClient) Block(height *int64) (*ctypes.ResultBlock, error) {
 	}
 
 	return sendRequestCommon[ctypes.ResultBlock](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		blockMethod,
@@ -279,6 +314,7 @@ func (c *RPCClient) BlockResults(height *int64) (*ctypes.ResultBlockResults, err
 	}
 
 	return sendRequestCommon[ctypes.ResultBlockResults](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		blockResultsMethod,
@@ -293,6 +329,7 @@ func (c *RPCClient) Commit(height *int64) (*ctypes.ResultCommit, error) {
 	}
 
 	return sendRequestCommon[ctypes.ResultCommit](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		commitMethod,
@@ -302,6 +339,7 @@ func (c *RPCClient) Commit(height *int64) (*ctypes.ResultCommit, error) {
 
 func (c *RPCClient) Tx(hash []byte) (*ctypes.ResultTx, error) {
 	return sendRequestCommon[ctypes.ResultTx](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		txMethod,
@@ -318,6 +356,7 @@ func (c *RPCClient) Validators(height *int64) (*ctypes.ResultValidators, error)
 	}
 
 	return sendRequestCommon[ctypes.ResultValidators](
+		c.Context(),
 		c.caller,
 		c.requestTimeout,
 		validatorsMethod,
@@ -335,6 +374,7 @@ func newRequest(method string, params map[string]any) (rpctypes.RPCRequest, erro
 
 // sendRequestCommon is the common request creation, sending, and parsing middleware
 func sendRequestCommon[T any](
+	parent context.Context,
 	caller rpcclient.Client,
 	timeout time.Duration,
 	method string,
@@ -347,7 +387,7 @@ func sendRequestCommon[T any](
 	}
 
 	// Send the request
-	ctx, cancelFn := context.WithTimeout(context.Background(), timeout)
+	ctx, cancelFn := context.WithTimeout(parent, timeout)
 	defer cancelFn()
 
 	response, err := caller.SendRequest(ctx, request)
@@ -13,6 +13,8 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/gnolang/gno/tm2/pkg/random"
 	"github.com/gnolang/gno/tm2/pkg/service"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/traces"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // -----------------------------------------------------------------------------
@@ -78,6 +80,8 @@ import (
 // |-----------+------+---------+----------+-----------------|
 // | tx        | Tx   | nil     | true     | The transaction |
 func BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	traces.SetTxContext(tx.Hash(), ctx.Context())
+
 	err := mempool.CheckTx(tx, nil)
 	if err != nil {
 		return nil, err
@@ -143,6 +147,8 @@ func BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadca
 // |-----------+------+---------+----------+-----------------|
 // | tx        | Tx   | nil     | true     | The transaction |
 func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	traces.SetTxContext(tx.Hash(), ctx.Context())
+
 	resCh := make(chan abci.Response, 1)
 	err := mempool.CheckTx(tx, func(res abci.Response) {
 		resCh <- res
@@ -224,10 +230,22 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 // | Parameter | Type | Default | Required | Description     |
 // |-----------+------+---------+----------+-----------------|
 // | tx        | Tx   | nil     | true     | The transaction |
-func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (res *ctypes.ResultBroadcastTxCommit, err error) {
+	// Trace the transaction from broadcast to commit
+	spanCtx, span := traces.StartSpan(
+		ctx.Context(),
+		"BroadcastTxCommit",
+		attribute.String("tx.hash", fmt.Sprintf("%X", tx.Hash())),
+	)
+	defer func() {
+		traces.EndSpan(span, err)
+	}()
+
+	traces.SetTxContext(tx.Hash(), spanCtx)
+
 	// Broadcast tx and wait for CheckTx result
 	checkTxResCh := make(chan abci.Response, 1)
-	err := mempool.CheckTx(tx, func(res abci.Response) {
+	err = mempool.CheckTx(tx, func(res abci.Response) {
 		checkTxResCh <- res
 	})
 	if err != nil {
@@ -244,11 +262,16 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 		}, nil
 	}
 
+	span.AddEvent("CheckTx passed")
+
 	// Wait for the tx to be included in a block or timeout.
 	txRes, err := gTxDispatcher.getTxResult(tx, nil)
 	if err != nil {
 		return nil, err
 	}
+
+	span.SetAttributes(attribute.Int64("tx.height", txRes.Height))
+
 	return &ctypes.ResultBroadcastTxCommit{
 		CheckTx:   checkTxRes,
 		DeliverTx: txRes.Response,
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	types "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/traces"
 )
 
 const (
@@ -131,6 +132,9 @@ func sendRequestCommon[T requestType, R responseType](
 	// Set the header content type
 	req.Header.Set("Content-Type", "application/json")
 
+	// Propagate the trace context, if any
+	traces.Inject(ctx, req.Header)
+
 	// Execute the request
 	httpResponse, err := client.Do(req.WithContext(ctx))
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	types "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestClient_parseRemoteAddr(t *testing.T) {
@@ -203,6 +204,59 @@ func TestClient_SendRequest(t *testing.T) {
 		assert.Nil(t, resp)
 		assert.ErrorIs(t, err, ErrRequestResponseIDMismatch)
 	})
+
+	t.Run("trace context propagated", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			request = types.RPCRequest{
+				JSONRPC: "2.0",
+				ID:      types.JSONRPCStringID("id"),
+			}
+
+			spanCtx = trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    trace.TraceID{0x01},
+				SpanID:     trace.SpanID{0x02},
+				TraceFlags: trace.FlagsSampled,
+			})
+
+			handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Make sure the trace context is set
+				require.Equal(
+					t,
+					"00-01000000000000000000000000000000-0200000000000000-01",
+					r.Header.Get("traceparent"),
+				)
+
+				response := types.RPCResponse{
+					JSONRPC: "2.0",
+					ID:      request.ID,
+				}
+
+				marshalledResponse, err := json.Marshal(response)
+				require.NoError(t, err)
+
+				_, err = w.Write(marshalledResponse)
+				require.NoError(t, err)
+			})
+
+			server = createTestServer(t, handler)
+		)
+
+		// Create the client
+		c, err := NewClient(server.URL)
+		require.NoError(t, err)
+
+		ctx, cancelFn := context.WithTimeout(
+			trace.ContextWithSpanContext(context.Background(), spanCtx),
+			time.Second*5,
+		)
+		defer cancelFn()
+
+		// Send the request
+		_, err = c.SendRequest(ctx, request)
+		require.NoError(t, err)
+	})
 }
 
 func TestClient_SendBatchRequest(t *testing.T) {
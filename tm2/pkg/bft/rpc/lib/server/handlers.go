@@ -18,7 +18,10 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/telemetry"
 	"github.com/gnolang/gno/tm2/pkg/telemetry/metrics"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/traces"
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	types "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
@@ -205,11 +208,13 @@ func processRequest(r *http.Request, req types.RPCRequest, funcMap map[string]*R
 	}
 
 	// Call the RPC function using reflection.
+	span := traceRPCCall(ctx, req.Method)
 	returns := rpcFunc.f.Call(args)
 	logger.Info("HTTPJSONRPC", "method", req.Method, "args", args, "returns", returns)
 
 	// Convert the reflection return values into a result value for JSON serialization.
 	result, err := unreflectResult(returns)
+	traces.EndSpan(span, err)
 	if err != nil {
 		resp := types.RPCInternalError(req.ID, err)
 		return &resp
@@ -233,6 +238,32 @@ func handleInvalidJSONRPCPaths(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// traceRPCCall starts the trace span of the RPC call. For HTTP requests,
+// the span continues the trace propagated by the client,
+// and is carried by the request context
+func traceRPCCall(ctx *types.Context, method string) trace.Span {
+	if !traces.Enabled() {
+		return trace.SpanFromContext(context.Background())
+	}
+
+	parent := ctx.Context()
+	if ctx.HTTPReq != nil {
+		parent = traces.Extract(parent, ctx.HTTPReq.Header)
+	}
+
+	spanCtx, span := traces.StartSpan(
+		parent,
+		"rpc."+method,
+		attribute.String("rpc.method", method),
+	)
+
+	if ctx.HTTPReq != nil {
+		ctx.HTTPReq = ctx.HTTPReq.WithContext(spanCtx)
+	}
+
+	return span
+}
+
 // telemetryMiddleware is the telemetry middleware handler
 func telemetryMiddleware(next http.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -343,10 +374,12 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger *slog.Logger) http.HandlerFunc {
 		}
 		args = append(args, fnArgs...)
 
+		span := traceRPCCall(ctx, strings.TrimPrefix(r.URL.Path, "/"))
 		returns := rpcFunc.f.Call(args)
 
 		logger.Info("HTTPRestRPC", "method", r.URL.Path, "args", args, "returns", returns)
 		result, err := unreflectResult(returns)
+		traces.EndSpan(span, err)
 		if err != nil {
 			WriteRPCResponseHTTP(w, types.RPCInternalError(types.JSONRPCStringID(""), err))
 			return
@@ -702,12 +735,14 @@ func (wsc *wsConnection) readRoutine() {
 					args = append(args, fnArgs...)
 				}
 
+				span := traceRPCCall(ctx, request.Method)
 				returns := rpcFunc.f.Call(args)
 
 				// TODO: Need to encode args/returns to string if we want to log them
 				wsc.Logger.Info("WSJSONRPC", "method", request.Method)
 
 				result, err := unreflectResult(returns)
+				traces.EndSpan(span, err)
 				if err != nil {
 					responses = append(responses, types.RPCInternalError(request.ID, err))
 
@@ -1,8 +1,10 @@
 package state
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
@@ -15,6 +17,8 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/traces"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // -----------------------------------------------------------------------------
@@ -37,6 +41,11 @@ type BlockExecutor struct {
 	// and update both with block results after commit.
 	mempool mempl.Mempool
 
+	// evidence of consensus violations seen by the node,
+	// to include in the blocks it proposes. It is persisted in db.
+	evidenceMtx sync.Mutex
+	evidence    types.EvidenceList
+
 	logger *slog.Logger
 }
 
@@ -50,6 +59,7 @@ func NewBlockExecutor(db dbm.DB, logger *slog.Logger, proxyApp appconn.Consensus
 		proxyApp: proxyApp,
 		evsw:     events.NilEventSwitch(),
 		mempool:  mempool,
+		evidence: loadPendingEvidence(db),
 		logger:   logger,
 	}
 
@@ -68,7 +78,8 @@ func (blockExec *BlockExecutor) SetEventSwitch(evsw events.EventSwitch) {
 	blockExec.evsw = evsw
 }
 
-// CreateProposalBlock calls state.MakeBlock with txs from the mempool.
+// CreateProposalBlock calls state.MakeBlock with txs from the mempool,
+// and the pending evidence of consensus violations.
 func (blockExec *BlockExecutor) CreateProposalBlock(
 	height int64,
 	state State, commit *types.Commit,
@@ -78,8 +89,86 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	maxGas := state.ConsensusParams.Block.MaxGas
 
 	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
+	evidence := blockExec.pendingEvidence(state)
+
+	return state.MakeBlockWithEvidence(height, txs, evidence, commit, proposerAddr)
+}
+
+// AddEvidence adds the evidence of a consensus violation seen by the node,
+// like conflicting votes, to be included in the next block it proposes.
+func (blockExec *BlockExecutor) AddEvidence(ev types.Evidence) error {
+	if err := ev.ValidateBasic(); err != nil {
+		return err
+	}
+	if loadCommittedEvidenceHeight(blockExec.db, ev) > 0 {
+		return nil
+	}
+
+	blockExec.evidenceMtx.Lock()
+	defer blockExec.evidenceMtx.Unlock()
+
+	if !blockExec.evidence.Has(ev) {
+		blockExec.evidence = append(blockExec.evidence, ev)
+		savePendingEvidence(blockExec.db, blockExec.evidence)
+	}
+	return nil
+}
+
+// pendingEvidence returns the pending evidence which can be committed in the
+// block following state.
+func (blockExec *BlockExecutor) pendingEvidence(state State) types.EvidenceList {
+	maxNum := maxEvidencePerBlock(state)
+
+	blockExec.evidenceMtx.Lock()
+	defer blockExec.evidenceMtx.Unlock()
+
+	var evidence types.EvidenceList
+	for _, ev := range blockExec.evidence {
+		if int64(len(evidence)) >= maxNum {
+			break
+		}
+		if err := VerifyEvidence(blockExec.db, state, ev); err != nil {
+			// Evidence of the current height can only be verified later.
+			continue
+		}
+		evidence = append(evidence, ev)
+	}
+	return evidence
+}
+
+// commitEvidence records the evidence of block as committed, and removes it
+// from the pending evidence, along with the evidence too old to be committed.
+func (blockExec *BlockExecutor) commitEvidence(block *types.Block) {
+	for _, ev := range block.Evidence {
+		saveCommittedEvidence(blockExec.db, ev, block.Height)
+	}
+
+	blockExec.evidenceMtx.Lock()
+	defer blockExec.evidenceMtx.Unlock()
+
+	pending := blockExec.evidence[:0]
+	for _, ev := range blockExec.evidence {
+		if block.Evidence.Has(ev) {
+			continue
+		}
+		if dve, ok := ev.(*types.DuplicateVoteEvidence); ok && block.Height-dve.Height() > maxEvidenceAge {
+			continue
+		}
+		pending = append(pending, ev)
+	}
+	if len(pending) != len(blockExec.evidence) {
+		savePendingEvidence(blockExec.db, pending)
+	}
+	blockExec.evidence = pending
+}
 
-	return state.MakeBlock(height, txs, commit, proposerAddr)
+// ValidateBlock validates the block against the state, including the
+// evidence of consensus violations it contains.
+func (blockExec *BlockExecutor) ValidateBlock(state State, block *types.Block) error {
+	if err := state.ValidateBlock(block); err != nil {
+		return err
+	}
+	return validateEvidence(blockExec.db, state, block)
 }
 
 // ApplyBlock validates the block against the state, executes it against the app,
@@ -87,8 +176,18 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 // It's the only function that needs to be called
 // from outside this package to process and commit an entire block.
 // It takes a blockID to avoid recomputing the parts hash.
-func (blockExec *BlockExecutor) ApplyBlock(state State, blockID types.BlockID, block *types.Block) (State, error) {
-	if err := state.ValidateBlock(block); err != nil {
+func (blockExec *BlockExecutor) ApplyBlock(state State, blockID types.BlockID, block *types.Block) (_ State, err error) {
+	_, span := traces.StartSpan(
+		context.Background(),
+		"ApplyBlock",
+		attribute.Int64("block.height", block.Height),
+		attribute.Int("block.txs", len(block.Txs)),
+	)
+	defer func() {
+		traces.EndSpan(span, err)
+	}()
+
+	if err := blockExec.ValidateBlock(state, block); err != nil {
 		return state, InvalidBlockError(err)
 	}
 
@@ -143,6 +242,7 @@ func (blockExec *BlockExecutor) ApplyBlock(state State, blockID types.BlockID, b
 	// Update the app hash and save the state.
 	state.AppHash = appHash
 	SaveState(blockExec.db, state)
+	blockExec.commitEvidence(block)
 
 	fail.Fail() // XXX
 
@@ -240,6 +340,7 @@ func execBlockOnProxyApp(
 	proxyAppConn.SetResponseCallback(proxyCb)
 
 	commitInfo := getBeginBlockLastCommitInfo(block, stateDB)
+	violations := getBeginBlockViolations(logger, block, stateDB)
 
 	// Begin block
 	var err error
@@ -247,6 +348,7 @@ func execBlockOnProxyApp(
 		Hash:           block.Hash(),
 		Header:         block.Header.Copy(),
 		LastCommitInfo: &commitInfo,
+		Violations:     violations,
 	})
 	if err != nil {
 		logger.Error("Error in proxyAppConn.BeginBlock", "err", err)
@@ -273,6 +375,43 @@ func execBlockOnProxyApp(
 	return abciResponses, nil
 }
 
+// getBeginBlockViolations returns the consensus violations proven by the
+// evidence of the block, which was verified against the state.
+// Evidence whose validator can't be found is logged and skipped.
+func getBeginBlockViolations(logger *slog.Logger, block *types.Block, stateDB dbm.DB) []abci.Violation {
+	violations := make([]abci.Violation, 0, len(block.Evidence))
+	for _, ev := range block.Evidence {
+		dve, ok := ev.(*types.DuplicateVoteEvidence)
+		if !ok {
+			continue // shouldn't happen, the evidence was verified
+		}
+
+		valset, err := LoadValidators(stateDB, dve.Height())
+		if err != nil {
+			logger.Error("Unable to load the validators of the evidence", "height", dve.Height(), "err", err)
+			continue
+		}
+		_, val := valset.GetByAddress(dve.Address())
+		if val == nil {
+			logger.Error("Evidence of unknown validator", "height", dve.Height(), "address", dve.Address())
+			continue
+		}
+
+		violations = append(violations, abci.Violation{
+			Evidence: dve,
+			Validators: []abci.Validator{{
+				Address: val.Address,
+				PubKey:  val.PubKey,
+				Power:   val.VotingPower,
+			}},
+			Height:           dve.Height(),
+			Time:             dve.VoteA.Timestamp,
+			TotalVotingPower: valset.TotalVotingPower(),
+		})
+	}
+	return violations
+}
+
 func getBeginBlockLastCommitInfo(block *types.Block, stateDB dbm.DB) abci.LastCommitInfo {
 	voteInfos := make([]abci.VoteInfo, block.LastCommit.Size())
 	var lastValSet *types.ValidatorSet
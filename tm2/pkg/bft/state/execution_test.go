@@ -19,6 +19,7 @@ import (
 	tmtime "github.com/gnolang/gno/tm2/pkg/bft/types/time"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
 	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/crypto/tmhash"
 	"github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/gnolang/gno/tm2/pkg/log"
 )
@@ -108,6 +109,105 @@ func TestBeginBlockValidators(t *testing.T) {
 	}
 }
 
+// TestBeginBlockViolations ensures the evidence of a block is sent to the
+// app as violations, and that pending evidence is included in proposals.
+func TestBeginBlockViolations(t *testing.T) {
+	t.Parallel()
+
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := appconn.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop()
+
+	state, stateDB, privVals := makeState(2, 2)
+
+	// Conflicting votes of a validator at height 1
+	_, val := state.Validators.GetByIndex(0)
+	privVal := privVals[val.Address.String()]
+	makeVote := func(hash string) *types.Vote {
+		blockID := types.BlockID{
+			Hash:        tmhash.Sum([]byte(hash)),
+			PartsHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte(hash + "/parts"))},
+		}
+		vote, err := types.MakeVote(1, blockID, state.Validators, privVal, chainID)
+		require.NoError(t, err)
+		return vote
+	}
+	ev := &types.DuplicateVoteEvidence{PubKey: val.PubKey, VoteA: makeVote("a"), VoteB: makeVote("b")}
+	require.NoError(t, sm.VerifyEvidence(stateDB, state, ev))
+
+	// Pending evidence is included in proposed blocks
+	blockExec := sm.NewBlockExecutor(stateDB, log.NewTestingLogger(t), proxyApp.Consensus(), mock.Mempool{})
+	require.NoError(t, blockExec.AddEvidence(ev))
+	require.NoError(t, blockExec.AddEvidence(ev))
+
+	now := tmtime.Now()
+	lastCommit := types.NewCommit(state.LastBlockID, []*types.CommitSig{
+		(&types.Vote{ValidatorIndex: 0, Timestamp: now, Type: types.PrecommitType}).CommitSig(),
+		(&types.Vote{ValidatorIndex: 1, Timestamp: now, Type: types.PrecommitType}).CommitSig(),
+	})
+	block, _ := blockExec.CreateProposalBlock(2, state, lastCommit, state.Validators.GetProposer().Address)
+	require.Equal(t, types.EvidenceList{ev}, block.Evidence)
+	require.Equal(t, block.Evidence.Hash(), block.EvidenceHash)
+
+	// Pending evidence survives a restart
+	restarted := sm.NewBlockExecutor(stateDB, log.NewTestingLogger(t), proxyApp.Consensus(), mock.Mempool{})
+	restartedBlock, _ := restarted.CreateProposalBlock(2, state, lastCommit, state.Validators.GetProposer().Address)
+	require.Equal(t, block.Evidence, restartedBlock.Evidence)
+
+	// -> app receives the violation of the validator
+	_, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, log.NewTestingLogger(t), stateDB)
+	require.NoError(t, err)
+
+	require.Len(t, app.Violations, 1)
+	violation := app.Violations[0]
+	assert.Equal(t, ev, violation.Evidence)
+	assert.Equal(t, int64(1), violation.Height)
+	assert.Equal(t, ev.VoteA.Timestamp, violation.Time)
+	assert.Equal(t, int64(2000), violation.TotalVotingPower)
+	assert.Equal(t, []abci.Validator{{Address: val.Address, PubKey: val.PubKey, Power: 1000}}, violation.Validators)
+}
+
+func TestVerifyEvidence(t *testing.T) {
+	t.Parallel()
+
+	state, stateDB, privVals := makeState(2, 2)
+	_, val := state.Validators.GetByIndex(0)
+	privVal := privVals[val.Address.String()]
+
+	makeVote := func(height int64, hash string) *types.Vote {
+		blockID := types.BlockID{
+			Hash:        tmhash.Sum([]byte(hash)),
+			PartsHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte(hash + "/parts"))},
+		}
+		vote, err := types.MakeVote(height, blockID, state.Validators, privVal, chainID)
+		require.NoError(t, err)
+		return vote
+	}
+
+	testCases := []struct {
+		desc string
+		ev   types.Evidence
+		err  string
+	}{
+		{"valid", &types.DuplicateVoteEvidence{PubKey: val.PubKey, VoteA: makeVote(1, "a"), VoteB: makeVote(1, "b")}, ""},
+		{"same block", &types.DuplicateVoteEvidence{PubKey: val.PubKey, VoteA: makeVote(1, "a"), VoteB: makeVote(1, "a")}, "not a real duplicate vote"},
+		{"future height", &types.DuplicateVoteEvidence{PubKey: val.PubKey, VoteA: makeVote(2, "a"), VoteB: makeVote(2, "b")}, "ahead of the last block height"},
+		{"unsupported type", types.NewMockGoodEvidence(1, 0, val.Address), "unsupported evidence type"},
+	}
+
+	for _, tc := range testCases {
+		err := sm.VerifyEvidence(stateDB, state, tc.ev)
+		if tc.err == "" {
+			assert.NoError(t, err, tc.desc)
+		} else {
+			assert.ErrorContains(t, err, tc.err, tc.desc)
+		}
+	}
+}
+
 func TestValidateValidatorUpdates(t *testing.T) {
 	t.Parallel()
 
@@ -197,6 +197,7 @@ type testApp struct {
 	abci.BaseApplication
 
 	CommitVotes      []abci.VoteInfo
+	Violations       []abci.Violation
 	ValidatorUpdates []abci.ValidatorUpdate
 }
 
@@ -208,6 +209,7 @@ func (app *testApp) Info(req abci.RequestInfo) (resInfo abci.ResponseInfo) {
 
 func (app *testApp) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginBlock {
 	app.CommitVotes = req.LastCommitInfo.Votes
+	app.Violations = req.Violations
 	return abci.ResponseBeginBlock{}
 }
 
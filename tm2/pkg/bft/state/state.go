@@ -122,9 +122,23 @@ func (state State) MakeBlock(
 	txs []types.Tx,
 	commit *types.Commit,
 	proposerAddress crypto.Address,
+) (*types.Block, *types.PartSet) {
+	return state.MakeBlockWithEvidence(height, txs, nil, commit, proposerAddress)
+}
+
+// MakeBlockWithEvidence is like MakeBlock, with the evidence of consensus
+// violations to commit in the block.
+func (state State) MakeBlockWithEvidence(
+	height int64,
+	txs []types.Tx,
+	evidence types.EvidenceList,
+	commit *types.Commit,
+	proposerAddress crypto.Address,
 ) (*types.Block, *types.PartSet) {
 	// Build base block with block data.
 	block := types.MakeBlock(height, txs, commit)
+	block.Evidence = evidence
+	block.EvidenceHash = evidence.Hash()
 
 	// Set time.
 	var timestamp time.Time
@@ -40,6 +40,12 @@ func CalcTxResultKey(hash []byte) []byte {
 	return fmt.Appendf(nil, "txResultKey:%x", hash)
 }
 
+func calcCommittedEvidenceKey(hash []byte) []byte {
+	return fmt.Appendf(nil, "committedEvidenceKey:%x", hash)
+}
+
+var pendingEvidenceKey = []byte("pendingEvidenceKey")
+
 // LoadStateFromDBOrGenesisFile loads the most recent state from the database,
 // or creates a new one from the given genesisFilePath and persists the result
 // to the database.
@@ -377,3 +383,43 @@ func saveConsensusParamsInfo(db dbm.DB, nextHeight, changeHeight int64, params a
 	}
 	db.Set(calcConsensusParamsKey(nextHeight), paramsInfo.Bytes())
 }
+
+// -----------------------------------------------------------------------------
+
+// loadCommittedEvidenceHeight returns the height of the block in which the
+// evidence was committed, or 0 if it was not committed.
+func loadCommittedEvidenceHeight(db dbm.DB, ev types.Evidence) int64 {
+	buf := db.Get(calcCommittedEvidenceKey(ev.Hash()))
+	if buf == nil {
+		return 0
+	}
+
+	var height int64
+	amino.MustUnmarshal(buf, &height)
+	return height
+}
+
+// saveCommittedEvidence records that the evidence was committed in the block
+// at the given height, so it is not committed again.
+func saveCommittedEvidence(db dbm.DB, ev types.Evidence, height int64) {
+	db.Set(calcCommittedEvidenceKey(ev.Hash()), amino.MustMarshal(height))
+}
+
+// loadPendingEvidence returns the evidence seen by the node which is not yet
+// committed, as saved by savePendingEvidence.
+func loadPendingEvidence(db dbm.DB) types.EvidenceList {
+	buf := db.Get(pendingEvidenceKey)
+	if len(buf) == 0 {
+		return nil
+	}
+
+	var evidence types.EvidenceList
+	amino.MustUnmarshal(buf, &evidence)
+	return evidence
+}
+
+// savePendingEvidence persists the evidence seen by the node which is not yet
+// committed, so it survives a restart.
+func savePendingEvidence(db dbm.DB, evidence types.EvidenceList) {
+	db.SetSync(pendingEvidenceKey, amino.MustMarshal(evidence))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
 )
 
 // -----------------------------------------------------
@@ -145,45 +146,75 @@ func (state State) ValidateBlock(block *types.Block) error {
 	return nil
 }
 
-/*
+// maxEvidenceAge is the maximum age, in blocks, of the evidence of a
+// consensus violation to be committed.
+const maxEvidenceAge = valSetCheckpointInterval
+
+// maxEvidencePerBlock returns the maximum number of evidences allowed in the
+// blocks following state, on top of their maximum data size.
+func maxEvidencePerBlock(state State) int64 {
+	maxNum, _ := types.MaxEvidencePerBlock(state.ConsensusParams.Block.MaxDataBytes)
+	return maxNum
+}
+
+// validateEvidence validates the evidence of the block against the state:
+// there is not too much of it, and each piece is verified and committed only
+// once.
+func validateEvidence(stateDB dbm.DB, state State, block *types.Block) error {
+	maxNum := maxEvidencePerBlock(state)
+	if got := int64(len(block.Evidence)); got > maxNum {
+		return types.NewErrEvidenceOverflow(maxNum, got)
+	}
+
+	for i, ev := range block.Evidence {
+		if block.Evidence[:i].Has(ev) {
+			return types.NewErrEvidenceInvalid(ev, errors.New("duplicate evidence"))
+		}
+		// The evidence can be committed at this height when replaying the block.
+		if height := loadCommittedEvidenceHeight(stateDB, ev); height > 0 && height < block.Height {
+			return types.NewErrEvidenceInvalid(ev, fmt.Errorf("evidence was already committed at height %d", height))
+		}
+		if err := VerifyEvidence(stateDB, state, ev); err != nil {
+			return types.NewErrEvidenceInvalid(ev, err)
+		}
+	}
+
+	return nil
+}
+
 // VerifyEvidence verifies the evidence fully by checking:
-// - it is sufficiently recent (MaxAge)
+// - it is of a supported type (duplicate votes)
+// - it is sufficiently recent (maxEvidenceAge)
 // - it is from a key who was a validator at the given height
 // - it is internally consistent
 // - it was properly signed by the alleged equivocator
 func VerifyEvidence(stateDB dbm.DB, state State, evidence types.Evidence) error {
-	height := state.LastBlockHeight
+	ev, ok := evidence.(*types.DuplicateVoteEvidence)
+	if !ok {
+		return fmt.Errorf("unsupported evidence type %T", evidence)
+	}
 
-	evidenceAge := height - evidence.Height()
-	maxAge := state.ConsensusParams.Evidence.MaxAge
-	if evidenceAge > maxAge {
-		return fmt.Errorf("Evidence from height %d is too old. Min height is %d",
-			evidence.Height(), height-maxAge)
+	height, lastHeight := ev.Height(), state.LastBlockHeight
+	if height > lastHeight {
+		return fmt.Errorf("evidence from height %d is ahead of the last block height %d", height, lastHeight)
+	}
+	if lastHeight-height > maxEvidenceAge {
+		return fmt.Errorf("evidence from height %d is too old, min height is %d", height, lastHeight-maxEvidenceAge)
 	}
 
-	valset, err := LoadValidators(stateDB, evidence.Height())
+	valset, err := LoadValidators(stateDB, height)
 	if err != nil {
-		// TODO: if err is just that we cant find it cuz we pruned, ignore.
-		// TODO: if its actually bad evidence, punish peer
 		return err
 	}
 
 	// The address must have been an active validator at the height.
 	// NOTE: we will ignore evidence from H if the key was not a validator
 	// at H, even if it is a validator at some nearby H'
-	// XXX: this makes lite-client bisection as is unsafe
-	// See https://github.com/tendermint/classic/issues/3244
-	ev := evidence
-	height, addr := ev.Height(), ev.Address()
+	addr := ev.Address()
 	_, val := valset.GetByAddress(addr)
 	if val == nil {
-		return fmt.Errorf("Address %X was not a validator at height %d", addr, height)
+		return fmt.Errorf("address %X was not a validator at height %d", addr, height)
 	}
 
-	if err := evidence.Verify(state.ChainID, val.PubKey); err != nil {
-		return err
-	}
-
-	return nil
+	return evidence.Verify(state.ChainID, val.PubKey)
 }
-*/
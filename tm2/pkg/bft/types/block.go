@@ -21,6 +21,8 @@ type Block struct {
 	Header     `json:"header"`
 	Data       `json:"data"`
 	LastCommit *Commit `json:"last_commit"`
+	// Evidence of consensus violations, committed to by Header.EvidenceHash.
+	Evidence EvidenceList `json:"evidence"`
 }
 
 // ValidateBasic performs basic validation that doesn't involve state data.
@@ -97,6 +99,27 @@ func (b *Block) ValidateBasic() error {
 		)
 	}
 
+	// Validate the evidence of consensus violations and its hash.
+	// Will validate fully against state in state#ValidateBlock.
+	if err := ValidateHash(b.EvidenceHash); err != nil {
+		return fmt.Errorf("wrong Header.EvidenceHash: %w", err)
+	}
+	if !bytes.Equal(b.EvidenceHash, b.Evidence.Hash()) {
+		return fmt.Errorf(
+			"wrong Header.EvidenceHash. Expected %v, got %v",
+			b.Evidence.Hash(),
+			b.EvidenceHash,
+		)
+	}
+	for i, ev := range b.Evidence {
+		if ev == nil {
+			return fmt.Errorf("nil evidence (#%d)", i)
+		}
+		if err := ev.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid evidence (#%d): %w", i, err)
+		}
+	}
+
 	// Basic validation of hashes related to application data.
 	// Will validate fully against state in state#ValidateBlock.
 	if err := ValidateHash(b.ValidatorsHash); err != nil {
@@ -124,6 +147,9 @@ func (b *Block) fillHeader() {
 	if b.DataHash == nil {
 		b.DataHash = b.Data.Hash()
 	}
+	if b.EvidenceHash == nil {
+		b.EvidenceHash = b.Evidence.Hash()
+	}
 }
 
 // Hash computes and returns the block hash.
@@ -244,6 +270,11 @@ type Header struct {
 
 	// consensus info
 	ProposerAddress Address `json:"proposer_address"` // original proposer of the block
+
+	// hash of the evidence of consensus violations included in the block.
+	// It is last and empty without evidence, so such blocks keep their
+	// encoding and hash.
+	EvidenceHash []byte `json:"evidence_hash"`
 }
 
 // Implements abci.Header
@@ -302,6 +333,7 @@ func (h *Header) Populate(
 // Hash returns the hash of the header.
 // It computes a Merkle tree from the header fields
 // ordered as they appear in the Header.
+// The EvidenceHash is only included if the block has evidence.
 // Returns nil if ValidatorHash is missing,
 // since a Header is not valid unless there is
 // a ValidatorsHash (corresponding to the validator set).
@@ -309,7 +341,7 @@ func (h *Header) Hash() []byte {
 	if h == nil || len(h.ValidatorsHash) == 0 {
 		return nil
 	}
-	return merkle.SimpleHashFromByteSlices([][]byte{
+	fields := [][]byte{
 		bytesOrNil(h.Version),
 		bytesOrNil(h.ChainID),
 		bytesOrNil(h.Height),
@@ -326,7 +358,11 @@ func (h *Header) Hash() []byte {
 		bytesOrNil(h.AppHash),
 		bytesOrNil(h.LastResultsHash),
 		bytesOrNil(h.ProposerAddress),
-	})
+	}
+	if len(h.EvidenceHash) > 0 {
+		fields = append(fields, bytesOrNil(h.EvidenceHash))
+	}
+	return merkle.SimpleHashFromByteSlices(fields)
 }
 
 // StringIndented returns a string representation of the header
@@ -351,6 +387,7 @@ func (h *Header) StringIndented(indent string) string {
 %s  Consensus:      %v
 %s  Results:        %v
 %s  Proposer:       %v
+%s  Evidence:       %v
 %s}#%v`,
 		indent, h.Version,
 		indent, h.ChainID,
@@ -368,6 +405,7 @@ func (h *Header) StringIndented(indent string) string {
 		indent, h.ConsensusHash,
 		indent, h.LastResultsHash,
 		indent, h.ProposerAddress,
+		indent, h.EvidenceHash,
 		indent, h.Hash())
 }
 
@@ -33,6 +33,13 @@ func TestBlockValidateBasic(t *testing.T) {
 	commit, err := MakeCommit(lastID, h-1, 1, voteSet, vals)
 	require.NoError(t, err)
 
+	val := NewMockPV()
+	evidence := &DuplicateVoteEvidence{
+		PubKey: val.GetPubKey(),
+		VoteA:  makeVote(val, "mychain", 0, 10, 2, 1, makeBlockID(tmhash.Sum([]byte("blockhash")), 1000, tmhash.Sum([]byte("partshash")))),
+		VoteB:  makeVote(val, "mychain", 0, 10, 2, 1, makeBlockID(tmhash.Sum([]byte("blockhash2")), 1000, tmhash.Sum([]byte("partshash")))),
+	}
+
 	testCases := []struct {
 		testName      string
 		malleateBlock func(*Block)
@@ -54,6 +61,16 @@ func TestBlockValidateBasic(t *testing.T) {
 		{"Tampered DataHash", func(blk *Block) {
 			blk.DataHash = random.RandBytes(len(blk.DataHash))
 		}, true},
+		{"Evidence without EvidenceHash", func(blk *Block) {
+			blk.Evidence = EvidenceList{evidence}
+		}, true},
+		{"Evidence with EvidenceHash", func(blk *Block) {
+			blk.Evidence = EvidenceList{evidence}
+			blk.EvidenceHash = blk.Evidence.Hash()
+		}, false},
+		{"EvidenceHash without evidence", func(blk *Block) {
+			blk.EvidenceHash = random.RandBytes(32)
+		}, true},
 	}
 	for i, tc := range testCases {
 		tc := tc
@@ -77,6 +94,29 @@ func TestBlockHash(t *testing.T) {
 	assert.Nil(t, MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil).Hash())
 }
 
+func TestBlockHashEvidence(t *testing.T) {
+	t.Parallel()
+
+	block := MakeBlock(int64(3), []Tx{Tx("Hello World")}, randCommit())
+	block.ValidatorsHash = tmhash.Sum([]byte("validators_hash"))
+	hash := block.Hash()
+
+	// Without evidence, the header hash doesn't depend on the EvidenceHash
+	assert.Nil(t, block.EvidenceHash)
+	assert.Equal(t, hash, block.Header.Hash())
+
+	// With evidence, the header hash commits to it
+	block.Evidence = EvidenceList{randomDuplicatedVoteEvidence()}
+	block.EvidenceHash = block.Evidence.Hash()
+	assert.NotEqual(t, hash, block.Hash())
+
+	// The evidence is kept when the block is gossiped
+	var decoded Block
+	require.NoError(t, amino.Unmarshal(amino.MustMarshal(block), &decoded))
+	assert.Equal(t, block.Evidence, decoded.Evidence)
+	assert.Equal(t, block.Hash(), decoded.Hash())
+}
+
 func TestBlockMakePartSet(t *testing.T) {
 	t.Parallel()
 
@@ -245,12 +285,13 @@ func TestHeaderByteSize(t *testing.T) {
 		AppHash:            tmhash.Sum([]byte("app_hash")),
 		LastResultsHash:    tmhash.Sum([]byte("last_results_hash")),
 		ProposerAddress:    crypto.AddressFromPreimage([]byte("proposer_address")),
+		EvidenceHash:       tmhash.Sum([]byte("evidence_hash")),
 	}
 
 	bz, err := amino.MarshalSized(h)
 	require.NoError(t, err)
 
-	assert.EqualValues(t, 647, len(bz))
+	assert.EqualValues(t, 682, len(bz))
 }
 
 func randCommit() *Commit {
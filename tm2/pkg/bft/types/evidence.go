@@ -94,6 +94,16 @@ func (dve *DuplicateVoteEvidence) String() string {
 	return fmt.Sprintf("VoteA: %v; VoteB: %v", dve.VoteA, dve.VoteB)
 }
 
+// Height returns the height at which the conflicting votes were signed.
+func (dve *DuplicateVoteEvidence) Height() int64 {
+	return dve.VoteA.Height
+}
+
+// Address returns the address of the validator who signed the votes.
+func (dve *DuplicateVoteEvidence) Address() crypto.Address {
+	return dve.VoteA.ValidatorAddress
+}
+
 // Hash returns the hash of the evidence.
 func (dve *DuplicateVoteEvidence) Bytes() []byte {
 	return bytesOrNil(dve)
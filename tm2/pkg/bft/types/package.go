@@ -50,7 +50,7 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 		EventValidatorSetUpdates{},
 
 		// Evidence types
-		DuplicateVoteEvidence{},
+		&DuplicateVoteEvidence{},
 		MockGoodEvidence{},
 		MockRandomGoodEvidence{},
 		MockBadEvidence{},
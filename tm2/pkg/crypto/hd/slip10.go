@@ -0,0 +1,127 @@
+package hd
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256r1"
+)
+
+// Curve is an elliptic curve supported by SLIP-0010 derivation.
+type Curve string
+
+const (
+	// CurveEd25519 derives ed25519 keys.
+	// As ed25519 only supports hardened derivation, all the path indexes are hardened.
+	CurveEd25519 = Curve("ed25519")
+	// CurveNist256p1 derives secp256r1 (NIST P-256) keys.
+	CurveNist256p1 = Curve("nist256p1")
+)
+
+var errUnsupportedCurve = errors.New("unsupported SLIP-0010 curve")
+
+// masterSecrets are the HMAC keys of the master key generation, per curve.
+var masterSecrets = map[Curve][]byte{
+	CurveEd25519:   []byte("ed25519 seed"),
+	CurveNist256p1: []byte("Nist256p1 seed"),
+}
+
+// DeriveSLIP10PrivateKeyForPath derives the private key for the given curve
+// by following the BIP 32/44 path from the seed, as defined by SLIP-0010:
+//
+//	https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+func DeriveSLIP10PrivateKeyForPath(curve Curve, seed []byte, path string) ([32]byte, error) {
+	masterSecret, ok := masterSecrets[curve]
+	if !ok {
+		return [32]byte{}, fmt.Errorf("%w: %s", errUnsupportedCurve, curve)
+	}
+
+	// master key generation
+	key, chainCode := i64(masterSecret, seed)
+	if curve == CurveNist256p1 {
+		for !isValidP256Scalar(key[:]) {
+			var I []byte
+			I = append(I, key[:]...)
+			I = append(I, chainCode[:]...)
+			key, chainCode = i64(masterSecret, I)
+		}
+	}
+
+	for _, part := range strings.Split(path, "/") {
+		if part == "" {
+			return [32]byte{}, errors.New("invalid BIP 32 path: empty index")
+		}
+
+		harden := strings.HasSuffix(part, "'")
+		part = strings.TrimSuffix(part, "'")
+
+		idx, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("invalid BIP 32 path: %w", err)
+		}
+
+		switch curve {
+		case CurveEd25519:
+			key, chainCode = deriveEd25519PrivateKey(key, chainCode, uint32(idx))
+		case CurveNist256p1:
+			key, chainCode = deriveP256PrivateKey(key, chainCode, uint32(idx), harden)
+		}
+	}
+
+	return key, nil
+}
+
+// deriveEd25519PrivateKey derives the hardened child key with index and chainCode.
+func deriveEd25519PrivateKey(key, chainCode [32]byte, index uint32) ([32]byte, [32]byte) {
+	data := append([]byte{0}, key[:]...)
+	data = append(data, uint32ToBytes(index|0x80000000)...)
+
+	return i64(chainCode[:], data)
+}
+
+// deriveP256PrivateKey derives the child key with index and chainCode.
+// If harden is true, the derivation is 'hardened'.
+func deriveP256PrivateKey(key, chainCode [32]byte, index uint32, harden bool) ([32]byte, [32]byte) {
+	var data []byte
+	if harden {
+		index |= 0x80000000
+		data = append([]byte{0}, key[:]...)
+	} else {
+		pub := secp256r1.PrivKeySecp256r1(key).PubKey().(secp256r1.PubKeySecp256r1)
+		data = bytes.Clone(pub[:])
+	}
+	data = append(data, uint32ToBytes(index)...)
+
+	n := elliptic.P256().Params().N
+	for {
+		IL, IR := i64(chainCode[:], data)
+
+		child := new(big.Int).SetBytes(IL[:])
+		if child.Cmp(n) < 0 {
+			child.Add(child, new(big.Int).SetBytes(key[:]))
+			child.Mod(child, n)
+
+			if child.Sign() != 0 {
+				var childKey [32]byte
+				child.FillBytes(childKey[:])
+
+				return childKey, IR
+			}
+		}
+
+		// invalid key, retry with 0x01 || IR || index
+		data = append([]byte{1}, IR[:]...)
+		data = append(data, uint32ToBytes(index)...)
+	}
+}
+
+func isValidP256Scalar(k []byte) bool {
+	d := new(big.Int).SetBytes(k)
+
+	return d.Sign() > 0 && d.Cmp(elliptic.P256().Params().N) < 0
+}
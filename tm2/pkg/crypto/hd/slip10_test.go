@@ -0,0 +1,53 @@
+package hd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from SLIP-0010 (test vector 1)
+func TestDeriveSLIP10PrivateKeyForPath(t *testing.T) {
+	t.Parallel()
+
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		curve Curve
+		path  string
+		key   string
+	}{
+		{CurveEd25519, "0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{CurveEd25519, "0'/1'/2'/2'/1000000000'", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+		{CurveNist256p1, "0'", "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c"},
+		{CurveNist256p1, "0'/1", "284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129"},
+		{CurveNist256p1, "0'/1/2'", "694596e8a54f252c960eb771a3c41e7e32496d03b954aeb90f61635b8e092aa7"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.curve)+"/"+tc.path, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := DeriveSLIP10PrivateKeyForPath(tc.curve, seed, tc.path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.key, hex.EncodeToString(key[:]))
+		})
+	}
+}
+
+func TestDeriveSLIP10PrivateKeyForPath_Errors(t *testing.T) {
+	t.Parallel()
+
+	seed := []byte("seed")
+
+	_, err := DeriveSLIP10PrivateKeyForPath(Curve("secp256k1"), seed, "0'")
+	assert.ErrorIs(t, err, errUnsupportedCurve)
+
+	for _, path := range []string{"", "0'/", "a'", "-1", "4294967296"} {
+		_, err := DeriveSLIP10PrivateKeyForPath(CurveEd25519, seed, path)
+		assert.Error(t, err, path)
+	}
+}
@@ -18,6 +18,7 @@ import (
 var (
 	errInvalidMnemonic       = errors.New("invalid bip39 mnemonic")
 	errInvalidDerivationPath = errors.New("invalid derivation path")
+	errInvalidSigningAlgo    = errors.New("invalid signing algo")
 )
 
 var reDerivationPath = regexp.MustCompile(`^44'\/118'\/\d+'\/0\/\d+$`)
@@ -29,6 +30,7 @@ type AddCfg struct {
 	NoBackup bool
 	Account  uint64
 	Index    uint64
+	Algo     string
 
 	DerivationPath commands.StringArr
 }
@@ -88,6 +90,13 @@ func (c *AddCfg) RegisterFlags(fs *flag.FlagSet) {
 		"address index number for HD derivation",
 	)
 
+	fs.StringVar(
+		&c.Algo,
+		"algo",
+		string(keys.Secp256k1),
+		"signing algo of the key (secp256k1, ed25519, secp256r1)",
+	)
+
 	fs.Var(
 		&c.DerivationPath,
 		"derivation-path",
@@ -118,6 +127,14 @@ func execAdd(cfg *AddCfg, args []string, io commands.IO) error {
 		}
 	}
 
+	// Validate the signing algo
+	algo := keys.SigningAlgo(cfg.Algo)
+	switch algo {
+	case keys.Secp256k1, keys.Ed25519, keys.Secp256r1:
+	default:
+		return fmt.Errorf("%w: %s", errInvalidSigningAlgo, cfg.Algo)
+	}
+
 	name := args[0]
 
 	// Read the keybase from the home directory
@@ -176,20 +193,20 @@ func execAdd(cfg *AddCfg, args []string, io commands.IO) error {
 	}
 
 	// Save the account
-	info, err := kb.CreateAccount(
+	info, err := kb.CreateAccountWithAlgo(
 		name,
 		mnemonic,
 		"",
 		encryptPassword,
-		uint32(cfg.Account),
-		uint32(cfg.Index),
+		algo,
+		*hd.NewFundraiserParams(uint32(cfg.Account), crypto.CoinType, uint32(cfg.Index)),
 	)
 	if err != nil {
 		return fmt.Errorf("unable to save account to keybase, %w", err)
 	}
 
 	// Print the derived address info
-	printDerive(mnemonic, cfg.DerivationPath, io)
+	printDerive(mnemonic, algo, cfg.DerivationPath, io)
 
 	// Recover key from seed passphrase
 	if cfg.Recover {
@@ -232,6 +249,7 @@ func printNewInfo(info keys.Info, io commands.IO) {
 // printDerive prints the derived accounts, if any
 func printDerive(
 	mnemonic string,
+	algo keys.SigningAlgo,
 	paths []string,
 	io commands.IO,
 ) {
@@ -243,6 +261,7 @@ func printDerive(
 	// Generate the accounts
 	accounts := generateAccounts(
 		mnemonic,
+		algo,
 		paths,
 	)
 
@@ -260,14 +279,14 @@ func printDerive(
 }
 
 // generateAccounts the accounts using the provided mnemonics
-func generateAccounts(mnemonic string, paths []string) []crypto.Address {
+func generateAccounts(mnemonic string, algo keys.SigningAlgo, paths []string) []crypto.Address {
 	addresses := make([]crypto.Address, len(paths))
 
 	// Generate the seed
 	seed := bip39.NewSeed(mnemonic, "")
 
 	for index, path := range paths {
-		key := generateKeyFromSeed(seed, path)
+		key, _ := keys.DerivePrivKey(algo, seed, path)
 		address := key.PubKey().Address()
 
 		addresses[index] = address
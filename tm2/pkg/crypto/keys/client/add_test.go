@@ -151,7 +151,7 @@ func TestAdd_Base_Add(t *testing.T) {
 		require.NotNil(t, key)
 
 		// Get the account
-		accounts := generateAccounts(mnemonic, []string{"44'/118'/0'/0/0"})
+		accounts := generateAccounts(mnemonic, keys.Secp256k1, []string{"44'/118'/0'/0/0"})
 
 		assert.Equal(t, accounts[0].String(), key.GetAddress().String())
 	})
@@ -207,6 +207,76 @@ func TestAdd_Base_Add(t *testing.T) {
 		// Make sure the key is not overwritten
 		assert.Equal(t, original.GetAddress(), newKey.GetAddress())
 	})
+
+	t.Run("valid key addition, signing algos", func(t *testing.T) {
+		t.Parallel()
+
+		for _, algo := range []keys.SigningAlgo{keys.Ed25519, keys.Secp256r1} {
+			var (
+				kbHome      = t.TempDir()
+				baseOptions = BaseOptions{
+					InsecurePasswordStdin: true,
+					Home:                  kbHome,
+				}
+
+				mnemonic = generateTestMnemonic(t)
+				keyName  = "key-name"
+			)
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancelFn()
+
+			io := commands.NewTestIO()
+			io.SetIn(strings.NewReader("test1234" + "\n" + "test1234" + "\n" + mnemonic + "\n"))
+
+			// Create the command
+			cmd := NewRootCmdWithBaseConfig(io, baseOptions)
+
+			args := []string{
+				"add",
+				"--insecure-password-stdin",
+				"--home",
+				kbHome,
+				"--recover",
+				"--algo",
+				string(algo),
+				keyName,
+			}
+
+			require.NoError(t, cmd.ParseAndRun(ctx, args))
+
+			// Check the keybase
+			kb, err := keys.NewKeyBaseFromDir(kbHome)
+			require.NoError(t, err)
+
+			key, err := kb.GetByName(keyName)
+			require.NoError(t, err)
+
+			accounts := generateAccounts(mnemonic, algo, []string{"44'/118'/0'/0/0"})
+			assert.Equal(t, accounts[0].String(), key.GetAddress().String())
+		}
+	})
+
+	t.Run("invalid signing algo", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelFn()
+
+		cmd := NewRootCmdWithBaseConfig(commands.NewTestIO(), BaseOptions{
+			InsecurePasswordStdin: true,
+			Home:                  t.TempDir(),
+		})
+
+		args := []string{
+			"add",
+			"--algo",
+			"sr25519",
+			"key-name",
+		}
+
+		assert.ErrorIs(t, cmd.ParseAndRun(ctx, args), errInvalidSigningAlgo)
+	})
 }
 
 func generateDerivationPaths(count int) []string {
@@ -273,6 +343,7 @@ func TestAdd_Derive(t *testing.T) {
 		// Verify the addresses are derived correctly
 		expectedAccounts := generateAccounts(
 			mnemonic,
+			keys.Secp256k1,
 			paths,
 		)
 
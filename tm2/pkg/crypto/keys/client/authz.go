@@ -0,0 +1,288 @@
+package client
+
+import (
+	"context"
+	"flag"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/authz"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type MakeGrantCfg struct {
+	RootCfg *MakeTxCfg
+
+	Grantee    string
+	MsgType    string
+	PkgPath    string
+	Func       string
+	SpendLimit string
+	Expiration int64
+}
+
+func NewMakeGrantCmd(rootCfg *MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeGrantCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "grant",
+			ShortUsage: "grant [flags] <key-name or address>",
+			ShortHelp:  "allows the grantee to execute msgs on behalf of the granter",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeGrant(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeGrantCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Grantee,
+		"grantee",
+		"",
+		"address of the account allowed to execute msgs on behalf of the granter",
+	)
+
+	fs.StringVar(
+		&c.MsgType,
+		"msg-type",
+		"",
+		`type of the authorized msgs, as "route/type" (ie. "vm/exec", "bank/send")`,
+	)
+
+	fs.StringVar(
+		&c.PkgPath,
+		"pkgpath",
+		"",
+		"package path the authorized msgs are limited to (any if empty)",
+	)
+
+	fs.StringVar(
+		&c.Func,
+		"func",
+		"",
+		"function of pkgpath the authorized msgs are limited to (any if empty)",
+	)
+
+	fs.StringVar(
+		&c.SpendLimit,
+		"spend-limit",
+		"",
+		"maximum amount the grantee can spend on behalf of the granter (none if empty)",
+	)
+
+	fs.Int64Var(
+		&c.Expiration,
+		"expiration",
+		0,
+		"unix time (in seconds) at which the grant expires (never if 0)",
+	)
+}
+
+func execMakeGrant(cfg *MakeGrantCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	if cfg.RootCfg.GasWanted == 0 {
+		return errors.New("gas-wanted not specified")
+	}
+	if cfg.RootCfg.GasFee == "" {
+		return errors.New("gas-fee not specified")
+	}
+	if cfg.Grantee == "" {
+		return errors.New("grantee not specified")
+	}
+	if cfg.MsgType == "" {
+		return errors.New("msg-type not specified")
+	}
+
+	// read account pubkey.
+	nameOrBech32 := args[0]
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return err
+	}
+
+	// parse grantee and spend limit.
+	grantee, err := crypto.AddressFromBech32(cfg.Grantee)
+	if err != nil {
+		return errors.Wrap(err, "invalid grantee address")
+	}
+	spendLimit, err := std.ParseCoins(cfg.SpendLimit)
+	if err != nil {
+		return errors.Wrap(err, "parsing spend limit coins")
+	}
+
+	// parse gas wanted & fee.
+	gaswanted := cfg.RootCfg.GasWanted
+	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
+	if err != nil {
+		return errors.Wrap(err, "parsing gas fee coin")
+	}
+	fee, err := cfg.RootCfg.NewFee(gaswanted, gasfee)
+	if err != nil {
+		return errors.Wrap(err, "parsing fee granter")
+	}
+
+	// construct msg & tx and marshal.
+	msg := authz.NewMsgGrant(authz.Grant{
+		Granter:    info.GetAddress(),
+		Grantee:    grantee,
+		MsgType:    cfg.MsgType,
+		PkgPath:    cfg.PkgPath,
+		Func:       cfg.Func,
+		SpendLimit: spendLimit,
+		Expiration: cfg.Expiration,
+	})
+	tx := std.Tx{
+		Msgs:       []std.Msg{msg},
+		Fee:        fee,
+		Signatures: nil,
+		Memo:       cfg.RootCfg.Memo,
+	}
+
+	if cfg.RootCfg.Broadcast {
+		err := ExecSignAndBroadcast(cfg.RootCfg, args, tx, io)
+		if err != nil {
+			return err
+		}
+	} else {
+		io.Println(string(amino.MustMarshalJSON(tx)))
+	}
+	return nil
+}
+
+type MakeRevokeCfg struct {
+	RootCfg *MakeTxCfg
+
+	Grantee string
+	MsgType string
+	PkgPath string
+	Func    string
+}
+
+func NewMakeRevokeCmd(rootCfg *MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeRevokeCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "revoke",
+			ShortUsage: "revoke [flags] <key-name or address>",
+			ShortHelp:  "revokes a grant given to the grantee",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeRevoke(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeRevokeCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Grantee,
+		"grantee",
+		"",
+		"address of the account whose grant is revoked",
+	)
+
+	fs.StringVar(
+		&c.MsgType,
+		"msg-type",
+		"",
+		"type of the msgs of the revoked grant",
+	)
+
+	fs.StringVar(
+		&c.PkgPath,
+		"pkgpath",
+		"",
+		"package path of the revoked grant",
+	)
+
+	fs.StringVar(
+		&c.Func,
+		"func",
+		"",
+		"function of the revoked grant",
+	)
+}
+
+func execMakeRevoke(cfg *MakeRevokeCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	if cfg.RootCfg.GasWanted == 0 {
+		return errors.New("gas-wanted not specified")
+	}
+	if cfg.RootCfg.GasFee == "" {
+		return errors.New("gas-fee not specified")
+	}
+	if cfg.Grantee == "" {
+		return errors.New("grantee not specified")
+	}
+	if cfg.MsgType == "" {
+		return errors.New("msg-type not specified")
+	}
+
+	// read account pubkey.
+	nameOrBech32 := args[0]
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return err
+	}
+
+	// parse grantee.
+	grantee, err := crypto.AddressFromBech32(cfg.Grantee)
+	if err != nil {
+		return errors.Wrap(err, "invalid grantee address")
+	}
+
+	// parse gas wanted & fee.
+	gaswanted := cfg.RootCfg.GasWanted
+	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
+	if err != nil {
+		return errors.Wrap(err, "parsing gas fee coin")
+	}
+	fee, err := cfg.RootCfg.NewFee(gaswanted, gasfee)
+	if err != nil {
+		return errors.Wrap(err, "parsing fee granter")
+	}
+
+	// construct msg & tx and marshal.
+	msg := authz.NewMsgRevoke(info.GetAddress(), grantee, cfg.MsgType, cfg.PkgPath, cfg.Func)
+	tx := std.Tx{
+		Msgs:       []std.Msg{msg},
+		Fee:        fee,
+		Signatures: nil,
+		Memo:       cfg.RootCfg.Memo,
+	}
+
+	if cfg.RootCfg.Broadcast {
+		err := ExecSignAndBroadcast(cfg.RootCfg, args, tx, io)
+		if err != nil {
+			return err
+		}
+	} else {
+		io.Println(string(amino.MustMarshalJSON(tx)))
+	}
+	return nil
+}
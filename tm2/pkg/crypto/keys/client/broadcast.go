@@ -70,6 +70,9 @@ func execBroadcast(cfg *BroadcastCfg, args []string, io commands.IO) error {
 	if err != nil {
 		return errors.Wrap(err, "unmarshaling tx json bytes")
 	}
+	if err := validateMultisigThresholds(&tx); err != nil {
+		return err
+	}
 	cfg.tx = &tx
 
 	res, err := BroadcastHandler(cfg)
@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	"flag"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type MakeGrantFeeCfg struct {
+	RootCfg *MakeTxCfg
+
+	Grantee    string
+	SpendLimit string
+	Expiration int64
+}
+
+func NewMakeGrantFeeCmd(rootCfg *MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeGrantFeeCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "grant-fee",
+			ShortUsage: "grant-fee [flags] <key-name or address>",
+			ShortHelp:  "allows the grantee to pay tx fees with the granter's funds",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeGrantFee(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeGrantFeeCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Grantee,
+		"grantee",
+		"",
+		"address of the account allowed to use the granter's funds for tx fees",
+	)
+
+	fs.StringVar(
+		&c.SpendLimit,
+		"spend-limit",
+		"",
+		"maximum amount of fees the grantee can spend (unlimited if empty)",
+	)
+
+	fs.Int64Var(
+		&c.Expiration,
+		"expiration",
+		0,
+		"unix time (in seconds) at which the allowance expires (never if 0)",
+	)
+}
+
+func execMakeGrantFee(cfg *MakeGrantFeeCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	if cfg.RootCfg.GasWanted == 0 {
+		return errors.New("gas-wanted not specified")
+	}
+	if cfg.RootCfg.GasFee == "" {
+		return errors.New("gas-fee not specified")
+	}
+	if cfg.Grantee == "" {
+		return errors.New("grantee not specified")
+	}
+
+	// read account pubkey.
+	nameOrBech32 := args[0]
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return err
+	}
+
+	// parse grantee and spend limit.
+	grantee, err := crypto.AddressFromBech32(cfg.Grantee)
+	if err != nil {
+		return errors.Wrap(err, "invalid grantee address")
+	}
+	spendLimit, err := std.ParseCoins(cfg.SpendLimit)
+	if err != nil {
+		return errors.Wrap(err, "parsing spend limit coins")
+	}
+
+	// parse gas wanted & fee.
+	gaswanted := cfg.RootCfg.GasWanted
+	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
+	if err != nil {
+		return errors.Wrap(err, "parsing gas fee coin")
+	}
+	fee, err := cfg.RootCfg.NewFee(gaswanted, gasfee)
+	if err != nil {
+		return errors.Wrap(err, "parsing fee granter")
+	}
+
+	// construct msg & tx and marshal.
+	msg := auth.NewMsgGrantFeeAllowance(info.GetAddress(), grantee, spendLimit, cfg.Expiration)
+	tx := std.Tx{
+		Msgs:       []std.Msg{msg},
+		Fee:        fee,
+		Signatures: nil,
+		Memo:       cfg.RootCfg.Memo,
+	}
+
+	if cfg.RootCfg.Broadcast {
+		err := ExecSignAndBroadcast(cfg.RootCfg, args, tx, io)
+		if err != nil {
+			return err
+		}
+	} else {
+		io.Println(string(amino.MustMarshalJSON(tx)))
+	}
+	return nil
+}
+
+type MakeRevokeFeeCfg struct {
+	RootCfg *MakeTxCfg
+
+	Grantee string
+}
+
+func NewMakeRevokeFeeCmd(rootCfg *MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeRevokeFeeCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "revoke-fee",
+			ShortUsage: "revoke-fee [flags] <key-name or address>",
+			ShortHelp:  "revokes a fee allowance given to the grantee",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeRevokeFee(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeRevokeFeeCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Grantee,
+		"grantee",
+		"",
+		"address of the account whose fee allowance is revoked",
+	)
+}
+
+func execMakeRevokeFee(cfg *MakeRevokeFeeCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	if cfg.RootCfg.GasWanted == 0 {
+		return errors.New("gas-wanted not specified")
+	}
+	if cfg.RootCfg.GasFee == "" {
+		return errors.New("gas-fee not specified")
+	}
+	if cfg.Grantee == "" {
+		return errors.New("grantee not specified")
+	}
+
+	// read account pubkey.
+	nameOrBech32 := args[0]
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return err
+	}
+
+	// parse grantee.
+	grantee, err := crypto.AddressFromBech32(cfg.Grantee)
+	if err != nil {
+		return errors.Wrap(err, "invalid grantee address")
+	}
+
+	// parse gas wanted & fee.
+	gaswanted := cfg.RootCfg.GasWanted
+	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
+	if err != nil {
+		return errors.Wrap(err, "parsing gas fee coin")
+	}
+	fee, err := cfg.RootCfg.NewFee(gaswanted, gasfee)
+	if err != nil {
+		return errors.Wrap(err, "parsing fee granter")
+	}
+
+	// construct msg & tx and marshal.
+	msg := auth.NewMsgRevokeFeeAllowance(info.GetAddress(), grantee)
+	tx := std.Tx{
+		Msgs:       []std.Msg{msg},
+		Fee:        fee,
+		Signatures: nil,
+		Memo:       cfg.RootCfg.Memo,
+	}
+
+	if cfg.RootCfg.Broadcast {
+		err := ExecSignAndBroadcast(cfg.RootCfg, args, tx, io)
+		if err != nil {
+			return err
+		}
+	} else {
+		io.Println(string(amino.MustMarshalJSON(tx)))
+	}
+	return nil
+}
@@ -8,17 +8,21 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	types "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/authz"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 type MakeTxCfg struct {
 	RootCfg *BaseCfg
 
-	GasWanted int64
-	GasFee    string
-	Memo      string
+	GasWanted  int64
+	GasFee     string
+	FeeGranter string
+	ExecAs     string
+	Memo       string
 
 	Broadcast bool
 	// Valid options are SimulateTest, SimulateSkip or SimulateOnly.
@@ -42,6 +46,48 @@ func (c *MakeTxCfg) Validate() error {
 	return nil
 }
 
+// NewFee returns the tx fee for the given gas wanted and gas fee,
+// paid by the fee granter if one is set
+func (c *MakeTxCfg) NewFee(gasWanted int64, gasFee std.Coin) (std.Fee, error) {
+	fee := std.NewFee(gasWanted, gasFee)
+
+	if c.FeeGranter != "" {
+		granter, err := crypto.AddressFromBech32(c.FeeGranter)
+		if err != nil {
+			return std.Fee{}, fmt.Errorf("invalid fee granter address: %w", err)
+		}
+
+		fee.Grant = &std.FeeGrant{Granter: granter}
+	}
+
+	return fee, nil
+}
+
+// MsgSigner returns the signer of the tx msgs, which is
+// the exec-as granter if set, or the given key address otherwise
+func (c *MakeTxCfg) MsgSigner(keyAddr crypto.Address) (crypto.Address, error) {
+	if c.ExecAs == "" {
+		return keyAddr, nil
+	}
+
+	granter, err := crypto.AddressFromBech32(c.ExecAs)
+	if err != nil {
+		return crypto.Address{}, fmt.Errorf("invalid exec-as address: %w", err)
+	}
+
+	return granter, nil
+}
+
+// WrapMsgs wraps the msgs in an authz MsgExec signed by the given
+// key address (the grantee), if exec-as is set
+func (c *MakeTxCfg) WrapMsgs(keyAddr crypto.Address, msgs ...std.Msg) []std.Msg {
+	if c.ExecAs == "" {
+		return msgs
+	}
+
+	return []std.Msg{authz.NewMsgExec(keyAddr, msgs)}
+}
+
 func NewMakeTxCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
 	cfg := &MakeTxCfg{
 		RootCfg: rootCfg,
@@ -59,6 +105,11 @@ func NewMakeTxCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
 
 	cmd.AddSubCommands(
 		NewMakeSendCmd(cfg, io),
+		NewMakeWithdrawCmd(cfg, io),
+		NewMakeGrantFeeCmd(cfg, io),
+		NewMakeRevokeFeeCmd(cfg, io),
+		NewMakeGrantCmd(cfg, io),
+		NewMakeRevokeCmd(cfg, io),
 	)
 
 	return cmd
@@ -79,6 +130,20 @@ func (c *MakeTxCfg) RegisterFlags(fs *flag.FlagSet) {
 		"gas payment fee",
 	)
 
+	fs.StringVar(
+		&c.FeeGranter,
+		"fee-granter",
+		"",
+		"address of the account paying the gas fee, using its fee allowance to the signer",
+	)
+
+	fs.StringVar(
+		&c.ExecAs,
+		"exec-as",
+		"",
+		"address of the account the msg is executed on behalf of, using its authz grant to the signer",
+	)
+
 	fs.StringVar(
 		&c.Memo,
 		"memo",
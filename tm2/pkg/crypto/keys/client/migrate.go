@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+)
+
+const (
+	backendFile = "file"
+	backendDB   = "db"
+)
+
+var (
+	errInvalidBackend   = errors.New("invalid keybase backend")
+	errAlreadyMigrated  = errors.New("keybase already uses the requested backend")
+	errMigrationPending = errors.New("a previous migration was interrupted")
+)
+
+type MigrateCfg struct {
+	RootCfg *BaseCfg
+
+	To  string
+	KDF string
+}
+
+func NewMigrateCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &MigrateCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "migrate",
+			ShortUsage: "migrate [flags]",
+			ShortHelp:  "migrates the keybase to another storage backend",
+			LongHelp: `Migrates all the keys of the keybase to another storage backend:
+  - db:   all keys are stored in a single leveldb database (default)
+  - file: each key is stored in its own encrypted JSON file (based on Web3 keystore v3),
+          in <home>/data/keystore
+
+The password of each local key is required, and is kept unchanged.
+The previous backend is left on disk as a backup.`,
+		},
+		cfg,
+		func(_ context.Context, _ []string) error {
+			return execMigrate(cfg, io)
+		},
+	)
+}
+
+func (c *MigrateCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.To,
+		"to",
+		backendFile,
+		"backend to migrate to (file, db)",
+	)
+
+	fs.StringVar(
+		&c.KDF,
+		"kdf",
+		keys.KDFScrypt,
+		fmt.Sprintf("key derivation function of the file backend (%s, %s)", keys.KDFScrypt, keys.KDFArgon2id),
+	)
+}
+
+func execMigrate(cfg *MigrateCfg, io commands.IO) error {
+	var (
+		home        = cfg.RootCfg.Home
+		keystoreDir = keys.KeystoreDir(home)
+		pendingDir  = keystoreDir + ".migrating"
+		usesFile    = osm.DirExists(keystoreDir)
+	)
+
+	getPassword := func(name string) (string, error) {
+		return io.GetPassword(
+			fmt.Sprintf("Enter the password of key %s:", name),
+			cfg.RootCfg.InsecurePasswordStdin,
+		)
+	}
+
+	switch cfg.To {
+	case backendFile:
+		if usesFile {
+			return errAlreadyMigrated
+		}
+
+		if osm.DirExists(pendingDir) {
+			return fmt.Errorf("%w, remove %s and try again", errMigrationPending, pendingDir)
+		}
+
+		// keys are written to a pending dir, so the file
+		// keystore isn't picked up until the migration succeeds
+		dst, err := keys.NewFileKeybase(pendingDir, keys.WithKDF(cfg.KDF))
+		if err != nil {
+			return err
+		}
+
+		infos, err := keys.Migrate(keys.NewDBKeyBaseFromDir(home), dst, getPassword)
+		if err != nil {
+			os.RemoveAll(pendingDir)
+
+			return err
+		}
+
+		if err := os.Rename(pendingDir, keystoreDir); err != nil {
+			return fmt.Errorf("unable to move keystore: %w", err)
+		}
+
+		io.Printfln("Migrated %d key(s) to %s", len(infos), keystoreDir)
+	case backendDB:
+		if !usesFile {
+			return errAlreadyMigrated
+		}
+
+		src, err := keys.NewFileKeybase(keystoreDir)
+		if err != nil {
+			return err
+		}
+
+		infos, err := keys.Migrate(src, keys.NewDBKeyBaseFromDir(home), getPassword)
+		if err != nil {
+			return err
+		}
+
+		// move the keystore aside, so the DB is used from now on
+		backupDir := fmt.Sprintf("%s.bak.%d", keystoreDir, time.Now().Unix())
+		if err := os.Rename(keystoreDir, backupDir); err != nil {
+			return fmt.Errorf("unable to move keystore: %w", err)
+		}
+
+		io.Printfln("Migrated %d key(s) to the DB keybase, keystore backed up to %s", len(infos), backupDir)
+	default:
+		return fmt.Errorf("%w: %q", errInvalidBackend, cfg.To)
+	}
+
+	return nil
+}
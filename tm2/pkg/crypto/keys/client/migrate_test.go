@@ -0,0 +1,96 @@
+package client
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/testutils"
+)
+
+func Test_execMigrate(t *testing.T) {
+	t.Parallel()
+
+	kbHome, kbCleanUp := testutils.NewTestCaseDir(t)
+	defer kbCleanUp()
+
+	baseCfg := &BaseCfg{
+		BaseOptions: BaseOptions{
+			Home:                  kbHome,
+			InsecurePasswordStdin: true,
+		},
+	}
+
+	kb, err := keys.NewKeyBaseFromDir(kbHome)
+	require.NoError(t, err)
+
+	keyName := "migrateKey"
+	pass := "12345678"
+	mnemonic := "equip will roof matter pink blind book anxiety banner elbow sun young"
+
+	info, err := kb.CreateAccount(keyName, mnemonic, "", pass, 0, 0)
+	require.NoError(t, err)
+
+	io := commands.NewTestIO()
+
+	{
+		// test: invalid backend
+		cfg := &MigrateCfg{RootCfg: baseCfg, To: "cloud", KDF: keys.KDFScrypt}
+		err = execMigrate(cfg, io)
+		assert.ErrorIs(t, err, errInvalidBackend)
+	}
+
+	{
+		// test: already using the DB
+		cfg := &MigrateCfg{RootCfg: baseCfg, To: backendDB, KDF: keys.KDFScrypt}
+		err = execMigrate(cfg, io)
+		assert.ErrorIs(t, err, errAlreadyMigrated)
+	}
+
+	{
+		// test: wrong password, nothing is migrated
+		cfg := &MigrateCfg{RootCfg: baseCfg, To: backendFile, KDF: keys.KDFScrypt}
+		io.SetIn(strings.NewReader("blah\n"))
+		err = execMigrate(cfg, io)
+		require.Error(t, err)
+		assert.NoDirExists(t, keys.KeystoreDir(kbHome))
+	}
+
+	{
+		// migrate to files
+		cfg := &MigrateCfg{RootCfg: baseCfg, To: backendFile, KDF: keys.KDFScrypt}
+		io.SetIn(strings.NewReader(pass + "\n"))
+		err = execMigrate(cfg, io)
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(keys.KeystoreDir(kbHome), keyName+".json"))
+
+		// the file keybase is now used
+		kb, err := keys.NewKeyBaseFromDir(kbHome)
+		require.NoError(t, err)
+		_, _, err = kb.Sign(keyName, pass, []byte("msg"))
+		require.NoError(t, err)
+	}
+
+	{
+		// migrate back to the DB, replacing the previous keys
+		kb := keys.NewDBKeyBaseFromDir(kbHome)
+		require.NoError(t, kb.Delete(keyName, pass, false))
+
+		cfg := &MigrateCfg{RootCfg: baseCfg, To: backendDB, KDF: keys.KDFScrypt}
+		io.SetIn(strings.NewReader(pass + "\n"))
+		err = execMigrate(cfg, io)
+		require.NoError(t, err)
+		assert.NoDirExists(t, keys.KeystoreDir(kbHome))
+
+		kb, err = keys.NewKeyBaseFromDir(kbHome)
+		require.NoError(t, err)
+		i, err := kb.GetByName(keyName)
+		require.NoError(t, err)
+		assert.Equal(t, info.GetAddress(), i.GetAddress())
+	}
+}
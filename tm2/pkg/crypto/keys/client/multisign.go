@@ -0,0 +1,258 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var (
+	errInvalidPartialSig   = errors.New("invalid partial signature")
+	errDuplicatePartialSig = errors.New("duplicate partial signature")
+	errThresholdNotMet     = errors.New("multisig threshold not met")
+)
+
+type MultisignCfg struct {
+	RootCfg *BaseCfg
+
+	ChainID        string
+	AccountNumber  uint64
+	Sequence       uint64
+	OutputDocument string
+}
+
+func NewMultisignCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &MultisignCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "multisign",
+			ShortUsage: "multisign [flags] <tx-path> <multisig-key-name or address> <signature-path>...",
+			ShortHelp:  "combines partial signatures into a multisig tx signature",
+			LongHelp: `Combines the partial signatures generated with "gnokey sign -multisig"
+into the multisig signature of the given tx document, and saves it to disk.
+The signatures are verified, and there must be at least as many as the multisig threshold.`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMultisign(cfg, args, io)
+		},
+	)
+}
+
+func (c *MultisignCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.ChainID,
+		"chainid",
+		"dev",
+		"the ID of the chain",
+	)
+
+	fs.Uint64Var(
+		&c.AccountNumber,
+		"account-number",
+		0,
+		"account number of the multisig",
+	)
+
+	fs.Uint64Var(
+		&c.Sequence,
+		"account-sequence",
+		0,
+		"account sequence of the multisig",
+	)
+
+	fs.StringVar(
+		&c.OutputDocument,
+		"output-document",
+		"",
+		"path to save the signed tx (defaults to the tx path)",
+	)
+}
+
+func execMultisign(cfg *MultisignCfg, args []string, io commands.IO) error {
+	if len(args) < 3 {
+		return flag.ErrHelp
+	}
+
+	txPath, multisigName, sigPaths := args[0], args[1], args[2:]
+
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	if err != nil {
+		return fmt.Errorf("unable to load keybase, %w", err)
+	}
+
+	multisigPub, err := getMultisigPubKey(kb, multisigName)
+	if err != nil {
+		return err
+	}
+
+	txRaw, err := os.ReadFile(txPath)
+	if err != nil {
+		return fmt.Errorf("unable to read transaction file")
+	}
+
+	if len(txRaw) == 0 {
+		return errInvalidTxFile
+	}
+
+	var tx std.Tx
+	if err := amino.UnmarshalJSON(txRaw, &tx); err != nil {
+		return fmt.Errorf("unable to unmarshal transaction, %w", err)
+	}
+
+	sigs := make([]std.Signature, 0, len(sigPaths))
+	for _, path := range sigPaths {
+		sigRaw, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to read signature file %s, %w", path, err)
+		}
+
+		var sig std.Signature
+		if err := amino.UnmarshalJSON(sigRaw, &sig); err != nil {
+			return fmt.Errorf("unable to unmarshal signature %s, %w", path, err)
+		}
+
+		sigs = append(sigs, sig)
+	}
+
+	sOpts := signOpts{
+		chainID:         cfg.ChainID,
+		accountSequence: cfg.Sequence,
+		accountNumber:   cfg.AccountNumber,
+	}
+
+	if err := multisignTx(&tx, multisigPub, sigs, sOpts); err != nil {
+		return fmt.Errorf("unable to sign transaction, %w", err)
+	}
+
+	encodedTx, err := amino.MarshalJSON(tx)
+	if err != nil {
+		return fmt.Errorf("unable to marshal tx to JSON, %w", err)
+	}
+
+	outputPath := txPath
+	if cfg.OutputDocument != "" {
+		outputPath = cfg.OutputDocument
+	}
+
+	if err := os.WriteFile(outputPath, encodedTx, 0o644); err != nil {
+		return fmt.Errorf("unable to write tx to %s, %w", outputPath, err)
+	}
+
+	io.Printf("\nTx successfully signed by %d of %d keys and saved to %s\n",
+		len(sigs), len(multisigPub.PubKeys), outputPath)
+
+	return nil
+}
+
+// multisignTx verifies the partial signatures, combines them
+// into a multisignature and saves it to the given transaction
+func multisignTx(
+	tx *std.Tx,
+	multisigPub multisig.PubKeyMultisigThreshold,
+	sigs []std.Signature,
+	signOpts signOpts,
+) error {
+	signBytes, err := tx.GetSignBytes(
+		signOpts.chainID,
+		signOpts.accountNumber,
+		signOpts.accountSequence,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to get signature bytes, %w", err)
+	}
+
+	multisignature := multisig.NewMultisig(len(multisigPub.PubKeys))
+
+	for _, sig := range sigs {
+		if sig.PubKey == nil || !sig.PubKey.VerifyBytes(signBytes, sig.Signature) {
+			return fmt.Errorf("%w: signature from %s doesn't match the tx", errInvalidPartialSig, sig.PubKey)
+		}
+
+		for i, pub := range multisigPub.PubKeys {
+			if pub.Equals(sig.PubKey) && multisignature.BitArray.GetIndex(i) {
+				return fmt.Errorf("%w: %s", errDuplicatePartialSig, sig.PubKey.Address())
+			}
+		}
+
+		if err := multisignature.AddSignatureFromPubKey(sig.Signature, sig.PubKey, multisigPub.PubKeys); err != nil {
+			return fmt.Errorf("%w: %w", errNotMultisigMember, err)
+		}
+	}
+
+	if len(multisignature.Sigs) < int(multisigPub.K) {
+		return fmt.Errorf(
+			"%w: %d of %d signatures",
+			errThresholdNotMet,
+			len(multisignature.Sigs),
+			multisigPub.K,
+		)
+	}
+
+	sig := std.Signature{
+		PubKey:    multisigPub,
+		Signature: multisignature.Marshal(),
+	}
+
+	if !multisigPub.VerifyBytes(signBytes, sig.Signature) {
+		return errInvalidPartialSig
+	}
+
+	// Overwrite the multisig signature if present,
+	// or add it at the multisig signer position
+	signers := tx.GetSigners()
+	signerIndex := -1
+	for i, signer := range signers {
+		if signer == multisigPub.Address() {
+			signerIndex = i
+			break
+		}
+	}
+
+	if signerIndex == -1 {
+		return fmt.Errorf("%w: %s", errMultisigNotSigner, multisigPub.Address())
+	}
+
+	if len(tx.Signatures) < len(signers) {
+		padded := make([]std.Signature, len(signers))
+		copy(padded, tx.Signatures)
+		tx.Signatures = padded
+	}
+	tx.Signatures[signerIndex] = sig
+
+	return nil
+}
+
+// validateMultisigThresholds makes sure the multisig
+// signatures of the transaction meet their threshold
+func validateMultisigThresholds(tx *std.Tx) error {
+	for _, sig := range tx.Signatures {
+		multisigPub, ok := sig.PubKey.(multisig.PubKeyMultisigThreshold)
+		if !ok {
+			continue
+		}
+
+		var multisignature multisig.Multisignature
+		if err := amino.Unmarshal(sig.Signature, &multisignature); err != nil {
+			return fmt.Errorf("unable to unmarshal multisignature, %w", err)
+		}
+
+		if multisignature.BitArray == nil ||
+			multisignature.BitArray.NumTrueBitsBefore(multisignature.BitArray.Size()) < int(multisigPub.K) {
+			return fmt.Errorf("%w: %s", errThresholdNotMet, multisigPub.Address())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// multisigTestEnv is a keybase with a 2-of-3 multisig key,
+// and a tx document sent from the multisig address
+type multisigTestEnv struct {
+	kbHome      string
+	password    string
+	memberNames []string
+	multisigPub multisig.PubKeyMultisigThreshold
+	txPath      string
+}
+
+func newMultisigTestEnv(t *testing.T) multisigTestEnv {
+	t.Helper()
+
+	env := multisigTestEnv{
+		kbHome:      t.TempDir(),
+		password:    "encrypt",
+		memberNames: []string{"member-1", "member-2", "member-3"},
+	}
+
+	kb, err := keys.NewKeyBaseFromDir(env.kbHome)
+	require.NoError(t, err)
+
+	pubs := make([]crypto.PubKey, 0, len(env.memberNames))
+	for _, name := range env.memberNames {
+		info, err := kb.CreateAccount(name, generateTestMnemonic(t), "", env.password, 0, 0)
+		require.NoError(t, err)
+
+		pubs = append(pubs, info.GetPubKey())
+	}
+
+	env.multisigPub = multisig.NewPubKeyMultisigThreshold(2, pubs).(multisig.PubKeyMultisigThreshold)
+	_, err = kb.CreateMulti("treasury", env.multisigPub)
+	require.NoError(t, err)
+
+	tx := std.Tx{
+		Msgs: []std.Msg{
+			bank.MsgSend{
+				FromAddress: env.multisigPub.Address(),
+				ToAddress:   env.multisigPub.Address(),
+				Amount:      std.NewCoins(std.NewCoin("ugnot", 10)),
+			},
+		},
+		Fee: std.NewFee(10, std.NewCoin("ugnot", 10)),
+	}
+
+	encodedTx, err := amino.MarshalJSON(tx)
+	require.NoError(t, err)
+
+	env.txPath = filepath.Join(t.TempDir(), "tx.json")
+	require.NoError(t, os.WriteFile(env.txPath, encodedTx, 0o644))
+
+	return env
+}
+
+// run runs the gnokey command with the given args
+func (env multisigTestEnv) run(t *testing.T, stdin string, args ...string) error {
+	t.Helper()
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFn()
+
+	io := commands.NewTestIO()
+	io.SetIn(strings.NewReader(stdin))
+
+	cmd := NewRootCmdWithBaseConfig(io, BaseOptions{
+		InsecurePasswordStdin: true,
+		Home:                  env.kbHome,
+		Quiet:                 true,
+	})
+
+	return cmd.ParseAndRun(ctx, args)
+}
+
+// partialSign generates the partial signature of the given member
+func (env multisigTestEnv) partialSign(t *testing.T, member string) string {
+	t.Helper()
+
+	sigPath := filepath.Join(t.TempDir(), member+".json")
+	require.NoError(t, env.run(
+		t,
+		env.password+"\n",
+		"sign",
+		"--tx-path", env.txPath,
+		"--multisig", "treasury",
+		"--output-document", sigPath,
+		member,
+	))
+
+	return sigPath
+}
+
+func TestMultisign(t *testing.T) {
+	t.Parallel()
+
+	t.Run("threshold met", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+
+		sig1 := env.partialSign(t, env.memberNames[0])
+		sig3 := env.partialSign(t, env.memberNames[2])
+
+		// The partial signatures don't modify the tx
+		var tx std.Tx
+		txRaw, err := os.ReadFile(env.txPath)
+		require.NoError(t, err)
+		require.NoError(t, amino.UnmarshalJSON(txRaw, &tx))
+		assert.Empty(t, tx.Signatures)
+
+		outputPath := filepath.Join(t.TempDir(), "signed.json")
+		require.NoError(t, env.run(
+			t,
+			"",
+			"multisign",
+			"--output-document", outputPath,
+			env.txPath, "treasury", sig1, sig3,
+		))
+
+		signedRaw, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+
+		var signed std.Tx
+		require.NoError(t, amino.UnmarshalJSON(signedRaw, &signed))
+		require.Len(t, signed.Signatures, 1)
+		require.NoError(t, signed.ValidateBasic())
+		require.NoError(t, validateMultisigThresholds(&signed))
+
+		signBytes, err := signed.GetSignBytes("dev", 0, 0)
+		require.NoError(t, err)
+
+		assert.True(t, signed.Signatures[0].PubKey.Equals(env.multisigPub))
+		assert.True(t, env.multisigPub.VerifyBytes(signBytes, signed.Signatures[0].Signature))
+	})
+
+	t.Run("threshold not met", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+
+		sig1 := env.partialSign(t, env.memberNames[0])
+
+		err := env.run(t, "", "multisign", env.txPath, "treasury", sig1)
+		assert.ErrorIs(t, err, errThresholdNotMet)
+	})
+
+	t.Run("duplicate signature", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+
+		sig1 := env.partialSign(t, env.memberNames[0])
+
+		err := env.run(t, "", "multisign", env.txPath, "treasury", sig1, sig1)
+		assert.ErrorIs(t, err, errDuplicatePartialSig)
+	})
+
+	t.Run("signature for another chain", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+
+		sig1 := env.partialSign(t, env.memberNames[0])
+		sig2 := env.partialSign(t, env.memberNames[1])
+
+		err := env.run(t, "", "multisign", "--chainid", "other", env.txPath, "treasury", sig1, sig2)
+		assert.ErrorIs(t, err, errInvalidPartialSig)
+	})
+
+	t.Run("signer not a member", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+
+		kb, err := keys.NewKeyBaseFromDir(env.kbHome)
+		require.NoError(t, err)
+
+		_, err = kb.CreateAccount("outsider", generateTestMnemonic(t), "", env.password, 0, 0)
+		require.NoError(t, err)
+
+		err = env.run(
+			t,
+			env.password+"\n",
+			"sign",
+			"--tx-path", env.txPath,
+			"--multisig", "treasury",
+			"outsider",
+		)
+		assert.ErrorIs(t, err, errNotMultisigMember)
+	})
+
+	t.Run("not a multisig key", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+
+		err := env.run(
+			t,
+			env.password+"\n",
+			"sign",
+			"--tx-path", env.txPath,
+			"--multisig", env.memberNames[1],
+			env.memberNames[0],
+		)
+		assert.ErrorIs(t, err, errNotMultisigKey)
+	})
+}
+
+func TestValidateMultisigThresholds(t *testing.T) {
+	t.Parallel()
+
+	env := newMultisigTestEnv(t)
+
+	multisignature := multisig.NewMultisig(len(env.multisigPub.PubKeys))
+	multisignature.AddSignature([]byte("sig"), 1)
+
+	tx := &std.Tx{
+		Signatures: []std.Signature{
+			{
+				PubKey:    env.multisigPub,
+				Signature: multisignature.Marshal(),
+			},
+		},
+	}
+
+	err := validateMultisigThresholds(tx)
+	assert.ErrorIs(t, err, errThresholdNotMet)
+	assert.Contains(t, err.Error(), fmt.Sprint(env.multisigPub.Address()))
+}
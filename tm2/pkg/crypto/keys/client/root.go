@@ -44,7 +44,9 @@ func NewRootCmdWithBaseConfig(io commands.IO, base BaseOptions) *commands.Comman
 		NewImportCmd(cfg, io),
 		NewListCmd(cfg, io),
 		NewRotateCmd(cfg, io),
+		NewMigrateCmd(cfg, io),
 		NewSignCmd(cfg, io),
+		NewMultisignCmd(cfg, io),
 		NewVerifyCmd(cfg, io),
 		NewQueryCmd(cfg, io),
 		NewBroadcastCmd(cfg, io),
@@ -82,8 +82,10 @@ func execMakeSend(cfg *MakeSendCfg, args []string, io commands.IO) error {
 	if err != nil {
 		return err
 	}
-	fromAddr := info.GetAddress()
-	// info.GetPubKey()
+	fromAddr, err := cfg.RootCfg.MsgSigner(info.GetAddress())
+	if err != nil {
+		return err
+	}
 
 	// Parse to address.
 	toAddr, err := crypto.AddressFromBech32(cfg.To)
@@ -103,6 +105,10 @@ func execMakeSend(cfg *MakeSendCfg, args []string, io commands.IO) error {
 	if err != nil {
 		return errors.Wrap(err, "parsing gas fee coin")
 	}
+	fee, err := cfg.RootCfg.NewFee(gaswanted, gasfee)
+	if err != nil {
+		return errors.Wrap(err, "parsing fee granter")
+	}
 
 	// construct msg & tx and marshal.
 	msg := bank.MsgSend{
@@ -111,8 +117,8 @@ func execMakeSend(cfg *MakeSendCfg, args []string, io commands.IO) error {
 		Amount:      send,
 	}
 	tx := std.Tx{
-		Msgs:       []std.Msg{msg},
-		Fee:        std.NewFee(gaswanted, gasfee),
+		Msgs:       cfg.RootCfg.WrapMsgs(info.GetAddress(), msg),
+		Fee:        fee,
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
 	}
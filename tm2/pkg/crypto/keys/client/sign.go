@@ -5,15 +5,23 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
-var errInvalidTxFile = errors.New("invalid transaction file")
+var (
+	errInvalidTxFile     = errors.New("invalid transaction file")
+	errNotMultisigKey    = errors.New("key is not a multisig key")
+	errNotMultisigMember = errors.New("key is not a member of the multisig")
+	errMultisigNotSigner = errors.New("multisig is not a signer of the transaction")
+)
 
 type signOpts struct {
 	chainID         string
@@ -34,6 +42,9 @@ type SignCfg struct {
 	AccountNumber uint64
 	Sequence      uint64
 	NameOrBech32  string
+
+	Multisig       string
+	OutputDocument string
 }
 
 func NewSignCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
@@ -82,6 +93,20 @@ func (c *SignCfg) RegisterFlags(fs *flag.FlagSet) {
 		0,
 		"account sequence to sign with",
 	)
+
+	fs.StringVar(
+		&c.Multisig,
+		"multisig",
+		"",
+		"name or address of the multisig key to generate a partial signature for",
+	)
+
+	fs.StringVar(
+		&c.OutputDocument,
+		"output-document",
+		"",
+		"path to save the signed tx, or the partial signature when using -multisig (defaults to -tx-path, or stdout)",
+	)
 }
 
 func execSign(cfg *SignCfg, args []string, io commands.IO) error {
@@ -169,12 +194,112 @@ func execSign(cfg *SignCfg, args []string, io commands.IO) error {
 		decryptPass: password,
 	}
 
+	// Generate a partial signature for the multisig
+	if cfg.Multisig != "" {
+		sig, err := signMultisigTx(&tx, kb, cfg.Multisig, info.GetPubKey(), sOpts, kOpts)
+		if err != nil {
+			return fmt.Errorf("unable to sign transaction, %w", err)
+		}
+
+		return saveSignature(sig, cfg.OutputDocument, io)
+	}
+
 	// Sign the transaction
 	if err := signTx(&tx, kb, sOpts, kOpts); err != nil {
 		return fmt.Errorf("unable to sign transaction, %w", err)
 	}
 
-	return saveTx(&tx, cfg.TxPath)
+	outputPath := cfg.TxPath
+	if cfg.OutputDocument != "" {
+		outputPath = cfg.OutputDocument
+	}
+
+	return saveTx(&tx, outputPath)
+}
+
+// signMultisigTx generates the partial signature of the given
+// multisig member, without adding it to the transaction
+func signMultisigTx(
+	tx *std.Tx,
+	kb keys.Keybase,
+	multisigNameOrBech32 string,
+	signerPub crypto.PubKey,
+	signOpts signOpts,
+	keyOpts keyOpts,
+) (std.Signature, error) {
+	multisigPub, err := getMultisigPubKey(kb, multisigNameOrBech32)
+	if err != nil {
+		return std.Signature{}, err
+	}
+
+	if !slices.ContainsFunc(multisigPub.PubKeys, signerPub.Equals) {
+		return std.Signature{}, fmt.Errorf("%w: %s", errNotMultisigMember, keyOpts.keyName)
+	}
+
+	if !slices.Contains(tx.GetSigners(), multisigPub.Address()) {
+		return std.Signature{}, fmt.Errorf("%w: %s", errMultisigNotSigner, multisigPub.Address())
+	}
+
+	signBytes, err := tx.GetSignBytes(
+		signOpts.chainID,
+		signOpts.accountNumber,
+		signOpts.accountSequence,
+	)
+	if err != nil {
+		return std.Signature{}, fmt.Errorf("unable to get signature bytes, %w", err)
+	}
+
+	sig, pub, err := kb.Sign(
+		keyOpts.keyName,
+		keyOpts.decryptPass,
+		signBytes,
+	)
+	if err != nil {
+		return std.Signature{}, fmt.Errorf("unable to sign transaction bytes, %w", err)
+	}
+
+	return std.Signature{
+		PubKey:    pub,
+		Signature: sig,
+	}, nil
+}
+
+// saveSignature saves the given signature to the given path (Amino-encoded JSON),
+// or prints it if no path is given
+func saveSignature(sig std.Signature, path string, io commands.IO) error {
+	encodedSig, err := amino.MarshalJSON(sig)
+	if err != nil {
+		return fmt.Errorf("unable to marshal signature to JSON, %w", err)
+	}
+
+	if path == "" {
+		io.Println(string(encodedSig))
+
+		return nil
+	}
+
+	if err := os.WriteFile(path, encodedSig, 0o644); err != nil {
+		return fmt.Errorf("unable to write signature to %s, %w", path, err)
+	}
+
+	io.Printf("\nSignature successfully saved to %s\n", path)
+
+	return nil
+}
+
+// getMultisigPubKey fetches the multisig public key from the keybase
+func getMultisigPubKey(kb keys.Keybase, nameOrBech32 string) (multisig.PubKeyMultisigThreshold, error) {
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return multisig.PubKeyMultisigThreshold{}, fmt.Errorf("unable to get multisig key from keybase, %w", err)
+	}
+
+	pub, ok := info.GetPubKey().(multisig.PubKeyMultisigThreshold)
+	if !ok {
+		return multisig.PubKeyMultisigThreshold{}, fmt.Errorf("%w: %s", errNotMultisigKey, nameOrBech32)
+	}
+
+	return pub, nil
 }
 
 // signTx generates the transaction signature,
@@ -211,7 +336,7 @@ func signTx(
 
 	// Check if the signature needs to be overwritten
 	for index, signature := range tx.Signatures {
-		if !signature.PubKey.Equals(pub) {
+		if signature.PubKey == nil || !signature.PubKey.Equals(pub) {
 			continue
 		}
 
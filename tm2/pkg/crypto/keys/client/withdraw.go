@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"flag"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/distribution"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type MakeWithdrawCfg struct {
+	RootCfg *MakeTxCfg
+}
+
+func NewMakeWithdrawCmd(rootCfg *MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeWithdrawCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "withdraw",
+			ShortUsage: "withdraw [flags] <key-name or address>",
+			ShortHelp:  "withdraws the accrued fee rewards",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeWithdraw(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeWithdrawCfg) RegisterFlags(_ *flag.FlagSet) {}
+
+func execMakeWithdraw(cfg *MakeWithdrawCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	if cfg.RootCfg.GasWanted == 0 {
+		return errors.New("gas-wanted not specified")
+	}
+	if cfg.RootCfg.GasFee == "" {
+		return errors.New("gas-fee not specified")
+	}
+
+	// read account pubkey.
+	nameOrBech32 := args[0]
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return err
+	}
+
+	// parse gas wanted & fee.
+	gaswanted := cfg.RootCfg.GasWanted
+	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
+	if err != nil {
+		return errors.Wrap(err, "parsing gas fee coin")
+	}
+	fee, err := cfg.RootCfg.NewFee(gaswanted, gasfee)
+	if err != nil {
+		return errors.Wrap(err, "parsing fee granter")
+	}
+
+	// construct msg & tx and marshal.
+	msg := distribution.NewMsgWithdrawRewards(info.GetAddress())
+	tx := std.Tx{
+		Msgs:       []std.Msg{msg},
+		Fee:        fee,
+		Signatures: nil,
+		Memo:       cfg.RootCfg.Memo,
+	}
+
+	if cfg.RootCfg.Broadcast {
+		err := ExecSignAndBroadcast(cfg.RootCfg, args, tx, io)
+		if err != nil {
+			return err
+		}
+	} else {
+		io.Println(string(amino.MustMarshalJSON(tx)))
+	}
+	return nil
+}
@@ -0,0 +1,555 @@
+package keys
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/bip39"
+	"github.com/gnolang/gno/tm2/pkg/crypto/hd"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/keyerror"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ledger"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+)
+
+const keystoreFileExt = ".json"
+
+var errInvalidKeyName = errors.New("invalid key name")
+
+var _ Keybase = fileKeybase{}
+
+// fileKeybase stores each key in its own encrypted JSON file,
+// so keys can be backed up, compared and moved individually.
+// See keystoreFile for the file format.
+type fileKeybase struct {
+	dir   string
+	kdf   string
+	light bool
+}
+
+// FileKeybaseOption configures the file keybase
+type FileKeybaseOption func(*fileKeybase)
+
+// WithKDF sets the key derivation function used to encrypt
+// new keys (KDFScrypt or KDFArgon2id). Defaults to KDFScrypt
+func WithKDF(kdf string) FileKeybaseOption {
+	return func(kb *fileKeybase) {
+		kb.kdf = kdf
+	}
+}
+
+// WithLightKDF lowers the KDF cost parameters used to encrypt new keys.
+// It should only be used for testing, or on very constrained devices
+func WithLightKDF() FileKeybaseOption {
+	return func(kb *fileKeybase) {
+		kb.light = true
+	}
+}
+
+// NewFileKeybase creates a new keybase instance storing keys as files in dir
+func NewFileKeybase(dir string, opts ...FileKeybaseOption) (Keybase, error) {
+	kb := fileKeybase{
+		dir: dir,
+		kdf: KDFScrypt,
+	}
+
+	for _, opt := range opts {
+		opt(&kb)
+	}
+
+	if kb.kdf != KDFScrypt && kb.kdf != KDFArgon2id {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedKDF, kb.kdf)
+	}
+
+	if err := osm.EnsureDir(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("unable to create keystore directory: %w", err)
+	}
+
+	return kb, nil
+}
+
+// CreateAccount converts a mnemonic to a private key and persists it, encrypted with the given password.
+func (kb fileKeybase) CreateAccount(name, mnemonic, bip39Passwd, encryptPasswd string, account uint32, index uint32) (Info, error) {
+	coinType := crypto.CoinType
+	hdPath := hd.NewFundraiserParams(account, coinType, index)
+	return kb.CreateAccountBip44(name, mnemonic, bip39Passwd, encryptPasswd, *hdPath)
+}
+
+func (kb fileKeybase) CreateAccountBip44(name, mnemonic, bip39Passphrase, encryptPasswd string, params hd.BIP44Params) (Info, error) {
+	return kb.CreateAccountWithAlgo(name, mnemonic, bip39Passphrase, encryptPasswd, Secp256k1, params)
+}
+
+func (kb fileKeybase) CreateAccountWithAlgo(name, mnemonic, bip39Passphrase, encryptPasswd string, algo SigningAlgo, params hd.BIP44Params) (Info, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	// derive the key from the seed
+	derivedPriv, err := DerivePrivKey(algo, seed, params.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return kb.writeLocalKey(name, derivedPriv, encryptPasswd)
+}
+
+// CreateLedger creates a new locally-stored reference to a Ledger keypair
+func (kb fileKeybase) CreateLedger(name string, algo SigningAlgo, hrp string, account, index uint32) (Info, error) {
+	if algo != Secp256k1 {
+		return nil, ErrUnsupportedSigningAlgo
+	}
+
+	coinType := crypto.CoinType
+	hdPath := hd.NewFundraiserParams(account, coinType, index)
+	priv, _, err := ledger.NewPrivKeyLedgerSecp256k1(*hdPath, hrp)
+	if err != nil {
+		return nil, err
+	}
+
+	info := newLedgerInfo(name, priv.PubKey(), *hdPath)
+	if err := kb.writeInfo(name, info); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// CreateOffline creates a new reference to an offline keypair
+func (kb fileKeybase) CreateOffline(name string, pub crypto.PubKey) (Info, error) {
+	info := newOfflineInfo(name, pub)
+	if err := kb.writeInfo(name, info); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// CreateMulti creates a new reference to a multisig (offline) keypair
+func (kb fileKeybase) CreateMulti(name string, pub crypto.PubKey) (Info, error) {
+	info := NewMultiInfo(name, pub)
+	if err := kb.writeInfo(name, info); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// List returns the keys from storage in alphabetical order.
+func (kb fileKeybase) List() ([]Info, error) {
+	files, err := kb.readAll()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Info, 0, len(files))
+	for _, f := range files {
+		res = append(res, f.info)
+	}
+
+	return res, nil
+}
+
+// HasByNameOrAddress checks if a key with the name or bech32 string address is in the keybase.
+func (kb fileKeybase) HasByNameOrAddress(nameOrBech32 string) (bool, error) {
+	address, err := crypto.AddressFromBech32(nameOrBech32)
+	if err != nil {
+		return kb.HasByName(nameOrBech32)
+	}
+	return kb.HasByAddress(address)
+}
+
+// HasByName checks if a key with the name is in the keybase.
+func (kb fileKeybase) HasByName(name string) (bool, error) {
+	path, err := kb.keyPath(name)
+	if err != nil {
+		return false, nil
+	}
+
+	return osm.FileExists(path), nil
+}
+
+// HasByAddress checks if a key with the address is in the keybase.
+func (kb fileKeybase) HasByAddress(address crypto.Address) (bool, error) {
+	_, ok, err := kb.findByAddress(address)
+
+	return ok, err
+}
+
+// GetByNameOrAddress returns the public information about one key.
+func (kb fileKeybase) GetByNameOrAddress(nameOrBech32 string) (Info, error) {
+	addr, err := crypto.AddressFromBech32(nameOrBech32)
+	if err != nil {
+		return kb.GetByName(nameOrBech32)
+	}
+	return kb.GetByAddress(addr)
+}
+
+func (kb fileKeybase) GetByName(name string) (Info, error) {
+	f, err := kb.readByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.info, nil
+}
+
+func (kb fileKeybase) GetByAddress(address crypto.Address) (Info, error) {
+	f, ok, err := kb.findByAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, keyerror.NewErrKeyNotFound(fmt.Sprintf("key with address %s not found", address))
+	}
+
+	return f.info, nil
+}
+
+// Sign signs the msg with the named key.
+// It returns an error if the key doesn't exist or the decryption fails.
+func (kb fileKeybase) Sign(nameOrBech32, passphrase string, msg []byte) ([]byte, crypto.PubKey, error) {
+	f, err := kb.read(nameOrBech32)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var priv crypto.PrivKey
+
+	switch info := f.info.(type) {
+	case localInfo:
+		priv, err = f.privKey(nameOrBech32, passphrase)
+		if err != nil {
+			return nil, nil, err
+		}
+
+	case ledgerInfo:
+		priv, err = ledger.NewPrivKeyLedgerSecp256k1Unsafe(info.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+	default:
+		return nil, nil, fmt.Errorf("cannot sign with key or addr %s", nameOrBech32)
+	}
+
+	sig, err := priv.Sign(msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sig, priv.PubKey(), nil
+}
+
+// Verify verifies the sig+msg with the named key.
+// It returns an error if the key doesn't exist or verification fails.
+func (kb fileKeybase) Verify(nameOrBech32 string, msg []byte, sig []byte) error {
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return err
+	}
+
+	if !info.GetPubKey().VerifyBytes(msg, sig) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func (kb fileKeybase) ImportPrivKey(name string, key crypto.PrivKey, encryptPass string) error {
+	if _, err := kb.GetByNameOrAddress(name); err == nil {
+		return fmt.Errorf("%w: %s", errCannotOverwrite, name)
+	}
+
+	_, err := kb.writeLocalKey(name, key, encryptPass)
+
+	return err
+}
+
+func (kb fileKeybase) ExportPrivKey(nameOrBech32 string, passphrase string) (crypto.PrivKey, error) {
+	f, err := kb.read(nameOrBech32)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := f.info.(localInfo); !ok {
+		return nil, errors.New("only works on local private keys")
+	}
+
+	return f.privKey(nameOrBech32, passphrase)
+}
+
+// Delete removes the key file, but we must present the
+// proper passphrase before deleting it (for security).
+// Passphrase is ignored when deleting references to
+// offline and Ledger / HW wallet keys.
+func (kb fileKeybase) Delete(nameOrBech32, passphrase string, skipPass bool) error {
+	f, err := kb.read(nameOrBech32)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := f.info.(localInfo); ok && !skipPass {
+		if _, err := f.privKey(nameOrBech32, passphrase); err != nil {
+			return err
+		}
+	}
+
+	return os.Remove(f.path)
+}
+
+// Rotate changes the passphrase with which an already stored key is encrypted.
+func (kb fileKeybase) Rotate(nameOrBech32, oldpass string, getNewpass func() (string, error)) error {
+	f, err := kb.read(nameOrBech32)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := f.info.(localInfo); !ok {
+		return fmt.Errorf("locally stored key required. Received: %v", reflect.TypeOf(f.info).String())
+	}
+
+	key, err := f.privKey(nameOrBech32, oldpass)
+	if err != nil {
+		return err
+	}
+
+	newpass, err := getNewpass()
+	if err != nil {
+		return err
+	}
+
+	_, err = kb.writeLocalKey(f.info.GetName(), key, newpass)
+
+	return err
+}
+
+// CloseDB is a no-op, as key files are not kept open.
+func (kb fileKeybase) CloseDB() {}
+
+// keyFile is a parsed keystore file
+type keyFile struct {
+	path   string
+	info   Info
+	crypto *keystoreCrypto
+}
+
+// privKey decrypts the private key of a local key file
+func (f keyFile) privKey(nameOrBech32, passphrase string) (crypto.PrivKey, error) {
+	if f.crypto == nil {
+		return nil, fmt.Errorf("%w: %s", errKeyNotAvailable, nameOrBech32)
+	}
+
+	return decryptPrivKey(f.crypto, passphrase)
+}
+
+func (kb fileKeybase) writeLocalKey(name string, priv crypto.PrivKey, passphrase string) (Info, error) {
+	params, err := newKDFParams(kb.kdf, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	if kb.light {
+		params.N, params.T, params.M = params.N>>4, min(params.T, 1), params.M>>4
+	}
+
+	kc, err := encryptPrivKey(priv, passphrase, kb.kdf, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// the armor is kept out of the info: the key is in the crypto section
+	info := newLocalInfo(name, priv.PubKey(), "")
+	if err := kb.write(name, info, kc); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+func (kb fileKeybase) writeInfo(name string, info Info) error {
+	return kb.write(name, info, nil)
+}
+
+func (kb fileKeybase) write(name string, info Info, kc *keystoreCrypto) error {
+	path, err := kb.keyPath(name)
+	if err != nil {
+		return err
+	}
+
+	infobz, err := amino.MarshalJSONAny(info)
+	if err != nil {
+		return err
+	}
+
+	bz, err := json.MarshalIndent(keystoreFile{
+		Version: keystoreVersion,
+		ID:      newKeystoreID(),
+		Address: info.GetAddress().String(),
+		Name:    name,
+		Info:    infobz,
+		Crypto:  kc,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Enforce 1-to-1 name to address. Remove the key with the same address
+	// stored under another name, only once the new key is written so that
+	// the key is never lost on failure
+	f, ok, err := kb.findByAddress(info.GetAddress())
+	if err != nil {
+		return err
+	}
+
+	if err := osm.WriteFileAtomic(path, bz, 0o600); err != nil {
+		return err
+	}
+
+	if ok && f.path != path {
+		return os.Remove(f.path)
+	}
+
+	return nil
+}
+
+// keyPath returns the path of the file for the named key
+func (kb fileKeybase) keyPath(name string) (string, error) {
+	if name == "" ||
+		name == "." ||
+		name == ".." ||
+		strings.ContainsAny(name, `/\`) ||
+		strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("%w: %q", errInvalidKeyName, name)
+	}
+
+	return filepath.Join(kb.dir, name+keystoreFileExt), nil
+}
+
+// read reads the key file by name or bech32 address
+func (kb fileKeybase) read(nameOrBech32 string) (keyFile, error) {
+	addr, err := crypto.AddressFromBech32(nameOrBech32)
+	if err != nil {
+		return kb.readByName(nameOrBech32)
+	}
+
+	f, ok, err := kb.findByAddress(addr)
+	if err != nil {
+		return keyFile{}, err
+	}
+
+	if !ok {
+		return keyFile{}, keyerror.NewErrKeyNotFound(fmt.Sprintf("key with address %s not found", addr))
+	}
+
+	return f, nil
+}
+
+func (kb fileKeybase) readByName(name string) (keyFile, error) {
+	path, err := kb.keyPath(name)
+	if err != nil {
+		return keyFile{}, keyerror.NewErrKeyNotFound(name)
+	}
+
+	if !osm.FileExists(path) {
+		return keyFile{}, keyerror.NewErrKeyNotFound(name)
+	}
+
+	return readKeyFile(path)
+}
+
+// findByAddress looks up the key file with the given address
+func (kb fileKeybase) findByAddress(address crypto.Address) (keyFile, bool, error) {
+	files, err := kb.readAll()
+	if err != nil {
+		return keyFile{}, false, err
+	}
+
+	for _, f := range files {
+		if f.info.GetAddress() == address {
+			return f, true, nil
+		}
+	}
+
+	return keyFile{}, false, nil
+}
+
+// readAll reads all the key files, in alphabetical order
+func (kb fileKeybase) readAll() ([]keyFile, error) {
+	entries, err := os.ReadDir(kb.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() ||
+			strings.HasPrefix(name, ".") ||
+			filepath.Ext(name) != keystoreFileExt {
+			continue
+		}
+
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := make([]keyFile, 0, len(names))
+	for _, name := range names {
+		f, err := readKeyFile(filepath.Join(kb.dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, f)
+	}
+
+	return files, nil
+}
+
+func readKeyFile(path string) (keyFile, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return keyFile{}, err
+	}
+
+	var ks keystoreFile
+	if err := json.Unmarshal(bz, &ks); err != nil {
+		return keyFile{}, fmt.Errorf("unable to parse key file %s: %w", path, err)
+	}
+
+	if ks.Version != keystoreVersion {
+		return keyFile{}, fmt.Errorf("unsupported key file version %d: %s", ks.Version, path)
+	}
+
+	if len(ks.Info) == 0 {
+		return keyFile{}, fmt.Errorf("missing key info in key file %s", path)
+	}
+
+	var info Info
+	if err := amino.UnmarshalJSON(ks.Info, &info); err != nil {
+		return keyFile{}, fmt.Errorf("unable to parse key info %s: %w", path, err)
+	}
+
+	// dereference, so infos have the same types as in the DB keybase
+	if v := reflect.ValueOf(info); v.Kind() == reflect.Ptr {
+		info = v.Elem().Interface().(Info)
+	}
+
+	if _, ok := info.(localInfo); ok && ks.Crypto == nil {
+		return keyFile{}, fmt.Errorf("missing crypto section in local key file %s", path)
+	}
+
+	return keyFile{
+		path:   path,
+		info:   info,
+		crypto: ks.Crypto,
+	}, nil
+}
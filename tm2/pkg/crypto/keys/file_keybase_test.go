@@ -0,0 +1,433 @@
+package keys
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/hd"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/keyerror"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+)
+
+const testMnemonic = `lounge napkin all odor tilt dove win inject sleep jazz uncover traffic hint require cargo arm rocket round scan bread report squirrel step lake`
+
+func newTestFileKeybase(t *testing.T, opts ...FileKeybaseOption) (Keybase, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	kb, err := NewFileKeybase(dir, append([]FileKeybaseOption{WithLightKDF()}, opts...)...)
+	require.NoError(t, err)
+
+	return kb, dir
+}
+
+func TestFileKeybase_KeyManagement(t *testing.T) {
+	t.Parallel()
+
+	kb, dir := newTestFileKeybase(t)
+
+	n1, n2 := "personal", "business"
+	p1, p2 := "1234", "really-secure!@#$"
+
+	l, err := kb.List()
+	require.NoError(t, err)
+	assert.Empty(t, l)
+
+	i1, err := kb.CreateAccount(n1, testMnemonic, "", p1, 0, 0)
+	require.NoError(t, err)
+	i2, err := kb.CreateAccount(n2, testMnemonic, "", p2, 0, 1)
+	require.NoError(t, err)
+
+	// one file per key
+	assert.FileExists(t, filepath.Join(dir, n1+keystoreFileExt))
+	assert.FileExists(t, filepath.Join(dir, n2+keystoreFileExt))
+
+	// lookups
+	has, err := kb.HasByName(n1)
+	require.NoError(t, err)
+	assert.True(t, has)
+	has, err = kb.HasByAddress(i2.GetAddress())
+	require.NoError(t, err)
+	assert.True(t, has)
+	has, err = kb.HasByNameOrAddress(crypto.AddressToBech32(i1.GetAddress()))
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	info, err := kb.GetByAddress(i1.GetAddress())
+	require.NoError(t, err)
+	assert.Equal(t, n1, info.GetName())
+	assert.Equal(t, TypeLocal, info.GetType())
+	assert.Equal(t, i1.GetPubKey(), info.GetPubKey())
+
+	_, err = kb.GetByName("unknown")
+	assert.True(t, keyerror.IsErrKeyNotFound(err))
+
+	// list is in alphabetical order
+	l, err = kb.List()
+	require.NoError(t, err)
+	require.Len(t, l, 2)
+	assert.Equal(t, n2, l[0].GetName())
+	assert.Equal(t, n1, l[1].GetName())
+
+	// sign and verify
+	msg := []byte("hello")
+	sig, pub, err := kb.Sign(n1, p1, msg)
+	require.NoError(t, err)
+	assert.Equal(t, i1.GetPubKey(), pub)
+	require.NoError(t, kb.Verify(n1, msg, sig))
+
+	_, _, err = kb.Sign(n1, p2, msg)
+	assert.True(t, keyerror.IsErrWrongPassword(err))
+
+	// rotate
+	require.NoError(t, kb.Rotate(n1, p1, func() (string, error) { return p2, nil }))
+	_, _, err = kb.Sign(n1, p1, msg)
+	assert.True(t, keyerror.IsErrWrongPassword(err))
+	_, _, err = kb.Sign(n1, p2, msg)
+	require.NoError(t, err)
+
+	// export and import
+	priv, err := kb.ExportPrivKey(n1, p2)
+	require.NoError(t, err)
+	assert.Equal(t, i1.GetPubKey(), priv.PubKey())
+	assert.Error(t, kb.ImportPrivKey(n1, priv, p1))
+
+	// delete
+	assert.True(t, keyerror.IsErrWrongPassword(kb.Delete(n1, p1, false)))
+	require.NoError(t, kb.Delete(n1, p2, false))
+	assert.NoFileExists(t, filepath.Join(dir, n1+keystoreFileExt))
+
+	require.NoError(t, kb.ImportPrivKey(n1, priv, p1))
+	_, _, err = kb.Sign(n1, p1, msg)
+	require.NoError(t, err)
+}
+
+func TestFileKeybase_OfflineMulti(t *testing.T) {
+	t.Parallel()
+
+	kb, _ := newTestFileKeybase(t)
+
+	pub := ed25519.GenPrivKey().PubKey()
+	i, err := kb.CreateOffline("offline", pub)
+	require.NoError(t, err)
+
+	info, err := kb.GetByName("offline")
+	require.NoError(t, err)
+	assert.Equal(t, TypeOffline, info.GetType())
+	assert.Equal(t, i.GetPubKey(), info.GetPubKey())
+
+	_, _, err = kb.Sign("offline", "", []byte("msg"))
+	assert.Error(t, err)
+
+	_, err = kb.ExportPrivKey("offline", "")
+	assert.Error(t, err)
+
+	require.NoError(t, kb.Delete("offline", "", false))
+}
+
+func TestFileKeybase_SameAddress(t *testing.T) {
+	t.Parallel()
+
+	kb, dir := newTestFileKeybase(t)
+
+	_, err := kb.CreateAccount("old", testMnemonic, "", "pass", 0, 0)
+	require.NoError(t, err)
+
+	// the same key under a new name replaces the old one
+	_, err = kb.CreateAccount("new", testMnemonic, "", "pass", 0, 0)
+	require.NoError(t, err)
+
+	l, err := kb.List()
+	require.NoError(t, err)
+	require.Len(t, l, 1)
+	assert.Equal(t, "new", l[0].GetName())
+	assert.NoFileExists(t, filepath.Join(dir, "old"+keystoreFileExt))
+}
+
+func TestFileKeybase_SameAddressWriteError(t *testing.T) {
+	t.Parallel()
+
+	kb, dir := newTestFileKeybase(t)
+
+	_, err := kb.CreateAccount("old", testMnemonic, "", "pass", 0, 0)
+	require.NoError(t, err)
+
+	// a non-empty directory in place of the new key file makes the write fail
+	newPath := filepath.Join(dir, "new"+keystoreFileExt)
+	require.NoError(t, os.MkdirAll(filepath.Join(newPath, "dir"), 0o700))
+
+	_, err = kb.CreateAccount("new", testMnemonic, "", "pass", 0, 0)
+	require.Error(t, err)
+
+	// the old key is kept
+	assert.FileExists(t, filepath.Join(dir, "old"+keystoreFileExt))
+	_, _, err = kb.Sign("old", "pass", []byte("msg"))
+	require.NoError(t, err)
+}
+
+func TestFileKeybase_InvalidName(t *testing.T) {
+	t.Parallel()
+
+	kb, _ := newTestFileKeybase(t)
+
+	for _, name := range []string{"", ".", "..", ".hidden", "a/b", `a\b`, "../escape"} {
+		_, err := kb.CreateAccount(name, testMnemonic, "", "pass", 0, 0)
+		assert.ErrorIs(t, err, errInvalidKeyName, name)
+	}
+}
+
+func TestFileKeybase_SigningAlgos(t *testing.T) {
+	t.Parallel()
+
+	kb, _ := newTestFileKeybase(t)
+	params := *hd.NewFundraiserParams(0, crypto.CoinType, 0)
+
+	for _, algo := range []SigningAlgo{Ed25519, Secp256r1} {
+		info, err := kb.CreateAccountWithAlgo(string(algo), testMnemonic, "", "pass", algo, params)
+		require.NoError(t, err)
+
+		priv, err := kb.ExportPrivKey(string(algo), "pass")
+		require.NoError(t, err)
+		assert.True(t, priv.PubKey().Equals(info.GetPubKey()))
+
+		stored, err := kb.GetByAddress(info.GetAddress())
+		require.NoError(t, err)
+		assert.True(t, stored.GetPubKey().Equals(info.GetPubKey()))
+	}
+}
+
+func TestFileKeybase_Argon2id(t *testing.T) {
+	t.Parallel()
+
+	kb, dir := newTestFileKeybase(t, WithKDF(KDFArgon2id))
+
+	_, err := kb.CreateAccount("key", testMnemonic, "", "pass", 0, 0)
+	require.NoError(t, err)
+
+	var ks keystoreFile
+	bz, err := os.ReadFile(filepath.Join(dir, "key"+keystoreFileExt))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bz, &ks))
+	assert.Equal(t, KDFArgon2id, ks.Crypto.KDF)
+
+	_, _, err = kb.Sign("key", "pass", []byte("msg"))
+	require.NoError(t, err)
+
+	_, err = NewFileKeybase(dir, WithKDF("pbkdf2"))
+	assert.ErrorIs(t, err, errUnsupportedKDF)
+}
+
+func TestFileKeybase_FileFormat(t *testing.T) {
+	t.Parallel()
+
+	kb, dir := newTestFileKeybase(t)
+
+	info, err := kb.CreateAccount("key", testMnemonic, "", "pass", 0, 0)
+	require.NoError(t, err)
+
+	var ks keystoreFile
+	bz, err := os.ReadFile(filepath.Join(dir, "key"+keystoreFileExt))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bz, &ks))
+
+	assert.Equal(t, 3, ks.Version)
+	assert.Len(t, ks.ID, 36)
+	assert.Equal(t, "key", ks.Name)
+	assert.Equal(t, info.GetAddress().String(), ks.Address)
+	assert.NotContains(t, string(ks.Info), "PRIVATE KEY")
+
+	require.NotNil(t, ks.Crypto)
+	assert.Equal(t, CipherXChaCha20Poly1305, ks.Crypto.Cipher)
+	assert.Equal(t, KDFScrypt, ks.Crypto.KDF)
+	assert.Equal(t, 64, ks.Crypto.KDFParams.DKLen)
+}
+
+func TestDecryptPrivKey_AES128CTR(t *testing.T) {
+	t.Parallel()
+
+	// Test vector from the Web3 Secret Storage Definition (scrypt)
+	kc := &keystoreCrypto{
+		Cipher:     CipherAES128CTR,
+		CipherText: "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+		CipherParams: keystoreCipherParams{
+			IV: "83dbcc02d8ccb40e466191a123791e0e",
+		},
+		KDF: KDFScrypt,
+		KDFParams: keystoreKDFParams{
+			DKLen: 32,
+			N:     262144,
+			P:     8,
+			R:     1,
+			Salt:  "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19",
+		},
+		MAC: "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097",
+	}
+
+	priv, err := decryptPrivKey(kc, "testpassword")
+	require.NoError(t, err)
+
+	key, ok := priv.(secp256k1.PrivKeySecp256k1)
+	require.True(t, ok)
+	assert.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", hex.EncodeToString(key[:]))
+
+	_, err = decryptPrivKey(kc, "wrongpassword")
+	assert.True(t, keyerror.IsErrWrongPassword(err))
+}
+
+func TestDecryptPrivKey_InvalidArgon2Params(t *testing.T) {
+	t.Parallel()
+
+	valid := keystoreKDFParams{
+		DKLen: 32,
+		T:     1,
+		M:     64,
+		P:     1,
+		Salt:  "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19",
+	}
+
+	for name, update := range map[string]func(p *keystoreKDFParams){
+		"zero time":       func(p *keystoreKDFParams) { p.T = 0 },
+		"negative time":   func(p *keystoreKDFParams) { p.T = -1 },
+		"zero memory":     func(p *keystoreKDFParams) { p.M = 0 },
+		"overflow memory": func(p *keystoreKDFParams) { p.M = math.MaxUint32 + 1 },
+		"memory over cap": func(p *keystoreKDFParams) { p.M = maxArgon2MemKB + 1 },
+		"time over cap":   func(p *keystoreKDFParams) { p.T = maxArgon2Time + 1 },
+		"zero lanes":      func(p *keystoreKDFParams) { p.P = 0 },
+		"overflow lanes":  func(p *keystoreKDFParams) { p.P = 256 },
+		"overflow dklen":  func(p *keystoreKDFParams) { p.DKLen = math.MaxUint32 + 1 },
+		"dklen over cap":  func(p *keystoreKDFParams) { p.DKLen = maxKDFKeyLen + 1 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			params := valid
+			update(&params)
+
+			kc := &keystoreCrypto{
+				Cipher:     CipherAES128CTR,
+				CipherText: "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+				CipherParams: keystoreCipherParams{
+					IV: "83dbcc02d8ccb40e466191a123791e0e",
+				},
+				KDF:       KDFArgon2id,
+				KDFParams: params,
+				MAC:       "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097",
+			}
+
+			_, err := decryptPrivKey(kc, "testpassword")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid argon2id")
+		})
+	}
+}
+
+func TestDecryptPrivKey_InvalidScryptParams(t *testing.T) {
+	t.Parallel()
+
+	valid := keystoreKDFParams{
+		DKLen: 32,
+		N:     1 << 10,
+		R:     8,
+		P:     1,
+		Salt:  "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19",
+	}
+
+	for name, update := range map[string]func(p *keystoreKDFParams){
+		"zero N":          func(p *keystoreKDFParams) { p.N = 0 },
+		"N over cap":      func(p *keystoreKDFParams) { p.N = maxScryptN << 1 },
+		"zero r":          func(p *keystoreKDFParams) { p.R = 0 },
+		"r over cap":      func(p *keystoreKDFParams) { p.R = maxScryptR + 1 },
+		"memory over cap": func(p *keystoreKDFParams) { p.N, p.R = maxScryptN, 16 },
+		"zero p":          func(p *keystoreKDFParams) { p.P = 0 },
+		"p over cap":      func(p *keystoreKDFParams) { p.P = maxScryptP + 1 },
+		"dklen over cap":  func(p *keystoreKDFParams) { p.DKLen = maxKDFKeyLen + 1 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			params := valid
+			update(&params)
+
+			kc := &keystoreCrypto{
+				Cipher:     CipherAES128CTR,
+				CipherText: "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+				CipherParams: keystoreCipherParams{
+					IV: "83dbcc02d8ccb40e466191a123791e0e",
+				},
+				KDF:       KDFScrypt,
+				KDFParams: params,
+				MAC:       "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097",
+			}
+
+			_, err := decryptPrivKey(kc, "testpassword")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid scrypt")
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	src := NewInMemory()
+	dst, _ := newTestFileKeybase(t)
+
+	passwords := map[string]string{
+		"key1": "pass1",
+		"key2": "pass2",
+	}
+
+	i1, err := src.CreateAccount("key1", testMnemonic, "", passwords["key1"], 0, 0)
+	require.NoError(t, err)
+	_, err = src.CreateAccount("key2", testMnemonic, "", passwords["key2"], 0, 1)
+	require.NoError(t, err)
+	_, err = src.CreateOffline("offline", ed25519.GenPrivKey().PubKey())
+	require.NoError(t, err)
+
+	getPassword := func(name string) (string, error) {
+		return passwords[name], nil
+	}
+
+	infos, err := Migrate(src, dst, getPassword)
+	require.NoError(t, err)
+	assert.Len(t, infos, 3)
+
+	l, err := dst.List()
+	require.NoError(t, err)
+	require.Len(t, l, 3)
+
+	// keys are encrypted with the same password
+	msg := []byte("msg")
+	sig, _, err := dst.Sign("key1", passwords["key1"], msg)
+	require.NoError(t, err)
+	require.NoError(t, src.Verify(i1.GetName(), msg, sig))
+
+	info, err := dst.GetByName("offline")
+	require.NoError(t, err)
+	assert.Equal(t, TypeOffline, info.GetType())
+
+	// and back to a DB keybase
+	back := NewInMemory()
+	_, err = Migrate(dst, back, getPassword)
+	require.NoError(t, err)
+
+	_, _, err = back.Sign("key2", passwords["key2"], msg)
+	require.NoError(t, err)
+
+	// existing keys are not overwritten
+	_, err = Migrate(src, dst, getPassword)
+	assert.ErrorIs(t, err, errCannotOverwrite)
+
+	// wrong password
+	_, err = Migrate(src, NewInMemory(), func(string) (string, error) { return "wrong", nil })
+	assert.True(t, keyerror.IsErrWrongPassword(err))
+}
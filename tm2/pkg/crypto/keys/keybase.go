@@ -11,7 +11,6 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys/armor"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys/keyerror"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ledger"
-	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/errors"
@@ -95,12 +94,16 @@ func (kb dbKeybase) CreateAccount(name, mnemonic, bip39Passwd, encryptPasswd str
 }
 
 func (kb dbKeybase) CreateAccountBip44(name, mnemonic, bip39Passphrase, encryptPasswd string, params hd.BIP44Params) (info Info, err error) {
+	return kb.CreateAccountWithAlgo(name, mnemonic, bip39Passphrase, encryptPasswd, Secp256k1, params)
+}
+
+func (kb dbKeybase) CreateAccountWithAlgo(name, mnemonic, bip39Passphrase, encryptPasswd string, algo SigningAlgo, params hd.BIP44Params) (info Info, err error) {
 	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passphrase)
 	if err != nil {
 		return
 	}
 
-	info, err = kb.persistDerivedKey(seed, encryptPasswd, name, params.String())
+	info, err = kb.persistDerivedKey(seed, encryptPasswd, name, algo, params.String())
 	return
 }
 
@@ -135,17 +138,16 @@ func (kb dbKeybase) CreateMulti(name string, pub crypto.PubKey) (Info, error) {
 	return kb.writeMultisigKey(name, pub)
 }
 
-func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath string) (Info, error) {
+func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name string, algo SigningAlgo, fullHdPath string) (Info, error) {
 	// create master key and derive first key:
-	masterPriv, ch := hd.ComputeMastersFromSeed(seed)
-	derivedPriv, err := hd.DerivePrivateKeyForPath(masterPriv, ch, fullHdPath)
+	derivedPriv, err := DerivePrivKey(algo, seed, fullHdPath)
 	if err != nil {
 		return nil, err
 	}
 
 	// use possibly blank password to encrypt the private
 	// key and store it. User must enforce good passwords.
-	return kb.writeLocalKey(name, secp256k1.PrivKeySecp256k1(derivedPriv), passwd)
+	return kb.writeLocalKey(name, derivedPriv, passwd)
 }
 
 // List returns the keys from storage in alphabetical order.
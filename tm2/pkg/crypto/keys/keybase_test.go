@@ -9,7 +9,10 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/hd"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys/keyerror"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256r1"
 )
 
 func TestCreateAccountInvalidMnemonic(t *testing.T) {
@@ -451,3 +454,63 @@ func TestKeybase_ExportPrivKey(t *testing.T) {
 		assert.True(t, key.Equals(exportedKey))
 	})
 }
+
+func TestCreateAccountWithAlgo(t *testing.T) {
+	t.Parallel()
+
+	mnemonic := `lounge napkin all odor tilt dove win inject sleep jazz uncover traffic hint require cargo arm rocket round scan bread report squirrel step lake`
+	params := *hd.NewFundraiserParams(0, crypto.CoinType, 0)
+
+	testCases := []struct {
+		algo   SigningAlgo
+		pubKey crypto.PubKey
+	}{
+		{Secp256k1, secp256k1.PubKeySecp256k1{}},
+		{Ed25519, ed25519.PubKeyEd25519{}},
+		{Secp256r1, secp256r1.PubKeySecp256r1{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.algo), func(t *testing.T) {
+			t.Parallel()
+
+			cstore := NewInMemory()
+
+			info, err := cstore.CreateAccountWithAlgo("key", mnemonic, "", "pass", tc.algo, params)
+			require.NoError(t, err)
+			assert.IsType(t, tc.pubKey, info.GetPubKey())
+			assert.Equal(t, info.GetPubKey().Address(), info.GetAddress())
+
+			// The derivation is deterministic
+			other, err := NewInMemory().CreateAccountWithAlgo("key", mnemonic, "", "pass", tc.algo, params)
+			require.NoError(t, err)
+			assert.Equal(t, info.GetAddress(), other.GetAddress())
+
+			msg := []byte("hello")
+			sig, pub, err := cstore.Sign("key", "pass", msg)
+			require.NoError(t, err)
+			assert.True(t, pub.VerifyBytes(msg, sig))
+			require.NoError(t, cstore.Verify("key", msg, sig))
+		})
+	}
+
+	t.Run("secp256k1 default", func(t *testing.T) {
+		t.Parallel()
+
+		cstore := NewInMemory()
+
+		info, err := cstore.CreateAccount("key", mnemonic, "", "pass", 0, 0)
+		require.NoError(t, err)
+
+		other, err := cstore.CreateAccountWithAlgo("other", mnemonic, "", "pass", Secp256k1, params)
+		require.NoError(t, err)
+		assert.Equal(t, info.GetAddress(), other.GetAddress())
+	})
+
+	t.Run("unsupported algo", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewInMemory().CreateAccountWithAlgo("key", mnemonic, "", "pass", SigningAlgo("sr25519"), params)
+		assert.Error(t, err)
+	})
+}
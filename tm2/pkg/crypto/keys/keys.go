@@ -1,5 +1,16 @@
 package keys
 
+import (
+	stded25519 "crypto/ed25519"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/hd"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256r1"
+)
+
 // SigningAlgo defines an algorithm to derive key-pairs which can be used for cryptographic signing.
 type SigningAlgo string
 
@@ -7,6 +18,46 @@ const (
 	// Secp256k1 uses the Bitcoin secp256k1 ECDSA parameters.
 	Secp256k1 = SigningAlgo("secp256k1")
 	// Ed25519 represents the Ed25519 signature system.
-	// It is currently not supported for end-user keys (wallets/ledgers).
+	// Keys are derived from the mnemonic using SLIP-0010, with a fully hardened path.
+	// It is not supported for ledgers.
 	Ed25519 = SigningAlgo("ed25519")
+	// Secp256r1 uses the NIST P-256 ECDSA parameters, as used by passkeys (WebAuthn).
+	// Keys are derived from the mnemonic using SLIP-0010.
+	// It is not supported for ledgers.
+	Secp256r1 = SigningAlgo("secp256r1")
 )
+
+// DerivePrivKey derives the private key for the given algo
+// by following the BIP 32/44 path from the BIP 39 seed.
+// Secp256k1 keys use BIP 32, other algos use SLIP-0010.
+func DerivePrivKey(algo SigningAlgo, seed []byte, hdPath string) (crypto.PrivKey, error) {
+	switch algo {
+	case Secp256k1:
+		masterPriv, ch := hd.ComputeMastersFromSeed(seed)
+		derivedPriv, err := hd.DerivePrivateKeyForPath(masterPriv, ch, hdPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return secp256k1.PrivKeySecp256k1(derivedPriv), nil
+	case Ed25519:
+		derivedPriv, err := hd.DeriveSLIP10PrivateKeyForPath(hd.CurveEd25519, seed, hdPath)
+		if err != nil {
+			return nil, err
+		}
+
+		var priv ed25519.PrivKeyEd25519
+		copy(priv[:], stded25519.NewKeyFromSeed(derivedPriv[:]))
+
+		return priv, nil
+	case Secp256r1:
+		derivedPriv, err := hd.DeriveSLIP10PrivateKeyForPath(hd.CurveNist256p1, seed, hdPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return secp256r1.PrivKeySecp256r1(derivedPriv), nil
+	default:
+		return nil, fmt.Errorf("unsupported signing algo: %s", algo)
+	}
+}
@@ -0,0 +1,308 @@
+package keys
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/keyerror"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/crypto/xchacha20poly1305"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+// Keystore file format.
+// The layout is based on the Web3 Secret Storage Definition (version 3),
+// but the files are not compatible with the v3 tools:
+//   - the address is bech32-encoded
+//   - the name and the public key Info are stored alongside the key
+//   - keys are encrypted with xchacha20-poly1305,
+//     and can be derived using argon2id
+//
+// Standard v3 files (aes-128-ctr, scrypt) can be read, but not written.
+const (
+	keystoreVersion = 3
+
+	CipherXChaCha20Poly1305 = "xchacha20-poly1305"
+	CipherAES128CTR         = "aes-128-ctr"
+
+	KDFScrypt   = "scrypt"
+	KDFArgon2id = "argon2id"
+)
+
+// Default KDF parameters
+const (
+	scryptN     = 1 << 16
+	scryptR     = 8
+	scryptP     = 1
+	argon2Time  = 3
+	argon2MemKB = 64 * 1024
+	argon2Lanes = 4
+	kdfSaltLen  = 32
+)
+
+// Maximum KDF parameters, so a keystore file can't make
+// the key derivation use an unbounded amount of memory or time
+const (
+	maxScryptN     = 1 << 20
+	maxScryptR     = 32
+	maxScryptP     = 16
+	maxArgon2MemKB = 1 << 20 // 1 GiB
+	maxArgon2Time  = 64
+	maxKDFMemory   = 1 << 30 // 1 GiB
+	maxKDFKeyLen   = 64
+)
+
+var (
+	errUnsupportedCipher = errors.New("unsupported keystore cipher")
+	errUnsupportedKDF    = errors.New("unsupported keystore kdf")
+)
+
+// keystoreFile is a single key, as stored on disk
+type keystoreFile struct {
+	Version int             `json:"version"`
+	ID      string          `json:"id"`
+	Address string          `json:"address"`
+	Name    string          `json:"name"`
+	Info    json.RawMessage `json:"info"`
+	// Crypto is only set for local keys
+	Crypto *keystoreCrypto `json:"crypto,omitempty"`
+}
+
+type keystoreCrypto struct {
+	Cipher       string               `json:"cipher"`
+	CipherText   string               `json:"ciphertext"`
+	CipherParams keystoreCipherParams `json:"cipherparams"`
+	KDF          string               `json:"kdf"`
+	KDFParams    keystoreKDFParams    `json:"kdfparams"`
+	MAC          string               `json:"mac"`
+}
+
+type keystoreCipherParams struct {
+	IV string `json:"iv"`
+}
+
+type keystoreKDFParams struct {
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+
+	// scrypt
+	N int `json:"n,omitempty"`
+	R int `json:"r,omitempty"`
+
+	// argon2id
+	T int `json:"t,omitempty"`
+	M int `json:"m,omitempty"`
+
+	// scrypt parallelization, argon2id lanes
+	P int `json:"p,omitempty"`
+}
+
+// deriveKey derives the encryption key from the passphrase
+func (p keystoreKDFParams) deriveKey(kdf, passphrase string) ([]byte, error) {
+	salt, err := hex.DecodeString(p.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kdf salt: %w", err)
+	}
+
+	switch kdf {
+	case KDFScrypt:
+		// scrypt uses 128 * N * R bytes of memory
+		if p.N <= 1 || p.N > maxScryptN {
+			return nil, fmt.Errorf("invalid scrypt N: %d", p.N)
+		}
+		if p.R <= 0 || p.R > maxScryptR || 128*p.N*p.R > maxKDFMemory {
+			return nil, fmt.Errorf("invalid scrypt r: %d", p.R)
+		}
+		if p.P <= 0 || p.P > maxScryptP {
+			return nil, fmt.Errorf("invalid scrypt p: %d", p.P)
+		}
+		if p.DKLen <= 0 || p.DKLen > maxKDFKeyLen {
+			return nil, fmt.Errorf("invalid scrypt key length: %d", p.DKLen)
+		}
+
+		return scrypt.Key([]byte(passphrase), salt, p.N, p.R, p.P, p.DKLen)
+	case KDFArgon2id:
+		// argon2.IDKey panics on zero rounds or lanes, and the parameters
+		// read from disk must fit in their unsigned types
+		if p.T <= 0 || p.T > maxArgon2Time {
+			return nil, fmt.Errorf("invalid argon2id time: %d", p.T)
+		}
+		if p.M <= 0 || p.M > maxArgon2MemKB {
+			return nil, fmt.Errorf("invalid argon2id memory: %d", p.M)
+		}
+		if p.P <= 0 || p.P > math.MaxUint8 {
+			return nil, fmt.Errorf("invalid argon2id lanes: %d", p.P)
+		}
+		if p.DKLen <= 0 || p.DKLen > maxKDFKeyLen {
+			return nil, fmt.Errorf("invalid argon2id key length: %d", p.DKLen)
+		}
+
+		return argon2.IDKey(
+			[]byte(passphrase),
+			salt,
+			uint32(p.T),
+			uint32(p.M),
+			uint8(p.P),
+			uint32(p.DKLen),
+		), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedKDF, kdf)
+	}
+}
+
+// newKDFParams returns the default parameters for the given kdf, with a random salt
+func newKDFParams(kdf string, dkLen int) (keystoreKDFParams, error) {
+	params := keystoreKDFParams{
+		DKLen: dkLen,
+		Salt:  hex.EncodeToString(crypto.CRandBytes(kdfSaltLen)),
+	}
+
+	switch kdf {
+	case KDFScrypt:
+		params.N, params.R, params.P = scryptN, scryptR, scryptP
+	case KDFArgon2id:
+		params.T, params.M, params.P = argon2Time, argon2MemKB, argon2Lanes
+	default:
+		return params, fmt.Errorf("%w: %s", errUnsupportedKDF, kdf)
+	}
+
+	return params, nil
+}
+
+// keystoreMAC computes the v3 MAC of the ciphertext
+func keystoreMAC(dk, cipherText []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(dk[16:32])
+	h.Write(cipherText)
+
+	return h.Sum(nil)
+}
+
+// encryptPrivKey encrypts the private key with the passphrase,
+// using xchacha20-poly1305 and the given kdf parameters
+func encryptPrivKey(priv crypto.PrivKey, passphrase, kdf string, params keystoreKDFParams) (*keystoreCrypto, error) {
+	// the first 32 bytes are used for the MAC (as in v3),
+	// the last 32 bytes are the xchacha20-poly1305 key
+	dk, err := params.deriveKey(kdf, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := xchacha20poly1305.New(dk[32:64])
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := crypto.CRandBytes(aead.NonceSize())
+	cipherText := aead.Seal(nil, nonce, amino.MustMarshalAny(priv), nil)
+
+	return &keystoreCrypto{
+		Cipher:     CipherXChaCha20Poly1305,
+		CipherText: hex.EncodeToString(cipherText),
+		CipherParams: keystoreCipherParams{
+			IV: hex.EncodeToString(nonce),
+		},
+		KDF:       kdf,
+		KDFParams: params,
+		MAC:       hex.EncodeToString(keystoreMAC(dk, cipherText)),
+	}, nil
+}
+
+// decryptPrivKey decrypts the private key with the passphrase.
+// A wrong passphrase results in a keyerror.ErrWrongPassword
+func decryptPrivKey(kc *keystoreCrypto, passphrase string) (crypto.PrivKey, error) {
+	cipherText, err := hex.DecodeString(kc.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+
+	iv, err := hex.DecodeString(kc.CipherParams.IV)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cipher iv: %w", err)
+	}
+
+	mac, err := hex.DecodeString(kc.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mac: %w", err)
+	}
+
+	if kc.KDFParams.DKLen < 32 {
+		return nil, fmt.Errorf("invalid kdf key length: %d", kc.KDFParams.DKLen)
+	}
+
+	dk, err := kc.KDFParams.deriveKey(kc.KDF, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare(keystoreMAC(dk, cipherText), mac) != 1 {
+		return nil, keyerror.NewErrWrongPassword()
+	}
+
+	var plainText []byte
+
+	switch kc.Cipher {
+	case CipherXChaCha20Poly1305:
+		if len(dk) < 64 {
+			return nil, fmt.Errorf("invalid kdf key length: %d", len(dk))
+		}
+
+		aead, err := xchacha20poly1305.New(dk[32:64])
+		if err != nil {
+			return nil, err
+		}
+
+		plainText, err = aead.Open(nil, iv, cipherText, nil)
+		if err != nil {
+			return nil, keyerror.NewErrWrongPassword()
+		}
+	case CipherAES128CTR:
+		block, err := aes.NewCipher(dk[:16])
+		if err != nil {
+			return nil, err
+		}
+
+		if len(iv) != block.BlockSize() {
+			return nil, fmt.Errorf("invalid cipher iv length: %d", len(iv))
+		}
+
+		plainText = make([]byte, len(cipherText))
+		cipher.NewCTR(block, iv).XORKeyStream(plainText, cipherText)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedCipher, kc.Cipher)
+	}
+
+	var priv crypto.PrivKey
+	if err := amino.UnmarshalAny(plainText, &priv); err != nil {
+		// standard v3 keystores hold raw secp256k1 keys
+		if len(plainText) != 32 {
+			return nil, fmt.Errorf("unable to decode private key: %w", err)
+		}
+
+		var key secp256k1.PrivKeySecp256k1
+		copy(key[:], plainText)
+
+		return key, nil
+	}
+
+	return priv, nil
+}
+
+// newKeystoreID returns a random (version 4) UUID
+func newKeystoreID() string {
+	b := crypto.CRandBytes(16)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
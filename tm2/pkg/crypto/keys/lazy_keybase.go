@@ -149,6 +149,16 @@ func (lkb lazyKeybase) CreateAccountBip44(name, mnemonic, bip39Passwd, encryptPa
 	return NewDBKeybase(db).CreateAccountBip44(name, mnemonic, bip39Passwd, encryptPasswd, params)
 }
 
+func (lkb lazyKeybase) CreateAccountWithAlgo(name, mnemonic, bip39Passwd, encryptPasswd string, algo SigningAlgo, params hd.BIP44Params) (Info, error) {
+	db, err := db.NewDB(lkb.name, dbBackend, lkb.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	return NewDBKeybase(db).CreateAccountWithAlgo(name, mnemonic, bip39Passwd, encryptPasswd, algo, params)
+}
+
 func (lkb lazyKeybase) CreateLedger(name string, algo SigningAlgo, hrp string, account, index uint32) (info Info, err error) {
 	db, err := db.NewDB(lkb.name, dbBackend, lkb.dir)
 	if err != nil {
@@ -211,3 +221,13 @@ func (lkb lazyKeybase) ExportPrivKey(name string, passphrase string) (crypto.Pri
 }
 
 func (lkb lazyKeybase) CloseDB() {}
+
+func (lkb lazyKeybase) writeInfo(name string, info Info) error {
+	db, err := db.NewDB(lkb.name, dbBackend, lkb.dir)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return dbKeybase{db: db}.writeInfo(name, info)
+}
@@ -0,0 +1,68 @@
+package keys
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errMigrationUnsupported = errors.New("keybase doesn't support migration")
+
+// infoWriter is implemented by keybases that can store key infos as-is
+type infoWriter interface {
+	writeInfo(name string, info Info) error
+}
+
+// Migrate copies all the keys of src into dst.
+// Local keys are decrypted with the password returned by getPassword,
+// and encrypted again with the same password in dst.
+// References to ledger, offline and multisig keys are copied as-is.
+// Keys are not removed from src.
+func Migrate(src, dst Keybase, getPassword func(name string) (string, error)) ([]Info, error) {
+	w, ok := dst.(infoWriter)
+	if !ok {
+		return nil, errMigrationUnsupported
+	}
+
+	infos, err := src.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range infos {
+		name := info.GetName()
+
+		if ok, err := dst.HasByName(name); err != nil {
+			return nil, err
+		} else if ok {
+			return nil, fmt.Errorf("%w: %s", errCannotOverwrite, name)
+		}
+	}
+
+	for _, info := range infos {
+		name := info.GetName()
+
+		if info.GetType() != TypeLocal {
+			if err := w.writeInfo(name, info); err != nil {
+				return nil, fmt.Errorf("unable to migrate key %s: %w", name, err)
+			}
+
+			continue
+		}
+
+		pass, err := getPassword(name)
+		if err != nil {
+			return nil, err
+		}
+
+		priv, err := src.ExportPrivKey(name, pass)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decrypt key %s: %w", name, err)
+		}
+
+		if err := dst.ImportPrivKey(name, priv, pass); err != nil {
+			return nil, fmt.Errorf("unable to migrate key %s: %w", name, err)
+		}
+	}
+
+	return infos, nil
+}
@@ -3,16 +3,35 @@ package keys
 import (
 	"fmt"
 	"path/filepath"
+
+	"github.com/gnolang/gno/tm2/pkg/os"
 )
 
 const (
-	defaultKeyDBName = "keys"
-	defaultKeyDBDir  = "data"
+	defaultKeyDBName   = "keys"
+	defaultKeyDBDir    = "data"
+	defaultKeystoreDir = "keystore"
 )
 
 // NewKeyBaseFromDir initializes a keybase at a particular dir.
+// If the dir holds a file keystore (see KeystoreDir), the file keybase is used,
+// otherwise keys are stored in the DB keybase.
 func NewKeyBaseFromDir(rootDir string) (Keybase, error) {
-	return NewLazyDBKeybase(defaultKeyDBName, filepath.Join(rootDir, defaultKeyDBDir)), nil
+	if dir := KeystoreDir(rootDir); os.DirExists(dir) {
+		return NewFileKeybase(dir)
+	}
+
+	return NewDBKeyBaseFromDir(rootDir), nil
+}
+
+// NewDBKeyBaseFromDir initializes a DB keybase at a particular dir.
+func NewDBKeyBaseFromDir(rootDir string) Keybase {
+	return NewLazyDBKeybase(defaultKeyDBName, filepath.Join(rootDir, defaultKeyDBDir))
+}
+
+// KeystoreDir returns the path of the file keystore in the given dir.
+func KeystoreDir(rootDir string) string {
+	return filepath.Join(rootDir, defaultKeyDBDir, defaultKeystoreDir)
 }
 
 func ValidateMultisigThreshold(k, nKeys int) error {
@@ -0,0 +1,229 @@
+// Package secp256r1 implements keys on the NIST P-256 curve (secp256r1),
+// as used by hardware security modules and WebAuthn passkeys.
+package secp256r1
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/tmhash"
+)
+
+const (
+	// PrivKeySecp256r1Size is the size of the private key scalar
+	PrivKeySecp256r1Size = 32
+
+	// PubKeySecp256r1Size is comprised of 32 bytes for the x-coordinate,
+	// plus one byte for the parity of the y-coordinate
+	PubKeySecp256r1Size = 33
+
+	// SignatureSize is the size of a R || S signature
+	SignatureSize = 64
+)
+
+var errInvalidKey = errors.New("invalid secp256r1 key")
+
+var (
+	curve     = elliptic.P256()
+	curveN    = curve.Params().N
+	halfOrder = new(big.Int).Rsh(curveN, 1)
+)
+
+//-------------------------------------
+
+var _ crypto.PrivKey = PrivKeySecp256r1{}
+
+// PrivKeySecp256r1 implements crypto.PrivKey.
+type PrivKeySecp256r1 [PrivKeySecp256r1Size]byte
+
+// Bytes marshals the private key using amino encoding.
+func (privKey PrivKeySecp256r1) Bytes() []byte {
+	return amino.MustMarshalAny(privKey)
+}
+
+// Sign creates an ECDSA signature on curve P-256, using SHA256 on the msg.
+// The returned signature will be of the form R || S (in lower-S form).
+func (privKey PrivKeySecp256r1) Sign(msg []byte) ([]byte, error) {
+	priv, err := privKey.ecdsa()
+	if err != nil {
+		return nil, err
+	}
+
+	r, s, err := ecdsa.Sign(crypto.CReader(), priv, crypto.Sha256(msg))
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the lower-S form, so signatures are not malleable
+	if s.Cmp(halfOrder) > 0 {
+		s.Sub(curveN, s)
+	}
+
+	sig := make([]byte, SignatureSize)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	return sig, nil
+}
+
+// PubKey performs the point-scalar multiplication from the privKey on the
+// generator point to get the pubkey.
+func (privKey PrivKeySecp256r1) PubKey() crypto.PubKey {
+	priv, err := ecdh.P256().NewPrivateKey(privKey[:])
+	if err != nil {
+		panic(err)
+	}
+
+	// uncompressed form: 0x04 || X || Y
+	uncompressed := priv.PublicKey().Bytes()
+
+	var pubKey PubKeySecp256r1
+	pubKey[0] = 0x02 | (uncompressed[64] & 0x01)
+	copy(pubKey[1:], uncompressed[1:33])
+
+	return pubKey
+}
+
+// Equals - you probably don't need to use this.
+// Runs in constant time based on length of the keys.
+func (privKey PrivKeySecp256r1) Equals(other crypto.PrivKey) bool {
+	otherR1, ok := other.(PrivKeySecp256r1)
+
+	return ok && subtle.ConstantTimeCompare(privKey[:], otherR1[:]) == 1
+}
+
+func (privKey PrivKeySecp256r1) ecdsa() (*ecdsa.PrivateKey, error) {
+	pub, ok := privKey.PubKey().(PubKeySecp256r1).ecdsa()
+	if !ok {
+		return nil, errInvalidKey
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: *pub,
+		D:         new(big.Int).SetBytes(privKey[:]),
+	}, nil
+}
+
+// GenPrivKey generates a new secp256r1 private key.
+// It uses OS randomness to generate the private key.
+func GenPrivKey() PrivKeySecp256r1 {
+	return genPrivKey(crypto.CReader())
+}
+
+// genPrivKey generates a new secp256r1 private key using the provided reader.
+func genPrivKey(rand io.Reader) PrivKeySecp256r1 {
+	var privKeyBytes [PrivKeySecp256r1Size]byte
+	d := new(big.Int)
+	for {
+		privKeyBytes = [PrivKeySecp256r1Size]byte{}
+		_, err := io.ReadFull(rand, privKeyBytes[:])
+		if err != nil {
+			panic(err)
+		}
+
+		d.SetBytes(privKeyBytes[:])
+		// break if we found a valid point (i.e. > 0 and < N == curverOrder)
+		if d.Sign() > 0 && d.Cmp(curveN) < 0 {
+			break
+		}
+	}
+
+	return PrivKeySecp256r1(privKeyBytes)
+}
+
+var one = big.NewInt(1)
+
+// GenPrivKeyFromSecret hashes the secret with SHA2, and uses
+// that 32 byte output to create the private key.
+//
+// It makes sure the private key is a valid field element by setting:
+//
+// c = sha256(secret)
+// k = (c mod (n − 1)) + 1, where n = curve order.
+//
+// NOTE: secret should be the output of a KDF like bcrypt,
+// if it's derived from user input.
+func GenPrivKeyFromSecret(secret []byte) PrivKeySecp256r1 {
+	secHash := sha256.Sum256(secret)
+
+	fe := new(big.Int).SetBytes(secHash[:])
+	n := new(big.Int).Sub(curveN, one)
+	fe.Mod(fe, n)
+	fe.Add(fe, one)
+
+	var privKey PrivKeySecp256r1
+	fe.FillBytes(privKey[:])
+
+	return privKey
+}
+
+//-------------------------------------
+
+var _ crypto.PubKey = PubKeySecp256r1{}
+
+// PubKeySecp256r1 implements crypto.PubKey.
+// It is the compressed form of the pubkey: a 0x02 or 0x03 byte,
+// depending on the parity of the y-coordinate, followed by the x-coordinate.
+type PubKeySecp256r1 [PubKeySecp256r1Size]byte
+
+// Address is the SHA256-20 of the compressed pubkey bytes.
+func (pubKey PubKeySecp256r1) Address() crypto.Address {
+	return crypto.AddressFromBytes(tmhash.SumTruncated(pubKey[:]))
+}
+
+// Bytes returns the pubkey marshalled with amino encoding.
+func (pubKey PubKeySecp256r1) Bytes() []byte {
+	return amino.MustMarshalAny(pubKey)
+}
+
+// VerifyBytes verifies either a signature of the form R || S,
+// in lower-S form, or a WebAuthn assertion (see WebAuthnSignature).
+func (pubKey PubKeySecp256r1) VerifyBytes(msg []byte, sig []byte) bool {
+	pub, ok := pubKey.ecdsa()
+	if !ok {
+		return false
+	}
+
+	if len(sig) != SignatureSize {
+		return verifyWebAuthn(pub, msg, sig)
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+
+	// Reject malleable signatures
+	if s.Cmp(halfOrder) > 0 {
+		return false
+	}
+
+	return ecdsa.Verify(pub, crypto.Sha256(msg), r, s)
+}
+
+func (pubKey PubKeySecp256r1) String() string {
+	return crypto.PubKeyToBech32(pubKey)
+}
+
+func (pubKey PubKeySecp256r1) Equals(other crypto.PubKey) bool {
+	if otherR1, ok := other.(PubKeySecp256r1); ok {
+		return bytes.Equal(pubKey[:], otherR1[:])
+	}
+	return false
+}
+
+func (pubKey PubKeySecp256r1) ecdsa() (*ecdsa.PublicKey, bool) {
+	x, y := elliptic.UnmarshalCompressed(curve, pubKey[:])
+	if x == nil {
+		return nil, false
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, true
+}
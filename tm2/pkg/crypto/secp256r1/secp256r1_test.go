@@ -0,0 +1,212 @@
+package secp256r1_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256r1"
+)
+
+func TestSignAndValidateSecp256r1(t *testing.T) {
+	t.Parallel()
+
+	privKey := secp256r1.GenPrivKey()
+	pubKey := privKey.PubKey()
+
+	msg := crypto.CRandBytes(128)
+	sig, err := privKey.Sign(msg)
+	require.Nil(t, err)
+	require.Len(t, sig, secp256r1.SignatureSize)
+
+	assert.True(t, pubKey.VerifyBytes(msg, sig))
+
+	// Mutate the signature, just one bit.
+	sig[3] ^= byte(0x01)
+
+	assert.False(t, pubKey.VerifyBytes(msg, sig))
+}
+
+func TestSecp256r1RejectsHighS(t *testing.T) {
+	t.Parallel()
+
+	privKey := secp256r1.GenPrivKey()
+	pubKey := privKey.PubKey()
+
+	msg := []byte("msg")
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+
+	// Sign always returns lower-S signatures
+	n := elliptic.P256().Params().N
+	s := new(big.Int).SetBytes(sig[32:])
+	require.True(t, s.Cmp(new(big.Int).Rsh(n, 1)) <= 0)
+
+	// (r, n - s) is also a valid ECDSA signature, but is malleable
+	highS := make([]byte, secp256r1.SignatureSize)
+	copy(highS, sig[:32])
+	new(big.Int).Sub(n, s).FillBytes(highS[32:])
+
+	assert.False(t, pubKey.VerifyBytes(msg, highS))
+}
+
+func TestPubKeySecp256r1(t *testing.T) {
+	t.Parallel()
+
+	privKey := secp256r1.GenPrivKeyFromSecret([]byte("secret"))
+	pubKey := privKey.PubKey().(secp256r1.PubKeySecp256r1)
+
+	// compare with the standard library
+	x, y := elliptic.P256().ScalarBaseMult(privKey[:])
+	assert.Equal(t, elliptic.MarshalCompressed(elliptic.P256(), x, y), pubKey[:])
+
+	// amino round trip
+	var decoded crypto.PubKey
+	require.NoError(t, amino.Unmarshal(pubKey.Bytes(), &decoded))
+	assert.True(t, pubKey.Equals(decoded))
+	assert.Equal(t, pubKey.Address(), decoded.Address())
+
+	var decodedPriv crypto.PrivKey
+	require.NoError(t, amino.Unmarshal(privKey.Bytes(), &decodedPriv))
+	assert.True(t, privKey.Equals(decodedPriv))
+}
+
+// ecdsaSignature is the ASN.1 structure of an ECDSA signature
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// passkeyAssert simulates a WebAuthn authenticator signing the given challenge,
+// and returns the signature in lower-S form
+func passkeyAssert(t *testing.T, privKey secp256r1.PrivKeySecp256r1, challenge []byte, typ string, flags byte) secp256r1.WebAuthnSignature {
+	t.Helper()
+
+	x, y := elliptic.P256().ScalarBaseMult(privKey[:])
+	priv := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y},
+		D:         new(big.Int).SetBytes(privKey[:]),
+	}
+
+	rpIDHash := sha256.Sum256([]byte("gno.land"))
+	authData := append(rpIDHash[:], flags, 0, 0, 0, 1)
+	clientData := fmt.Sprintf(
+		`{"type":%q,"challenge":%q,"origin":"https://gno.land"}`,
+		typ,
+		base64.RawURLEncoding.EncodeToString(challenge),
+	)
+
+	clientDataHash := sha256.Sum256([]byte(clientData))
+	signed := sha256.Sum256(append(authData, clientDataHash[:]...))
+
+	r, s, err := ecdsa.Sign(crypto.CReader(), priv, signed[:])
+	require.NoError(t, err)
+
+	n := elliptic.P256().Params().N
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		s.Sub(n, s)
+	}
+
+	sig, err := asn1.Marshal(ecdsaSignature{R: r, S: s})
+	require.NoError(t, err)
+
+	return secp256r1.WebAuthnSignature{
+		AuthenticatorData: authData,
+		ClientDataJSON:    []byte(clientData),
+		Signature:         sig,
+	}
+}
+
+func TestSecp256r1WebAuthn(t *testing.T) {
+	t.Parallel()
+
+	privKey := secp256r1.GenPrivKey()
+	pubKey := privKey.PubKey()
+	msg := []byte("sign bytes")
+
+	t.Run("valid assertion", func(t *testing.T) {
+		t.Parallel()
+
+		sig := passkeyAssert(t, privKey, crypto.Sha256(msg), "webauthn.get", 0x05)
+		assert.True(t, pubKey.VerifyBytes(msg, sig.Bytes()))
+	})
+
+	t.Run("other message", func(t *testing.T) {
+		t.Parallel()
+
+		sig := passkeyAssert(t, privKey, crypto.Sha256(msg), "webauthn.get", 0x05)
+		assert.False(t, pubKey.VerifyBytes([]byte("other"), sig.Bytes()))
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		t.Parallel()
+
+		sig := passkeyAssert(t, privKey, crypto.Sha256(msg), "webauthn.create", 0x05)
+		assert.False(t, pubKey.VerifyBytes(msg, sig.Bytes()))
+	})
+
+	t.Run("user not present", func(t *testing.T) {
+		t.Parallel()
+
+		sig := passkeyAssert(t, privKey, crypto.Sha256(msg), "webauthn.get", 0x04)
+		assert.False(t, pubKey.VerifyBytes(msg, sig.Bytes()))
+	})
+
+	t.Run("other key", func(t *testing.T) {
+		t.Parallel()
+
+		sig := passkeyAssert(t, secp256r1.GenPrivKey(), crypto.Sha256(msg), "webauthn.get", 0x05)
+		assert.False(t, pubKey.VerifyBytes(msg, sig.Bytes()))
+	})
+
+	t.Run("tampered authenticator data", func(t *testing.T) {
+		t.Parallel()
+
+		sig := passkeyAssert(t, privKey, crypto.Sha256(msg), "webauthn.get", 0x05)
+		sig.AuthenticatorData[36]++
+		assert.False(t, pubKey.VerifyBytes(msg, sig.Bytes()))
+	})
+
+	t.Run("high S", func(t *testing.T) {
+		t.Parallel()
+
+		sig := passkeyAssert(t, privKey, crypto.Sha256(msg), "webauthn.get", 0x05)
+
+		// (r, n - s) is also a valid ECDSA signature, but is malleable
+		var ecSig ecdsaSignature
+		_, err := asn1.Unmarshal(sig.Signature, &ecSig)
+		require.NoError(t, err)
+
+		ecSig.S.Sub(elliptic.P256().Params().N, ecSig.S)
+		sig.Signature, err = asn1.Marshal(ecSig)
+		require.NoError(t, err)
+
+		assert.False(t, pubKey.VerifyBytes(msg, sig.Bytes()))
+
+		// NewWebAuthnSignature normalizes the raw authenticator signature
+		normalized, err := secp256r1.NewWebAuthnSignature(sig.AuthenticatorData, sig.ClientDataJSON, sig.Signature)
+		require.NoError(t, err)
+		assert.NotEqual(t, sig.Signature, normalized.Signature)
+		assert.True(t, pubKey.VerifyBytes(msg, normalized.Bytes()))
+	})
+
+	t.Run("invalid DER signature", func(t *testing.T) {
+		t.Parallel()
+
+		sig := passkeyAssert(t, privKey, crypto.Sha256(msg), "webauthn.get", 0x05)
+
+		_, err := secp256r1.NewWebAuthnSignature(sig.AuthenticatorData, sig.ClientDataJSON, sig.Signature[1:])
+		assert.Error(t, err)
+		_, err = secp256r1.NewWebAuthnSignature(sig.AuthenticatorData, sig.ClientDataJSON, append(sig.Signature, 0))
+		assert.Error(t, err)
+	})
+}
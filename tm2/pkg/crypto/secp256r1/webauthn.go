@@ -0,0 +1,120 @@
+package secp256r1
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+const (
+	webAuthnTypeGet = "webauthn.get"
+
+	// authenticator data: rpIdHash (32) || flags (1) || signCount (4)
+	authDataMinSize = 37
+	authDataFlagsUP = 0x01 // user present
+)
+
+// WebAuthnSignature is a WebAuthn assertion made by a passkey
+// (https://www.w3.org/TR/webauthn-2/#sctn-verifying-assertion).
+// The challenge of the assertion must be SHA256(msg), so passkeys
+// can be used to sign transactions.
+//
+// NOTE: the relying party ID and the origin are not checked,
+// as the chain doesn't know which website requested the signature.
+type WebAuthnSignature struct {
+	AuthenticatorData []byte `json:"authenticator_data"`
+	ClientDataJSON    []byte `json:"client_data_json"`
+	// Signature is the ASN.1 DER-encoded ECDSA signature, in lower-S form
+	Signature []byte `json:"signature"`
+}
+
+// NewWebAuthnSignature returns the signature of a WebAuthn assertion, from
+// the outputs of the authenticator. Authenticators may return high-S
+// signatures, which are normalized to the lower-S form accepted by the chain.
+func NewWebAuthnSignature(authenticatorData, clientDataJSON, signature []byte) (WebAuthnSignature, error) {
+	var ecSig ecdsaSignature
+	rest, err := asn1.Unmarshal(signature, &ecSig)
+	if err != nil {
+		return WebAuthnSignature{}, fmt.Errorf("invalid DER signature: %w", err)
+	}
+	if len(rest) != 0 {
+		return WebAuthnSignature{}, errors.New("invalid DER signature: trailing data")
+	}
+
+	if ecSig.S.Cmp(halfOrder) > 0 {
+		ecSig.S.Sub(curveN, ecSig.S)
+		if signature, err = asn1.Marshal(ecSig); err != nil {
+			return WebAuthnSignature{}, err
+		}
+	}
+
+	return WebAuthnSignature{
+		AuthenticatorData: authenticatorData,
+		ClientDataJSON:    clientDataJSON,
+		Signature:         signature,
+	}, nil
+}
+
+// Bytes returns the amino encoded signature, to be used as a tx signature
+func (sig WebAuthnSignature) Bytes() []byte {
+	return amino.MustMarshal(sig)
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+type webAuthnClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+}
+
+func verifyWebAuthn(pub *ecdsa.PublicKey, msg, sigbz []byte) bool {
+	var sig WebAuthnSignature
+	if err := amino.Unmarshal(sigbz, &sig); err != nil {
+		return false
+	}
+
+	if len(sig.AuthenticatorData) < authDataMinSize ||
+		sig.AuthenticatorData[32]&authDataFlagsUP == 0 {
+		return false
+	}
+
+	var clientData webAuthnClientData
+	if err := json.Unmarshal(sig.ClientDataJSON, &clientData); err != nil {
+		return false
+	}
+
+	if clientData.Type != webAuthnTypeGet {
+		return false
+	}
+
+	challenge, err := base64.RawURLEncoding.DecodeString(clientData.Challenge)
+	if err != nil || !bytes.Equal(challenge, crypto.Sha256(msg)) {
+		return false
+	}
+
+	var ecSig ecdsaSignature
+	if rest, err := asn1.Unmarshal(sig.Signature, &ecSig); err != nil || len(rest) != 0 {
+		return false
+	}
+
+	// Reject malleable signatures, as for R || S signatures
+	if ecSig.R.Sign() <= 0 || ecSig.S.Sign() <= 0 || ecSig.S.Cmp(halfOrder) > 0 {
+		return false
+	}
+
+	clientDataHash := sha256.Sum256(sig.ClientDataJSON)
+	signed := sha256.Sum256(append(bytes.Clone(sig.AuthenticatorData), clientDataHash[:]...))
+
+	return ecdsa.Verify(pub, signed[:], ecSig.R, ecSig.S)
+}
@@ -49,7 +49,7 @@ func EnsureDir(dir string, mode os.FileMode) error {
 
 func DirExists(dirPath string) bool {
 	st, err := os.Stat(dirPath)
-	return !os.IsNotExist(err) && st.IsDir()
+	return err == nil && st.IsDir()
 }
 
 // Note: returns true for files and dirs.
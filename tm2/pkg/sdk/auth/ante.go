@@ -10,6 +10,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
 	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
 	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256r1"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
@@ -37,7 +38,7 @@ type AnteOptions struct {
 
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
 // numbers, checks signatures & account numbers, and deducts fees from the first
-// signer.
+// signer, or from the fee granter if one is set.
 func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer SignatureVerificationGasConsumer, opts AnteOptions) sdk.AnteHandler {
 	return func(
 		ctx sdk.Context, tx std.Tx, simulate bool,
@@ -114,7 +115,7 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 		signerAccs := make([]std.Account, len(signerAddrs))
 		isGenesis := ctx.BlockHeight() == 0
 
-		// fetch first signer, who's going to pay the fees
+		// fetch first signer, who's going to pay the fees (unless a granter is set)
 		signerAccs[0], res = GetSignerAcc(newCtx, ak, signerAddrs[0])
 		if !res.IsOK() {
 			return newCtx, res, true
@@ -122,7 +123,22 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 
 		// deduct the fees
 		if !tx.Fee.GasFee.IsZero() {
-			res = DeductFees(bank, newCtx, signerAccs[0], std.Coins{tx.Fee.GasFee})
+			fees := std.Coins{tx.Fee.GasFee}
+			payer := signerAccs[0]
+
+			// the fees are paid by the granter, within its allowance to the first signer
+			if grant := tx.Fee.Grant; grant != nil {
+				if err := ak.UseFeeAllowance(newCtx, grant.Granter, signerAddrs[0], fees); err != nil {
+					return newCtx, abciResult(err), true
+				}
+
+				payer, res = GetSignerAcc(newCtx, ak, grant.Granter)
+				if !res.IsOK() {
+					return newCtx, res, true
+				}
+			}
+
+			res = DeductFees(bank, newCtx, payer, fees)
 			if !res.IsOK() {
 				return newCtx, res, true
 			}
@@ -230,7 +246,10 @@ func processSig(
 	}
 
 	if !simulate && !pubKey.VerifyBytes(signBytes, sig.Signature) {
-		return nil, abciResult(std.ErrUnauthorized("signature verification failed; verify correct account, sequence, and chain-id"))
+		return nil, abciResult(std.ErrUnauthorized(fmt.Sprintf(
+			"signature verification failed; verify correct account, sequence, and chain-id (expected sequence %d)",
+			acc.GetSequence(),
+		)))
 	}
 
 	if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
@@ -277,6 +296,10 @@ func DefaultSigVerificationGasConsumer(
 		meter.ConsumeGas(params.SigVerifyCostSecp256k1, "ante verify: secp256k1")
 		return sdk.Result{}
 
+	case secp256r1.PubKeySecp256r1:
+		meter.ConsumeGas(params.sigVerifyCostSecp256r1(), "ante verify: secp256r1")
+		return sdk.Result{}
+
 	case multisig.PubKeyMultisigThreshold:
 		var multisignature multisig.Multisignature
 		amino.MustUnmarshal(sig, &multisignature)
@@ -17,6 +17,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
 	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
 	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256r1"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	tu "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -167,6 +168,42 @@ func TestAnteHandlerAccountNumbers(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx, false)
 }
 
+// Test accounts using the ed25519 and secp256r1 key types.
+func TestAnteHandlerKeyTypes(t *testing.T) {
+	t.Parallel()
+
+	for _, priv := range []crypto.PrivKey{
+		ed25519.GenPrivKey(),
+		secp256r1.GenPrivKey(),
+	} {
+		t.Run(fmt.Sprintf("%T", priv), func(t *testing.T) {
+			t.Parallel()
+
+			env := setupTestEnv()
+			anteHandler := NewAnteHandler(env.acck, env.bankk, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+			ctx := env.ctx
+
+			addr := priv.PubKey().Address()
+			acc := env.acck.NewAccountWithAddress(ctx, addr)
+			acc.SetCoins(tu.NewTestCoins())
+			env.acck.SetAccount(ctx, acc)
+
+			msgs := []std.Msg{tu.NewTestMsg(addr)}
+			privs, accnums, seqs := []crypto.PrivKey{priv}, []uint64{0}, []uint64{0}
+			tx := tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, seqs, tu.NewTestFee())
+			checkValidTx(t, anteHandler, ctx, tx, false)
+
+			// the public key is set on the account
+			acc = env.acck.GetAccount(ctx, addr)
+			require.True(t, priv.PubKey().Equals(acc.GetPubKey()))
+
+			// wrong sequence
+			tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, seqs, tu.NewTestFee())
+			checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+		})
+	}
+}
+
 // Test logic around account number checking with many signers when BlockHeight is 0.
 func TestAnteHandlerAccountNumbersAtBlockHeightZero(t *testing.T) {
 	t.Parallel()
@@ -271,6 +308,10 @@ func TestAnteHandlerSequences(t *testing.T) {
 	// test sending it again fails (replay protection)
 	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
 
+	// the expected sequence is part of the error
+	_, result, _ := anteHandler(ctx, tx, false)
+	require.Contains(t, result.Log, "expected sequence 1")
+
 	// fix sequence, should pass
 	seqs = []uint64{1}
 	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, seqs, fee)
@@ -351,6 +392,55 @@ func TestAnteHandlerFees(t *testing.T) {
 }
 
 // Test logic around memo gas consumption.
+func TestAnteHandlerFeeGranter(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	ctx := env.ctx
+	anteHandler := NewAnteHandler(env.acck, env.bankk, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+	_, _, granter := tu.KeyTestPubAddr()
+
+	// set the accounts, the grantee has no funds
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	env.acck.SetAccount(ctx, acc1)
+
+	accGranter := env.acck.NewAccountWithAddress(ctx, granter)
+	accGranter.SetCoins(std.NewCoins(std.NewCoin("atom", 300)))
+	env.acck.SetAccount(ctx, accGranter)
+
+	// msg and signatures
+	var tx std.Tx
+	msg := tu.NewTestMsg(addr1)
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []uint64{0}, []uint64{0}
+	fee := tu.NewTestFee()
+	fee.Grant = &std.FeeGrant{Granter: granter}
+	msgs := []std.Msg{msg}
+
+	// the granter has not given any allowance to the signer
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, seqs, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	// the allowance doesn't cover the fee
+	env.acck.SetFeeAllowance(ctx, NewFeeAllowance(granter, addr1, std.NewCoins(std.NewCoin("atom", 100)), 0))
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.InsufficientFeeError{})
+
+	// the fee is paid by the granter
+	env.acck.SetFeeAllowance(ctx, NewFeeAllowance(granter, addr1, std.NewCoins(std.NewCoin("atom", 200)), 0))
+	checkValidTx(t, anteHandler, ctx, tx, false)
+
+	require.Equal(t, int64(150), env.bankk.(DummyBankKeeper).acck.GetAccount(ctx, FeeCollectorAddress()).GetCoins().AmountOf("atom"))
+	require.Equal(t, int64(150), env.acck.GetAccount(ctx, granter).GetCoins().AmountOf("atom"))
+	require.True(t, env.acck.GetAccount(ctx, addr1).GetCoins().IsZero())
+
+	fa, ok := env.acck.GetFeeAllowance(ctx, granter, addr1)
+	require.True(t, ok)
+	require.Equal(t, std.NewCoins(std.NewCoin("atom", 50)), fa.SpendLimit)
+}
+
 func TestAnteHandlerMemoGas(t *testing.T) {
 	t.Parallel()
 
@@ -643,6 +733,10 @@ func TestConsumeSignatureVerificationGas(t *testing.T) {
 		multisignature1.AddSignatureFromPubKey(sigSet1[i], pkSet1[i], pkSet1)
 	}
 
+	// Params set before secp256r1 keys were supported
+	unsetR1Params := params
+	unsetR1Params.SigVerifyCostSecp256r1 = 0
+
 	type args struct {
 		meter  store.GasMeter
 		sig    []byte
@@ -657,6 +751,8 @@ func TestConsumeSignatureVerificationGas(t *testing.T) {
 	}{
 		{"PubKeyEd25519", args{store.NewInfiniteGasMeter(), nil, ed25519.GenPrivKey().PubKey(), params}, DefaultSigVerifyCostED25519, false},
 		{"PubKeySecp256k1", args{store.NewInfiniteGasMeter(), nil, secp256k1.GenPrivKey().PubKey(), params}, DefaultSigVerifyCostSecp256k1, false},
+		{"PubKeySecp256r1", args{store.NewInfiniteGasMeter(), nil, secp256r1.GenPrivKey().PubKey(), params}, DefaultSigVerifyCostSecp256r1, false},
+		{"PubKeySecp256r1 unset cost", args{store.NewInfiniteGasMeter(), nil, secp256r1.GenPrivKey().PubKey(), unsetR1Params}, DefaultSigVerifyCostSecp256r1, false},
 		{"Multisig", args{store.NewInfiniteGasMeter(), amino.MustMarshal(multisignature1), multisigKey1, params}, expectedCost1, false},
 		{"unknown key", args{store.NewInfiniteGasMeter(), nil, nil, params}, 0, true},
 	}
@@ -19,6 +19,8 @@ const (
 
 	// AddressStoreKeyPrefix prefix for account-by-address store
 	AddressStoreKeyPrefix = "/a/"
+	// FeeAllowanceStoreKeyPrefix prefix for fee-allowance-by-granter-and-grantee store
+	FeeAllowanceStoreKeyPrefix = "/fa/"
 	// key for gas price
 	GasPriceKey = "gasPrice"
 	// param key for global account number
@@ -30,6 +32,13 @@ func AddressStoreKey(addr crypto.Address) []byte {
 	return append([]byte(AddressStoreKeyPrefix), addr.Bytes()...)
 }
 
+// FeeAllowanceStoreKey turn a granter and grantee pair to key used to get
+// the fee allowance from the account store
+func FeeAllowanceStoreKey(granter, grantee crypto.Address) []byte {
+	key := append([]byte(FeeAllowanceStoreKeyPrefix), granter.Bytes()...)
+	return append(key, grantee.Bytes()...)
+}
+
 // NOTE: do not modify.
 // XXX: consider parameterization at the keeper level.
 var feeCollector crypto.Address
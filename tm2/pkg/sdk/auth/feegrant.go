@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// FeeAllowance allows the grantee to pay transaction fees
+// using the funds of the granter (ie. a sponsor account).
+type FeeAllowance struct {
+	Granter crypto.Address `json:"granter" yaml:"granter"`
+	Grantee crypto.Address `json:"grantee" yaml:"grantee"`
+	// SpendLimit is the amount of fees the grantee can still spend.
+	// If empty, the allowance is unlimited.
+	SpendLimit std.Coins `json:"spend_limit" yaml:"spend_limit"`
+	// Expiration is the unix time (in seconds) at which the allowance expires.
+	// If zero, the allowance never expires.
+	Expiration int64 `json:"expiration" yaml:"expiration"`
+}
+
+// NewFeeAllowance returns a new instance of FeeAllowance
+func NewFeeAllowance(granter, grantee crypto.Address, spendLimit std.Coins, expiration int64) FeeAllowance {
+	return FeeAllowance{
+		Granter:    granter,
+		Grantee:    grantee,
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+// IsExpired returns true if the allowance is expired at the given unix time
+func (fa FeeAllowance) IsExpired(now int64) bool {
+	return fa.Expiration != 0 && now >= fa.Expiration
+}
+
+// Accept checks whether the allowance covers the given fees at the given unix time,
+// and returns the allowance updated with the remaining spend limit
+func (fa FeeAllowance) Accept(fees std.Coins, now int64) (FeeAllowance, error) {
+	if fa.IsExpired(now) {
+		return fa, std.ErrUnauthorized("fee allowance expired")
+	}
+
+	// unlimited allowance
+	if fa.SpendLimit.Empty() {
+		return fa, nil
+	}
+
+	if !fa.SpendLimit.IsAllGTE(fees) {
+		return fa, std.ErrInsufficientFee(
+			fmt.Sprintf("fee allowance exceeded; remaining: %s, fees: %s", fa.SpendLimit, fees),
+		)
+	}
+
+	fa.SpendLimit = fa.SpendLimit.Sub(fees)
+
+	return fa, nil
+}
+
+// SetFeeAllowance stores the fee allowance, overwriting any
+// existing allowance between the same granter and grantee.
+func (ak AccountKeeper) SetFeeAllowance(ctx sdk.Context, fa FeeAllowance) {
+	stor := ctx.GasStore(ak.key)
+	bz, err := amino.Marshal(fa)
+	if err != nil {
+		panic(err)
+	}
+	stor.Set(FeeAllowanceStoreKey(fa.Granter, fa.Grantee), bz)
+}
+
+// GetFeeAllowance returns the fee allowance the granter has given to the grantee, if any.
+func (ak AccountKeeper) GetFeeAllowance(ctx sdk.Context, granter, grantee crypto.Address) (FeeAllowance, bool) {
+	stor := ctx.GasStore(ak.key)
+	bz := stor.Get(FeeAllowanceStoreKey(granter, grantee))
+	if bz == nil {
+		return FeeAllowance{}, false
+	}
+
+	var fa FeeAllowance
+	if err := amino.Unmarshal(bz, &fa); err != nil {
+		panic(err)
+	}
+	return fa, true
+}
+
+// RemoveFeeAllowance removes the fee allowance the granter has given to the grantee.
+func (ak AccountKeeper) RemoveFeeAllowance(ctx sdk.Context, granter, grantee crypto.Address) {
+	stor := ctx.GasStore(ak.key)
+	stor.Delete(FeeAllowanceStoreKey(granter, grantee))
+}
+
+// UseFeeAllowance consumes the given fees from the allowance the granter
+// has given to the grantee. Exhausted allowances are removed.
+func (ak AccountKeeper) UseFeeAllowance(ctx sdk.Context, granter, grantee crypto.Address, fees std.Coins) error {
+	fa, ok := ak.GetFeeAllowance(ctx, granter, grantee)
+	if !ok {
+		return std.ErrUnauthorized(
+			fmt.Sprintf("no fee allowance from %s to %s", granter, grantee),
+		)
+	}
+
+	updated, err := fa.Accept(fees, ctx.BlockTime().Unix())
+	if err != nil {
+		return err
+	}
+
+	// the spend limit was consumed entirely
+	if !fa.SpendLimit.Empty() && updated.SpendLimit.Empty() {
+		ak.RemoveFeeAllowance(ctx, granter, grantee)
+
+		return nil
+	}
+
+	ak.SetFeeAllowance(ctx, updated)
+
+	return nil
+}
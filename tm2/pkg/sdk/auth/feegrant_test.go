@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestFeeAllowanceAccept(t *testing.T) {
+	t.Parallel()
+
+	var (
+		granter = crypto.AddressFromPreimage([]byte("granter"))
+		grantee = crypto.AddressFromPreimage([]byte("grantee"))
+		fees    = std.NewCoins(std.NewCoin("atom", 10))
+	)
+
+	t.Run("unlimited", func(t *testing.T) {
+		t.Parallel()
+
+		fa := NewFeeAllowance(granter, grantee, nil, 0)
+
+		updated, err := fa.Accept(fees, 100)
+		require.NoError(t, err)
+		assert.Equal(t, fa, updated)
+	})
+
+	t.Run("within spend limit", func(t *testing.T) {
+		t.Parallel()
+
+		fa := NewFeeAllowance(granter, grantee, std.NewCoins(std.NewCoin("atom", 25)), 0)
+
+		updated, err := fa.Accept(fees, 100)
+		require.NoError(t, err)
+		assert.Equal(t, std.NewCoins(std.NewCoin("atom", 15)), updated.SpendLimit)
+	})
+
+	t.Run("spend limit exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		fa := NewFeeAllowance(granter, grantee, std.NewCoins(std.NewCoin("atom", 5)), 0)
+
+		_, err := fa.Accept(fees, 100)
+		assert.ErrorIs(t, err, std.InsufficientFeeError{})
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+
+		fa := NewFeeAllowance(granter, grantee, nil, 100)
+
+		_, err := fa.Accept(fees, 99)
+		require.NoError(t, err)
+
+		_, err = fa.Accept(fees, 100)
+		assert.ErrorIs(t, err, std.UnauthorizedError{})
+	})
+}
+
+func TestUseFeeAllowance(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{Height: 1, Time: time.Unix(1000, 0)})
+
+	var (
+		granter = crypto.AddressFromPreimage([]byte("granter"))
+		grantee = crypto.AddressFromPreimage([]byte("grantee"))
+		fees    = std.NewCoins(std.NewCoin("atom", 10))
+	)
+
+	// no allowance
+	err := env.acck.UseFeeAllowance(ctx, granter, grantee, fees)
+	require.ErrorIs(t, err, std.UnauthorizedError{})
+
+	// set an allowance covering exactly two txs
+	env.acck.SetFeeAllowance(ctx, NewFeeAllowance(granter, grantee, std.NewCoins(std.NewCoin("atom", 20)), 0))
+
+	require.NoError(t, env.acck.UseFeeAllowance(ctx, granter, grantee, fees))
+
+	fa, ok := env.acck.GetFeeAllowance(ctx, granter, grantee)
+	require.True(t, ok)
+	assert.Equal(t, std.NewCoins(std.NewCoin("atom", 10)), fa.SpendLimit)
+
+	// the exhausted allowance is removed
+	require.NoError(t, env.acck.UseFeeAllowance(ctx, granter, grantee, fees))
+
+	_, ok = env.acck.GetFeeAllowance(ctx, granter, grantee)
+	assert.False(t, ok)
+
+	// the allowance isn't usable after it expires
+	env.acck.SetFeeAllowance(ctx, NewFeeAllowance(granter, grantee, nil, 1000))
+
+	err = env.acck.UseFeeAllowance(ctx, granter, grantee, fees)
+	assert.ErrorIs(t, err, std.UnauthorizedError{})
+}
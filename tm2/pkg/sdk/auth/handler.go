@@ -23,21 +23,66 @@ func NewHandler(acck AccountKeeper) authHandler {
 }
 
 func (ah authHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
-	// no messages supported yet.
-	errMsg := fmt.Sprintf("unrecognized auth message type: %T", msg)
-	return abciResult(std.ErrUnknownRequest(errMsg))
+	switch msg := msg.(type) {
+	case MsgGrantFeeAllowance:
+		return ah.handleMsgGrantFeeAllowance(ctx, msg)
+
+	case MsgRevokeFeeAllowance:
+		return ah.handleMsgRevokeFeeAllowance(ctx, msg)
+
+	default:
+		errMsg := fmt.Sprintf("unrecognized auth message type: %T", msg)
+		return abciResult(std.ErrUnknownRequest(errMsg))
+	}
+}
+
+// Handle MsgGrantFeeAllowance.
+func (ah authHandler) handleMsgGrantFeeAllowance(ctx sdk.Context, msg MsgGrantFeeAllowance) sdk.Result {
+	fa := NewFeeAllowance(msg.Granter, msg.Grantee, msg.SpendLimit, msg.Expiration)
+	if fa.IsExpired(ctx.BlockTime().Unix()) {
+		return abciResult(std.ErrUnknownRequest("fee allowance expiration is in the past"))
+	}
+
+	// create the grantee account if it doesn't exist yet,
+	// so it's able to sign its first (sponsored) transaction.
+	if ah.acck.GetAccount(ctx, msg.Grantee) == nil {
+		acc := ah.acck.NewAccountWithAddress(ctx, msg.Grantee)
+		ah.acck.SetAccount(ctx, acc)
+	}
+
+	ah.acck.SetFeeAllowance(ctx, fa)
+
+	return sdk.Result{}
+}
+
+// Handle MsgRevokeFeeAllowance.
+func (ah authHandler) handleMsgRevokeFeeAllowance(ctx sdk.Context, msg MsgRevokeFeeAllowance) sdk.Result {
+	if _, ok := ah.acck.GetFeeAllowance(ctx, msg.Granter, msg.Grantee); !ok {
+		return abciResult(std.ErrUnknownRequest(
+			fmt.Sprintf("no fee allowance from %s to %s", msg.Granter, msg.Grantee),
+		))
+	}
+
+	ah.acck.RemoveFeeAllowance(ctx, msg.Granter, msg.Grantee)
+
+	return sdk.Result{}
 }
 
 //----------------------------------------
 // Query
 
-// query account path
-const QueryAccount = "accounts"
+// query paths
+const (
+	QueryAccount      = "accounts"
+	QueryFeeAllowance = "fee_allowance"
+)
 
 func (ah authHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
 	switch secondPart(req.Path) {
 	case QueryAccount:
 		return ah.queryAccount(ctx, req)
+	case QueryFeeAllowance:
+		return ah.queryFeeAllowance(ctx, req)
 	default:
 		res = sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest("unknown auth query endpoint"))
@@ -72,6 +117,49 @@ func (ah authHandler) queryAccount(ctx sdk.Context, req abci.RequestQuery) (res
 	return
 }
 
+// queryFeeAllowance fetch the fee allowance between a granter and a grantee.
+// Granter and grantee addresses are passed as path components.
+func (ah authHandler) queryFeeAllowance(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	var (
+		b32granter = thirdPart(req.Path)
+		b32grantee = fourthPart(req.Path)
+	)
+
+	granter, err := crypto.AddressFromBech32(b32granter)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress(
+				"invalid query granter address " + b32granter))
+		return
+	}
+
+	grantee, err := crypto.AddressFromBech32(b32grantee)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress(
+				"invalid query grantee address " + b32grantee))
+		return
+	}
+
+	fa, ok := ah.acck.GetFeeAllowance(ctx, granter, grantee)
+	if !ok {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest(
+				fmt.Sprintf("no fee allowance from %s to %s", granter, grantee)))
+		return
+	}
+
+	bz, err := amino.MarshalJSONIndent(fa, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
 //----------------------------------------
 // misc
 
@@ -94,3 +182,13 @@ func thirdPart(path string) string {
 		return parts[2]
 	}
 }
+
+// returns the fourth component of a path.
+func fourthPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 4 {
+		return ""
+	} else {
+		return parts[3]
+	}
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestHandlerFeeAllowance(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.acck)
+
+	var (
+		granter    = crypto.AddressFromPreimage([]byte("granter"))
+		grantee    = crypto.AddressFromPreimage([]byte("grantee"))
+		spendLimit = std.NewCoins(std.NewCoin("atom", 100))
+	)
+
+	// grant an allowance to a non-existing account
+	res := h.Process(env.ctx, NewMsgGrantFeeAllowance(granter, grantee, spendLimit, 0))
+	require.True(t, res.IsOK(), res.Log)
+
+	// the grantee account was created
+	require.NotNil(t, env.acck.GetAccount(env.ctx, grantee))
+
+	// query the allowance
+	qres := h.Query(env.ctx, abci.RequestQuery{
+		Path: fmt.Sprintf("auth/%s/%s/%s", QueryFeeAllowance, granter, grantee),
+	})
+	require.Nil(t, qres.Error)
+
+	var fa FeeAllowance
+	require.NoError(t, amino.UnmarshalJSON(qres.Data, &fa))
+	assert.Equal(t, NewFeeAllowance(granter, grantee, spendLimit, 0), fa)
+
+	// revoke the allowance
+	res = h.Process(env.ctx, NewMsgRevokeFeeAllowance(granter, grantee))
+	require.True(t, res.IsOK(), res.Log)
+
+	_, ok := env.acck.GetFeeAllowance(env.ctx, granter, grantee)
+	assert.False(t, ok)
+
+	// revoking a missing allowance fails
+	res = h.Process(env.ctx, NewMsgRevokeFeeAllowance(granter, grantee))
+	assert.False(t, res.IsOK())
+
+	qres = h.Query(env.ctx, abci.RequestQuery{
+		Path: fmt.Sprintf("auth/%s/%s/%s", QueryFeeAllowance, granter, grantee),
+	})
+	assert.NotNil(t, qres.Error)
+}
+
+func TestMsgGrantFeeAllowanceValidation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = crypto.AddressFromPreimage([]byte("addr1"))
+		addr2 = crypto.AddressFromPreimage([]byte("addr2"))
+		atom  = std.NewCoins(std.NewCoin("atom", 10))
+	)
+
+	cases := []struct {
+		name  string
+		msg   MsgGrantFeeAllowance
+		valid bool
+	}{
+		{"valid", NewMsgGrantFeeAllowance(addr1, addr2, atom, 0), true},
+		{"unlimited", NewMsgGrantFeeAllowance(addr1, addr2, nil, 100), true},
+		{"missing granter", NewMsgGrantFeeAllowance(crypto.Address{}, addr2, atom, 0), false},
+		{"missing grantee", NewMsgGrantFeeAllowance(addr1, crypto.Address{}, atom, 0), false},
+		{"self grant", NewMsgGrantFeeAllowance(addr1, addr1, atom, 0), false},
+		{"invalid spend limit", NewMsgGrantFeeAllowance(addr1, addr2, std.Coins{std.NewCoin("atom", 0)}, 0), false},
+		{"negative expiration", NewMsgGrantFeeAllowance(addr1, addr2, atom, -1), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
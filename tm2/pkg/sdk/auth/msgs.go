@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// RouterKey is they name of the auth module
+const RouterKey = ModuleName
+
+// MsgGrantFeeAllowance - grants a fee allowance from the granter to the grantee
+type MsgGrantFeeAllowance struct {
+	Granter    crypto.Address `json:"granter" yaml:"granter"`
+	Grantee    crypto.Address `json:"grantee" yaml:"grantee"`
+	SpendLimit std.Coins      `json:"spend_limit" yaml:"spend_limit"`
+	Expiration int64          `json:"expiration" yaml:"expiration"`
+}
+
+var _ std.Msg = MsgGrantFeeAllowance{}
+
+// NewMsgGrantFeeAllowance - construct a fee allowance grant msg.
+func NewMsgGrantFeeAllowance(granter, grantee crypto.Address, spendLimit std.Coins, expiration int64) MsgGrantFeeAllowance {
+	return MsgGrantFeeAllowance{
+		Granter:    granter,
+		Grantee:    grantee,
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgGrantFeeAllowance) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgGrantFeeAllowance) Type() string { return "grant_fee_allowance" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgGrantFeeAllowance) ValidateBasic() error {
+	if msg.Granter.IsZero() {
+		return std.ErrInvalidAddress("missing granter address")
+	}
+	if msg.Grantee.IsZero() {
+		return std.ErrInvalidAddress("missing grantee address")
+	}
+	if msg.Granter == msg.Grantee {
+		return std.ErrInvalidAddress("granter and grantee must differ")
+	}
+	if !msg.SpendLimit.Empty() {
+		if !msg.SpendLimit.IsValid() {
+			return std.ErrInvalidCoins(msg.SpendLimit.String())
+		}
+		if !msg.SpendLimit.IsAllPositive() {
+			return std.ErrInvalidCoins("spend limit must be positive")
+		}
+	}
+	if msg.Expiration < 0 {
+		return std.ErrUnknownRequest("expiration must not be negative")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgGrantFeeAllowance) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgGrantFeeAllowance) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Granter}
+}
+
+// MsgRevokeFeeAllowance - revokes the fee allowance from the granter to the grantee
+type MsgRevokeFeeAllowance struct {
+	Granter crypto.Address `json:"granter" yaml:"granter"`
+	Grantee crypto.Address `json:"grantee" yaml:"grantee"`
+}
+
+var _ std.Msg = MsgRevokeFeeAllowance{}
+
+// NewMsgRevokeFeeAllowance - construct a fee allowance revocation msg.
+func NewMsgRevokeFeeAllowance(granter, grantee crypto.Address) MsgRevokeFeeAllowance {
+	return MsgRevokeFeeAllowance{
+		Granter: granter,
+		Grantee: grantee,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgRevokeFeeAllowance) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgRevokeFeeAllowance) Type() string { return "revoke_fee_allowance" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgRevokeFeeAllowance) ValidateBasic() error {
+	if msg.Granter.IsZero() {
+		return std.ErrInvalidAddress("missing granter address")
+	}
+	if msg.Grantee.IsZero() {
+		return std.ErrInvalidAddress("missing grantee address")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgRevokeFeeAllowance) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgRevokeFeeAllowance) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Granter}
+}
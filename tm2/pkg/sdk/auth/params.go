@@ -19,6 +19,7 @@ const (
 	DefaultTxSizeCostPerByte      int64 = 10
 	DefaultSigVerifyCostED25519   int64 = 590
 	DefaultSigVerifyCostSecp256k1 int64 = 1000
+	DefaultSigVerifyCostSecp256r1 int64 = 1000
 
 	DefaultGasPricesChangeCompressor int64 = 10
 	DefaultTargetGasRatio            int64 = 70 //  70% of the MaxGas in a block
@@ -31,6 +32,7 @@ type Params struct {
 	TxSizeCostPerByte         int64            `json:"tx_size_cost_per_byte" yaml:"tx_size_cost_per_byte"`
 	SigVerifyCostED25519      int64            `json:"sig_verify_cost_ed25519" yaml:"sig_verify_cost_ed25519"`
 	SigVerifyCostSecp256k1    int64            `json:"sig_verify_cost_secp256k1" yaml:"sig_verify_cost_secp256k1"`
+	SigVerifyCostSecp256r1    int64            `json:"sig_verify_cost_secp256r1" yaml:"sig_verify_cost_secp256r1"`
 	GasPricesChangeCompressor int64            `json:"gas_price_change_compressor" yaml:"gas_price_change_compressor"`
 	TargetGasRatio            int64            `json:"target_gas_ratio" yaml:"target_gas_ratio"`
 	InitialGasPrice           std.GasPrice     `json:"initial_gasprice"`
@@ -39,7 +41,7 @@ type Params struct {
 
 // NewParams creates a new Params object
 func NewParams(maxMemoBytes, txSigLimit, txSizeCostPerByte,
-	sigVerifyCostED25519, sigVerifyCostSecp256k1, gasPricesChangeCompressor, targetGasRatio int64,
+	sigVerifyCostED25519, sigVerifyCostSecp256k1, sigVerifyCostSecp256r1, gasPricesChangeCompressor, targetGasRatio int64,
 ) Params {
 	return Params{
 		MaxMemoBytes:              maxMemoBytes,
@@ -47,6 +49,7 @@ func NewParams(maxMemoBytes, txSigLimit, txSizeCostPerByte,
 		TxSizeCostPerByte:         txSizeCostPerByte,
 		SigVerifyCostED25519:      sigVerifyCostED25519,
 		SigVerifyCostSecp256k1:    sigVerifyCostSecp256k1,
+		SigVerifyCostSecp256r1:    sigVerifyCostSecp256r1,
 		GasPricesChangeCompressor: gasPricesChangeCompressor,
 		TargetGasRatio:            targetGasRatio,
 	}
@@ -65,6 +68,7 @@ func DefaultParams() Params {
 		DefaultTxSizeCostPerByte,
 		DefaultSigVerifyCostED25519,
 		DefaultSigVerifyCostSecp256k1,
+		DefaultSigVerifyCostSecp256r1,
 		DefaultGasPricesChangeCompressor,
 		DefaultTargetGasRatio,
 	)
@@ -80,11 +84,21 @@ func (p Params) String() string {
 	fmt.Fprintf(sb, "TxSizeCostPerByte: %d\n", p.TxSizeCostPerByte)
 	fmt.Fprintf(sb, "SigVerifyCostED25519: %d\n", p.SigVerifyCostED25519)
 	fmt.Fprintf(sb, "SigVerifyCostSecp256k1: %d\n", p.SigVerifyCostSecp256k1)
+	fmt.Fprintf(sb, "SigVerifyCostSecp256r1: %d\n", p.SigVerifyCostSecp256r1)
 	fmt.Fprintf(sb, "GasPricesChangeCompressor: %d\n", p.GasPricesChangeCompressor)
 	fmt.Fprintf(sb, "TargetGasRatio: %d\n", p.TargetGasRatio)
 	return sb.String()
 }
 
+// sigVerifyCostSecp256r1 returns the cost of verifying a secp256r1 signature,
+// which defaults to DefaultSigVerifyCostSecp256r1 if unset.
+func (p Params) sigVerifyCostSecp256r1() int64 {
+	if p.SigVerifyCostSecp256r1 == 0 {
+		return DefaultSigVerifyCostSecp256r1
+	}
+	return p.SigVerifyCostSecp256r1
+}
+
 func (p Params) Validate() error {
 	if p.MaxMemoBytes <= 0 {
 		return fmt.Errorf("invalid max memo bytes: %d", p.MaxMemoBytes)
@@ -98,6 +112,11 @@ func (p Params) Validate() error {
 	if p.SigVerifyCostSecp256k1 <= 0 {
 		return fmt.Errorf("invalid SECK256k1 signature verification cost: %d", p.SigVerifyCostSecp256k1)
 	}
+	// 0 is allowed for the params set before secp256r1 keys were supported,
+	// DefaultSigVerifyCostSecp256r1 is used instead (see sigVerifyCostSecp256r1).
+	if p.SigVerifyCostSecp256r1 < 0 {
+		return fmt.Errorf("invalid SECP256r1 signature verification cost: %d", p.SigVerifyCostSecp256r1)
+	}
 	if p.TxSizeCostPerByte <= 0 {
 		return fmt.Errorf("invalid tx size cost per byte: %d", p.TxSizeCostPerByte)
 	}
@@ -22,6 +22,7 @@ func TestValidate(t *testing.T) {
 				TxSizeCostPerByte:         1,
 				SigVerifyCostED25519:      100,
 				SigVerifyCostSecp256k1:    200,
+				SigVerifyCostSecp256r1:    200,
 				GasPricesChangeCompressor: 1,
 				TargetGasRatio:            50,
 			},
@@ -41,6 +42,31 @@ func TestValidate(t *testing.T) {
 			},
 			expectsError: true,
 		},
+		{
+			name: "Invalid SigVerifyCostSecp256r1",
+			params: Params{
+				MaxMemoBytes:              256,
+				TxSigLimit:                10,
+				TxSizeCostPerByte:         1,
+				SigVerifyCostED25519:      100,
+				SigVerifyCostSecp256k1:    200,
+				SigVerifyCostSecp256r1:    -1,
+				GasPricesChangeCompressor: 1,
+			},
+			expectsError: true,
+		},
+		{
+			name: "Unset SigVerifyCostSecp256r1",
+			params: Params{
+				MaxMemoBytes:              256,
+				TxSigLimit:                10,
+				TxSizeCostPerByte:         1,
+				SigVerifyCostED25519:      100,
+				SigVerifyCostSecp256k1:    200,
+				GasPricesChangeCompressor: 1,
+			},
+			expectsError: false,
+		},
 		{
 			name: "Invalid GasPricesChangeCompressor",
 			params: Params{
@@ -76,6 +102,7 @@ func TestNewParams(t *testing.T) {
 	txSizeCostPerByte := int64(5)
 	sigVerifyCostED25519 := int64(100)
 	sigVerifyCostSecp256k1 := int64(200)
+	sigVerifyCostSecp256r1 := int64(300)
 	gasPricesChangeCompressor := int64(50)
 	targetGasRatio := int64(75)
 
@@ -86,6 +113,7 @@ func TestNewParams(t *testing.T) {
 		txSizeCostPerByte,
 		sigVerifyCostED25519,
 		sigVerifyCostSecp256k1,
+		sigVerifyCostSecp256r1,
 		gasPricesChangeCompressor,
 		targetGasRatio,
 	)
@@ -97,6 +125,7 @@ func TestNewParams(t *testing.T) {
 		TxSizeCostPerByte:         txSizeCostPerByte,
 		SigVerifyCostED25519:      sigVerifyCostED25519,
 		SigVerifyCostSecp256k1:    sigVerifyCostSecp256k1,
+		SigVerifyCostSecp256r1:    sigVerifyCostSecp256r1,
 		GasPricesChangeCompressor: gasPricesChangeCompressor,
 		TargetGasRatio:            targetGasRatio,
 	}
@@ -113,11 +142,11 @@ func TestParamsString(t *testing.T) {
 		params Params
 		want   string
 	}{
-		{"blank params", Params{}, "Params: \nMaxMemoBytes: 0\nTxSigLimit: 0\nTxSizeCostPerByte: 0\nSigVerifyCostED25519: 0\nSigVerifyCostSecp256k1: 0\nGasPricesChangeCompressor: 0\nTargetGasRatio: 0\n"},
+		{"blank params", Params{}, "Params: \nMaxMemoBytes: 0\nTxSigLimit: 0\nTxSizeCostPerByte: 0\nSigVerifyCostED25519: 0\nSigVerifyCostSecp256k1: 0\nSigVerifyCostSecp256r1: 0\nGasPricesChangeCompressor: 0\nTargetGasRatio: 0\n"},
 		{"some values", Params{
 			MaxMemoBytes:      1_000_000,
 			TxSizeCostPerByte: 8192,
-		}, "Params: \nMaxMemoBytes: 1000000\nTxSigLimit: 0\nTxSizeCostPerByte: 8192\nSigVerifyCostED25519: 0\nSigVerifyCostSecp256k1: 0\nGasPricesChangeCompressor: 0\nTargetGasRatio: 0\n"},
+		}, "Params: \nMaxMemoBytes: 1000000\nTxSigLimit: 0\nTxSizeCostPerByte: 8192\nSigVerifyCostED25519: 0\nSigVerifyCostSecp256k1: 0\nSigVerifyCostSecp256r1: 0\nGasPricesChangeCompressor: 0\nTargetGasRatio: 0\n"},
 	}
 
 	for _, tt := range cases {
@@ -0,0 +1,73 @@
+package authz
+
+// DONTCOVER
+
+import (
+	"time"
+
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+)
+
+type testEnv struct {
+	ctx    sdk.Context
+	authzk AuthzKeeper
+	bankk  bank.BankKeeper
+	acck   auth.AccountKeeper
+	router sdk.Router
+}
+
+func setupTestEnv() testEnv {
+	db := memdb.NewMemDB()
+
+	authCapKey := store.NewStoreKey("authCapKey")
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(authCapKey, iavl.StoreConstructor, db)
+	ms.LoadLatestVersion()
+	ctx := sdk.NewContext(
+		sdk.RunTxModeDeliver,
+		ms,
+		&bft.Header{ChainID: "test-chain-id", Time: time.Unix(1000, 0)},
+		log.NewNoopLogger(),
+	)
+
+	prmk := params.NewParamsKeeper(authCapKey)
+	acck := auth.NewAccountKeeper(authCapKey, prmk.ForModule(auth.ModuleName), std.ProtoBaseAccount)
+	bankk := bank.NewBankKeeper(acck, prmk.ForModule(bank.ModuleName))
+	authzk := NewAuthzKeeper(authCapKey)
+
+	prmk.Register(auth.ModuleName, acck)
+	prmk.Register(bank.ModuleName, bankk)
+
+	router := sdk.NewRouter()
+	router.AddRoute(bank.ModuleName, bank.NewHandler(bankk))
+
+	return testEnv{ctx: ctx, authzk: authzk, bankk: bankk, acck: acck, router: router}
+}
+
+// scopedMsg is a test msg targeting a package function
+type scopedMsg struct {
+	Caller  crypto.Address
+	PkgPath string
+	Func    string
+}
+
+var _ ScopedMsg = scopedMsg{}
+
+func (msg scopedMsg) Route() string                    { return "test" }
+func (msg scopedMsg) Type() string                     { return "call" }
+func (msg scopedMsg) ValidateBasic() error             { return nil }
+func (msg scopedMsg) GetSignBytes() []byte             { return nil }
+func (msg scopedMsg) GetSigners() []crypto.Address     { return []crypto.Address{msg.Caller} }
+func (msg scopedMsg) AuthzScope() (pkgPath, fn string) { return msg.PkgPath, msg.Func }
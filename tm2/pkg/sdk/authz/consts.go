@@ -0,0 +1,26 @@
+package authz
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+const (
+	// module name
+	ModuleName = "authz"
+
+	// GrantStoreKeyPrefix prefix for the grants, by granter and grantee
+	GrantStoreKeyPrefix = "/ag/"
+)
+
+// GrantsStoreKey turn a granter and grantee pair to the key prefix
+// used to get all the grants between them from the store
+func GrantsStoreKey(granter, grantee crypto.Address) []byte {
+	key := append([]byte(GrantStoreKeyPrefix), granter.Bytes()...)
+	return append(key, grantee.Bytes()...)
+}
+
+// GrantStoreKey turn a granter and grantee pair, and a grant scope
+// (msg type, package path and function) to the key used to get the grant from the store
+func GrantStoreKey(granter, grantee crypto.Address, msgType, pkgPath, fn string) []byte {
+	return append(GrantsStoreKey(granter, grantee), []byte(msgType+":"+pkgPath+":"+fn)...)
+}
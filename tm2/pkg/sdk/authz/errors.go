@@ -0,0 +1,40 @@
+package authz
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// for convenience:
+type abciError struct{}
+
+func (abciError) AssertABCIError() {}
+
+// declare all authz errors.
+// NOTE: these are meant to be used in conjunction with pkgs/errors.
+type (
+	NoGrantError            struct{ abciError }
+	GrantExpiredError       struct{ abciError }
+	SpendLimitExceededError struct{ abciError }
+	InvalidExecMsgError     struct{ abciError }
+)
+
+func (e NoGrantError) Error() string            { return "no authorization grant" }
+func (e GrantExpiredError) Error() string       { return "authorization grant expired" }
+func (e SpendLimitExceededError) Error() string { return "authorization spend limit exceeded" }
+func (e InvalidExecMsgError) Error() string     { return "invalid msg to execute" }
+
+func ErrNoGrant(msg string) error {
+	return errors.Wrap(NoGrantError{}, msg)
+}
+
+func ErrGrantExpired(msg string) error {
+	return errors.Wrap(GrantExpiredError{}, msg)
+}
+
+func ErrSpendLimitExceeded(msg string) error {
+	return errors.Wrap(SpendLimitExceededError{}, msg)
+}
+
+func ErrInvalidExecMsg(msg string) error {
+	return errors.Wrap(InvalidExecMsgError{}, msg)
+}
@@ -0,0 +1,191 @@
+package authz
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type authzHandler struct {
+	authz  AuthzKeeperI
+	router sdk.Router
+}
+
+// NewHandler returns a handler for "authz" type messages.
+// The router is used to execute the msgs of MsgExec.
+func NewHandler(authz AuthzKeeperI, router sdk.Router) authzHandler {
+	return authzHandler{
+		authz:  authz,
+		router: router,
+	}
+}
+
+func (ah authzHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
+	switch msg := msg.(type) {
+	case MsgGrant:
+		return ah.handleMsgGrant(ctx, msg)
+
+	case MsgRevoke:
+		return ah.handleMsgRevoke(ctx, msg)
+
+	case MsgExec:
+		return ah.handleMsgExec(ctx, msg)
+
+	default:
+		errMsg := fmt.Sprintf("unrecognized authz message type: %T", msg)
+		return abciResult(std.ErrUnknownRequest(errMsg))
+	}
+}
+
+// Handle MsgGrant.
+func (ah authzHandler) handleMsgGrant(ctx sdk.Context, msg MsgGrant) sdk.Result {
+	if msg.Grant.IsExpired(ctx.BlockTime().Unix()) {
+		return abciResult(std.ErrUnknownRequest("grant expiration is in the past"))
+	}
+
+	ah.authz.SetGrant(ctx, msg.Grant)
+
+	return sdk.Result{}
+}
+
+// Handle MsgRevoke.
+func (ah authzHandler) handleMsgRevoke(ctx sdk.Context, msg MsgRevoke) sdk.Result {
+	if _, ok := ah.authz.GetGrant(ctx, msg.Granter, msg.Grantee, msg.MsgType, msg.PkgPath, msg.Func); !ok {
+		return abciResult(ErrNoGrant(
+			fmt.Sprintf("no %q grant from %s to %s", msg.MsgType, msg.Granter, msg.Grantee),
+		))
+	}
+
+	ah.authz.RemoveGrant(ctx, msg.Granter, msg.Grantee, msg.MsgType, msg.PkgPath, msg.Func)
+
+	return sdk.Result{}
+}
+
+// Handle MsgExec.
+// Each msg is routed to its handler as if it was signed by its signer (the granter),
+// after checking the grantee is authorized to execute it.
+func (ah authzHandler) handleMsgExec(ctx sdk.Context, msg MsgExec) sdk.Result {
+	var (
+		data   []byte
+		events []sdk.Event
+		logs   = make([]string, 0, len(msg.Msgs))
+	)
+
+	for i, m := range msg.Msgs {
+		if err := ah.authz.Accept(ctx, msg.Grantee, m); err != nil {
+			return abciResult(err)
+		}
+
+		handler := ah.router.Route(m.Route())
+		if handler == nil {
+			return abciResult(std.ErrUnknownRequest("unrecognized message type: " + m.Route()))
+		}
+
+		res := handler.Process(ctx, m)
+		if !res.IsOK() {
+			return res
+		}
+
+		data = append(data, res.Data...)
+		events = append(events, res.Events...)
+		logs = append(logs, fmt.Sprintf("msg:%d,log:%s", i, res.Log))
+	}
+
+	res := sdk.Result{}
+	res.Data = data
+	res.Events = events
+	res.Log = strings.Join(logs, "\n")
+	return res
+}
+
+//----------------------------------------
+// Query
+
+// query paths
+const QueryGrants = "grants"
+
+func (ah authzHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	switch secondPart(req.Path) {
+	case QueryGrants:
+		return ah.queryGrants(ctx, req)
+	default:
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest("unknown authz query endpoint"))
+		return
+	}
+}
+
+// queryGrants fetch the grants between a granter and a grantee.
+// Granter and grantee addresses are passed as path components.
+func (ah authzHandler) queryGrants(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	var (
+		b32granter = thirdPart(req.Path)
+		b32grantee = fourthPart(req.Path)
+	)
+
+	granter, err := crypto.AddressFromBech32(b32granter)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid query granter address " + b32granter))
+		return
+	}
+
+	grantee, err := crypto.AddressFromBech32(b32grantee)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid query grantee address " + b32grantee))
+		return
+	}
+
+	bz, err := amino.MarshalJSONIndent(ah.authz.GetGrants(ctx, granter, grantee), "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
+//----------------------------------------
+// misc
+
+func abciResult(err error) sdk.Result {
+	return sdk.ABCIResultFromError(err)
+}
+
+// returns the second component of a path.
+func secondPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return ""
+	} else {
+		return parts[1]
+	}
+}
+
+// returns the third component of a path.
+func thirdPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return ""
+	} else {
+		return parts[2]
+	}
+}
+
+// returns the fourth component of a path.
+func fourthPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 4 {
+		return ""
+	} else {
+		return parts[3]
+	}
+}
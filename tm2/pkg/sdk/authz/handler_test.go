@@ -0,0 +1,126 @@
+package authz
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	tu "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestInvalidMsg(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(AuthzKeeper{}, sdk.NewRouter())
+	res := h.Process(sdk.NewContext(sdk.RunTxModeDeliver, nil, &bft.Header{ChainID: "test-chain"}, nil), tu.NewTestMsg())
+	require.False(t, res.IsOK())
+	require.True(t, strings.Contains(res.Log, "unrecognized authz message type"))
+}
+
+func TestExecMsg(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.authzk, env.router)
+
+	var (
+		granter = crypto.AddressFromPreimage([]byte("granter"))
+		grantee = crypto.AddressFromPreimage([]byte("grantee"))
+		other   = crypto.AddressFromPreimage([]byte("other"))
+	)
+
+	_, err := env.bankk.AddCoins(env.ctx, granter, std.NewCoins(std.NewCoin("foo", 100)))
+	require.NoError(t, err)
+
+	exec := NewMsgExec(grantee, []std.Msg{
+		bank.NewMsgSend(granter, other, std.NewCoins(std.NewCoin("foo", 30))),
+	})
+
+	// not authorized yet
+	res := h.Process(env.ctx, exec)
+	require.False(t, res.IsOK())
+	assert.True(t, strings.Contains(res.Log, "not authorized"))
+
+	// grant the grantee, and execute the send on behalf of the granter
+	res = h.Process(env.ctx, NewMsgGrant(Grant{
+		Granter:    granter,
+		Grantee:    grantee,
+		MsgType:    "bank/send",
+		SpendLimit: std.NewCoins(std.NewCoin("foo", 50)),
+	}))
+	require.True(t, res.IsOK(), res.Log)
+
+	res = h.Process(env.ctx, exec)
+	require.True(t, res.IsOK(), res.Log)
+
+	assert.Equal(t, int64(70), env.bankk.GetCoins(env.ctx, granter).AmountOf("foo"))
+	assert.Equal(t, int64(30), env.bankk.GetCoins(env.ctx, other).AmountOf("foo"))
+
+	// the spend limit is exceeded
+	res = h.Process(env.ctx, exec)
+	require.False(t, res.IsOK())
+
+	// revoke the grant
+	res = h.Process(env.ctx, NewMsgRevoke(granter, grantee, "bank/send", "", ""))
+	require.True(t, res.IsOK(), res.Log)
+
+	res = h.Process(env.ctx, NewMsgRevoke(granter, grantee, "bank/send", "", ""))
+	require.False(t, res.IsOK())
+}
+
+func TestQueryGrants(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.authzk, env.router)
+
+	var (
+		granter = crypto.AddressFromPreimage([]byte("granter"))
+		grantee = crypto.AddressFromPreimage([]byte("grantee"))
+		grant   = Grant{Granter: granter, Grantee: grantee, MsgType: "vm/exec", PkgPath: "gno.land/r/demo/foo"}
+	)
+
+	env.authzk.SetGrant(env.ctx, grant)
+
+	res := h.Query(env.ctx, abci.RequestQuery{
+		Path: fmt.Sprintf("authz/%s/%s/%s", QueryGrants, granter, grantee),
+	})
+	require.Nil(t, res.Error)
+
+	var grants []Grant
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &grants))
+	assert.Equal(t, []Grant{grant}, grants)
+
+	res = h.Query(env.ctx, abci.RequestQuery{
+		Path: fmt.Sprintf("authz/%s/invalid/%s", QueryGrants, grantee),
+	})
+	assert.NotNil(t, res.Error)
+}
+
+func TestMsgExecValidation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		granter = crypto.AddressFromPreimage([]byte("granter"))
+		grantee = crypto.AddressFromPreimage([]byte("grantee"))
+		send    = bank.NewMsgSend(granter, grantee, std.NewCoins(std.NewCoin("foo", 1)))
+	)
+
+	assert.NoError(t, NewMsgExec(grantee, []std.Msg{send}).ValidateBasic())
+	assert.Error(t, NewMsgExec(grantee, nil).ValidateBasic())
+	assert.Error(t, NewMsgExec(crypto.Address{}, []std.Msg{send}).ValidateBasic())
+	assert.Error(t, NewMsgExec(grantee, []std.Msg{NewMsgExec(grantee, []std.Msg{send})}).ValidateBasic())
+
+	assert.Error(t, NewMsgGrant(Grant{Granter: granter, Grantee: grantee, MsgType: "authz/exec"}).ValidateBasic())
+	assert.Error(t, NewMsgGrant(Grant{Granter: granter, Grantee: grantee, MsgType: "vm/exec", Func: "Foo"}).ValidateBasic())
+}
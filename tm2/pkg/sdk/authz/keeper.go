@@ -0,0 +1,137 @@
+package authz
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// AuthzKeeperI defines a module interface that stores the authorization
+// grants, and checks that msgs executed on behalf of a granter are covered by them.
+type AuthzKeeperI interface {
+	SetGrant(ctx sdk.Context, grant Grant)
+	GetGrant(ctx sdk.Context, granter, grantee crypto.Address, msgType, pkgPath, fn string) (Grant, bool)
+	GetGrants(ctx sdk.Context, granter, grantee crypto.Address) []Grant
+	RemoveGrant(ctx sdk.Context, granter, grantee crypto.Address, msgType, pkgPath, fn string)
+
+	Accept(ctx sdk.Context, grantee crypto.Address, msg std.Msg) error
+}
+
+var _ AuthzKeeperI = AuthzKeeper{}
+
+// AuthzKeeper stores the authorization grants.
+type AuthzKeeper struct {
+	// The (unexposed) key used to access the store from the Context.
+	key store.StoreKey
+}
+
+// NewAuthzKeeper returns a new AuthzKeeper.
+func NewAuthzKeeper(key store.StoreKey) AuthzKeeper {
+	return AuthzKeeper{
+		key: key,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (ak AuthzKeeper) Logger(ctx sdk.Context) *slog.Logger {
+	return ctx.Logger().With("module", ModuleName)
+}
+
+// SetGrant stores the grant, overwriting any existing grant with the same scope.
+func (ak AuthzKeeper) SetGrant(ctx sdk.Context, grant Grant) {
+	stor := ctx.GasStore(ak.key)
+	bz, err := amino.Marshal(grant)
+	if err != nil {
+		panic(err)
+	}
+	stor.Set(GrantStoreKey(grant.Granter, grant.Grantee, grant.MsgType, grant.PkgPath, grant.Func), bz)
+}
+
+// GetGrant returns the grant with the exact given scope, if any.
+func (ak AuthzKeeper) GetGrant(ctx sdk.Context, granter, grantee crypto.Address, msgType, pkgPath, fn string) (Grant, bool) {
+	stor := ctx.GasStore(ak.key)
+	bz := stor.Get(GrantStoreKey(granter, grantee, msgType, pkgPath, fn))
+	if bz == nil {
+		return Grant{}, false
+	}
+
+	var grant Grant
+	amino.MustUnmarshal(bz, &grant)
+	return grant, true
+}
+
+// GetGrants returns all the grants the granter has given to the grantee.
+func (ak AuthzKeeper) GetGrants(ctx sdk.Context, granter, grantee crypto.Address) []Grant {
+	stor := ctx.GasStore(ak.key)
+	iter := store.PrefixIterator(stor, GrantsStoreKey(granter, grantee))
+	defer iter.Close()
+
+	grants := []Grant{}
+	for ; iter.Valid(); iter.Next() {
+		var grant Grant
+		amino.MustUnmarshal(iter.Value(), &grant)
+
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+// RemoveGrant removes the grant with the exact given scope.
+func (ak AuthzKeeper) RemoveGrant(ctx sdk.Context, granter, grantee crypto.Address, msgType, pkgPath, fn string) {
+	stor := ctx.GasStore(ak.key)
+	stor.Delete(GrantStoreKey(granter, grantee, msgType, pkgPath, fn))
+}
+
+// Accept checks that the grantee is authorized to execute the msg on behalf
+// of its signer, and consumes the amount spent by the msg from the grant.
+// The most specific grant covering the msg is used.
+func (ak AuthzKeeper) Accept(ctx sdk.Context, grantee crypto.Address, msg std.Msg) error {
+	signers := msg.GetSigners()
+	if len(signers) != 1 {
+		return ErrInvalidExecMsg("msg must have exactly one signer")
+	}
+
+	granter := signers[0]
+	msgType := MsgTypeOf(msg)
+
+	grant, ok := ak.findGrant(ctx, granter, grantee, msg)
+	if !ok {
+		return ErrNoGrant(
+			fmt.Sprintf("%s is not authorized to execute %q on behalf of %s", grantee, msgType, granter),
+		)
+	}
+
+	updated, err := grant.Accept(msg, ctx.BlockTime().Unix())
+	if err != nil {
+		return err
+	}
+
+	ak.SetGrant(ctx, updated)
+
+	return nil
+}
+
+// findGrant returns the most specific grant covering the msg:
+// the grant for its function, then for its package, then for its type
+func (ak AuthzKeeper) findGrant(ctx sdk.Context, granter, grantee crypto.Address, msg std.Msg) (Grant, bool) {
+	msgType := MsgTypeOf(msg)
+
+	if sm, ok := msg.(ScopedMsg); ok {
+		pkgPath, fn := sm.AuthzScope()
+
+		if grant, ok := ak.GetGrant(ctx, granter, grantee, msgType, pkgPath, fn); ok && fn != "" {
+			return grant, true
+		}
+
+		if grant, ok := ak.GetGrant(ctx, granter, grantee, msgType, pkgPath, ""); ok && pkgPath != "" {
+			return grant, true
+		}
+	}
+
+	return ak.GetGrant(ctx, granter, grantee, msgType, "", "")
+}
@@ -0,0 +1,124 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestKeeperGrants(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+
+	var (
+		granter = crypto.AddressFromPreimage([]byte("granter"))
+		grantee = crypto.AddressFromPreimage([]byte("grantee"))
+	)
+
+	_, ok := env.authzk.GetGrant(env.ctx, granter, grantee, "bank/send", "", "")
+	require.False(t, ok)
+
+	g1 := Grant{Granter: granter, Grantee: grantee, MsgType: "bank/send"}
+	g2 := Grant{Granter: granter, Grantee: grantee, MsgType: "vm/exec", PkgPath: "gno.land/r/demo/foo", Func: "Bar"}
+
+	env.authzk.SetGrant(env.ctx, g1)
+	env.authzk.SetGrant(env.ctx, g2)
+
+	grant, ok := env.authzk.GetGrant(env.ctx, granter, grantee, "vm/exec", "gno.land/r/demo/foo", "Bar")
+	require.True(t, ok)
+	assert.Equal(t, g2, grant)
+
+	assert.ElementsMatch(t, []Grant{g1, g2}, env.authzk.GetGrants(env.ctx, granter, grantee))
+	assert.Empty(t, env.authzk.GetGrants(env.ctx, grantee, granter))
+
+	env.authzk.RemoveGrant(env.ctx, granter, grantee, "bank/send", "", "")
+	assert.Equal(t, []Grant{g2}, env.authzk.GetGrants(env.ctx, granter, grantee))
+}
+
+func TestKeeperAcceptScope(t *testing.T) {
+	t.Parallel()
+
+	var (
+		granter = crypto.AddressFromPreimage([]byte("granter"))
+		grantee = crypto.AddressFromPreimage([]byte("grantee"))
+
+		msg = scopedMsg{Caller: granter, PkgPath: "gno.land/r/demo/foo", Func: "Bar"}
+	)
+
+	testTable := []struct {
+		name     string
+		grant    Grant
+		accepted bool
+	}{
+		{"any func", Grant{MsgType: "test/call"}, true},
+		{"package funcs", Grant{MsgType: "test/call", PkgPath: "gno.land/r/demo/foo"}, true},
+		{"exact func", Grant{MsgType: "test/call", PkgPath: "gno.land/r/demo/foo", Func: "Bar"}, true},
+		{"other type", Grant{MsgType: "test/other"}, false},
+		{"other package", Grant{MsgType: "test/call", PkgPath: "gno.land/r/demo/baz"}, false},
+		{"other func", Grant{MsgType: "test/call", PkgPath: "gno.land/r/demo/foo", Func: "Baz"}, false},
+		{"expired", Grant{MsgType: "test/call", Expiration: 1000}, false},
+		{"not expired", Grant{MsgType: "test/call", Expiration: 1001}, true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := setupTestEnv()
+
+			grant := testCase.grant
+			grant.Granter = granter
+			grant.Grantee = grantee
+
+			env.authzk.SetGrant(env.ctx, grant)
+
+			err := env.authzk.Accept(env.ctx, grantee, msg)
+			if testCase.accepted {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestKeeperAcceptSpendLimit(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+
+	var (
+		granter = crypto.AddressFromPreimage([]byte("granter"))
+		grantee = crypto.AddressFromPreimage([]byte("grantee"))
+
+		send = func(amount int64) bank.MsgSend {
+			return bank.NewMsgSend(granter, grantee, std.NewCoins(std.NewCoin("foo", amount)))
+		}
+	)
+
+	// no spend limit, no funds can be spent
+	env.authzk.SetGrant(env.ctx, Grant{Granter: granter, Grantee: grantee, MsgType: "bank/send"})
+	assert.ErrorIs(t, env.authzk.Accept(env.ctx, grantee, send(1)), SpendLimitExceededError{})
+
+	env.authzk.SetGrant(env.ctx, Grant{
+		Granter:    granter,
+		Grantee:    grantee,
+		MsgType:    "bank/send",
+		SpendLimit: std.NewCoins(std.NewCoin("foo", 100)),
+	})
+
+	require.NoError(t, env.authzk.Accept(env.ctx, grantee, send(60)))
+
+	grant, ok := env.authzk.GetGrant(env.ctx, granter, grantee, "bank/send", "", "")
+	require.True(t, ok)
+	assert.Equal(t, std.NewCoins(std.NewCoin("foo", 40)), grant.SpendLimit)
+
+	assert.ErrorIs(t, env.authzk.Accept(env.ctx, grantee, send(60)), SpendLimitExceededError{})
+	require.NoError(t, env.authzk.Accept(env.ctx, grantee, send(40)))
+}
@@ -0,0 +1,153 @@
+package authz
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// RouterKey is they name of the authz module
+const RouterKey = ModuleName
+
+// MsgGrant - grants the grantee the authorization to execute msgs on behalf of the granter
+type MsgGrant struct {
+	Grant Grant `json:"grant" yaml:"grant"`
+}
+
+var _ std.Msg = MsgGrant{}
+
+// NewMsgGrant - construct an authorization grant msg.
+func NewMsgGrant(grant Grant) MsgGrant {
+	return MsgGrant{Grant: grant}
+}
+
+// Route Implements Msg.
+func (msg MsgGrant) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgGrant) Type() string { return "grant" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgGrant) ValidateBasic() error {
+	return msg.Grant.ValidateBasic()
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgGrant) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgGrant) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Grant.Granter}
+}
+
+// MsgRevoke - revokes an authorization grant
+type MsgRevoke struct {
+	Granter crypto.Address `json:"granter" yaml:"granter"`
+	Grantee crypto.Address `json:"grantee" yaml:"grantee"`
+	MsgType string         `json:"msg_type" yaml:"msg_type"`
+	PkgPath string         `json:"pkg_path" yaml:"pkg_path"`
+	Func    string         `json:"func" yaml:"func"`
+}
+
+var _ std.Msg = MsgRevoke{}
+
+// NewMsgRevoke - construct an authorization revocation msg.
+func NewMsgRevoke(granter, grantee crypto.Address, msgType, pkgPath, fn string) MsgRevoke {
+	return MsgRevoke{
+		Granter: granter,
+		Grantee: grantee,
+		MsgType: msgType,
+		PkgPath: pkgPath,
+		Func:    fn,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgRevoke) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgRevoke) Type() string { return "revoke" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgRevoke) ValidateBasic() error {
+	if msg.Granter.IsZero() {
+		return std.ErrInvalidAddress("missing granter address")
+	}
+	if msg.Grantee.IsZero() {
+		return std.ErrInvalidAddress("missing grantee address")
+	}
+	if msg.MsgType == "" {
+		return std.ErrUnknownRequest("missing msg type")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgRevoke) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgRevoke) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Granter}
+}
+
+// MsgExec - executes msgs on behalf of their signers,
+// using the authorization grants given to the grantee
+type MsgExec struct {
+	Grantee crypto.Address `json:"grantee" yaml:"grantee"`
+	Msgs    []std.Msg      `json:"msgs" yaml:"msgs"`
+}
+
+var _ std.Msg = MsgExec{}
+
+// NewMsgExec - construct an authorized execution msg.
+func NewMsgExec(grantee crypto.Address, msgs []std.Msg) MsgExec {
+	return MsgExec{
+		Grantee: grantee,
+		Msgs:    msgs,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgExec) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgExec) Type() string { return "exec" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgExec) ValidateBasic() error {
+	if msg.Grantee.IsZero() {
+		return std.ErrInvalidAddress("missing grantee address")
+	}
+	if len(msg.Msgs) == 0 {
+		return ErrInvalidExecMsg("no msgs to execute")
+	}
+
+	for i, m := range msg.Msgs {
+		if _, ok := m.(MsgExec); ok {
+			return ErrInvalidExecMsg(fmt.Sprintf("msg %d: nested exec msgs are not allowed", i))
+		}
+		if len(m.GetSigners()) != 1 {
+			return ErrInvalidExecMsg(fmt.Sprintf("msg %d: must have exactly one signer", i))
+		}
+		if err := m.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgExec) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgExec) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Grantee}
+}
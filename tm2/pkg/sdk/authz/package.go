@@ -0,0 +1,20 @@
+package authz
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+)
+
+var Package = amino.RegisterPackage(amino.NewPackage(
+	"github.com/gnolang/gno/tm2/pkg/sdk/authz",
+	"authz",
+	amino.GetCallersDirname(),
+).WithDependencies().WithTypes(
+	NoGrantError{}, "NoGrantError",
+	GrantExpiredError{}, "GrantExpiredError",
+	SpendLimitExceededError{}, "SpendLimitExceededError",
+	InvalidExecMsgError{}, "InvalidExecMsgError",
+	Grant{}, "Grant",
+	MsgGrant{}, "MsgGrant",
+	MsgRevoke{}, "MsgRevoke",
+	MsgExec{}, "MsgExec",
+))
@@ -0,0 +1,115 @@
+package authz
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// ScopedMsg is implemented by messages that can be authorized for a given
+// package path and function only (ie. a call to a specific realm function).
+type ScopedMsg interface {
+	std.Msg
+
+	// AuthzScope returns the package path and the function targeted by the msg.
+	AuthzScope() (pkgPath, fn string)
+}
+
+// SpendMsg is implemented by messages that spend the funds of their signer.
+type SpendMsg interface {
+	std.Msg
+
+	// AuthzSpend returns the amount spent by the msg signer.
+	AuthzSpend() std.Coins
+}
+
+// MsgTypeOf returns the msg type used to scope grants, "route/type" (ie. "vm/exec")
+func MsgTypeOf(msg std.Msg) string {
+	return msg.Route() + "/" + msg.Type()
+}
+
+// Grant allows the grantee to execute messages of the given type
+// on behalf of the granter (ie. a trading bot calling a realm function).
+type Grant struct {
+	Granter crypto.Address `json:"granter" yaml:"granter"`
+	Grantee crypto.Address `json:"grantee" yaml:"grantee"`
+	// MsgType is the type of the authorized messages, as "route/type" (ie. "vm/exec").
+	MsgType string `json:"msg_type" yaml:"msg_type"`
+	// PkgPath optionally limits the grant to messages targeting the given package.
+	PkgPath string `json:"pkg_path" yaml:"pkg_path"`
+	// Func optionally limits the grant to messages calling the given function of PkgPath.
+	Func string `json:"func" yaml:"func"`
+	// SpendLimit is the amount the grantee can still spend on behalf of the granter.
+	// If empty, the authorized messages can't spend any funds.
+	SpendLimit std.Coins `json:"spend_limit" yaml:"spend_limit"`
+	// Expiration is the unix time (in seconds) at which the grant expires.
+	// If zero, the grant never expires.
+	Expiration int64 `json:"expiration" yaml:"expiration"`
+}
+
+// IsExpired returns true if the grant is expired at the given unix time
+func (g Grant) IsExpired(now int64) bool {
+	return g.Expiration != 0 && now >= g.Expiration
+}
+
+// ValidateBasic performs stateless validation of the grant
+func (g Grant) ValidateBasic() error {
+	if g.Granter.IsZero() {
+		return std.ErrInvalidAddress("missing granter address")
+	}
+	if g.Grantee.IsZero() {
+		return std.ErrInvalidAddress("missing grantee address")
+	}
+	if g.Granter == g.Grantee {
+		return std.ErrInvalidAddress("granter and grantee must differ")
+	}
+	if g.MsgType == "" {
+		return std.ErrUnknownRequest("missing msg type")
+	}
+	if g.MsgType == MsgTypeOf(MsgExec{}) {
+		return std.ErrUnknownRequest("exec msgs can't be granted")
+	}
+	if g.Func != "" && g.PkgPath == "" {
+		return std.ErrUnknownRequest("func scope requires a pkg path")
+	}
+	if !g.SpendLimit.Empty() {
+		if !g.SpendLimit.IsValid() {
+			return std.ErrInvalidCoins(g.SpendLimit.String())
+		}
+		if !g.SpendLimit.IsAllPositive() {
+			return std.ErrInvalidCoins("spend limit must be positive")
+		}
+	}
+	if g.Expiration < 0 {
+		return std.ErrUnknownRequest("expiration must not be negative")
+	}
+	return nil
+}
+
+// Accept checks whether the grant covers the given msg at the given unix time,
+// and returns the grant updated with the remaining spend limit
+func (g Grant) Accept(msg std.Msg, now int64) (Grant, error) {
+	if g.IsExpired(now) {
+		return g, ErrGrantExpired("")
+	}
+
+	var spend std.Coins
+	if sm, ok := msg.(SpendMsg); ok {
+		spend = sm.AuthzSpend()
+	}
+
+	if spend.IsZero() {
+		return g, nil
+	}
+
+	if g.SpendLimit.Empty() || !g.SpendLimit.IsAllGTE(spend) {
+		return g, ErrSpendLimitExceeded(
+			fmt.Sprintf("remaining: %q, spent: %q", g.SpendLimit.String(), spend.String()),
+		)
+	}
+
+	g.SpendLimit = g.SpendLimit.Sub(spend)
+
+	return g, nil
+}
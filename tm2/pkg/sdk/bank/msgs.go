@@ -57,6 +57,11 @@ func (msg MsgSend) GetSigners() []crypto.Address {
 	return []crypto.Address{msg.FromAddress}
 }
 
+// AuthzSpend returns the amount spent by the sender, when executed through authz.
+func (msg MsgSend) AuthzSpend() std.Coins {
+	return msg.Amount
+}
+
 // MsgMultiSend - high level transaction of the coin module
 type MsgMultiSend struct {
 	Inputs  []Input  `json:"inputs" yaml:"inputs"`
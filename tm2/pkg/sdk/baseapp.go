@@ -16,6 +16,9 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/traces"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Key to store the consensus params in the main store.
@@ -579,8 +582,10 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx)
 		return
 	} else {
 		ctx := app.getContextForTx(RunTxModeCheck, req.Tx)
+		ctx, span := startTxSpan(ctx, "CheckTx", req.Tx)
 
 		result := app.runTx(ctx, tx)
+		traces.EndSpan(span, result.Error)
 		res.ResponseBase = result.ResponseBase
 		res.GasWanted = result.GasWanted
 		res.GasUsed = result.GasUsed
@@ -597,8 +602,10 @@ func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliv
 		return
 	} else {
 		ctx := app.getContextForTx(RunTxModeDeliver, req.Tx)
+		ctx, span := startTxSpan(ctx, "DeliverTx", req.Tx)
 
 		result := app.runTx(ctx, tx)
+		traces.EndSpan(span, result.Error)
 		res.ResponseBase = result.ResponseBase
 		res.GasWanted = result.GasWanted
 		res.GasUsed = result.GasUsed
@@ -606,6 +613,31 @@ func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliv
 	}
 }
 
+// startTxSpan starts the trace span of the transaction execution,
+// continuing the trace that submitted the transaction to the node, if any
+func startTxSpan(ctx Context, name string, txBytes []byte) (Context, trace.Span) {
+	if !traces.Enabled() {
+		return ctx, trace.SpanFromContext(ctx.Context())
+	}
+
+	hash := bft.Tx(txBytes).Hash()
+
+	// The trace context is kept until the transaction is delivered
+	txCtx := traces.TxContext
+	if ctx.Mode() == RunTxModeDeliver {
+		txCtx = traces.ConsumeTxContext
+	}
+
+	spanCtx, span := traces.StartSpan(
+		txCtx(ctx.Context(), hash),
+		name,
+		attribute.String("tx.hash", fmt.Sprintf("%X", hash)),
+		attribute.Int64("block.height", ctx.BlockHeight()),
+	)
+
+	return ctx.WithContext(spanCtx), span
+}
+
 // validateBasicTxMsgs executes basic validator calls for messages.
 func validateBasicTxMsgs(msgs []Msg) error {
 	if msgs == nil || len(msgs) == 0 {
@@ -669,7 +701,14 @@ func (app *BaseApp) runMsgs(ctx Context, msgs []Msg, mode RunTxMode) (result Res
 		// run the message!
 		// skip actual execution for CheckTx mode
 		if mode != RunTxModeCheck {
-			msgResult = handler.Process(ctx, msg) // ctx event logger being updated in handler
+			msgCtx, span := traces.StartSpan(
+				ctx.Context(),
+				"msg."+msgRoute+"."+msg.Type(),
+				attribute.Int("msg.index", i),
+			)
+
+			msgResult = handler.Process(ctx.WithContext(msgCtx), msg) // ctx event logger being updated in handler
+			traces.EndSpan(span, msgResult.Error)
 		}
 
 		// Each message result's Data must be length prefixed in order to separate
@@ -884,8 +923,11 @@ func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
 func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBlock) {
 	if app.endBlocker != nil {
 		// we need to load consensusParams to the end blocker Context
-		// end blocker use consensusParams to calculat the gas price changes.
-		ctx := app.deliverState.ctx.WithConsensusParams(app.consensusParams)
+		// end blocker use consensusParams to calculat the gas price changes,
+		// and the vote infos to distribute the collected fees.
+		ctx := app.deliverState.ctx.
+			WithConsensusParams(app.consensusParams).
+			WithVoteInfos(app.voteInfos)
 		res = app.endBlocker(ctx, req)
 	}
 
@@ -902,6 +944,13 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	header := app.deliverState.ctx.BlockHeader()
 
+	spanCtx, span := traces.StartSpan(
+		app.deliverState.ctx.Context(),
+		"Commit",
+		attribute.Int64("block.height", header.GetHeight()),
+	)
+	defer span.End()
+
 	var halt bool
 
 	switch {
@@ -924,7 +973,9 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// The write to the DeliverTx state writes all state transitions to the root
 	// MultiStore (app.cms) so when Commit() is called is persists those values.
 	app.deliverState.ms.MultiWrite()
+	_, storeSpan := traces.StartSpan(spanCtx, "store.Commit")
 	commitID := app.cms.Commit()
+	storeSpan.End()
 	app.logger.Debug("Commit synced", "commit", fmt.Sprintf("%X", commitID))
 
 	// Save this header.
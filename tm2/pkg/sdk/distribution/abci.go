@@ -0,0 +1,11 @@
+package distribution
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+)
+
+// EndBlocker is called in the EndBlock(), it distributes the fees
+// collected in the block
+func EndBlocker(ctx sdk.Context, dk DistributionKeeperI) {
+	dk.DistributeFees(ctx)
+}
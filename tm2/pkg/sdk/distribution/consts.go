@@ -0,0 +1,57 @@
+package distribution
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+const (
+	// module name
+	ModuleName = "distribution"
+
+	// DistributionAccountName the root string for the distribution account address
+	DistributionAccountName = "distribution"
+
+	// RewardsStoreKeyPrefix prefix for the withdrawable rewards, by address
+	RewardsStoreKeyPrefix = "/dr/"
+	// AuthorGasStoreKeyPrefix prefix for the gas consumed by realm code in the current block, by author
+	AuthorGasStoreKeyPrefix = "/dg/"
+	// RewardAddressStoreKeyPrefix prefix for the reward addresses, by validator address
+	RewardAddressStoreKeyPrefix = "/dva/"
+	// ValidatorRewardsStoreKeyPrefix prefix for the rewards held for validators
+	// without a reward address, by validator address
+	ValidatorRewardsStoreKeyPrefix = "/dvr/"
+	// key for the community pool
+	CommunityPoolKey = "/dcp"
+)
+
+// RewardsStoreKey turn an address to key used to get its rewards from the store
+func RewardsStoreKey(addr crypto.Address) []byte {
+	return append([]byte(RewardsStoreKeyPrefix), addr.Bytes()...)
+}
+
+// AuthorGasStoreKey turn an address to key used to get its consumed gas from the store
+func AuthorGasStoreKey(addr crypto.Address) []byte {
+	return append([]byte(AuthorGasStoreKeyPrefix), addr.Bytes()...)
+}
+
+// RewardAddressStoreKey turn a validator address to key used to get its reward address from the store
+func RewardAddressStoreKey(val crypto.Address) []byte {
+	return append([]byte(RewardAddressStoreKeyPrefix), val.Bytes()...)
+}
+
+// ValidatorRewardsStoreKey turn a validator address to key used to get its held rewards from the store
+func ValidatorRewardsStoreKey(val crypto.Address) []byte {
+	return append([]byte(ValidatorRewardsStoreKeyPrefix), val.Bytes()...)
+}
+
+// NOTE: do not modify.
+var distributionAccount crypto.Address
+
+// DistributionAddress returns the address of the account
+// holding all the unclaimed rewards, and the community pool
+func DistributionAddress() crypto.Address {
+	if distributionAccount.IsZero() {
+		distributionAccount = crypto.AddressFromPreimage([]byte(DistributionAccountName))
+	}
+	return distributionAccount
+}
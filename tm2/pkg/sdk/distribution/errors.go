@@ -0,0 +1,36 @@
+package distribution
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// for convenience:
+type abciError struct{}
+
+func (abciError) AssertABCIError() {}
+
+// declare all distribution errors.
+// NOTE: these are meant to be used in conjunction with pkgs/errors.
+type NoRewardsError struct{ abciError }
+
+func (e NoRewardsError) Error() string { return "no rewards to withdraw" }
+
+type UnauthorizedError struct{ abciError }
+
+func (e UnauthorizedError) Error() string { return "unauthorized" }
+
+type InsufficientPoolError struct{ abciError }
+
+func (e InsufficientPoolError) Error() string { return "insufficient community pool" }
+
+func ErrNoRewards() error {
+	return errors.Wrap(NoRewardsError{}, "")
+}
+
+func ErrUnauthorized(msg string) error {
+	return errors.Wrap(UnauthorizedError{}, msg)
+}
+
+func ErrInsufficientPool(msg string) error {
+	return errors.Wrap(InsufficientPoolError{}, msg)
+}
@@ -0,0 +1,87 @@
+package distribution
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// GenesisState - all state that must be provided at genesis
+type GenesisState struct {
+	Params          Params          `json:"params" yaml:"params"`
+	RewardAddresses []RewardAddress `json:"reward_addresses" yaml:"reward_addresses"`
+}
+
+// RewardAddress is the address receiving the rewards of a validator,
+// which is identified by the address of its consensus key
+type RewardAddress struct {
+	Validator crypto.Address `json:"validator" yaml:"validator"`
+	Address   crypto.Address `json:"address" yaml:"address"`
+}
+
+// NewGenesisState - Create a new genesis state
+func NewGenesisState(params Params, rewardAddrs ...RewardAddress) GenesisState {
+	return GenesisState{
+		Params:          params,
+		RewardAddresses: rewardAddrs,
+	}
+}
+
+// DefaultGenesisState - Return a default genesis state
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState(DefaultParams())
+}
+
+// ValidateGenesis performs basic validation of genesis data returning an
+// error for any failed validation criteria.
+func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+
+	seen := make(map[crypto.Address]struct{}, len(data.RewardAddresses))
+	for _, ra := range data.RewardAddresses {
+		if ra.Validator.IsZero() || ra.Address.IsZero() {
+			return fmt.Errorf("invalid reward address %s for validator %s", ra.Address, ra.Validator)
+		}
+		if _, ok := seen[ra.Validator]; ok {
+			return fmt.Errorf("duplicate reward address for validator %s", ra.Validator)
+		}
+		seen[ra.Validator] = struct{}{}
+	}
+	return nil
+}
+
+// InitGenesis - Init store state from genesis data
+func (dk DistributionKeeper) InitGenesis(ctx sdk.Context, data GenesisState) {
+	if err := ValidateGenesis(data); err != nil {
+		panic(err)
+	}
+
+	if err := dk.SetParams(ctx, data.Params); err != nil {
+		panic(err)
+	}
+
+	for _, ra := range data.RewardAddresses {
+		dk.SetRewardAddress(ctx, ra.Validator, ra.Address)
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper
+func (dk DistributionKeeper) ExportGenesis(ctx sdk.Context) GenesisState {
+	params := dk.GetParams(ctx)
+
+	var rewardAddrs []RewardAddress
+	iter := store.PrefixIterator(ctx.Store(dk.key), []byte(RewardAddressStoreKeyPrefix))
+	for ; iter.Valid(); iter.Next() {
+		rewardAddrs = append(rewardAddrs, RewardAddress{
+			Validator: crypto.AddressFromBytes(iter.Key()[len(RewardAddressStoreKeyPrefix):]),
+			Address:   crypto.AddressFromBytes(iter.Value()),
+		})
+	}
+	iter.Close()
+
+	return NewGenesisState(params, rewardAddrs...)
+}
@@ -0,0 +1,162 @@
+package distribution
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type distributionHandler struct {
+	dist DistributionKeeperI
+}
+
+// NewHandler returns a handler for "distribution" type messages.
+func NewHandler(dist DistributionKeeperI) distributionHandler {
+	return distributionHandler{
+		dist: dist,
+	}
+}
+
+func (dh distributionHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
+	switch msg := msg.(type) {
+	case MsgWithdrawRewards:
+		return dh.handleMsgWithdrawRewards(ctx, msg)
+	case MsgSetRewardAddress:
+		return dh.handleMsgSetRewardAddress(ctx, msg)
+	case MsgWithdrawCommunityPool:
+		return dh.handleMsgWithdrawCommunityPool(ctx, msg)
+
+	default:
+		errMsg := fmt.Sprintf("unrecognized distribution message type: %T", msg)
+		return abciResult(std.ErrUnknownRequest(errMsg))
+	}
+}
+
+// Handle MsgWithdrawRewards.
+func (dh distributionHandler) handleMsgWithdrawRewards(ctx sdk.Context, msg MsgWithdrawRewards) sdk.Result {
+	rewards, err := dh.dist.WithdrawRewards(ctx, msg.Address)
+	if err != nil {
+		return abciResult(err)
+	}
+
+	res := sdk.Result{}
+	res.Data = []byte(rewards.String())
+	return res
+}
+
+// Handle MsgSetRewardAddress.
+func (dh distributionHandler) handleMsgSetRewardAddress(ctx sdk.Context, msg MsgSetRewardAddress) sdk.Result {
+	if err := dh.checkAuthority(ctx, msg.Authority); err != nil {
+		return abciResult(err)
+	}
+
+	dh.dist.SetRewardAddress(ctx, msg.Validator, msg.RewardAddress)
+
+	return sdk.Result{}
+}
+
+// Handle MsgWithdrawCommunityPool.
+func (dh distributionHandler) handleMsgWithdrawCommunityPool(ctx sdk.Context, msg MsgWithdrawCommunityPool) sdk.Result {
+	if err := dh.checkAuthority(ctx, msg.Authority); err != nil {
+		return abciResult(err)
+	}
+
+	if err := dh.dist.WithdrawCommunityPool(ctx, msg.Recipient, msg.Amount); err != nil {
+		return abciResult(err)
+	}
+
+	return sdk.Result{}
+}
+
+// checkAuthority returns an error if addr is not the distribution authority.
+func (dh distributionHandler) checkAuthority(ctx sdk.Context, addr crypto.Address) error {
+	authority := dh.dist.GetParams(ctx).Authority
+	if authority.IsZero() || authority != addr {
+		return ErrUnauthorized("only the distribution authority can do this")
+	}
+	return nil
+}
+
+//----------------------------------------
+// Query
+
+// query paths
+const (
+	QueryRewards       = "rewards"
+	QueryCommunityPool = "community_pool"
+	QueryParams        = "params"
+)
+
+func (dh distributionHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	switch secondPart(req.Path) {
+	case QueryRewards:
+		return dh.queryRewards(ctx, req)
+	case QueryCommunityPool:
+		return queryJSON(dh.dist.GetCommunityPool(ctx))
+	case QueryParams:
+		return queryJSON(dh.dist.GetParams(ctx))
+	default:
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest("unknown distribution query endpoint"))
+		return
+	}
+}
+
+// queryRewards fetch the withdrawable rewards of an address for the supplied height.
+// Address is passed as path component.
+func (dh distributionHandler) queryRewards(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	// parse addr from path.
+	b32addr := thirdPart(req.Path)
+	addr, err := crypto.AddressFromBech32(b32addr)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid query address " + b32addr))
+		return
+	}
+
+	return queryJSON(dh.dist.GetRewards(ctx, addr))
+}
+
+//----------------------------------------
+// misc
+
+func queryJSON(v any) (res abci.ResponseQuery) {
+	bz, err := amino.MarshalJSONIndent(v, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
+func abciResult(err error) sdk.Result {
+	return sdk.ABCIResultFromError(err)
+}
+
+// returns the second component of a path.
+func secondPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return ""
+	} else {
+		return parts[1]
+	}
+}
+
+// returns the third component of a path.
+func thirdPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return ""
+	} else {
+		return parts[2]
+	}
+}
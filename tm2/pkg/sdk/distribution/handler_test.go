@@ -0,0 +1,150 @@
+package distribution
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	tu "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestInvalidMsg(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(DistributionKeeper{})
+	res := h.Process(sdk.NewContext(sdk.RunTxModeDeliver, nil, &bft.Header{ChainID: "test-chain"}, nil), tu.NewTestMsg())
+	require.False(t, res.IsOK())
+	require.True(t, strings.Contains(res.Log, "unrecognized distribution message type"))
+}
+
+func TestWithdrawRewardsMsg(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.distk)
+	_, _, addr := tu.KeyTestPubAddr()
+	proposer := crypto.AddressFromPreimage([]byte("proposer"))
+	env.distk.SetRewardAddress(env.ctx, proposer, addr)
+
+	// Nothing to withdraw
+	res := h.Process(env.ctx, NewMsgWithdrawRewards(addr))
+	require.False(t, res.IsOK())
+
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", ProposerAddress: proposer})
+	_, err := env.bankk.AddCoins(ctx, auth.FeeCollectorAddress(), std.NewCoins(std.NewCoin("foo", 100)))
+	require.NoError(t, err)
+	env.distk.DistributeFees(ctx)
+
+	res = h.Process(ctx, NewMsgWithdrawRewards(addr))
+	require.True(t, res.IsOK())
+	require.Equal(t, "10foo", string(res.Data))
+	require.Equal(t, int64(10), env.bankk.GetCoins(ctx, addr).AmountOf("foo"))
+}
+
+func TestAuthorityMsgs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		authority = crypto.AddressFromPreimage([]byte("authority"))
+		other     = crypto.AddressFromPreimage([]byte("other"))
+		val       = crypto.AddressFromPreimage([]byte("val"))
+		operator  = crypto.AddressFromPreimage([]byte("operator"))
+		fees      = std.NewCoins(std.NewCoin("foo", 100))
+	)
+
+	env := setupTestEnv()
+	h := NewHandler(env.distk)
+	ctx := env.ctx
+
+	_, err := env.bankk.AddCoins(ctx, auth.FeeCollectorAddress(), fees)
+	require.NoError(t, err)
+	env.distk.DistributeFees(ctx)
+
+	// Without an authority, nobody can use the messages
+	res := h.Process(ctx, NewMsgWithdrawCommunityPool(authority, other, fees))
+	require.False(t, res.IsOK())
+	require.Contains(t, res.Log, "only the distribution authority")
+
+	params := env.distk.GetParams(ctx)
+	params.Authority = authority
+	require.NoError(t, env.distk.SetParams(ctx, params))
+
+	// Only the authority can use the messages
+	res = h.Process(ctx, NewMsgSetRewardAddress(other, val, operator))
+	require.False(t, res.IsOK())
+	res = h.Process(ctx, NewMsgWithdrawCommunityPool(other, other, fees))
+	require.False(t, res.IsOK())
+
+	res = h.Process(ctx, NewMsgSetRewardAddress(authority, val, operator))
+	require.True(t, res.IsOK(), res.Log)
+	addr, ok := env.distk.GetRewardAddress(ctx, val)
+	require.True(t, ok)
+	require.Equal(t, operator, addr)
+
+	res = h.Process(ctx, NewMsgWithdrawCommunityPool(authority, other, fees))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, fees, env.bankk.GetCoins(ctx, other))
+	require.True(t, env.distk.GetCommunityPool(ctx).IsZero())
+}
+
+func TestQueryRewards(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.distk)
+	_, _, addr := tu.KeyTestPubAddr()
+
+	req := abci.RequestQuery{
+		Path: fmt.Sprintf("distribution/%s/%s", QueryRewards, addr.String()),
+		Data: []byte{},
+	}
+
+	res := h.Query(env.ctx, req)
+	require.Nil(t, res.Error)
+
+	var coins std.Coins
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &coins))
+	require.True(t, coins.IsZero())
+
+	proposer := crypto.AddressFromPreimage([]byte("proposer"))
+	env.distk.SetRewardAddress(env.ctx, proposer, addr)
+
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", ProposerAddress: proposer})
+	_, err := env.bankk.AddCoins(ctx, auth.FeeCollectorAddress(), std.NewCoins(std.NewCoin("foo", 100)))
+	require.NoError(t, err)
+	env.distk.DistributeFees(ctx)
+
+	res = h.Query(ctx, req)
+	require.Nil(t, res.Error)
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &coins))
+	require.Equal(t, int64(10), coins.AmountOf("foo"))
+
+	// The community pool gets the rest
+	res = h.Query(ctx, abci.RequestQuery{Path: "distribution/" + QueryCommunityPool})
+	require.Nil(t, res.Error)
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &coins))
+	require.Equal(t, int64(90), coins.AmountOf("foo"))
+}
+
+func TestQuerierRouteNotFound(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.distk)
+	req := abci.RequestQuery{
+		Path: "distribution/notfound",
+		Data: []byte{},
+	}
+
+	res := h.Query(env.ctx, req)
+	require.Error(t, res.Error)
+}
@@ -0,0 +1,358 @@
+package distribution
+
+import (
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// BankKeeperI is the limited interface only needed for distribution.
+type BankKeeperI interface {
+	GetCoins(ctx sdk.Context, addr crypto.Address) std.Coins
+	SendCoinsUnrestricted(ctx sdk.Context, fromAddr crypto.Address, toAddr crypto.Address, amt std.Coins) error
+}
+
+// DistributionKeeperI defines a module interface that distributes the fees
+// collected in a block to the proposer, the signers, the realm authors and
+// the community pool, and keeps track of the withdrawable rewards.
+type DistributionKeeperI interface {
+	AddAuthorGas(ctx sdk.Context, author crypto.Address, gas int64)
+	DistributeFees(ctx sdk.Context)
+
+	GetRewards(ctx sdk.Context, addr crypto.Address) std.Coins
+	WithdrawRewards(ctx sdk.Context, addr crypto.Address) (std.Coins, error)
+	GetCommunityPool(ctx sdk.Context) std.Coins
+	WithdrawCommunityPool(ctx sdk.Context, recipient crypto.Address, amt std.Coins) error
+
+	GetRewardAddress(ctx sdk.Context, val crypto.Address) (crypto.Address, bool)
+	SetRewardAddress(ctx sdk.Context, val, addr crypto.Address)
+	GetValidatorRewards(ctx sdk.Context, val crypto.Address) std.Coins
+
+	InitGenesis(ctx sdk.Context, data GenesisState)
+	GetParams(ctx sdk.Context) Params
+}
+
+var _ DistributionKeeperI = DistributionKeeper{}
+
+// DistributionKeeper distributes the collected fees.
+// All undistributed coins are held by the distribution account,
+// see DistributionAddress().
+type DistributionKeeper struct {
+	// The (unexposed) key used to access the store from the Context.
+	key store.StoreKey
+
+	bank BankKeeperI
+	// The keeper used to store parameters
+	prmk params.ParamsKeeperI
+}
+
+// NewDistributionKeeper returns a new DistributionKeeper.
+func NewDistributionKeeper(key store.StoreKey, bank BankKeeperI, pk params.ParamsKeeperI) DistributionKeeper {
+	return DistributionKeeper{
+		key:  key,
+		bank: bank,
+		prmk: pk,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (dk DistributionKeeper) Logger(ctx sdk.Context) *slog.Logger {
+	return ctx.Logger().With("module", ModuleName)
+}
+
+// AddAuthorGas records the gas consumed by code written by the given author
+// in the current block. Authors are rewarded in proportion to the recorded gas.
+//
+// NOTE: the VM keeper attributes all the gas of a call to the author of the
+// called realm, including the gas used by the code of the packages it imports.
+func (dk DistributionKeeper) AddAuthorGas(ctx sdk.Context, author crypto.Address, gas int64) {
+	if author.IsZero() || gas <= 0 {
+		return
+	}
+
+	stor := ctx.Store(dk.key)
+	key := AuthorGasStoreKey(author)
+
+	var total int64
+	if bz := stor.Get(key); bz != nil {
+		amino.MustUnmarshal(bz, &total)
+	}
+	stor.Set(key, amino.MustMarshal(total+gas))
+}
+
+// DistributeFees splits the fees collected in the current block between the
+// block proposer, the signers of the last block (weighted by voting power),
+// the realm authors (weighted by consumed gas), and the community pool.
+// The community pool receives any share that can't be attributed,
+// including rounding remainders.
+//
+// Validators are identified by the address of their consensus key, which
+// can't be used to sign transactions safely: their rewards are credited to
+// their reward address, or held until one is set (see SetRewardAddress).
+func (dk DistributionKeeper) DistributeFees(ctx sdk.Context) {
+	// The author gas is only relevant for the current block
+	authors, authorsGas := dk.takeAuthorGas(ctx)
+
+	fees := dk.bank.GetCoins(ctx, auth.FeeCollectorAddress())
+	if fees.IsZero() {
+		return
+	}
+
+	// Move the fees to the distribution account
+	err := dk.bank.SendCoinsUnrestricted(ctx, auth.FeeCollectorAddress(), DistributionAddress(), fees)
+	if err != nil {
+		panic(err)
+	}
+
+	// The params are validated when set, but make sure to never hand out
+	// more than the fees, whatever is in the store.
+	var (
+		params      = dk.GetParams(ctx).capped()
+		distributed = std.Coins{}
+	)
+
+	// Reward the block proposer
+	if proposer := proposerAddress(ctx); !proposer.IsZero() {
+		reward := portion(fees, params.ProposerShare, 100)
+		dk.addValidatorRewards(ctx, proposer, reward)
+		distributed = distributed.Add(reward)
+	}
+
+	// Reward the signers of the last block, by voting power
+	var signersPower int64
+	for _, vote := range ctx.VoteInfos() {
+		if vote.SignedLastBlock {
+			signersPower += vote.Power
+		}
+	}
+
+	if signersPower > 0 {
+		signersReward := portion(fees, params.SignersShare, 100)
+		for _, vote := range ctx.VoteInfos() {
+			if !vote.SignedLastBlock {
+				continue
+			}
+			reward := portion(signersReward, vote.Power, signersPower)
+			dk.addValidatorRewards(ctx, vote.Address, reward)
+			distributed = distributed.Add(reward)
+		}
+	}
+
+	// Reward the realm authors, by consumed gas
+	if authorsGas > 0 {
+		authorsReward := portion(fees, params.AuthorsShare, 100)
+		for _, author := range authors {
+			reward := portion(authorsReward, author.gas, authorsGas)
+			dk.addRewards(ctx, author.address, reward)
+			distributed = distributed.Add(reward)
+		}
+	}
+
+	// Whatever is left goes to the community pool
+	if left := fees.Sub(distributed); !left.IsZero() {
+		dk.setCommunityPool(ctx, dk.GetCommunityPool(ctx).Add(left))
+	}
+
+	dk.Logger(ctx).Debug("distributed fees", "fees", fees.String(), "distributed", distributed.String())
+}
+
+// GetRewards returns the withdrawable rewards of the given address.
+func (dk DistributionKeeper) GetRewards(ctx sdk.Context, addr crypto.Address) std.Coins {
+	stor := ctx.Store(dk.key)
+	bz := stor.Get(RewardsStoreKey(addr))
+	if bz == nil {
+		return std.NewCoins()
+	}
+
+	var rewards std.Coins
+	amino.MustUnmarshal(bz, &rewards)
+	return rewards
+}
+
+// WithdrawRewards sends all the rewards of the given address to it.
+func (dk DistributionKeeper) WithdrawRewards(ctx sdk.Context, addr crypto.Address) (std.Coins, error) {
+	rewards := dk.GetRewards(ctx, addr)
+	if rewards.IsZero() {
+		return nil, ErrNoRewards()
+	}
+
+	if err := dk.bank.SendCoinsUnrestricted(ctx, DistributionAddress(), addr, rewards); err != nil {
+		return nil, err
+	}
+
+	ctx.Store(dk.key).Delete(RewardsStoreKey(addr))
+
+	return rewards, nil
+}
+
+// GetCommunityPool returns the coins in the community pool.
+func (dk DistributionKeeper) GetCommunityPool(ctx sdk.Context) std.Coins {
+	stor := ctx.Store(dk.key)
+	bz := stor.Get([]byte(CommunityPoolKey))
+	if bz == nil {
+		return std.NewCoins()
+	}
+
+	var pool std.Coins
+	amino.MustUnmarshal(bz, &pool)
+	return pool
+}
+
+// WithdrawCommunityPool sends the given coins from the community pool
+// to the recipient.
+func (dk DistributionKeeper) WithdrawCommunityPool(ctx sdk.Context, recipient crypto.Address, amt std.Coins) error {
+	pool := dk.GetCommunityPool(ctx)
+	if !pool.IsAllGTE(amt) {
+		return ErrInsufficientPool(fmt.Sprintf("%s < %s", pool, amt))
+	}
+
+	if err := dk.bank.SendCoinsUnrestricted(ctx, DistributionAddress(), recipient, amt); err != nil {
+		return err
+	}
+
+	dk.setCommunityPool(ctx, pool.Sub(amt))
+
+	return nil
+}
+
+// GetRewardAddress returns the address receiving the rewards
+// of the given validator, if set.
+func (dk DistributionKeeper) GetRewardAddress(ctx sdk.Context, val crypto.Address) (crypto.Address, bool) {
+	bz := ctx.Store(dk.key).Get(RewardAddressStoreKey(val))
+	if bz == nil {
+		return crypto.Address{}, false
+	}
+	return crypto.AddressFromBytes(bz), true
+}
+
+// SetRewardAddress sets the address receiving the rewards of the given
+// validator, and credits it with the rewards held for the validator.
+func (dk DistributionKeeper) SetRewardAddress(ctx sdk.Context, val, addr crypto.Address) {
+	stor := ctx.Store(dk.key)
+	stor.Set(RewardAddressStoreKey(val), addr.Bytes())
+
+	if held := dk.GetValidatorRewards(ctx, val); !held.IsZero() {
+		stor.Delete(ValidatorRewardsStoreKey(val))
+		dk.addRewards(ctx, addr, held)
+	}
+}
+
+// GetValidatorRewards returns the rewards held for the given validator,
+// until it has a reward address.
+func (dk DistributionKeeper) GetValidatorRewards(ctx sdk.Context, val crypto.Address) std.Coins {
+	bz := ctx.Store(dk.key).Get(ValidatorRewardsStoreKey(val))
+	if bz == nil {
+		return std.NewCoins()
+	}
+
+	var rewards std.Coins
+	amino.MustUnmarshal(bz, &rewards)
+	return rewards
+}
+
+func (dk DistributionKeeper) setCommunityPool(ctx sdk.Context, pool std.Coins) {
+	stor := ctx.Store(dk.key)
+	if pool.IsZero() {
+		stor.Delete([]byte(CommunityPoolKey))
+		return
+	}
+	stor.Set([]byte(CommunityPoolKey), amino.MustMarshal(pool))
+}
+
+func (dk DistributionKeeper) addRewards(ctx sdk.Context, addr crypto.Address, amt std.Coins) {
+	if amt.IsZero() {
+		return
+	}
+
+	rewards := dk.GetRewards(ctx, addr).Add(amt)
+	ctx.Store(dk.key).Set(RewardsStoreKey(addr), amino.MustMarshal(rewards))
+}
+
+// addValidatorRewards credits the rewards of the given validator
+// to its reward address, or holds them until it has one.
+func (dk DistributionKeeper) addValidatorRewards(ctx sdk.Context, val crypto.Address, amt std.Coins) {
+	if amt.IsZero() {
+		return
+	}
+
+	if addr, ok := dk.GetRewardAddress(ctx, val); ok {
+		dk.addRewards(ctx, addr, amt)
+		return
+	}
+
+	rewards := dk.GetValidatorRewards(ctx, val).Add(amt)
+	ctx.Store(dk.key).Set(ValidatorRewardsStoreKey(val), amino.MustMarshal(rewards))
+}
+
+type authorGas struct {
+	address crypto.Address
+	gas     int64
+}
+
+// takeAuthorGas returns (and clears) the gas recorded for each author
+// in the current block, ordered by address, along with the total.
+func (dk DistributionKeeper) takeAuthorGas(ctx sdk.Context) ([]authorGas, int64) {
+	var (
+		stor    = ctx.Store(dk.key)
+		authors = []authorGas{}
+		keys    = [][]byte{}
+		total   int64
+	)
+
+	iter := store.PrefixIterator(stor, []byte(AuthorGasStoreKeyPrefix))
+	for ; iter.Valid(); iter.Next() {
+		var gas int64
+		amino.MustUnmarshal(iter.Value(), &gas)
+
+		key := iter.Key()
+		authors = append(authors, authorGas{
+			address: crypto.AddressFromBytes(key[len(AuthorGasStoreKeyPrefix):]),
+			gas:     gas,
+		})
+		keys = append(keys, key)
+		total += gas
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		stor.Delete(key)
+	}
+
+	return authors, total
+}
+
+// proposerAddress returns the proposer of the current block, if known.
+func proposerAddress(ctx sdk.Context) crypto.Address {
+	header, ok := ctx.BlockHeader().(*bft.Header)
+	if !ok || header == nil {
+		return crypto.Address{}
+	}
+	return header.ProposerAddress
+}
+
+// portion returns coins * num / den, rounded down.
+func portion(coins std.Coins, num, den int64) std.Coins {
+	if num <= 0 || den <= 0 {
+		return std.NewCoins()
+	}
+
+	res := make([]std.Coin, 0, len(coins))
+	for _, coin := range coins {
+		amt := new(big.Int).Mul(big.NewInt(coin.Amount), big.NewInt(num))
+		amt.Quo(amt, big.NewInt(den))
+		if !amt.IsInt64() {
+			panic(fmt.Sprintf("portion overflow: %s * %d / %d", coin, num, den))
+		}
+		res = append(res, std.NewCoin(coin.Denom, amt.Int64()))
+	}
+	return std.NewCoins(res...)
+}
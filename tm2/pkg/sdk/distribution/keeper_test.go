@@ -0,0 +1,246 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestDistributeFees(t *testing.T) {
+	t.Parallel()
+
+	var (
+		proposer = crypto.AddressFromPreimage([]byte("proposer"))
+		signer1  = crypto.AddressFromPreimage([]byte("signer1"))
+		operator = crypto.AddressFromPreimage([]byte("operator"))
+		signer2  = crypto.AddressFromPreimage([]byte("signer2"))
+		absent   = crypto.AddressFromPreimage([]byte("absent"))
+		author1  = crypto.AddressFromPreimage([]byte("author1"))
+		author2  = crypto.AddressFromPreimage([]byte("author2"))
+	)
+
+	env := setupTestEnv()
+	ctx := env.ctx.
+		WithBlockHeader(&bft.Header{ChainID: "test-chain-id", ProposerAddress: proposer}).
+		WithVoteInfos([]abci.VoteInfo{
+			{Address: signer1, Power: 3, SignedLastBlock: true},
+			{Address: signer2, Power: 1, SignedLastBlock: true},
+			{Address: absent, Power: 4, SignedLastBlock: false},
+		})
+
+	// Collect some fees, and record the gas consumed by realm code
+	_, err := env.bankk.AddCoins(ctx, auth.FeeCollectorAddress(), std.NewCoins(std.NewCoin("ugnot", 1001)))
+	require.NoError(t, err)
+
+	// Only signer1 has a reward address
+	env.distk.SetRewardAddress(ctx, signer1, operator)
+
+	env.distk.AddAuthorGas(ctx, author1, 300)
+	env.distk.AddAuthorGas(ctx, author1, 100)
+	env.distk.AddAuthorGas(ctx, author2, 100)
+
+	env.distk.DistributeFees(ctx)
+
+	// The fee collector is emptied
+	assert.True(t, env.bankk.GetCoins(ctx, auth.FeeCollectorAddress()).IsZero())
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 1001)), env.bankk.GetCoins(ctx, DistributionAddress()))
+
+	// 10% to the proposer, held until it has a reward address
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 100)), env.distk.GetValidatorRewards(ctx, proposer))
+	assert.True(t, env.distk.GetRewards(ctx, proposer).IsZero())
+
+	// 50% to the signers, by voting power
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 375)), env.distk.GetRewards(ctx, operator))
+	assert.True(t, env.distk.GetRewards(ctx, signer1).IsZero())
+	assert.True(t, env.distk.GetValidatorRewards(ctx, signer1).IsZero())
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 125)), env.distk.GetValidatorRewards(ctx, signer2))
+	assert.True(t, env.distk.GetValidatorRewards(ctx, absent).IsZero())
+
+	// 20% to the authors, by gas
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 160)), env.distk.GetRewards(ctx, author1))
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 40)), env.distk.GetRewards(ctx, author2))
+
+	// The rest, including the rounding remainder, to the community pool
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 201)), env.distk.GetCommunityPool(ctx))
+
+	// The author gas is reset after each block
+	authors, total := env.distk.takeAuthorGas(ctx)
+	assert.Empty(t, authors)
+	assert.Zero(t, total)
+}
+
+func TestDistributeFees_Unattributed(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	_, err := env.bankk.AddCoins(ctx, auth.FeeCollectorAddress(), std.NewCoins(std.NewCoin("ugnot", 1000)))
+	require.NoError(t, err)
+
+	// No proposer, signers or authors, everything goes to the community pool
+	env.distk.DistributeFees(ctx)
+
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 1000)), env.distk.GetCommunityPool(ctx))
+}
+
+func TestWithdrawRewards(t *testing.T) {
+	t.Parallel()
+
+	var (
+		proposer = crypto.AddressFromPreimage([]byte("proposer"))
+		operator = crypto.AddressFromPreimage([]byte("operator"))
+	)
+
+	env := setupTestEnv()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", ProposerAddress: proposer})
+
+	// Nothing to withdraw yet
+	_, err := env.distk.WithdrawRewards(ctx, operator)
+	assert.ErrorIs(t, err, NoRewardsError{})
+
+	_, err = env.bankk.AddCoins(ctx, auth.FeeCollectorAddress(), std.NewCoins(std.NewCoin("ugnot", 1000)))
+	require.NoError(t, err)
+
+	env.distk.DistributeFees(ctx)
+
+	// The rewards held for the proposer go to its reward address once set
+	_, err = env.distk.WithdrawRewards(ctx, proposer)
+	assert.ErrorIs(t, err, NoRewardsError{})
+
+	env.distk.SetRewardAddress(ctx, proposer, operator)
+	assert.True(t, env.distk.GetValidatorRewards(ctx, proposer).IsZero())
+
+	rewards, err := env.distk.WithdrawRewards(ctx, operator)
+	require.NoError(t, err)
+
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 100)), rewards)
+	assert.Equal(t, rewards, env.bankk.GetCoins(ctx, operator))
+	assert.True(t, env.distk.GetRewards(ctx, operator).IsZero())
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 900)), env.bankk.GetCoins(ctx, DistributionAddress()))
+}
+
+func TestParamsValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, DefaultParams().Validate())
+	assert.NoError(t, NewParams(0, 0, 0).Validate())
+	assert.NoError(t, NewParams(20, 50, 30).Validate())
+	assert.Error(t, NewParams(-1, 50, 30).Validate())
+	assert.Error(t, NewParams(20, 101, 0).Validate())
+	assert.Error(t, NewParams(20, 50, 31).Validate())
+}
+
+func TestDistributeFees_SharesOver100(t *testing.T) {
+	t.Parallel()
+
+	var (
+		proposer = crypto.AddressFromPreimage([]byte("proposer"))
+		signer   = crypto.AddressFromPreimage([]byte("signer"))
+		author   = crypto.AddressFromPreimage([]byte("author"))
+	)
+
+	env := setupTestEnv()
+	ctx := env.ctx.
+		WithBlockHeader(&bft.Header{ChainID: "test-chain-id", ProposerAddress: proposer}).
+		WithVoteInfos([]abci.VoteInfo{{Address: signer, Power: 1, SignedLastBlock: true}})
+
+	// Setting a share making the total exceed 100% is rejected
+	assert.Panics(t, func() { env.prmk.SetInt64(ctx, "distribution:p:signers_share", 80) })
+	assert.Panics(t, func() { env.prmk.SetInt64(ctx, "distribution:p:proposer_share", -1) })
+	assert.Panics(t, func() { env.prmk.SetInt64(ctx, "distribution:p:authors_share", 101) })
+	assert.Equal(t, DefaultParams(), env.distk.GetParams(ctx))
+	env.prmk.SetInt64(ctx, "distribution:p:signers_share", 70)
+	assert.Equal(t, int64(70), env.distk.GetParams(ctx).SignersShare)
+
+	// Invalid shares already in the store are capped
+	env.distk.prmk.SetStruct(ctx, "p", NewParams(60, 60, 60))
+
+	_, err := env.bankk.AddCoins(ctx, auth.FeeCollectorAddress(), std.NewCoins(std.NewCoin("ugnot", 1000)))
+	require.NoError(t, err)
+	env.distk.AddAuthorGas(ctx, author, 100)
+
+	require.NotPanics(t, func() { env.distk.DistributeFees(ctx) })
+
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 600)), env.distk.GetValidatorRewards(ctx, proposer))
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 400)), env.distk.GetValidatorRewards(ctx, signer))
+	assert.True(t, env.distk.GetRewards(ctx, author).IsZero())
+	assert.True(t, env.distk.GetCommunityPool(ctx).IsZero())
+}
+
+func TestWithdrawCommunityPool(t *testing.T) {
+	t.Parallel()
+
+	recipient := crypto.AddressFromPreimage([]byte("recipient"))
+
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	_, err := env.bankk.AddCoins(ctx, auth.FeeCollectorAddress(), std.NewCoins(std.NewCoin("ugnot", 1000)))
+	require.NoError(t, err)
+	env.distk.DistributeFees(ctx)
+
+	// More than the pool
+	err = env.distk.WithdrawCommunityPool(ctx, recipient, std.NewCoins(std.NewCoin("ugnot", 1001)))
+	assert.ErrorIs(t, err, InsufficientPoolError{})
+
+	require.NoError(t, env.distk.WithdrawCommunityPool(ctx, recipient, std.NewCoins(std.NewCoin("ugnot", 400))))
+
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 400)), env.bankk.GetCoins(ctx, recipient))
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 600)), env.distk.GetCommunityPool(ctx))
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 600)), env.bankk.GetCoins(ctx, DistributionAddress()))
+}
+
+func TestSetAuthorityParam(t *testing.T) {
+	t.Parallel()
+
+	authority := crypto.AddressFromPreimage([]byte("authority"))
+
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	assert.Panics(t, func() { env.prmk.SetString(ctx, "distribution:p:authority", "invalid") })
+	assert.True(t, env.distk.GetParams(ctx).Authority.IsZero())
+
+	env.prmk.SetString(ctx, "distribution:p:authority", authority.String())
+	assert.Equal(t, authority, env.distk.GetParams(ctx).Authority)
+
+	// An empty authority disables the authority messages
+	env.prmk.SetString(ctx, "distribution:p:authority", "")
+	assert.True(t, env.distk.GetParams(ctx).Authority.IsZero())
+}
+
+func TestGenesisRewardAddresses(t *testing.T) {
+	t.Parallel()
+
+	var (
+		val      = crypto.AddressFromPreimage([]byte("val"))
+		operator = crypto.AddressFromPreimage([]byte("operator"))
+	)
+
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Invalid reward addresses are rejected
+	assert.Error(t, ValidateGenesis(NewGenesisState(DefaultParams(), RewardAddress{Validator: val})))
+	assert.Error(t, ValidateGenesis(NewGenesisState(
+		DefaultParams(),
+		RewardAddress{Validator: val, Address: operator},
+		RewardAddress{Validator: val, Address: operator},
+	)))
+
+	genesis := NewGenesisState(DefaultParams(), RewardAddress{Validator: val, Address: operator})
+	env.distk.InitGenesis(ctx, genesis)
+
+	addr, ok := env.distk.GetRewardAddress(ctx, val)
+	require.True(t, ok)
+	assert.Equal(t, operator, addr)
+	assert.Equal(t, genesis, env.distk.ExportGenesis(ctx))
+}
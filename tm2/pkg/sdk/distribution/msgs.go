@@ -0,0 +1,148 @@
+package distribution
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// RouterKey is the name of the distribution module
+const RouterKey = ModuleName
+
+// MsgWithdrawRewards - withdraws all the rewards accrued by an address
+type MsgWithdrawRewards struct {
+	Address crypto.Address `json:"address" yaml:"address"`
+}
+
+var _ std.Msg = MsgWithdrawRewards{}
+
+// NewMsgWithdrawRewards - construct a msg withdrawing the rewards of the given address.
+func NewMsgWithdrawRewards(addr crypto.Address) MsgWithdrawRewards {
+	return MsgWithdrawRewards{Address: addr}
+}
+
+// Route Implements Msg.
+func (msg MsgWithdrawRewards) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgWithdrawRewards) Type() string { return "withdraw_rewards" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgWithdrawRewards) ValidateBasic() error {
+	if msg.Address.IsZero() {
+		return std.ErrInvalidAddress("missing address")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgWithdrawRewards) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgWithdrawRewards) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Address}
+}
+
+// MsgSetRewardAddress - sets the address receiving the rewards of a validator,
+// which are otherwise held by the distribution module. It must be signed by
+// the distribution authority (see Params.Authority)
+type MsgSetRewardAddress struct {
+	Authority     crypto.Address `json:"authority" yaml:"authority"`
+	Validator     crypto.Address `json:"validator" yaml:"validator"`
+	RewardAddress crypto.Address `json:"reward_address" yaml:"reward_address"`
+}
+
+var _ std.Msg = MsgSetRewardAddress{}
+
+// NewMsgSetRewardAddress - construct a msg setting the reward address of a validator.
+func NewMsgSetRewardAddress(authority, validator, rewardAddr crypto.Address) MsgSetRewardAddress {
+	return MsgSetRewardAddress{
+		Authority:     authority,
+		Validator:     validator,
+		RewardAddress: rewardAddr,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgSetRewardAddress) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgSetRewardAddress) Type() string { return "set_reward_address" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgSetRewardAddress) ValidateBasic() error {
+	if msg.Authority.IsZero() {
+		return std.ErrInvalidAddress("missing authority address")
+	}
+	if msg.Validator.IsZero() {
+		return std.ErrInvalidAddress("missing validator address")
+	}
+	if msg.RewardAddress.IsZero() {
+		return std.ErrInvalidAddress("missing reward address")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgSetRewardAddress) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgSetRewardAddress) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Authority}
+}
+
+// MsgWithdrawCommunityPool - sends coins from the community pool to a recipient.
+// It must be signed by the distribution authority (see Params.Authority)
+type MsgWithdrawCommunityPool struct {
+	Authority crypto.Address `json:"authority" yaml:"authority"`
+	Recipient crypto.Address `json:"recipient" yaml:"recipient"`
+	Amount    std.Coins      `json:"amount" yaml:"amount"`
+}
+
+var _ std.Msg = MsgWithdrawCommunityPool{}
+
+// NewMsgWithdrawCommunityPool - construct a msg withdrawing coins from the community pool.
+func NewMsgWithdrawCommunityPool(authority, recipient crypto.Address, amount std.Coins) MsgWithdrawCommunityPool {
+	return MsgWithdrawCommunityPool{
+		Authority: authority,
+		Recipient: recipient,
+		Amount:    amount,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgWithdrawCommunityPool) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgWithdrawCommunityPool) Type() string { return "withdraw_community_pool" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgWithdrawCommunityPool) ValidateBasic() error {
+	if msg.Authority.IsZero() {
+		return std.ErrInvalidAddress("missing authority address")
+	}
+	if msg.Recipient.IsZero() {
+		return std.ErrInvalidAddress("missing recipient address")
+	}
+	if !msg.Amount.IsValid() {
+		return std.ErrInvalidCoins("amount is not valid: " + msg.Amount.String())
+	}
+	if !msg.Amount.IsAllPositive() {
+		return std.ErrInsufficientCoins("amount must be positive: " + msg.Amount.String())
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgWithdrawCommunityPool) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgWithdrawCommunityPool) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Authority}
+}
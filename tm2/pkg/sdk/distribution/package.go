@@ -0,0 +1,19 @@
+package distribution
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+)
+
+var Package = amino.RegisterPackage(amino.NewPackage(
+	"github.com/gnolang/gno/tm2/pkg/sdk/distribution",
+	"distribution",
+	amino.GetCallersDirname(),
+).WithDependencies().WithTypes(
+	NoRewardsError{}, "NoRewardsError",
+	UnauthorizedError{}, "UnauthorizedError",
+	InsufficientPoolError{}, "InsufficientPoolError",
+	MsgWithdrawRewards{}, "MsgWithdrawRewards",
+	MsgSetRewardAddress{}, "MsgSetRewardAddress",
+	MsgWithdrawCommunityPool{}, "MsgWithdrawCommunityPool",
+	RewardAddress{}, "RewardAddress",
+))
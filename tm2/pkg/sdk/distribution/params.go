@@ -0,0 +1,153 @@
+package distribution
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+)
+
+// Default parameter values
+const (
+	DefaultProposerShare int64 = 10 // 10% of the fees go to the block proposer
+	DefaultSignersShare  int64 = 50 // 50% of the fees go to the block signers
+	DefaultAuthorsShare  int64 = 20 // 20% of the fees go to the realm authors
+)
+
+// Params defines the parameters for the distribution module.
+// All shares are percentages of the fees collected in a block.
+// Whatever is not distributed goes to the community pool.
+//
+// The authority is the address allowed to set the reward addresses of the
+// validators and to withdraw from the community pool. It is set at genesis,
+// and can then only be changed through governance (ie. the GovDAO of gno.land).
+// Without an authority, the community pool can't be spent.
+type Params struct {
+	ProposerShare int64          `json:"proposer_share" yaml:"proposer_share"`
+	SignersShare  int64          `json:"signers_share" yaml:"signers_share"`
+	AuthorsShare  int64          `json:"authors_share" yaml:"authors_share"`
+	Authority     crypto.Address `json:"authority" yaml:"authority"`
+}
+
+// NewParams creates a new Params object
+func NewParams(proposerShare, signersShare, authorsShare int64) Params {
+	return Params{
+		ProposerShare: proposerShare,
+		SignersShare:  signersShare,
+		AuthorsShare:  authorsShare,
+	}
+}
+
+// DefaultParams returns a default set of parameters.
+func DefaultParams() Params {
+	return NewParams(
+		DefaultProposerShare,
+		DefaultSignersShare,
+		DefaultAuthorsShare,
+	)
+}
+
+// CommunityShare returns the percentage of the fees
+// that goes to the community pool
+func (p Params) CommunityShare() int64 {
+	return 100 - p.ProposerShare - p.SignersShare - p.AuthorsShare
+}
+
+// String implements the stringer interface.
+func (p Params) String() string {
+	var builder strings.Builder
+	sb := &builder // Pointer for use with fmt.Fprintf
+	sb.WriteString("Params: \n")
+	fmt.Fprintf(sb, "ProposerShare: %d\n", p.ProposerShare)
+	fmt.Fprintf(sb, "SignersShare: %d\n", p.SignersShare)
+	fmt.Fprintf(sb, "AuthorsShare: %d\n", p.AuthorsShare)
+	fmt.Fprintf(sb, "Authority: %s\n", p.Authority)
+	return sb.String()
+}
+
+func (p Params) Validate() error {
+	if p.ProposerShare < 0 || p.ProposerShare > 100 {
+		return fmt.Errorf("invalid proposer share: %d, it should be between 0 and 100", p.ProposerShare)
+	}
+	if p.SignersShare < 0 || p.SignersShare > 100 {
+		return fmt.Errorf("invalid signers share: %d, it should be between 0 and 100", p.SignersShare)
+	}
+	if p.AuthorsShare < 0 || p.AuthorsShare > 100 {
+		return fmt.Errorf("invalid authors share: %d, it should be between 0 and 100", p.AuthorsShare)
+	}
+	if p.CommunityShare() < 0 {
+		return fmt.Errorf("invalid shares: the sum of all shares should not exceed 100")
+	}
+	return nil
+}
+
+func (dk DistributionKeeper) SetParams(ctx sdk.Context, params Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	dk.prmk.SetStruct(ctx, "p", params)
+	return nil
+}
+
+func (dk DistributionKeeper) GetParams(ctx sdk.Context) Params {
+	params := Params{}
+	dk.prmk.GetStruct(ctx, "p", &params)
+	return params
+}
+
+// WillSetParam validates a share before it is set, against the current value
+// of the other shares, so that they never add up to more than 100%,
+// and validates the authority address.
+// It panics on an invalid value, which aborts the transaction setting it.
+func (dk DistributionKeeper) WillSetParam(ctx sdk.Context, key string, value any) {
+	params := dk.GetParams(ctx)
+	var share *int64
+	switch key {
+	case "p:authority":
+		v, ok := value.(string)
+		if !ok {
+			panic(fmt.Sprintf("invalid value for %s: %v, it should be a string", key, value))
+		}
+		if v == "" {
+			return // no authority
+		}
+		if _, err := crypto.AddressFromBech32(v); err != nil {
+			panic(fmt.Sprintf("invalid value for %s: %v", key, err))
+		}
+		return
+	case "p:proposer_share":
+		share = &params.ProposerShare
+	case "p:signers_share":
+		share = &params.SignersShare
+	case "p:authors_share":
+		share = &params.AuthorsShare
+	default:
+		// Allow setting non-existent key.
+		return
+	}
+
+	v, ok := value.(int64)
+	if !ok {
+		panic(fmt.Sprintf("invalid value for %s: %v, it should be an int64", key, value))
+	}
+	*share = v
+	if err := params.Validate(); err != nil {
+		panic(err)
+	}
+}
+
+// capped returns p with each share bounded so that, in order, they are
+// between 0 and 100 and their sum doesn't exceed 100.
+func (p Params) capped() Params {
+	left := int64(100)
+	capShare := func(share int64) int64 {
+		share = min(max(share, 0), left)
+		left -= share
+		return share
+	}
+	p.ProposerShare = capShare(p.ProposerShare)
+	p.SignersShare = capShare(p.SignersShare)
+	p.AuthorsShare = capShare(p.AuthorsShare)
+	return p
+}
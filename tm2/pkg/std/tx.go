@@ -123,9 +123,18 @@ func (tx Tx) GetSignBytes(chainID string, accountNumber uint64, sequence uint64)
 // Fee includes the amount of coins paid in fees and the maximum
 // gas to be used by the transaction. The ratio yields an effective "gasprice",
 // which must be above some miminum to be accepted into the mempool.
+// If a grant is set, the fee is paid by its granter instead of the first signer.
 type Fee struct {
-	GasWanted int64 `json:"gas_wanted" yaml:"gas_wanted"`
-	GasFee    Coin  `json:"gas_fee" yaml:"gas_fee"`
+	GasWanted int64     `json:"gas_wanted" yaml:"gas_wanted"`
+	GasFee    Coin      `json:"gas_fee" yaml:"gas_fee"`
+	Grant     *FeeGrant `json:"grant,omitempty" yaml:"grant,omitempty"`
+}
+
+// FeeGrant designates the payer of a fee, using a fee allowance the granter
+// has given to the first signer. It is an optional extension of the Fee,
+// so that a fee without a grant encodes exactly as it did before.
+type FeeGrant struct {
+	Granter crypto.Address `json:"granter" yaml:"granter"`
 }
 
 // NewFee returns a new instance of Fee
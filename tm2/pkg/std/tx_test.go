@@ -0,0 +1,58 @@
+package std
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+func TestFeeGranterEncoding(t *testing.T) {
+	t.Parallel()
+
+	// The fee as it was before fee allowances
+	type legacyFee struct {
+		GasWanted int64 `json:"gas_wanted" yaml:"gas_wanted"`
+		GasFee    Coin  `json:"gas_fee" yaml:"gas_fee"`
+	}
+
+	fee := NewFee(100000, NewCoin("ugnot", 1000))
+	legacy := legacyFee{GasWanted: fee.GasWanted, GasFee: fee.GasFee}
+
+	t.Run("unset granter", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, amino.MustMarshal(legacy), amino.MustMarshal(fee))
+		assert.Equal(t, amino.MustMarshalJSON(legacy), amino.MustMarshalJSON(fee))
+
+		var decoded Fee
+		amino.MustUnmarshal(amino.MustMarshal(legacy), &decoded)
+		assert.Nil(t, decoded.Grant)
+
+		tx := Tx{Fee: fee, Memo: "memo"}
+		signBytes, err := tx.GetSignBytes("dev", 1, 2)
+		require.NoError(t, err)
+		assert.NotContains(t, string(signBytes), "granter")
+	})
+
+	t.Run("set granter", func(t *testing.T) {
+		t.Parallel()
+
+		granter := crypto.AddressFromPreimage([]byte("granter"))
+		fee := fee
+		fee.Grant = &FeeGrant{Granter: granter}
+
+		var decoded Fee
+		amino.MustUnmarshal(amino.MustMarshal(fee), &decoded)
+		require.NotNil(t, decoded.Grant)
+		assert.Equal(t, granter, decoded.Grant.Granter)
+
+		tx := Tx{Fee: fee}
+		signBytes, err := tx.GetSignBytes("dev", 1, 2)
+		require.NoError(t, err)
+		assert.Contains(t, string(signBytes), granter.String())
+	})
+}
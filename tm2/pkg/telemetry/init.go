@@ -9,6 +9,7 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/telemetry/config"
 	"github.com/gnolang/gno/tm2/pkg/telemetry/metrics"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/traces"
 )
 
 var (
@@ -21,10 +22,15 @@ func MetricsEnabled() bool {
 	return globalConfig.MetricsEnabled
 }
 
+// TracesEnabled returns true if traces have been initialized
+func TracesEnabled() bool {
+	return globalConfig.TracesEnabled
+}
+
 // Init initializes the global telemetry
 func Init(c config.Config) error {
-	// Check if the metrics are enabled at all
-	if !c.MetricsEnabled {
+	// Check if the metrics or traces are enabled at all
+	if !c.MetricsEnabled && !c.TracesEnabled {
 		return nil
 	}
 
@@ -41,5 +47,17 @@ func Init(c config.Config) error {
 	// Update the global configuration
 	globalConfig = c
 
-	return metrics.Init(c)
+	if c.MetricsEnabled {
+		if err := metrics.Init(c); err != nil {
+			return err
+		}
+	}
+
+	if c.TracesEnabled {
+		if err := traces.Init(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
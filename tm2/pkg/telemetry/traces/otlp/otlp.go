@@ -0,0 +1,55 @@
+// Package otlp registers the OTLP span exporter of the traces package.
+// It is imported by the node binaries only, so the clients importing
+// the traces package for propagation don't depend on the OTLP exporters:
+//
+//	import _ "github.com/gnolang/gno/tm2/pkg/telemetry/traces/otlp"
+package otlp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/gnolang/gno/tm2/pkg/telemetry/config"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/traces"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func init() {
+	traces.InternalRegisterExporter(newExporter)
+}
+
+// newExporter returns an OTLP span exporter, using http/https
+// or grpc depending on the scheme of the exporter endpoint
+func newExporter(ctx context.Context, config config.Config) (sdkTrace.SpanExporter, error) {
+	u, err := url.Parse(config.ExporterEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing exporter endpoint: %s, %w", config.ExporterEndpoint, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		exp, err := otlptracehttp.New(
+			ctx,
+			otlptracehttp.WithEndpointURL(config.ExporterEndpoint),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create http trace exporter, %w", err)
+		}
+
+		return exp, nil
+	default:
+		exp, err := otlptracegrpc.New(
+			ctx,
+			otlptracegrpc.WithEndpoint(config.ExporterEndpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create grpc trace exporter, %w", err)
+		}
+
+		return exp, nil
+	}
+}
@@ -0,0 +1,205 @@
+// Package traces provides OpenTelemetry distributed tracing for tm2 nodes.
+// Spans are exported over OTLP (see the otlp package), and are no-ops
+// until tracing is initialized
+package traces
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gnolang/gno/tm2/pkg/telemetry/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation name of the tm2 spans
+const tracerName = "github.com/gnolang/gno/tm2"
+
+// maxTxContexts is the maximum number of transaction
+// trace contexts kept until the transaction is executed
+const maxTxContexts = 10_000
+
+var (
+	enabled atomic.Bool
+
+	// propagator propagates the W3C trace context over HTTP headers.
+	// It doesn't rely on the global propagator, so clients
+	// only need a tracer provider to propagate their traces
+	propagator = propagation.TraceContext{}
+)
+
+// Enabled returns true if tracing has been initialized
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Exporter returns the span exporter of the given telemetry config
+type Exporter func(ctx context.Context, config config.Config) (sdkTrace.SpanExporter, error)
+
+// newExporter is registered by the otlp package, so only the binaries
+// exporting spans depend on the OTLP exporters
+var newExporter Exporter
+
+// InternalRegisterExporter is used by the init function
+// of the otlp package to register the span exporter.
+//
+// This function is not meant for usage outside of traces/.
+func InternalRegisterExporter(exporter Exporter) {
+	newExporter = exporter
+}
+
+// Init initializes the global tracer provider, exporting spans with the
+// registered exporter (see the otlp package)
+func Init(config config.Config) error {
+	if newExporter == nil {
+		return errors.New("no trace exporter registered, import the traces/otlp package")
+	}
+
+	exp, err := newExporter(context.Background(), config)
+	if err != nil {
+		return err
+	}
+
+	provider := sdkTrace.NewTracerProvider(
+		sdkTrace.WithBatcher(exp),
+		sdkTrace.WithResource(
+			resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.ServiceNameKey.String(config.ServiceName),
+				semconv.ServiceVersionKey.String("1.0.0"),
+				semconv.ServiceInstanceIDKey.String(config.ServiceInstanceID),
+			),
+		),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+
+	enabled.Store(true)
+
+	return nil
+}
+
+// Tracer returns the tm2 tracer, from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// StartSpan starts a new span, child of the span in ctx (if any)
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the span, marking it as failed if err is set
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// Inject writes the trace context of ctx to the HTTP headers
+func Inject(ctx context.Context, header http.Header) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// Extract returns a copy of ctx, with the trace context read from the HTTP headers
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// txContexts keeps the trace context of received transactions, by hash,
+// so their execution can be attached to the trace that submitted them.
+// Transactions go through the mempool and consensus, which don't carry contexts
+var txContexts = struct {
+	sync.Mutex
+
+	spans map[string]*list.Element // values are *txContext
+	order *list.List               // insertion order, for eviction
+}{
+	spans: make(map[string]*list.Element),
+	order: list.New(),
+}
+
+type txContext struct {
+	key     string
+	spanCtx trace.SpanContext
+}
+
+// SetTxContext saves the trace context of the transaction with the given hash
+func SetTxContext(hash []byte, ctx context.Context) {
+	if !Enabled() {
+		return
+	}
+
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return
+	}
+
+	txContexts.Lock()
+	defer txContexts.Unlock()
+
+	key := string(hash)
+
+	if elem, ok := txContexts.spans[key]; ok {
+		elem.Value.(*txContext).spanCtx = spanCtx
+		return
+	}
+
+	// Evict the oldest context if needed
+	if txContexts.order.Len() >= maxTxContexts {
+		oldest := txContexts.order.Front()
+		delete(txContexts.spans, oldest.Value.(*txContext).key)
+		txContexts.order.Remove(oldest)
+	}
+
+	txContexts.spans[key] = txContexts.order.PushBack(&txContext{key: key, spanCtx: spanCtx})
+}
+
+// TxContext returns a copy of ctx holding the trace context of the
+// transaction with the given hash, or ctx itself if unknown
+func TxContext(ctx context.Context, hash []byte) context.Context {
+	return txContextFor(ctx, hash, false)
+}
+
+// ConsumeTxContext is like TxContext, but also forgets the trace context
+// of the transaction. It is used once the transaction is executed
+func ConsumeTxContext(ctx context.Context, hash []byte) context.Context {
+	return txContextFor(ctx, hash, true)
+}
+
+func txContextFor(ctx context.Context, hash []byte, consume bool) context.Context {
+	if !Enabled() {
+		return ctx
+	}
+
+	txContexts.Lock()
+	defer txContexts.Unlock()
+
+	key := string(hash)
+
+	elem, ok := txContexts.spans[key]
+	if !ok {
+		return ctx
+	}
+
+	if consume {
+		delete(txContexts.spans, key)
+		txContexts.order.Remove(elem)
+	}
+
+	return trace.ContextWithRemoteSpanContext(ctx, elem.Value.(*txContext).spanCtx)
+}
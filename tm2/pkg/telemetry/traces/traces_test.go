@@ -0,0 +1,172 @@
+package traces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/telemetry/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// setupTracing enables tracing with an in-memory exporter
+func setupTracing(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+
+	otel.SetTracerProvider(sdkTrace.NewTracerProvider(sdkTrace.WithSyncer(exporter)))
+	enabled.Store(true)
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+		enabled.Store(false)
+	})
+
+	return exporter
+}
+
+func TestTraces_Init(t *testing.T) {
+	t.Cleanup(func() {
+		InternalRegisterExporter(nil)
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+		enabled.Store(false)
+	})
+
+	// No exporter is registered without the otlp package
+	require.Error(t, Init(*config.DefaultTelemetryConfig()))
+	assert.False(t, Enabled())
+
+	exporter := tracetest.NewInMemoryExporter()
+	InternalRegisterExporter(func(context.Context, config.Config) (sdkTrace.SpanExporter, error) {
+		return exporter, nil
+	})
+
+	require.NoError(t, Init(*config.DefaultTelemetryConfig()))
+	assert.True(t, Enabled())
+}
+
+func TestTraces_TxContext(t *testing.T) {
+	exporter := setupTracing(t)
+
+	hash := []byte("hash")
+
+	// Unknown transactions have no trace context
+	assert.False(t, trace.SpanContextFromContext(TxContext(context.Background(), hash)).IsValid())
+
+	// Broadcast the transaction
+	ctx, broadcast := StartSpan(context.Background(), "broadcast")
+	SetTxContext(hash, ctx)
+	EndSpan(broadcast, nil)
+
+	// Execute the transaction
+	_, execution := StartSpan(TxContext(context.Background(), hash), "execution")
+	EndSpan(execution, errors.New("failed"))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "broadcast", spans[0].Name)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+
+	// The execution is part of the broadcast trace
+	assert.Equal(t, "execution", spans[1].Name)
+	assert.Equal(t, spans[0].SpanContext.TraceID(), spans[1].SpanContext.TraceID())
+	assert.Equal(t, spans[0].SpanContext.SpanID(), spans[1].Parent.SpanID())
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+}
+
+func TestTraces_TxContextParent(t *testing.T) {
+	setupTracing(t)
+
+	type key struct{}
+
+	ctx, span := StartSpan(context.Background(), "broadcast")
+	defer span.End()
+
+	hash := []byte("parent-hash")
+	SetTxContext(hash, ctx)
+
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+
+	// The trace context is added to the parent, which is kept
+	txCtx := TxContext(parent, hash)
+	assert.Equal(t, span.SpanContext().TraceID(), trace.SpanContextFromContext(txCtx).TraceID())
+	assert.Equal(t, "value", txCtx.Value(key{}))
+
+	cancel()
+	assert.ErrorIs(t, txCtx.Err(), context.Canceled)
+
+	// Unknown transactions get the parent itself
+	assert.Equal(t, parent, TxContext(parent, []byte("unknown")))
+}
+
+func TestTraces_TxContextEviction(t *testing.T) {
+	setupTracing(t)
+
+	ctx, span := StartSpan(context.Background(), "broadcast")
+	defer span.End()
+
+	for i := 0; i <= maxTxContexts; i++ {
+		SetTxContext([]byte(fmt.Sprintf("hash-%d", i)), ctx)
+	}
+
+	// The oldest transaction was evicted
+	assert.False(t, trace.SpanContextFromContext(TxContext(context.Background(), []byte("hash-0"))).IsValid())
+	assert.True(t, trace.SpanContextFromContext(TxContext(context.Background(), []byte("hash-1"))).IsValid())
+	assert.True(t, trace.SpanContextFromContext(TxContext(context.Background(), []byte(fmt.Sprintf("hash-%d", maxTxContexts)))).IsValid())
+}
+
+func TestTraces_ConsumeTxContext(t *testing.T) {
+	setupTracing(t)
+
+	ctx, span := StartSpan(context.Background(), "broadcast")
+	defer span.End()
+
+	hash := []byte("consumed-hash")
+	SetTxContext(hash, ctx)
+
+	txContexts.Lock()
+	size := txContexts.order.Len()
+	txContexts.Unlock()
+
+	// Checking the transaction keeps its trace context
+	assert.True(t, trace.SpanContextFromContext(TxContext(context.Background(), hash)).IsValid())
+
+	// Executing the transaction releases it
+	assert.True(t, trace.SpanContextFromContext(ConsumeTxContext(context.Background(), hash)).IsValid())
+	assert.False(t, trace.SpanContextFromContext(TxContext(context.Background(), hash)).IsValid())
+
+	txContexts.Lock()
+	defer txContexts.Unlock()
+
+	assert.Equal(t, size-1, txContexts.order.Len())
+	assert.NotContains(t, txContexts.spans, string(hash))
+}
+
+func TestTraces_Propagation(t *testing.T) {
+	t.Parallel()
+
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	header := http.Header{}
+	Inject(trace.ContextWithSpanContext(context.Background(), spanCtx), header)
+
+	extracted := trace.SpanContextFromContext(Extract(context.Background(), header))
+
+	assert.Equal(t, spanCtx.TraceID(), extracted.TraceID())
+	assert.Equal(t, spanCtx.SpanID(), extracted.SpanID())
+	assert.True(t, extracted.IsRemote())
+}